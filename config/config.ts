@@ -52,6 +52,7 @@ export const options: StackOptions = {
         domainName: "",
         domainNameAliasHostedZoneId: "",
         domainNameAliasTarget: "",
+        sesIdentity: "",
         logRetentionDays: 1,
     },
     stageOptions: [
@@ -63,6 +64,7 @@ export const options: StackOptions = {
             domainName: "",
             domainNameAliasHostedZoneId: "",
             domainNameAliasTarget: "",
+            sesIdentity: "dev.buildboard.com",
         },
         {
             environment: StageEnvironment.PROD,
@@ -72,6 +74,7 @@ export const options: StackOptions = {
             domainName: "",
             domainNameAliasHostedZoneId: "",
             domainNameAliasTarget: "",
+            sesIdentity: "buildboard.com",
         },
     ],
 };
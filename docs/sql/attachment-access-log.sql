@@ -0,0 +1,27 @@
+-- Migration: Add attachment access log
+-- Date: 2026-10-16
+-- Description: Records who generated a download URL for which attachment, for document-access audits.
+-- attachment_id is not a foreign key because attachments live in per-entity tables (see entity_type).
+
+-- Step 1: Create table
+CREATE TABLE project.attachment_access_log (
+    id            BIGSERIAL PRIMARY KEY,
+    attachment_id BIGINT      NOT NULL,
+    entity_type   VARCHAR(50) NOT NULL,
+    org_id        BIGINT      NOT NULL REFERENCES iam.organizations(id),
+    user_id       BIGINT      NOT NULL REFERENCES iam.users(id),
+    ip_address    VARCHAR(64),
+    user_agent    TEXT,
+    accessed_at   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Step 2: Create indexes for performance
+CREATE INDEX idx_attachment_access_log_attachment ON project.attachment_access_log(entity_type, attachment_id, accessed_at DESC);
+CREATE INDEX idx_attachment_access_log_user ON project.attachment_access_log(user_id);
+
+-- Step 3: Grants
+GRANT SELECT, INSERT ON project.attachment_access_log TO app_user;
+GRANT SELECT, UPDATE, USAGE ON SEQUENCE project.attachment_access_log_id_seq TO app_user;
+
+-- Step 4: Add comments for documentation
+COMMENT ON TABLE project.attachment_access_log IS 'Audit trail of attachment download URL generation';
@@ -0,0 +1,30 @@
+-- Migration: Track attachment virus-scan status
+-- Date: 2026-10-16
+-- Description: New attachments start with scan_status 'pending'. An async scanner reports the result through
+-- POST /attachments/{id}/scan-result, which sets 'clean' or 'infected'. Download and thumbnail URLs are
+-- only issued for 'clean' files, and export packages leave out files that are not clean. Rows that exist
+-- before this migration were uploaded without scanning and are backfilled as 'clean' so they stay available.
+
+-- Step 1: Add column (existing rows are treated as clean)
+ALTER TABLE project.project_attachments ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'clean';
+ALTER TABLE project.issue_attachments ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'clean';
+ALTER TABLE project.rfi_attachments ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'clean';
+ALTER TABLE project.submittal_attachments ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'clean';
+ALTER TABLE project.issue_comment_attachments ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'clean';
+ALTER TABLE project.rfi_comment_attachments ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'clean';
+
+-- Step 2: New rows start pending until the scanner reports
+ALTER TABLE project.project_attachments ALTER COLUMN scan_status SET DEFAULT 'pending';
+ALTER TABLE project.issue_attachments ALTER COLUMN scan_status SET DEFAULT 'pending';
+ALTER TABLE project.rfi_attachments ALTER COLUMN scan_status SET DEFAULT 'pending';
+ALTER TABLE project.submittal_attachments ALTER COLUMN scan_status SET DEFAULT 'pending';
+ALTER TABLE project.issue_comment_attachments ALTER COLUMN scan_status SET DEFAULT 'pending';
+ALTER TABLE project.rfi_comment_attachments ALTER COLUMN scan_status SET DEFAULT 'pending';
+
+-- Step 3: Add comments for documentation
+COMMENT ON COLUMN project.project_attachments.scan_status IS 'pending until the virus scanner reports, then clean or infected; only clean files can be downloaded';
+COMMENT ON COLUMN project.issue_attachments.scan_status IS 'pending until the virus scanner reports, then clean or infected; only clean files can be downloaded';
+COMMENT ON COLUMN project.rfi_attachments.scan_status IS 'pending until the virus scanner reports, then clean or infected; only clean files can be downloaded';
+COMMENT ON COLUMN project.submittal_attachments.scan_status IS 'pending until the virus scanner reports, then clean or infected; only clean files can be downloaded';
+COMMENT ON COLUMN project.issue_comment_attachments.scan_status IS 'pending until the virus scanner reports, then clean or infected; only clean files can be downloaded';
+COMMENT ON COLUMN project.rfi_comment_attachments.scan_status IS 'pending until the virus scanner reports, then clean or infected; only clean files can be downloaded';
@@ -0,0 +1,15 @@
+-- Migration: Add attachment thumbnails
+-- Date: 2026-10-16
+-- Description: POST /attachments/confirm generates a 256px thumbnail for PNG and JPEG uploads and stores it
+-- under a thumbnails/ folder next to the original. GET /attachments/{id}/thumbnail-url presigns it.
+
+-- Step 1: Add column
+ALTER TABLE project.project_attachments ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(1000);
+ALTER TABLE project.issue_attachments ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(1000);
+ALTER TABLE project.rfi_attachments ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(1000);
+ALTER TABLE project.submittal_attachments ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(1000);
+ALTER TABLE project.issue_comment_attachments ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(1000);
+ALTER TABLE project.rfi_comment_attachments ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(1000);
+
+-- Step 2: Add comments for documentation
+COMMENT ON COLUMN project.project_attachments.thumbnail_path IS 'S3 key of the generated thumbnail; NULL for non-image attachments';
@@ -0,0 +1,16 @@
+-- Migration: Track attachment upload status
+-- Date: 2026-10-16
+-- Description: Attachments created by POST /attachments/upload-url(s) are inserted as 'pending' and move to
+-- 'confirmed' once POST /attachments/confirm finds the object in S3. Listings only show confirmed rows.
+-- The column default stays 'confirmed' so existing rows, and rows inserted by other paths, are visible.
+
+-- Step 1: Add column (existing rows are treated as confirmed)
+ALTER TABLE project.project_attachments ADD COLUMN IF NOT EXISTS upload_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+ALTER TABLE project.issue_attachments ADD COLUMN IF NOT EXISTS upload_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+ALTER TABLE project.rfi_attachments ADD COLUMN IF NOT EXISTS upload_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+ALTER TABLE project.submittal_attachments ADD COLUMN IF NOT EXISTS upload_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+ALTER TABLE project.issue_comment_attachments ADD COLUMN IF NOT EXISTS upload_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+ALTER TABLE project.rfi_comment_attachments ADD COLUMN IF NOT EXISTS upload_status VARCHAR(20) NOT NULL DEFAULT 'confirmed';
+
+-- Step 2: Add comments for documentation
+COMMENT ON COLUMN project.project_attachments.upload_status IS 'pending (upload-url rows) until POST /attachments/confirm finds the object in S3, then confirmed';
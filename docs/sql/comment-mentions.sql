@@ -0,0 +1,25 @@
+-- Migration: Add comment mentions
+-- Date: 2026-10-16
+-- Description: New issue and RFI comments are scanned for @[userId] and @email mentions. Mentions that
+-- resolve to an active user in the organization are stored here and the users are notified through the
+-- events topic; other mentions are ignored.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.comment_mentions (
+    id                 BIGSERIAL PRIMARY KEY,
+    entity_type        VARCHAR(50) NOT NULL,
+    comment_id         BIGINT NOT NULL,
+    mentioned_user_id  BIGINT NOT NULL REFERENCES iam.users(id),
+    token              VARCHAR(320) NOT NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_comment_mentions_entity_type CHECK (entity_type IN ('issue_comment', 'rfi_comment')),
+    CONSTRAINT uq_comment_mentions_user UNIQUE (entity_type, comment_id, mentioned_user_id)
+);
+
+-- Step 2: Add index for "mentions of me" lookups
+CREATE INDEX IF NOT EXISTS idx_comment_mentions_user ON project.comment_mentions(mentioned_user_id, created_at DESC);
+
+-- Step 3: Add comments for documentation
+COMMENT ON TABLE project.comment_mentions IS 'Users @mentioned in issue and RFI comments';
+COMMENT ON COLUMN project.comment_mentions.entity_type IS 'issue_comment (project.issue_comments) or rfi_comment (project.rfi_comments)';
+COMMENT ON COLUMN project.comment_mentions.token IS 'Mention text as written, e.g. @[42] or @jane@example.com';
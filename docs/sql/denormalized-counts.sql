@@ -0,0 +1,75 @@
+-- Migration: Add denormalized attachment and comment counts
+-- Date: 2026-10-16
+-- Description: Stores attachment_count/comment_count on issues and RFIs and attachment_count on
+-- submittals so lists avoid per-row count queries. Triggers keep the counts current; if they ever
+-- drift (e.g. rows edited outside the triggers), POST /admin/recount rebuilds them from the source tables.
+
+-- Step 1: Add new columns
+ALTER TABLE project.issues
+ADD COLUMN IF NOT EXISTS attachment_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE project.rfis
+ADD COLUMN IF NOT EXISTS attachment_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE project.submittals
+ADD COLUMN IF NOT EXISTS attachment_count INTEGER NOT NULL DEFAULT 0;
+
+-- Step 2: Trigger function that adjusts a parent's count when a child row is inserted, soft deleted,
+-- restored or hard deleted. Arguments: parent table, parent count column, child foreign key column.
+CREATE OR REPLACE FUNCTION project.adjust_denormalized_count() RETURNS TRIGGER AS $$
+DECLARE
+    parent_table TEXT := TG_ARGV[0];
+    count_column TEXT := TG_ARGV[1];
+    fk_column TEXT := TG_ARGV[2];
+    delta INTEGER := 0;
+    parent_id BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NOT NEW.is_deleted THEN delta := 1; END IF;
+        EXECUTE format('SELECT ($1).%I', fk_column) USING NEW INTO parent_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF NOT OLD.is_deleted THEN delta := -1; END IF;
+        EXECUTE format('SELECT ($1).%I', fk_column) USING OLD INTO parent_id;
+    ELSIF OLD.is_deleted IS DISTINCT FROM NEW.is_deleted THEN
+        delta := CASE WHEN NEW.is_deleted THEN -1 ELSE 1 END;
+        EXECUTE format('SELECT ($1).%I', fk_column) USING NEW INTO parent_id;
+    END IF;
+
+    IF delta <> 0 THEN
+        EXECUTE format('UPDATE %s SET %I = GREATEST(%I + $1, 0) WHERE id = $2', parent_table, count_column, count_column)
+        USING delta, parent_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Step 3: Attach triggers
+CREATE TRIGGER trg_issue_attachments_count AFTER INSERT OR UPDATE OF is_deleted OR DELETE ON project.issue_attachments
+FOR EACH ROW EXECUTE FUNCTION project.adjust_denormalized_count('project.issues', 'attachment_count', 'issue_id');
+CREATE TRIGGER trg_issue_comments_count AFTER INSERT OR UPDATE OF is_deleted OR DELETE ON project.issue_comments
+FOR EACH ROW EXECUTE FUNCTION project.adjust_denormalized_count('project.issues', 'comment_count', 'issue_id');
+CREATE TRIGGER trg_rfi_attachments_count AFTER INSERT OR UPDATE OF is_deleted OR DELETE ON project.rfi_attachments
+FOR EACH ROW EXECUTE FUNCTION project.adjust_denormalized_count('project.rfis', 'attachment_count', 'rfi_id');
+CREATE TRIGGER trg_rfi_comments_count AFTER INSERT OR UPDATE OF is_deleted OR DELETE ON project.rfi_comments
+FOR EACH ROW EXECUTE FUNCTION project.adjust_denormalized_count('project.rfis', 'comment_count', 'rfi_id');
+CREATE TRIGGER trg_submittal_attachments_count AFTER INSERT OR UPDATE OF is_deleted OR DELETE ON project.submittal_attachments
+FOR EACH ROW EXECUTE FUNCTION project.adjust_denormalized_count('project.submittals', 'attachment_count', 'submittal_id');
+
+-- Step 4: Backfill existing rows
+UPDATE project.issues i SET
+    attachment_count = (SELECT COUNT(*) FROM project.issue_attachments a WHERE a.issue_id = i.id AND a.is_deleted = FALSE),
+    comment_count = (SELECT COUNT(*) FROM project.issue_comments c WHERE c.issue_id = i.id AND c.is_deleted = FALSE);
+UPDATE project.rfis r SET
+    attachment_count = (SELECT COUNT(*) FROM project.rfi_attachments a WHERE a.rfi_id = r.id AND a.is_deleted = FALSE),
+    comment_count = (SELECT COUNT(*) FROM project.rfi_comments c WHERE c.rfi_id = r.id AND c.is_deleted = FALSE);
+UPDATE project.submittals s SET
+    attachment_count = (SELECT COUNT(*) FROM project.submittal_attachments a WHERE a.submittal_id = s.id AND a.is_deleted = FALSE);
+
+-- Step 5: Add comments for documentation
+COMMENT ON COLUMN project.issues.attachment_count IS 'Denormalized count of live issue_attachments; rebuild with POST /admin/recount';
+COMMENT ON COLUMN project.issues.comment_count IS 'Denormalized count of live issue_comments; rebuild with POST /admin/recount';
+COMMENT ON COLUMN project.rfis.attachment_count IS 'Denormalized count of live rfi_attachments; rebuild with POST /admin/recount';
+COMMENT ON COLUMN project.rfis.comment_count IS 'Denormalized count of live rfi_comments; rebuild with POST /admin/recount';
+COMMENT ON COLUMN project.submittals.attachment_count IS 'Denormalized count of live submittal_attachments; rebuild with POST /admin/recount';
@@ -0,0 +1,14 @@
+-- Migration: Lease export jobs so crashed workers are retried
+-- Date: 2026-10-16
+-- Description: A claimed export job holds a lease for 20 minutes from started_at (the worker times out after 15).
+-- A processing job whose lease has expired is claimed again, up to 3 attempts; after that it is marked failed
+-- instead of staying in processing forever.
+
+-- Step 1: Add column
+ALTER TABLE project.export_jobs ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+
+-- Step 2: Create index for finding expired leases
+CREATE INDEX IF NOT EXISTS idx_export_jobs_processing ON project.export_jobs(started_at) WHERE status = 'processing';
+
+-- Step 3: Add comments for documentation
+COMMENT ON COLUMN project.export_jobs.attempts IS 'Number of times a worker has claimed the job; a job is retried after its lease expires until it reaches the attempt cap';
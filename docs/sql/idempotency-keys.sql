@@ -0,0 +1,25 @@
+-- Migration: Add idempotency keys
+-- Date: 2026-10-16
+-- Description: POST /issues and POST /rfis accept an Idempotency-Key header. The key, a hash of the request
+-- body and the created resource ID are kept for 24 hours so a retried create returns the original resource.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.idempotency_keys (
+    org_id           BIGINT NOT NULL,
+    scope            VARCHAR(50) NOT NULL,
+    idempotency_key  VARCHAR(255) NOT NULL,
+    user_id          BIGINT NOT NULL,
+    request_hash     VARCHAR(64) NOT NULL,
+    resource_id      BIGINT,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at       TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, scope, idempotency_key)
+);
+
+-- Step 2: Add index for purging expired keys
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON project.idempotency_keys(expires_at);
+
+-- Step 3: Add comments for documentation
+COMMENT ON TABLE project.idempotency_keys IS 'Idempotency-Key headers seen on create endpoints; rows past expires_at may be purged or reused';
+COMMENT ON COLUMN project.idempotency_keys.scope IS 'Endpoint the key belongs to, e.g. create_issue or create_rfi';
+COMMENT ON COLUMN project.idempotency_keys.resource_id IS 'ID of the created resource, recorded in the creating transaction; NULL while the first request is still running. A NULL claim older than 2 minutes can be taken over';
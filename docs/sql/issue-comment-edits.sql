@@ -0,0 +1,26 @@
+-- Migration: Issue comment editing and deletion
+-- Date: 2026-10-16
+-- Description: Comment authors (or super admins) can edit and delete issue comments. Edits set edited_at
+-- and keep the replaced text in project.issue_comment_edits. Deletes are soft: deleted_at marks comments
+-- removed this way so the comment list can show a "[deleted]" placeholder in their place.
+
+-- Step 1: Add columns
+ALTER TABLE project.issue_comments
+    ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Step 2: Create edit history table
+CREATE TABLE IF NOT EXISTS project.issue_comment_edits (
+    id                BIGSERIAL PRIMARY KEY,
+    comment_id        BIGINT NOT NULL REFERENCES project.issue_comments(id),
+    previous_comment  TEXT NOT NULL,
+    edited_by         BIGINT NOT NULL REFERENCES iam.users(id),
+    edited_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_comment_edits_comment_id ON project.issue_comment_edits(comment_id, edited_at);
+
+-- Step 3: Add comments for documentation
+COMMENT ON COLUMN project.issue_comments.edited_at IS 'Time of the last edit; NULL if the comment was never edited';
+COMMENT ON COLUMN project.issue_comments.deleted_at IS 'Time the comment was deleted by its author or an admin; such comments are listed as placeholders';
+COMMENT ON TABLE project.issue_comment_edits IS 'Previous text of edited issue comments, one row per edit';
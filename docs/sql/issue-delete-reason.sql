@@ -0,0 +1,15 @@
+-- Migration: Record why and by whom an issue was deleted
+-- Date: 2026-10-16
+-- Description: DELETE /issues/{issueId} accepts an optional delete_reason. POST /issues/{issueId}/restore
+-- undoes the soft delete and clears these columns; both actions are written to the issue activity log.
+
+-- Step 1: Add columns
+ALTER TABLE project.issues
+    ADD COLUMN IF NOT EXISTS delete_reason TEXT,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT;
+
+-- Step 2: Add comments for documentation
+COMMENT ON COLUMN project.issues.delete_reason IS 'Optional reason given when the issue was soft-deleted; cleared on restore';
+COMMENT ON COLUMN project.issues.deleted_at IS 'When the issue was soft-deleted; cleared on restore';
+COMMENT ON COLUMN project.issues.deleted_by IS 'User who soft-deleted the issue; cleared on restore';
@@ -0,0 +1,51 @@
+-- Migration: Add issue watchers
+-- Date: 2026-10-16
+-- Description: Users can watch an issue to be notified of new comments and status changes. The creator and
+-- the assignee are added on create. Each notification is recorded in project.issue_notifications with the
+-- watchers it goes to and published to the issue events topic for delivery.
+
+-- Step 1: Create watchers table
+CREATE TABLE IF NOT EXISTS project.issue_watchers (
+    issue_id    BIGINT NOT NULL REFERENCES project.issues(id),
+    user_id     BIGINT NOT NULL REFERENCES iam.users(id),
+    added_by    BIGINT NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_watchers_user_id ON project.issue_watchers(user_id);
+
+-- Step 2: Create notifications table
+CREATE TABLE IF NOT EXISTS project.issue_notifications (
+    id              BIGSERIAL PRIMARY KEY,
+    issue_id        BIGINT NOT NULL REFERENCES project.issues(id),
+    org_id          BIGINT NOT NULL,
+    event_type      VARCHAR(50) NOT NULL,
+    recipient_ids   BIGINT[] NOT NULL DEFAULT '{}',
+    comment_id      BIGINT,
+    old_status      VARCHAR(50),
+    new_status      VARCHAR(50),
+    actor_id        BIGINT NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_notifications_issue_id ON project.issue_notifications(issue_id, created_at DESC);
+
+-- Step 3: Backfill creators and assignees of existing issues as watchers
+INSERT INTO project.issue_watchers (issue_id, user_id, added_by, created_at)
+SELECT i.id, i.created_by, i.created_by, i.created_at
+FROM project.issues i
+WHERE i.is_deleted = FALSE
+ON CONFLICT DO NOTHING;
+
+INSERT INTO project.issue_watchers (issue_id, user_id, added_by, created_at)
+SELECT i.id, i.assigned_to, i.created_by, i.created_at
+FROM project.issues i
+WHERE i.is_deleted = FALSE AND i.assigned_to IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- Step 4: Add comments for documentation
+COMMENT ON TABLE project.issue_watchers IS 'Users notified of new comments and status changes on an issue';
+COMMENT ON TABLE project.issue_notifications IS 'Issue watcher notifications recorded for delivery';
+COMMENT ON COLUMN project.issue_notifications.event_type IS 'What triggered the notification, e.g. issue.comment_added or issue.status_changed';
+COMMENT ON COLUMN project.issue_notifications.recipient_ids IS 'Watchers notified, excluding the user who made the change';
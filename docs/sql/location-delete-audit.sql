@@ -0,0 +1,26 @@
+-- Migration: Location delete audit
+-- Date: 2026-10-16
+-- Description: Append-only record of everything removed by DELETE /locations/{id}. A forced delete
+-- (?force=true) also soft deletes the projects at the location, so each deleted location and project
+-- gets its own row pointing back at the location the delete was issued against.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS iam.location_delete_audit (
+    id             BIGSERIAL PRIMARY KEY,
+    org_id         BIGINT NOT NULL REFERENCES iam.organizations(id),
+    location_id    BIGINT NOT NULL REFERENCES iam.locations(id),
+    entity_type    VARCHAR(20) NOT NULL CHECK (entity_type IN ('location', 'project')),
+    entity_id      BIGINT NOT NULL,
+    forced         BOOLEAN NOT NULL DEFAULT FALSE,
+    actor_user_id  BIGINT NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Step 2: Add index for reading an organization's delete history
+CREATE INDEX IF NOT EXISTS idx_location_delete_audit_org
+    ON iam.location_delete_audit(org_id, created_at);
+
+-- Step 3: Add comments
+COMMENT ON TABLE iam.location_delete_audit IS 'Append-only audit trail of locations and projects removed by location deletes';
+COMMENT ON COLUMN iam.location_delete_audit.location_id IS 'Location the delete request was issued against';
+COMMENT ON COLUMN iam.location_delete_audit.forced IS 'TRUE when force=true deleted active projects along with the location';
@@ -0,0 +1,22 @@
+-- Migration: Location hierarchy
+-- Date: 2026-10-16
+-- Description: Locations can be nested (region -> site -> building) through a nullable parent_location_id.
+-- Cycles are rejected by the API; deleting a location with children requires ?cascade=true.
+
+-- Step 1: Add parent column
+ALTER TABLE iam.locations
+    ADD COLUMN IF NOT EXISTS parent_location_id BIGINT REFERENCES iam.locations(id);
+
+-- Step 2: A location cannot be its own parent
+ALTER TABLE iam.locations
+    DROP CONSTRAINT IF EXISTS chk_locations_parent_not_self;
+ALTER TABLE iam.locations
+    ADD CONSTRAINT chk_locations_parent_not_self CHECK (parent_location_id IS NULL OR parent_location_id <> id);
+
+-- Step 3: Index child lookups
+CREATE INDEX IF NOT EXISTS idx_locations_parent_location_id
+    ON iam.locations (parent_location_id)
+    WHERE is_deleted = FALSE;
+
+-- Step 4: Add comments for documentation
+COMMENT ON COLUMN iam.locations.parent_location_id IS 'Parent location in the org hierarchy; NULL for top-level locations';
@@ -0,0 +1,16 @@
+-- Migration: Enforce unique location names within an organization
+-- Date: 2026-10-16
+-- Description: Location names must be unique per organization, compared trimmed and
+-- case-insensitively. Deleted locations are excluded so their names can be reused.
+
+-- Step 1: Find existing duplicates (rename or delete these before creating the index)
+SELECT org_id, LOWER(TRIM(name)) AS normalized_name, COUNT(*) AS duplicates, ARRAY_AGG(id ORDER BY id) AS location_ids
+FROM iam.locations
+WHERE is_deleted = FALSE
+GROUP BY org_id, LOWER(TRIM(name))
+HAVING COUNT(*) > 1;
+
+-- Step 2: Add the unique index
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_org_name_unique
+ON iam.locations (org_id, LOWER(TRIM(name)))
+WHERE is_deleted = FALSE;
@@ -0,0 +1,32 @@
+-- Migration: Grant manage_project_users to admin and manager roles
+-- Date: 2026-10-16
+-- Description: Assigning, updating and removing project users now requires the manage_project_users
+-- permission (super admins bypass the check). This creates the permission in every organization and
+-- grants it to the existing admin and management roles so org admins and managers keep access.
+-- Other roles must be granted it explicitly through the role permission endpoints.
+
+-- Step 1: Create the permission in each organization that doesn't have it yet
+INSERT INTO iam.permission (permission_name, description, org_id)
+SELECT 'manage_project_users', 'Assign, update and remove users on a project', o.id
+FROM iam.organizations o
+WHERE NOT EXISTS (
+    SELECT 1 FROM iam.permission p
+    WHERE p.org_id = o.id AND p.permission_name = 'manage_project_users'
+);
+
+-- Step 2: Grant it to the admin and management roles of the same organization
+INSERT INTO iam.role_permission (role_id, permission_id)
+SELECT r.id, p.permission_id
+FROM iam.roles r
+JOIN iam.permission p ON p.org_id = r.org_id AND p.permission_name = 'manage_project_users'
+WHERE r.is_deleted = FALSE
+  AND r.construction_role_category IN ('admin', 'management')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- Step 3: Verify which roles hold the permission
+SELECT r.org_id, r.id AS role_id, r.name AS role_name
+FROM iam.roles r
+JOIN iam.role_permission rp ON rp.role_id = r.id
+JOIN iam.permission p ON p.permission_id = rp.permission_id
+WHERE p.permission_name = 'manage_project_users'
+ORDER BY r.org_id, r.name;
@@ -0,0 +1,12 @@
+-- Migration: Add email domain allow-list to organizations
+-- Date: 2026-10-16
+-- Description: Adds allowed_email_domains to organizations. When non-empty, new users can only be
+-- invited with an email address on one of these domains (matched case-insensitively). Super admins
+-- can bypass the check per invite. Defaults to an empty list so existing orgs allow any domain.
+
+-- Step 1: Add new column
+ALTER TABLE iam.organizations
+ADD COLUMN allowed_email_domains TEXT[] NOT NULL DEFAULT '{}';
+
+-- Step 2: Add comment for documentation
+COMMENT ON COLUMN iam.organizations.allowed_email_domains IS 'Lowercase email domains users may be invited from; empty allows any';
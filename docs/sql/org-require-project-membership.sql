@@ -0,0 +1,12 @@
+-- Migration: Add project membership requirement setting to organizations
+-- Date: 2026-10-16
+-- Description: Adds require_project_membership to organizations. When true, only users with a role
+-- on a project (or super admins) can create issues and RFIs on it. Defaults to false so existing
+-- orgs keep the permissive behavior.
+
+-- Step 1: Add new column
+ALTER TABLE iam.organizations
+ADD COLUMN require_project_membership BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Step 2: Add comment for documentation
+COMMENT ON COLUMN iam.organizations.require_project_membership IS 'Restrict issue/RFI creation to project members';
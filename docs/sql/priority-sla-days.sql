@@ -0,0 +1,12 @@
+-- Migration: Add per-priority SLA offsets to organizations
+-- Date: 2026-10-16
+-- Description: When an issue or RFI is created without a due date, the due date is derived from the
+-- organization's SLA for its priority, e.g. {"critical": 1, "high": 3, "urgent": 1}. Keys are lowercase
+-- issue or RFI priorities; values are calendar days after creation. An empty object disables SLAs.
+
+-- Step 1: Add column
+ALTER TABLE iam.organizations
+    ADD COLUMN IF NOT EXISTS priority_sla_days JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Step 2: Add comments for documentation
+COMMENT ON COLUMN iam.organizations.priority_sla_days IS 'Per-priority SLA offsets in days used to default due dates on create';
@@ -0,0 +1,11 @@
+-- Migration: Add default assignee to projects
+-- Date: 2026-10-16
+-- Description: Adds an optional default_assignee_id to projects. Issue and RFI create
+-- handlers fall back to this user when no assignee is provided in the request.
+
+-- Step 1: Add new column
+ALTER TABLE project.projects
+ADD COLUMN default_assignee_id BIGINT REFERENCES iam.users(id);
+
+-- Step 2: Add comment for documentation
+COMMENT ON COLUMN project.projects.default_assignee_id IS 'User ID applied as assignee on new issues/RFIs when none is provided';
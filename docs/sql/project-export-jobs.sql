@@ -0,0 +1,33 @@
+-- Migration: Add project export jobs
+-- Date: 2026-10-16
+-- Description: Tracks asynchronous project export package jobs. The API inserts a pending job,
+-- the export worker claims it, builds the ZIP (issue/RFI/submittal CSVs plus attachment files)
+-- and stores the S3 key on completion.
+
+-- Step 1: Create table
+CREATE TABLE project.export_jobs (
+    id            BIGSERIAL PRIMARY KEY,
+    org_id        BIGINT       NOT NULL REFERENCES iam.organizations(id),
+    project_id    BIGINT       NOT NULL REFERENCES project.projects(id),
+    status        VARCHAR(50)  NOT NULL DEFAULT 'pending',
+    file_path     VARCHAR(1000),
+    file_size     BIGINT,
+    error_message TEXT,
+    requested_by  BIGINT       NOT NULL REFERENCES iam.users(id),
+    created_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at    TIMESTAMP,
+    completed_at  TIMESTAMP,
+    CONSTRAINT chk_export_jobs_status CHECK (status IN ('pending', 'processing', 'completed', 'failed'))
+);
+
+-- Step 2: Create indexes for performance
+CREATE INDEX idx_export_jobs_pending ON project.export_jobs(created_at) WHERE status = 'pending';
+CREATE INDEX idx_export_jobs_project ON project.export_jobs(project_id, created_at DESC);
+
+-- Step 3: Grants
+GRANT SELECT, INSERT, UPDATE ON project.export_jobs TO app_user;
+GRANT SELECT, UPDATE, USAGE ON SEQUENCE project.export_jobs_id_seq TO app_user;
+
+-- Step 4: Add comments for documentation
+COMMENT ON TABLE project.export_jobs IS 'Asynchronous project export package jobs';
+COMMENT ON COLUMN project.export_jobs.file_path IS 'S3 key of the generated ZIP; set when the job completes';
@@ -0,0 +1,36 @@
+-- Migration: Create project_folders table and add folder_id to project_attachments
+-- Date: 2026-10-16
+-- Description: Project-level document folders. Folders nest through parent_folder_id and
+-- attachments can optionally live in a folder; a NULL folder_id means the project root.
+-- Folder names are unique among siblings. Cycle prevention is enforced by the API.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.project_folders (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL REFERENCES project.projects(id),
+    parent_folder_id BIGINT REFERENCES project.project_folders(id),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by BIGINT NOT NULL REFERENCES iam.users(id),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by BIGINT NOT NULL REFERENCES iam.users(id),
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    CHECK (parent_folder_id IS NULL OR parent_folder_id <> id)
+);
+
+-- Step 2: Create indexes
+CREATE INDEX IF NOT EXISTS idx_project_folders_project ON project.project_folders(project_id) WHERE is_deleted = FALSE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_folders_sibling_name
+    ON project.project_folders(project_id, COALESCE(parent_folder_id, 0), LOWER(name))
+    WHERE is_deleted = FALSE;
+
+-- Step 3: Add folder_id to project_attachments
+ALTER TABLE project.project_attachments
+    ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES project.project_folders(id);
+
+CREATE INDEX IF NOT EXISTS idx_project_attachments_folder ON project.project_attachments(project_id, folder_id) WHERE is_deleted = FALSE;
+
+-- Step 4: Add comments for documentation
+COMMENT ON TABLE project.project_folders IS 'Document folders for organizing project attachments';
+COMMENT ON COLUMN project.project_folders.parent_folder_id IS 'Parent folder; NULL for top-level folders';
+COMMENT ON COLUMN project.project_attachments.folder_id IS 'Containing folder; NULL for the project root';
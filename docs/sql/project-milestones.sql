@@ -0,0 +1,32 @@
+-- Migration: Add project milestones
+-- Date: 2026-10-16
+-- Description: Tracks named schedule milestones on a project (target date, actual date, status).
+-- Milestone dates are validated against the project timeline (start_date .. project_finish_date) by the API.
+
+-- Step 1: Create table
+CREATE TABLE project.project_milestones (
+    id          BIGSERIAL PRIMARY KEY,
+    project_id  BIGINT       NOT NULL REFERENCES project.projects(id),
+    name        VARCHAR(255) NOT NULL,
+    description TEXT,
+    target_date DATE         NOT NULL,
+    actual_date DATE,
+    status      VARCHAR(50)  NOT NULL DEFAULT 'pending',
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by  BIGINT       NOT NULL REFERENCES iam.users(id),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by  BIGINT       NOT NULL REFERENCES iam.users(id),
+    is_deleted  BOOLEAN      NOT NULL DEFAULT FALSE,
+    CONSTRAINT chk_project_milestones_status CHECK (status IN ('pending', 'in_progress', 'completed', 'missed'))
+);
+
+-- Step 2: Create indexes for performance
+CREATE INDEX idx_project_milestones_project ON project.project_milestones(project_id, target_date) WHERE is_deleted = FALSE;
+
+-- Step 3: Grants
+GRANT SELECT, INSERT, UPDATE ON project.project_milestones TO app_user;
+GRANT SELECT, UPDATE, USAGE ON SEQUENCE project.project_milestones_id_seq TO app_user;
+
+-- Step 4: Add comments for documentation
+COMMENT ON TABLE project.project_milestones IS 'Schedule milestones for a project';
+COMMENT ON COLUMN project.project_milestones.actual_date IS 'Date the milestone was actually reached; set when marked complete';
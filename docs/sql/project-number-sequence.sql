@@ -0,0 +1,36 @@
+-- Migration: Generate project numbers from a per-org sequence
+-- Date: 2026-10-16
+-- Description: Project numbers (PROJ-YYYY-NNNN) were generated with MAX()+1, which hands the same
+-- number to concurrent creates. They now come from data.NextSequence using an org-wide row in
+-- project.sequences named project_number_<year>. This seeds those rows from existing numbers and
+-- enforces uniqueness per organization. Deleted projects are excluded from the index because
+-- RestoreProject already refuses to restore a project whose number has been reused.
+
+-- Step 1: Seed the per-org, per-year counters from existing project numbers
+INSERT INTO project.sequences (org_id, project_id, sequence_name, current_value, updated_at)
+SELECT org_id,
+       0,
+       'project_number_' || SUBSTRING(project_number, 6, 4),
+       MAX(CAST(SUBSTRING(project_number, 11) AS BIGINT)),
+       CURRENT_TIMESTAMP
+FROM project.projects
+WHERE project_number ~ '^PROJ-[0-9]{4}-[0-9]+$'
+GROUP BY org_id, SUBSTRING(project_number, 6, 4)
+ON CONFLICT (org_id, project_id, sequence_name)
+DO UPDATE SET current_value = GREATEST(project.sequences.current_value, EXCLUDED.current_value),
+              updated_at = CURRENT_TIMESTAMP;
+
+-- Step 2: Find existing duplicates (renumber or delete these before creating the index)
+SELECT org_id, project_number, COUNT(*) AS duplicates, ARRAY_AGG(id ORDER BY id) AS project_ids
+FROM project.projects
+WHERE is_deleted = FALSE AND project_number IS NOT NULL
+GROUP BY org_id, project_number
+HAVING COUNT(*) > 1;
+
+-- Step 3: Add the unique index (CreateProject retries when it hits this index)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_org_project_number_unique
+ON project.projects (org_id, project_number)
+WHERE is_deleted = FALSE;
+
+-- Step 4: Add comments for documentation
+COMMENT ON INDEX project.idx_projects_org_project_number_unique IS 'Project numbers are unique among an organization''s active projects';
@@ -0,0 +1,22 @@
+-- Migration: Add per-project sequence counters
+-- Date: 2026-10-16
+-- Description: Counter rows backing data.NextSequence. Each row is one named sequence scoped to an
+-- org and project (e.g. rfi_number, issue_number). Increments happen under a transaction-scoped
+-- advisory lock so numbers are gap-free and never handed out twice.
+
+-- Step 1: Create table
+CREATE TABLE project.sequences (
+    org_id        BIGINT       NOT NULL REFERENCES iam.organizations(id),
+    project_id    BIGINT       NOT NULL,
+    sequence_name VARCHAR(100) NOT NULL,
+    current_value BIGINT       NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, project_id, sequence_name)
+);
+
+-- Step 2: Grants
+GRANT SELECT, INSERT, UPDATE ON project.sequences TO app_user;
+
+-- Step 3: Add comments for documentation
+COMMENT ON TABLE project.sequences IS 'Gap-free per-project counters used for human-readable numbers';
+COMMENT ON COLUMN project.sequences.project_id IS 'Project the sequence belongs to; 0 for org-wide sequences such as project numbers';
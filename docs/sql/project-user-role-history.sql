@@ -0,0 +1,25 @@
+-- Migration: Add project user role history
+-- Date: 2026-10-16
+-- Description: Append-only audit trail of project user assignments, role changes and removals so
+-- "who had access when" can be answered after the live assignment row is updated or soft-deleted.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.project_user_role_history (
+    id                BIGSERIAL PRIMARY KEY,
+    project_id        BIGINT NOT NULL REFERENCES project.projects(id),
+    user_id           BIGINT NOT NULL,
+    role_id           BIGINT NOT NULL,
+    previous_role_id  BIGINT,
+    action            VARCHAR(20) NOT NULL CHECK (action IN ('assigned', 'role_changed', 'removed')),
+    actor_user_id     BIGINT NOT NULL,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Step 2: Add index for reading a project's history in order
+CREATE INDEX IF NOT EXISTS idx_project_user_role_history_project
+    ON project.project_user_role_history(project_id, created_at, id);
+
+-- Step 3: Add comments
+COMMENT ON TABLE project.project_user_role_history IS 'Append-only audit trail of project user role mutations';
+COMMENT ON COLUMN project.project_user_role_history.previous_role_id IS 'Role replaced by a role_changed entry';
+COMMENT ON COLUMN project.project_user_role_history.actor_user_id IS 'User who performed the mutation';
@@ -0,0 +1,17 @@
+-- Migration: Add RFI auto-close threshold to organizations
+-- Date: 2026-10-16
+-- Description: A daily scheduled run closes OPEN RFIs that have been answered (a response comment or a
+-- comment from an assignee) and have had no new comments for rfi_auto_close_days. Each close is
+-- recorded as a status_change comment. Setting the threshold to 0 disables auto-close for the org.
+
+-- Step 1: Add column
+ALTER TABLE iam.organizations
+    ADD COLUMN IF NOT EXISTS rfi_auto_close_days INTEGER NOT NULL DEFAULT 14
+    CHECK (rfi_auto_close_days BETWEEN 0 AND 365);
+
+-- Step 2: Create indexes
+CREATE INDEX IF NOT EXISTS idx_rfis_open_status ON project.rfis(org_id) WHERE status = 'OPEN' AND is_deleted = FALSE;
+CREATE INDEX IF NOT EXISTS idx_rfi_comments_rfi_created ON project.rfi_comments(rfi_id, created_at) WHERE is_deleted = FALSE;
+
+-- Step 3: Add comments for documentation
+COMMENT ON COLUMN iam.organizations.rfi_auto_close_days IS 'Days without activity after an RFI is answered before it is auto-closed; 0 disables';
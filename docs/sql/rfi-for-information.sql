@@ -0,0 +1,11 @@
+-- Migration: Add for-information flag to RFIs
+-- Date: 2026-10-16
+-- Description: Some RFIs are issued "for information only" and expect no answer. Such RFIs
+-- carry no due date, use the DISTRIBUTED status in place of OPEN and are never counted as overdue.
+
+-- Step 1: Add new column
+ALTER TABLE project.rfis
+ADD COLUMN IF NOT EXISTS for_information BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Step 2: Add comment for documentation
+COMMENT ON COLUMN project.rfis.for_information IS 'Informational distribution: no answer or due date expected; issued status is DISTRIBUTED';
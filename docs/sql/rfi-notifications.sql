@@ -0,0 +1,25 @@
+-- Migration: Add RFI notifications
+-- Date: 2026-10-16
+-- Description: PUT /rfis/{rfiId} records an rfi.assignee_changed row when assigned_to changes to a
+-- different set of users. The same event is published to the events topic for delivery.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.rfi_notifications (
+    id                  BIGSERIAL PRIMARY KEY,
+    rfi_id              BIGINT NOT NULL REFERENCES project.rfis(id),
+    org_id              BIGINT NOT NULL,
+    event_type          VARCHAR(50) NOT NULL,
+    rfi_number          VARCHAR(50),
+    previous_assignees  BIGINT[] NOT NULL DEFAULT '{}',
+    new_assignees       BIGINT[] NOT NULL DEFAULT '{}',
+    changed_by          BIGINT NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Step 2: Add index
+CREATE INDEX IF NOT EXISTS idx_rfi_notifications_rfi_id ON project.rfi_notifications(rfi_id, created_at DESC);
+
+-- Step 3: Add comments for documentation
+COMMENT ON TABLE project.rfi_notifications IS 'RFI events recorded for notification delivery, e.g. assignee changes';
+COMMENT ON COLUMN project.rfi_notifications.previous_assignees IS 'User IDs assigned before the change';
+COMMENT ON COLUMN project.rfi_notifications.new_assignees IS 'User IDs assigned after the change';
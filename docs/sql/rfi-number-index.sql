@@ -0,0 +1,9 @@
+-- Migration: Index RFIs by project and number
+-- Date: 2026-10-16
+-- Description: Supports GET /projects/{projectId}/rfis/by-number/{rfiNumber}, which looks up an
+-- RFI by the human-readable number quoted in emails and drawings.
+
+-- Step 1: Create index
+CREATE INDEX IF NOT EXISTS idx_rfis_project_rfi_number
+    ON project.rfis(project_id, rfi_number)
+    WHERE is_deleted = FALSE AND rfi_number IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Migration: Track RFI response SLA
+-- Date: 2026-10-16
+-- Description: RFIs carry an optional response_due_date, the date an answer is owed by, and responded_at,
+-- stamped the first time the RFI is closed. An RFI without responded_at past its response_due_date is
+-- reported as sla_breached and listed by GET /projects/{projectId}/rfis/sla-breaches.
+
+-- Step 1: Add columns
+ALTER TABLE project.rfis
+    ADD COLUMN IF NOT EXISTS response_due_date DATE,
+    ADD COLUMN IF NOT EXISTS responded_at TIMESTAMP;
+
+-- Step 2: Backfill responded_at for RFIs that are already closed
+UPDATE project.rfis
+SET responded_at = closed_date
+WHERE status = 'CLOSE' AND responded_at IS NULL AND closed_date IS NOT NULL;
+
+-- Step 3: Index unanswered RFIs with a response due date for the breach listing
+CREATE INDEX IF NOT EXISTS idx_rfis_response_due_date
+    ON project.rfis (project_id, response_due_date)
+    WHERE responded_at IS NULL AND response_due_date IS NOT NULL AND is_deleted = FALSE;
+
+-- Step 4: Add comments for documentation
+COMMENT ON COLUMN project.rfis.response_due_date IS 'Date a response is owed by; past it without responded_at the RFI breaches its SLA';
+COMMENT ON COLUMN project.rfis.responded_at IS 'Time the RFI was first closed; never cleared when it is reopened';
@@ -0,0 +1,11 @@
+-- Migration: Add full-text search index for RFIs
+-- Date: 2026-10-16
+-- Description: Supports GET /projects/{projectId}/rfis?q=, which matches subject, description and
+-- rfi_number with to_tsvector/plainto_tsquery. The index expression must match rfiSearchVectorSQL.
+
+-- Step 1: Create GIN index
+CREATE INDEX IF NOT EXISTS idx_rfis_search ON project.rfis
+    USING GIN (to_tsvector('english', coalesce(subject, '') || ' ' || coalesce(description, '') || ' ' || coalesce(rfi_number, '')));
+
+-- Step 2: Add comments for documentation
+COMMENT ON INDEX project.idx_rfis_search IS 'Full-text search over RFI subject, description and number for the q= list filter';
@@ -0,0 +1,11 @@
+-- Migration: Add RFI version for optimistic concurrency
+-- Date: 2026-10-16
+-- Description: PUT /rfis/{rfiId} accepts the version the client last read and rejects the update with
+-- 409 Conflict when another user has updated the RFI since. Every update increments the version.
+
+-- Step 1: Add column
+ALTER TABLE project.rfis
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- Step 2: Add comments for documentation
+COMMENT ON COLUMN project.rfis.version IS 'Incremented on every RFI update; clients send it back on PUT to detect concurrent edits';
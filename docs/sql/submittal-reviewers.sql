@@ -0,0 +1,29 @@
+-- Migration: Create submittal_reviewers table
+-- Date: 2026-10-16
+-- Description: Ordered review routing for submittals. Each row is one reviewer step; the first
+-- pending step is the submittal's current reviewer (submittals.reviewer). Reviewers can be
+-- reordered while pending or skipped by a project manager/admin, which is logged to submittal_history.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.submittal_reviewers (
+    id BIGSERIAL PRIMARY KEY,
+    submittal_id BIGINT NOT NULL REFERENCES project.submittals(id) ON DELETE CASCADE,
+    reviewer_id BIGINT NOT NULL REFERENCES iam.users(id),
+    sequence INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'skipped')),
+    skipped_by BIGINT REFERENCES iam.users(id),
+    skipped_at TIMESTAMP,
+    skip_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by BIGINT NOT NULL REFERENCES iam.users(id),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by BIGINT NOT NULL REFERENCES iam.users(id),
+    UNIQUE (submittal_id, reviewer_id)
+);
+
+-- Step 2: Create indexes
+CREATE INDEX IF NOT EXISTS idx_submittal_reviewers_submittal_sequence ON project.submittal_reviewers(submittal_id, sequence);
+
+-- Step 3: Add comments for documentation
+COMMENT ON TABLE project.submittal_reviewers IS 'Ordered reviewer routing for submittals';
+COMMENT ON COLUMN project.submittal_reviewers.status IS 'pending, completed or skipped';
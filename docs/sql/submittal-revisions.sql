@@ -0,0 +1,28 @@
+-- Migration: Add submittal revisions
+-- Date: 2026-10-16
+-- Description: A revise_resubmit workflow action bumps submittals.revision_number (existing column,
+-- starting at 1) and snapshots the review state of the revision being closed into submittal_revisions.
+-- Approve and reject actions leave the revision number unchanged.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS project.submittal_revisions (
+    id               BIGSERIAL PRIMARY KEY,
+    submittal_id     BIGINT NOT NULL REFERENCES project.submittals(id) ON DELETE CASCADE,
+    revision_number  INTEGER NOT NULL,
+    workflow_status  VARCHAR(50) NOT NULL,
+    current_phase    VARCHAR(50) NOT NULL,
+    ball_in_court    VARCHAR(50) NOT NULL,
+    reviewer         BIGINT REFERENCES iam.users(id),
+    reviewed_by      BIGINT REFERENCES iam.users(id),
+    reviewed_date    TIMESTAMP,
+    review_comments  TEXT,
+    revision_notes   TEXT,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by       BIGINT NOT NULL REFERENCES iam.users(id),
+    UNIQUE (submittal_id, revision_number)
+);
+
+-- Step 2: Add comments
+COMMENT ON TABLE project.submittal_revisions IS 'Review state of each closed submittal revision';
+COMMENT ON COLUMN project.submittal_revisions.revision_number IS 'Revision the snapshot was taken from';
+COMMENT ON COLUMN project.submittal_revisions.revision_notes IS 'Revisions requested by the reviewer';
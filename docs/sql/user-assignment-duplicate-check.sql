@@ -0,0 +1,13 @@
+-- Migration: Index user assignment duplicate check
+-- Date: 2026-10-16
+-- Description: POST /assignments rejects (or, with upsert=true, updates) a live assignment of the same user,
+-- role and context whose dates overlap. Assignments with disjoint date ranges are allowed, so this is a lookup
+-- index rather than a unique constraint.
+
+-- Step 1: Add index
+CREATE INDEX IF NOT EXISTS idx_user_assignments_duplicate_check
+    ON iam.user_assignments (user_id, role_id, context_type, context_id)
+    WHERE is_deleted = FALSE;
+
+-- Step 2: Add comments for documentation
+COMMENT ON INDEX iam.idx_user_assignments_duplicate_check IS 'Supports the overlapping-assignment check in CreateAssignment';
@@ -0,0 +1,18 @@
+-- Migration: Normalize user emails
+-- Date: 2026-10-16
+-- Description: User emails are now stored trimmed and lowercased, and POST /users rejects an email that
+-- already exists in the organization (ignoring case) with 409. This normalizes existing rows and indexes
+-- the lookup.
+
+-- Step 1: Normalize existing emails
+UPDATE iam.users
+SET email = LOWER(TRIM(email))
+WHERE email <> LOWER(TRIM(email));
+
+-- Step 2: Index the case-insensitive lookup used by the duplicate check
+CREATE INDEX IF NOT EXISTS idx_users_org_lower_email
+    ON iam.users (org_id, LOWER(email))
+    WHERE is_deleted = FALSE;
+
+-- Step 3: Add comments for documentation
+COMMENT ON INDEX iam.idx_users_org_lower_email IS 'Supports the per-organization duplicate email check on user creation';
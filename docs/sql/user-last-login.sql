@@ -0,0 +1,11 @@
+-- Migration: Track user last login
+-- Date: 2026-10-16
+-- Description: The token customizer records when a user last signed in with credentials. Token refreshes
+-- do not update it. GET /users/{userId} returns it as last_login_at.
+
+-- Step 1: Add column
+ALTER TABLE iam.users
+    ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP;
+
+-- Step 2: Add comments for documentation
+COMMENT ON COLUMN iam.users.last_login_at IS 'Time of the last credential sign-in (refresh-token grants are not counted)';
@@ -0,0 +1,24 @@
+-- Migration: Add user notification preferences
+-- Date: 2026-10-16
+-- Description: GET/PUT /users/{userId}/notification-prefs lets a user opt in or out of email and SNS
+-- per event type. A missing row means the defaults (email and SNS on). RFI assignment emails and every
+-- SNS notification sender check these before dispatching.
+
+-- Step 1: Create table
+CREATE TABLE IF NOT EXISTS iam.user_notification_prefs (
+    user_id         BIGINT NOT NULL REFERENCES iam.users(id),
+    event_type      VARCHAR(50) NOT NULL,
+    email_enabled   BOOLEAN NOT NULL DEFAULT TRUE,
+    sns_enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by      BIGINT NOT NULL,
+    PRIMARY KEY (user_id, event_type),
+    CONSTRAINT chk_user_notification_prefs_event_type
+        CHECK (event_type IN ('rfi_assigned', 'issue_assigned', 'issue_status_changed',
+                              'issue_watcher_update', 'submittal_review_requested'))
+);
+
+-- Step 2: Add comments for documentation
+COMMENT ON TABLE iam.user_notification_prefs IS 'Per-user, per-event notification channel opt-ins; a missing row means the defaults';
+COMMENT ON COLUMN iam.user_notification_prefs.email_enabled IS 'Whether the user is emailed for this event';
+COMMENT ON COLUMN iam.user_notification_prefs.sns_enabled IS 'Whether the user is included in SNS notifications for this event';
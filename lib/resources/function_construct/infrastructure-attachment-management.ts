@@ -25,6 +25,8 @@ export class InfrastructureAttachmentManagement extends Construct {
         const environment = {
             ...getBaseLambdaEnvironment(props.stageEnvironment),
             BUCKET_NAME: props.attachmentBucket.bucketName,
+            ATTACHMENT_ACCESS_LOG_ENABLED: 'true',
+            ATTACHMENT_MAX_PER_ENTITY: '200',
         };
 
         this.func = new GoFunction(this, id, {
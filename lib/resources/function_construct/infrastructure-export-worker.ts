@@ -0,0 +1,63 @@
+import {GoFunction} from "@aws-cdk/aws-lambda-go-alpha";
+import {Construct} from "constructs";
+import {FuncProps} from "../../types/func-props";
+import * as path from 'path';
+import {Duration, Size} from "aws-cdk-lib";
+import {GetRetentionDays} from "../../utils/lambda-utils";
+import {getBaseLambdaEnvironment} from "../../utils/lambda-environment";
+import {ssmPolicy} from "../../utils/policy-utils";
+import * as s3 from "aws-cdk-lib/aws-s3";
+import * as events from "aws-cdk-lib/aws-events";
+import * as targets from "aws-cdk-lib/aws-events-targets";
+
+interface ExportWorkerFuncProps extends FuncProps {
+    attachmentBucket: s3.Bucket;
+}
+
+export class InfrastructureExportWorker extends Construct {
+    private readonly func: GoFunction;
+
+    constructor(scope: Construct, id: string, props: ExportWorkerFuncProps) {
+        super(scope, id);
+
+        const functionName = `${props?.options.githubRepo}-export-worker`
+
+        const environment = {
+            ...getBaseLambdaEnvironment(props.stageEnvironment),
+            BUCKET_NAME: props.attachmentBucket.bucketName,
+        };
+
+        this.func = new GoFunction(this, id, {
+            entry: path.join(__dirname, `../../../src/infrastructure-export-worker`),
+            functionName: functionName,
+            timeout: Duration.minutes(15), // Packages include every attachment file on the project
+            memorySize: 1024,
+            ephemeralStorageSize: Size.gibibytes(10), // ZIP is staged in /tmp before upload
+            environment: environment,
+            logRetention: GetRetentionDays(props),
+            bundling: {
+                goBuildFlags: ['-ldflags "-s -w"'],
+            },
+        });
+
+        // Add SSM policy for accessing database parameters
+        this.func.addToRolePolicy(ssmPolicy());
+
+        // Reads attachment files and writes the generated package under exports/
+        props.attachmentBucket.grantReadWrite(this.func);
+
+        // Pick up pending export jobs every minute
+        new events.Rule(this, 'ExportWorkerSchedule', {
+            schedule: events.Schedule.rate(Duration.minutes(1)),
+            targets: [new targets.LambdaFunction(this.func)],
+        });
+    }
+
+    get function(): GoFunction {
+        return this.func
+    }
+
+    get functionArn(): string {
+        return this.func.functionArn;
+    }
+}
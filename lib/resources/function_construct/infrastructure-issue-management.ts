@@ -6,6 +6,8 @@ import {Duration} from "aws-cdk-lib";
 import {GetRetentionDays} from "../../utils/lambda-utils";
 import {getBaseLambdaEnvironment} from "../../utils/lambda-environment";
 import {ssmPolicy} from "../../utils/policy-utils";
+import * as sns from "aws-cdk-lib/aws-sns";
+import * as ssm from "aws-cdk-lib/aws-ssm";
 
 export class InfrastructureIssueManagement extends Construct {
     private readonly func: GoFunction;
@@ -27,6 +29,18 @@ export class InfrastructureIssueManagement extends Construct {
         });
 
         this.func.addToRolePolicy(ssmPolicy());
+
+        // Issue lifecycle events (status changes) for notifications and analytics consumers
+        const issueEventsTopic = new sns.Topic(this, 'IssueEventsTopic', {
+            topicName: `${props?.options.githubRepo}-issue-events`,
+        });
+        issueEventsTopic.grantPublish(this.func);
+
+        // The Lambda reads the topic ARN from SSM alongside its other parameters
+        new ssm.StringParameter(this, 'IssueEventsTopicArn', {
+            parameterName: '/infrastructure/ISSUE_EVENTS_TOPIC_ARN',
+            stringValue: issueEventsTopic.topicArn,
+        });
     }
 
     get function(): GoFunction {
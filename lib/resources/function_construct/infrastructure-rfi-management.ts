@@ -2,10 +2,14 @@ import {GoFunction} from "@aws-cdk/aws-lambda-go-alpha";
 import {Construct} from "constructs";
 import {FuncProps} from "../../types/func-props";
 import * as path from 'path';
-import {Duration} from "aws-cdk-lib";
+import {Aws, Duration} from "aws-cdk-lib";
 import {GetRetentionDays} from "../../utils/lambda-utils";
 import {getBaseLambdaEnvironment} from "../../utils/lambda-environment";
 import {ssmPolicy} from "../../utils/policy-utils";
+import {findStageOption} from "../../types/stack-options";
+import * as events from "aws-cdk-lib/aws-events";
+import * as targets from "aws-cdk-lib/aws-events-targets";
+import * as iam from "aws-cdk-lib/aws-iam";
 
 export class InfrastructureRFIManagement extends Construct {
     private readonly func: GoFunction;
@@ -27,6 +31,35 @@ export class InfrastructureRFIManagement extends Construct {
         });
 
         this.func.addToRolePolicy(ssmPolicy());
+
+        // Assignee change events go to the topic owned by the issue management construct
+        this.func.addToRolePolicy(new iam.PolicyStatement({
+            actions: ['sns:Publish'],
+            resources: [`arn:aws:sns:*:*:${props?.options.githubRepo}-issue-events`],
+        }));
+
+        // Assignment emails are sent directly to assignees through SES, only from the stage's verified identity
+        const sesIdentity = findStageOption(props.options, props.stageEnvironment).sesIdentity;
+        if (sesIdentity) {
+            this.func.addToRolePolicy(new iam.PolicyStatement({
+                actions: ['ses:SendEmail'],
+                resources: [`arn:aws:ses:us-east-2:${Aws.ACCOUNT_ID}:identity/${sesIdentity}`],
+            }));
+        }
+
+        // Auto-close answered RFIs that have passed their org's threshold once a day.
+        // The event is shaped like an API Gateway request so it goes through the same Handler.
+        new events.Rule(this, 'RFIAutoCloseSchedule', {
+            schedule: events.Schedule.cron({minute: '0', hour: '6'}),
+            targets: [new targets.LambdaFunction(this.func, {
+                event: events.RuleTargetInput.fromObject({
+                    resource: '/internal/rfis/auto-close',
+                    path: '/internal/rfis/auto-close',
+                    httpMethod: 'POST',
+                    headers: {'X-Internal-Invocation': 'scheduled'},
+                }),
+            })],
+        });
     }
 
     get function(): GoFunction {
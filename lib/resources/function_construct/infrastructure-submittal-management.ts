@@ -4,6 +4,7 @@ import {GoFunction} from '@aws-cdk/aws-lambda-go-alpha';
 import {FuncProps} from "../../types/func-props";
 import {getBaseLambdaEnvironment} from "../../utils/lambda-environment";
 import {ssmPolicy} from "../../utils/policy-utils";
+import * as iam from "aws-cdk-lib/aws-iam";
 
 export class InfrastructureSubmittalManagement extends Construct {
 
@@ -23,6 +24,12 @@ export class InfrastructureSubmittalManagement extends Construct {
 
         this.function.addToRolePolicy(ssmPolicy());
 
+        // Review request events go to the topic owned by the issue management construct
+        this.function.addToRolePolicy(new iam.PolicyStatement({
+            actions: ['sns:Publish'],
+            resources: [`arn:aws:sns:*:*:${props?.options.githubRepo}-issue-events`],
+        }));
+
         this.functionArn = this.function.functionArn;
     }
 }
\ No newline at end of file
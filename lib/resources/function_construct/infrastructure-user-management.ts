@@ -19,7 +19,7 @@ export class InfrastructureUserManagement extends Construct {
         this.func = new GoFunction(this, id, {
             entry: path.join(__dirname, `../../../src/infrastructure-user-management`),
             functionName: functionName,
-            timeout: Duration.seconds(10),
+            timeout: Duration.seconds(28), // Room for POST /users/import (up to 20 Cognito creates in sequence), under API Gateway's 29s limit
             environment: getBaseLambdaEnvironment(props.stageEnvironment),
             logRetention: GetRetentionDays(props),
             bundling: {
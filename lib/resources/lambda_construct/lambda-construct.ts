@@ -16,6 +16,7 @@ import {InfrastructureRFIManagement} from "../function_construct/infrastructure-
 import {InfrastructureAssignmentManagement} from "../function_construct/infrastructure-assignment-management";
 import {InfrastructureSubmittalManagement} from "../function_construct/infrastructure-submittal-management";
 import {InfrastructureAttachmentManagement} from "../function_construct/infrastructure-attachment-management";
+import {InfrastructureExportWorker} from "../function_construct/infrastructure-export-worker";
 
 export class LambdaConstruct extends Construct {
 
@@ -33,6 +34,7 @@ export class LambdaConstruct extends Construct {
     private readonly infrastructureAssignmentManagement: InfrastructureAssignmentManagement;
     private readonly infrastructureSubmittalManagement: InfrastructureSubmittalManagement;
     private readonly infrastructureAttachmentManagement: InfrastructureAttachmentManagement;
+    private readonly infrastructureExportWorker: InfrastructureExportWorker;
 
     constructor(scope: Construct, id: string, props: LambdaConstructProps) {
         super(scope, id);
@@ -62,6 +64,10 @@ export class LambdaConstruct extends Construct {
                 ...funcProps,
                 attachmentBucket: props.attachmentBucket
             });
+            this.infrastructureExportWorker = new InfrastructureExportWorker(this, 'InfrastructureExportWorker', {
+                ...funcProps,
+                attachmentBucket: props.attachmentBucket
+            });
         }
     }
 
@@ -176,4 +182,8 @@ export class LambdaConstruct extends Construct {
     get attachmentManagementLambdaArn(): string {
         return this.infrastructureAttachmentManagement?.function.functionArn;
     }
+
+    get exportWorkerLambda(): any {
+        return this.infrastructureExportWorker?.function;
+    }
 }
\ No newline at end of file
@@ -59,6 +59,8 @@ export class SubStack extends NestedStack {
             deployOptions: {
                 stageName: props.options.apiStageName,
             },
+            // Lambda file downloads (e.g. RFI CSV export) return base64-encoded bodies
+            binaryMediaTypes: ['text/csv'],
             defaultCorsPreflightOptions: {
                 allowOrigins: Cors.ALL_ORIGINS,
                 allowMethods: Cors.ALL_METHODS,
@@ -68,7 +70,9 @@ export class SubStack extends NestedStack {
                     'Authorization',
                     'X-Api-Key',
                     'X-Amz-Security-Token',
-                    'X-Amz-User-Agent'
+                    'X-Amz-User-Agent',
+                    'Idempotency-Key',
+                    'If-None-Match'
                 ]
             }
         });
@@ -118,6 +122,12 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /locations/tree resource for the nested location hierarchy
+        locationsResource.addResource('tree').addMethod('GET', locationManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /locations/{id} resource for specific location operations
         const locationIdResource = locationsResource.addResource('{id}');
         locationIdResource.addMethod('GET', locationManagementIntegration, {
@@ -187,6 +197,14 @@ export class SubStack extends NestedStack {
         // }); // Temporarily commented to avoid API Gateway limits
         // CORS handled at API Gateway level
 
+        // Caller's effective permissions (any authenticated user)
+        const meResource = this.api.root.addResource('me');
+        const mePermissionsResource = meResource.addResource('permissions');
+        mePermissionsResource.addMethod('GET', permissionsManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /projects resource with Cognito authorization
         const projectsResource = this.api.root.addResource('projects');
         projectsResource.addMethod('GET', projectManagementIntegration, {
@@ -197,6 +215,25 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /projects/accessible resource for the projects the caller can reach through assignments
+        const projectsAccessibleResource = projectsResource.addResource('accessible');
+        projectsAccessibleResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/warranty-expiring resource for projects whose warranty ends soon
+        const projectsWarrantyExpiringResource = projectsResource.addResource('warranty-expiring');
+        projectsWarrantyExpiringResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Unauthenticated /projects/health for synthetic monitoring
+        const projectsHealthResource = projectsResource.addResource('health');
+        projectsHealthResource.addMethod('GET', projectManagementIntegration);
+        // CORS handled at API Gateway level
+
         // Create /projects/{projectId} resource for specific project operations
         const projectIdResource = projectsResource.addResource('{projectId}');
         projectIdResource.addMethod('GET', projectManagementIntegration, {
@@ -210,6 +247,90 @@ export class SubStack extends NestedStack {
         // }); // Temporarily commented to avoid API Gateway limits
         // CORS handled at API Gateway level
 
+        // Create /projects/{projectId}/restore resource to undo a project soft delete
+        const projectRestoreResource = projectIdResource.addResource('restore');
+        projectRestoreResource.addMethod('POST', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+
+        // Create /projects/{projectId}/milestones resource for project schedule milestones
+        const projectMilestonesResource = projectIdResource.addResource('milestones');
+        projectMilestonesResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        projectMilestonesResource.addMethod('POST', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/milestones/{milestoneId} resource for specific milestone operations
+        const projectMilestoneIdResource = projectMilestonesResource.addResource('{milestoneId}');
+        projectMilestoneIdResource.addMethod('PUT', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        projectMilestoneIdResource.addMethod('DELETE', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/milestones/{milestoneId}/complete resource for marking milestones complete
+        const projectMilestoneCompleteResource = projectMilestoneIdResource.addResource('complete');
+        projectMilestoneCompleteResource.addMethod('POST', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/folders resource for project document folders
+        const projectFoldersResource = projectIdResource.addResource('folders');
+        projectFoldersResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        projectFoldersResource.addMethod('POST', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/folders/{folderId} resource for renaming and moving folders
+        const projectFolderIdResource = projectFoldersResource.addResource('{folderId}');
+        projectFolderIdResource.addMethod('PUT', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/documents resource for the folder tree and filed attachments
+        const projectDocumentsResource = projectIdResource.addResource('documents');
+        projectDocumentsResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/attachments/{attachmentId}/folder resource for moving attachments between folders
+        const projectAttachmentsResource = projectIdResource.addResource('attachments');
+        const projectAttachmentIdResource = projectAttachmentsResource.addResource('{attachmentId}');
+        const projectAttachmentFolderResource = projectAttachmentIdResource.addResource('folder');
+        projectAttachmentFolderResource.addMethod('PUT', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/search resource for search across issues, RFIs and submittals
+        const projectSearchResource = projectIdResource.addResource('search');
+        projectSearchResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/default-assignee resource for project default assignee settings
+        const projectDefaultAssigneeResource = projectIdResource.addResource('default-assignee');
+        projectDefaultAssigneeResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        projectDefaultAssigneeResource.addMethod('PUT', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
 
         // Project attachments now handled by centralized attachment management service
         // Removed: /projects/{projectId}/attachments and /projects/{projectId}/attachments/{attachmentId}
@@ -224,6 +345,27 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /projects/{projectId}/users/history resource for the user role audit trail
+        const projectUsersHistoryResource = projectUsersResource.addResource('history');
+        projectUsersHistoryResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/stats resource for dashboard counts by status
+        const projectStatsResource = projectIdResource.addResource('stats');
+        projectStatsResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/my-roles resource for the caller's roles and permissions on the project
+        const projectMyRolesResource = projectIdResource.addResource('my-roles');
+        projectMyRolesResource.addMethod('GET', projectManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /projects/{projectId}/users/{assignmentId} resource for specific user role operations
         const projectUserAssignmentIdResource = projectUsersResource.addResource('{assignmentId}');
         projectUserAssignmentIdResource.addMethod('PUT', projectManagementIntegration, {
@@ -244,6 +386,13 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /users/import resource for CSV onboarding of many users at once
+        const usersImportResource = usersResource.addResource('import');
+        usersImportResource.addMethod('POST', userManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /users/{userId} resource for specific user operations
         const userIdResource = usersResource.addResource('{userId}');
         userIdResource.addMethod('GET', userManagementIntegration, {
@@ -257,6 +406,30 @@ export class SubStack extends NestedStack {
         // }); // Temporarily commented to avoid API Gateway limits
         // CORS handled at API Gateway level
 
+        // Create /users/{userId}/projects resource for listing a user's project assignments
+        const userProjectsResource = userIdResource.addResource('projects');
+        userProjectsResource.addMethod('GET', userManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /users/{userId}/assignments resource for a user's active assignments across contexts
+        const userAssignmentsResource = userIdResource.addResource('assignments');
+        userAssignmentsResource.addMethod('GET', assignmentManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /users/{userId}/notification-prefs resource for per-event email/SNS opt-ins
+        const userNotificationPrefsResource = userIdResource.addResource('notification-prefs');
+        userNotificationPrefsResource.addMethod('GET', userManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        userNotificationPrefsResource.addMethod('PUT', userManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /users/{userId}/reset-password resource for password reset
         const userPasswordResetResource = userIdResource.addResource('reset-password');
         userPasswordResetResource.addMethod('PATCH', userManagementIntegration, {
@@ -297,11 +470,49 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /projects/{projectId}/rfis/export resource for RFI log export
+        const projectRfisExportResource = projectRfisResource.addResource('export');
+        projectRfisExportResource.addMethod('GET', rfiManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/rfis/sla-breaches resource for RFIs past their response due date
+        const projectRfisSlaBreachesResource = projectRfisResource.addResource('sla-breaches');
+        projectRfisSlaBreachesResource.addMethod('GET', rfiManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /projects/{projectId}/rfis/by-number/{rfiNumber} resource for looking up RFIs by number
+        const projectRfisByNumberResource = projectRfisResource.addResource('by-number');
+        const projectRfiNumberResource = projectRfisByNumberResource.addResource('{rfiNumber}');
+        projectRfiNumberResource.addMethod('GET', rfiManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /issues resource for direct issue operations
         const issuesResource = this.api.root.addResource('issues');
         issuesResource.addMethod('POST', issueManagementIntegration, {
             authorizer: cognitoAuthorizer
         });
+        // GET /issues searches issues across every project in the caller's organization
+        issuesResource.addMethod('GET', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /issues/bulk-status resource for bulk status updates
+        const issuesBulkStatusResource = issuesResource.addResource('bulk-status');
+        issuesBulkStatusResource.addMethod('POST', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Unauthenticated /issues/health for synthetic monitoring
+        const issuesHealthResource = issuesResource.addResource('health');
+        issuesHealthResource.addMethod('GET', issueManagementIntegration);
         // CORS handled at API Gateway level
 
         // Create /issues/{issueId} resource for specific issue operations
@@ -334,6 +545,51 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /issues/{issueId}/comments/{commentId} resource for editing and deleting a comment
+        const issueCommentIdResource = issueCommentsResource.addResource('{commentId}');
+        issueCommentIdResource.addMethod('PUT', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        issueCommentIdResource.addMethod('DELETE', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /issues/{issueId}/watchers resource for users subscribed to issue updates
+        const issueWatchersResource = issueIdResource.addResource('watchers');
+        issueWatchersResource.addMethod('GET', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        issueWatchersResource.addMethod('POST', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        const issueWatcherUserResource = issueWatchersResource.addResource('{userId}');
+        issueWatcherUserResource.addMethod('DELETE', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /issues/{issueId}/activity resource for the issue's activity log
+        const issueActivityResource = issueIdResource.addResource('activity');
+        issueActivityResource.addMethod('GET', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /issues/{issueId}/copy resource for copying an issue into another project
+        const issueCopyResource = issueIdResource.addResource('copy');
+        issueCopyResource.addMethod('POST', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Create /issues/{issueId}/restore resource for undoing a soft delete
+        const issueRestoreResource = issueIdResource.addResource('restore');
+        issueRestoreResource.addMethod('POST', issueManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // CONSOLIDATED RFI MANAGEMENT (6 endpoints total)
 
         // Core RFI CRUD operations
@@ -343,6 +599,18 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // RFIs assigned to the caller across all projects in their org
+        const rfisAssignedToMeResource = rfisResource.addResource('assigned-to-me');
+        rfisAssignedToMeResource.addMethod('GET', rfiManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Unauthenticated /rfis/health for synthetic monitoring
+        const rfisHealthResource = rfisResource.addResource('health');
+        rfisHealthResource.addMethod('GET', rfiManagementIntegration);
+        // CORS handled at API Gateway level
+
         const rfiIdResource = rfisResource.addResource('{rfiId}');
         rfiIdResource.addMethod('GET', rfiManagementIntegration, {
             authorizer: cognitoAuthorizer
@@ -361,6 +629,12 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        const rfiCopyResource = rfiIdResource.addResource('copy');
+        rfiCopyResource.addMethod('POST', rfiManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /assignments resource for direct assignment operations
         const assignmentsResource = this.api.root.addResource('assignments');
         assignmentsResource.addMethod('POST', assignmentManagementIntegration, {
@@ -368,6 +642,13 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Create /assignments/bulk resource for staffing many assignments at once
+        const assignmentsBulkResource = assignmentsResource.addResource('bulk');
+        assignmentsBulkResource.addMethod('POST', assignmentManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Create /assignments/{assignmentId} resource for specific assignment operations
         const assignmentIdResource = assignmentsResource.addResource('{assignmentId}');
         assignmentIdResource.addMethod('GET', assignmentManagementIntegration, {
@@ -443,6 +724,32 @@ export class SubStack extends NestedStack {
         });
         // CORS handled at API Gateway level
 
+        // Submittal revision history
+        const submittalRevisionsResource = submittalIdResource.addResource('revisions');
+        submittalRevisionsResource.addMethod('GET', submittalManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Copy submittal into another project
+        const submittalCopyResource = submittalIdResource.addResource('copy');
+        submittalCopyResource.addMethod('POST', submittalManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
+        // Submittal review routing: reorder pending reviewers and skip a reviewer
+        const submittalDistributionResource = submittalIdResource.addResource('distribution');
+        submittalDistributionResource.addMethod('PUT', submittalManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        const submittalReviewerResource = submittalDistributionResource.addResource('{reviewerId}');
+        const submittalReviewerSkipResource = submittalReviewerResource.addResource('skip');
+        submittalReviewerSkipResource.addMethod('POST', submittalManagementIntegration, {
+            authorizer: cognitoAuthorizer
+        });
+        // CORS handled at API Gateway level
+
         // Submittal attachments now handled by centralized attachment management service
         // Removed: /submittals/{submittalId}/attachments
 
@@ -459,11 +766,27 @@ export class SubStack extends NestedStack {
                 authorizer: cognitoAuthorizer
             });
 
+            // Batch upload URLs for multi-file uploads
+            const attachmentUploadUrlsResource = attachmentsResource.addResource('upload-urls');
+            attachmentUploadUrlsResource.addMethod('POST', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Unauthenticated health check for synthetic monitoring
+            const attachmentHealthResource = attachmentsResource.addResource('health');
+            attachmentHealthResource.addMethod('GET', attachmentManagementIntegration);
+
             const attachmentConfirmResource = attachmentsResource.addResource('confirm');
             attachmentConfirmResource.addMethod('POST', attachmentManagementIntegration, {
                 authorizer: cognitoAuthorizer
             });
 
+            // Orphaned comment attachment listing (super admin only)
+            const attachmentOrphansResource = attachmentsResource.addResource('orphans');
+            attachmentOrphansResource.addMethod('GET', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
             // Attachment operations by ID
             const attachmentIdResource = attachmentsResource.addResource('{id}');
             attachmentIdResource.addMethod('GET', attachmentManagementIntegration, {
@@ -479,6 +802,34 @@ export class SubStack extends NestedStack {
                 authorizer: cognitoAuthorizer
             });
 
+            // Thumbnail URL for image attachments
+            const attachmentThumbnailUrlResource = attachmentIdResource.addResource('thumbnail-url');
+            attachmentThumbnailUrlResource.addMethod('GET', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Download access audit log (super admin only)
+            const attachmentAccessLogResource = attachmentIdResource.addResource('access-log');
+            attachmentAccessLogResource.addMethod('GET', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Relink orphaned comment attachment (super admin only)
+            const attachmentRelinkResource = attachmentIdResource.addResource('relink');
+            attachmentRelinkResource.addMethod('POST', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Link a comment attachment uploaded with entity_id 0 to its comment
+            const attachmentEntityResource = attachmentIdResource.addResource('entity');
+            attachmentEntityResource.addMethod('PATCH', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Virus scanner callback; authenticated with the internal API key header, not Cognito
+            const attachmentScanResultResource = attachmentIdResource.addResource('scan-result');
+            attachmentScanResultResource.addMethod('POST', attachmentManagementIntegration);
+
             // Entity-based attachment queries
             const entitiesResource = this.api.root.addResource('entities');
             const entityTypeResource = entitiesResource.addResource('{type}');
@@ -487,6 +838,31 @@ export class SubStack extends NestedStack {
             entityAttachmentsResource.addMethod('GET', attachmentManagementIntegration, {
                 authorizer: cognitoAuthorizer
             });
+            // Attachment counts for many entities of one type in a single call (e.g. list views)
+            const entityTypeAttachmentsResource = entityTypeResource.addResource('attachments');
+            const entityAttachmentCountsResource = entityTypeAttachmentsResource.addResource('counts');
+            entityAttachmentCountsResource.addMethod('POST', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Project export packages (built asynchronously by the export worker)
+            const projectExportPackageResource = projectIdResource.addResource('export-package');
+            projectExportPackageResource.addMethod('GET', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            const exportsResource = this.api.root.addResource('exports');
+            const exportJobIdResource = exportsResource.addResource('{jobId}');
+            exportJobIdResource.addMethod('GET', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
+
+            // Maintenance: rebuild denormalized attachment/comment counts (super admin only)
+            const adminResource = this.api.root.addResource('admin');
+            const adminRecountResource = adminResource.addResource('recount');
+            adminRecountResource.addMethod('POST', attachmentManagementIntegration, {
+                authorizer: cognitoAuthorizer
+            });
         }
 
         // CORS handled at API Gateway level
@@ -34,6 +34,8 @@ export type StageOptions = {
     domainName: string,
     domainNameAliasTarget: string,
     domainNameAliasHostedZoneId: string,
+    // Verified SES identity (domain) emails are sent from; empty disables the SES grant
+    sesIdentity: string,
 };
 
 export const findStageOption = (options: StackOptions, o: StageEnvironment) => {
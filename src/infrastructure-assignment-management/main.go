@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -26,6 +27,7 @@ var (
 	ssmParams            map[string]string
 	sqlDB                *sql.DB
 	assignmentRepository data.AssignmentRepository
+	userRepository       data.UserManagementRepository
 )
 
 // Handler processes API Gateway requests for assignment management operations
@@ -34,12 +36,16 @@ var (
 //
 // Core CRUD Operations:
 //   GET    /assignments/{id}                                 - Get single assignment
-//   POST   /assignments                                      - Create assignment
+//   POST   /assignments?upsert=true                          - Create assignment (upsert updates a duplicate)
+//   POST   /assignments/bulk                                 - Create many assignments (partial results)
 //   PUT    /assignments/{id}                                 - Update assignment
 //   DELETE /assignments/{id}                                 - Delete assignment
 //
 // Project Team Query:
 //   GET    /contexts/{contextType}/{contextId}/assignments  - Get team for project/location
+//
+// User Query:
+//   GET    /users/{userId}/assignments?context_type=        - Get a user's active assignments
 func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	logger.WithFields(logrus.Fields{
 		"method":      request.HTTPMethod,
@@ -73,6 +79,8 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return handleGetAssignment(ctx, request, claims)
 	case request.Resource == "/assignments" && request.HTTPMethod == "POST":
 		return handleCreateAssignment(ctx, request, claims)
+	case request.Resource == "/assignments/bulk" && request.HTTPMethod == "POST":
+		return handleBulkCreateAssignments(ctx, request, claims)
 	case request.Resource == "/assignments/{assignmentId}" && request.HTTPMethod == "PUT":
 		return handleUpdateAssignment(ctx, request, claims)
 	case request.Resource == "/assignments/{assignmentId}" && request.HTTPMethod == "DELETE":
@@ -82,6 +90,10 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	case request.Resource == "/contexts/{contextType}/{contextId}/assignments" && request.HTTPMethod == "GET":
 		return handleGetContextAssignments(ctx, request, claims)
 
+	// User assignments endpoint
+	case request.Resource == "/users/{userId}/assignments" && request.HTTPMethod == "GET":
+		return handleGetUserAssignments(ctx, request, claims)
+
 	default:
 		logger.WithFields(logrus.Fields{
 			"method":    request.HTTPMethod,
@@ -93,6 +105,8 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 }
 
 // handleCreateAssignment handles POST /assignments
+// A duplicate of a live assignment returns 409 with its id; with upsert=true the existing assignment's
+// trade type, primary flag and dates are updated instead and 200 is returned.
 func handleCreateAssignment(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	var createRequest models.CreateAssignmentRequest
 	if err := api.ParseJSONBody(request.Body, &createRequest); err != nil {
@@ -100,9 +114,23 @@ func handleCreateAssignment(ctx context.Context, request events.APIGatewayProxyR
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
 	}
 
+	if statusCode, errCode, errMsg := api.ValidateUserInOrg(ctx, userRepository, createRequest.UserID, claims.OrgID, "user_id"); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
 	userID := claims.UserID
 	assignment, err := assignmentRepository.CreateAssignment(ctx, &createRequest, userID)
 	if err != nil {
+		var conflictErr *models.AssignmentConflictError
+		if errors.As(err, &conflictErr) {
+			if request.QueryStringParameters["upsert"] == "true" {
+				return upsertExistingAssignment(ctx, conflictErr.AssignmentID, &createRequest, userID)
+			}
+			return api.ConflictResponse("User already has this role in this context", map[string]interface{}{
+				"code":          api.ErrorCodeAssignmentExists,
+				"assignment_id": conflictErr.AssignmentID,
+			}, logger), nil
+		}
 		logger.WithError(err).Error("Failed to create assignment")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create assignment", logger), nil
 	}
@@ -111,6 +139,72 @@ func handleCreateAssignment(ctx context.Context, request events.APIGatewayProxyR
 }
 
 
+// upsertExistingAssignment applies the attributes of a duplicate create request to the existing assignment
+func upsertExistingAssignment(ctx context.Context, assignmentID int64, createRequest *models.CreateAssignmentRequest, userID int64) (events.APIGatewayProxyResponse, error) {
+	isPrimary := createRequest.IsPrimary
+	assignment, err := assignmentRepository.UpdateAssignment(ctx, assignmentID, &models.UpdateAssignmentRequest{
+		TradeType: createRequest.TradeType,
+		IsPrimary: &isPrimary,
+		StartDate: createRequest.StartDate,
+		EndDate:   createRequest.EndDate,
+	}, userID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusConflict, "Existing assignment changed during upsert; retry the request", logger), nil
+		}
+		logger.WithError(err).Error("Failed to upsert assignment")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update existing assignment", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, assignment, logger), nil
+}
+
+// handleBulkCreateAssignments handles POST /assignments/bulk
+func handleBulkCreateAssignments(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	var bulkRequest models.BulkCreateAssignmentsRequest
+	if err := api.ParseJSONBody(request.Body, &bulkRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for bulk create assignments")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if len(bulkRequest.Assignments) == 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "assignments must contain at least one item", logger), nil
+	}
+	if len(bulkRequest.Assignments) > models.MaxBulkAssignments {
+		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("assignments cannot contain more than %d items", models.MaxBulkAssignments), logger), nil
+	}
+
+	batchResults, err := assignmentRepository.CreateAssignmentBatch(ctx, bulkRequest.Assignments, claims.OrgID, claims.UserID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create bulk assignments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create assignments", logger), nil
+	}
+
+	// Items are identified by their index in the request
+	results := api.NewBulkResults(len(batchResults))
+	for i, result := range batchResults {
+		if result.Err == nil {
+			results.AddSuccessWithData(i, result.Assignment)
+			continue
+		}
+
+		var conflictErr *models.AssignmentConflictError
+		switch {
+		case errors.As(result.Err, &conflictErr), errors.Is(result.Err, data.ErrConflict):
+			results.AddError(i, api.BulkErrorConflict, result.Err.Error())
+		case errors.Is(result.Err, data.ErrOrgMismatch):
+			results.AddError(i, api.BulkErrorForbidden, result.Err.Error())
+		case errors.Is(result.Err, data.ErrInvalid):
+			results.AddError(i, api.BulkErrorInvalid, result.Err.Error())
+		default:
+			logger.WithError(result.Err).WithField("index", i).Error("Failed to create bulk assignment item")
+			results.AddError(i, api.BulkErrorInternalError, "Failed to create assignment")
+		}
+	}
+
+	return api.BulkResponse(results, logger), nil
+}
+
 // handleGetAssignment handles GET /assignments/{assignmentId}
 func handleGetAssignment(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	assignmentID, err := strconv.ParseInt(request.PathParameters["assignmentId"], 10, 64)
@@ -121,7 +215,7 @@ func handleGetAssignment(ctx context.Context, request events.APIGatewayProxyRequ
 
 	assignment, err := assignmentRepository.GetAssignment(ctx, assignmentID, claims.OrgID)
 	if err != nil {
-		if err.Error() == "assignment not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Assignment not found", logger), nil
 		}
 		logger.WithError(err).Error("Failed to get assignment")
@@ -148,7 +242,7 @@ func handleUpdateAssignment(ctx context.Context, request events.APIGatewayProxyR
 	userID := claims.UserID
 	assignment, err := assignmentRepository.UpdateAssignment(ctx, assignmentID, &updateRequest, userID)
 	if err != nil {
-		if err.Error() == "assignment not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Assignment not found", logger), nil
 		}
 		logger.WithError(err).Error("Failed to update assignment")
@@ -169,7 +263,7 @@ func handleDeleteAssignment(ctx context.Context, request events.APIGatewayProxyR
 	userID := claims.UserID
 	err = assignmentRepository.DeleteAssignment(ctx, assignmentID, userID)
 	if err != nil {
-		if err.Error() == "assignment not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Assignment not found", logger), nil
 		}
 		logger.WithError(err).Error("Failed to delete assignment")
@@ -201,14 +295,60 @@ func handleGetContextAssignments(ctx context.Context, request events.APIGatewayP
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get context assignments", logger), nil
 	}
 
-	return api.SuccessResponse(http.StatusOK, contextAssignments, logger), nil
+	return api.ListResponse(request, contextAssignments, contextAssignments.Assignments, api.SinglePageMeta(len(contextAssignments.Assignments)), logger), nil
 }
 
+// handleGetUserAssignments handles GET /users/{userId}/assignments?context_type=
+// Super admins may query any user in their organization; everyone else may only query themselves.
+func handleGetUserAssignments(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	userID, err := strconv.ParseInt(request.PathParameters["userId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid user ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid user ID", logger), nil
+	}
+
+	if userID != claims.UserID {
+		if !claims.IsSuperAdmin {
+			return api.ErrorResponse(http.StatusForbidden, "You can only view your own assignments", logger), nil
+		}
+		if _, err := userRepository.GetUserByID(ctx, userID, claims.OrgID); err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				return api.ErrorResponse(http.StatusNotFound, "User not found", logger), nil
+			}
+			logger.WithError(err).Error("Failed to get user")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to get user", logger), nil
+		}
+	}
+
+	contextType := request.QueryStringParameters["context_type"]
+	switch contextType {
+	case "", models.ContextTypeOrganization, models.ContextTypeProject, models.ContextTypeLocation,
+		models.ContextTypeDepartment, models.ContextTypeEquipment, models.ContextTypePhase:
+	default:
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid context_type. Must be one of: organization, project, location, department, equipment, phase", logger), nil
+	}
+
+	assignments, err := assignmentRepository.GetAssignmentsByUser(ctx, userID, claims.OrgID, contextType)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get user assignments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get user assignments", logger), nil
+	}
+
+	response := models.AssignmentListResponse{
+		Assignments: assignments,
+		Total:       len(assignments),
+	}
+
+	return api.ListResponse(request, response, assignments, api.SinglePageMeta(len(assignments)), logger), nil
+}
 
 // setupPostgresSQLClient initializes the PostgreSQL database connection and repository
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -228,8 +368,14 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		Logger: logger,
 	}
 
+	// Initialize user repository (assignee organization checks)
+	userRepository = &data.UserManagementDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -13,6 +15,7 @@ import (
 	"infrastructure/lib/util"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -30,7 +33,14 @@ var (
 	ssmParams             map[string]string
 	sqlDB                 *sql.DB
 	attachmentRepository  data.AttachmentRepository
+	exportJobRepository   data.ExportJobRepository
+	issueRepository       data.IssueRepository
+	rfiRepository         data.RFIRepository
+	submittalRepository   data.SubmittalRepository
 	s3Client              clients.S3ClientInterface
+	accessLogEnabled      bool
+	maxAttachmentsPerType map[string]int
+	scanAPIKey            string
 )
 
 // Handler processes API Gateway requests for attachment management operations
@@ -43,10 +53,21 @@ var (
 //   GET    /attachments/{id}                           - Get attachment metadata
 //   GET    /attachments/{id}/download-url              - Generate presigned download URL
 //   DELETE /attachments/{id}                           - Soft delete attachment
+//   GET    /attachments/{id}/access-log                - Download history (super admin only)
 //
 // Entity Queries:
 //   GET    /entities/{type}/{id}/attachments           - List attachments for entity
 //
+// Export Packages:
+//   GET    /projects/{projectId}/export-package        - Start a project export package job (async)
+//   GET    /exports/{jobId}                            - Poll export job status and fetch the download URL
+//
+// Maintenance (super admin only):
+//   POST   /admin/recount?entity_type=&project_id=     - Rebuild denormalized attachment/comment counts
+//
+// Internal (X-Internal-Api-Key instead of a Cognito token):
+//   POST   /attachments/{id}/scan-result               - Virus scanner reports clean or infected
+//
 func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	logger.WithFields(logrus.Fields{
 		"method":      request.HTTPMethod,
@@ -56,6 +77,20 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		"operation":   "Handler",
 	}).Debug("Processing attachment management request")
 
+	// Health checks are unauthenticated so synthetic monitors can call them without a token
+	if request.Resource == "/attachments/health" && request.HTTPMethod == http.MethodGet {
+		return api.HealthCheckResponse(ctx, sqlDB, logger), nil
+	}
+
+	// The virus scanner authenticates with the internal API key rather than a user token
+	if request.Resource == "/attachments/{id}/scan-result" && request.HTTPMethod == http.MethodPost {
+		if !auth.HasInternalAPIKey(request, scanAPIKey) {
+			logger.WithField("operation", "Handler").Warn("Rejected scan result without a valid internal API key")
+			return api.ErrorResponse(http.StatusUnauthorized, "Authentication failed", logger), nil
+		}
+		return handleScanResult(ctx, request), nil
+	}
+
 	// Extract claims from JWT token via API Gateway authorizer
 	claims, err := auth.ExtractClaimsFromRequest(request)
 	if err != nil {
@@ -78,14 +113,30 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	// Upload operations
 	case request.Resource == "/attachments/upload-url" && request.HTTPMethod == "POST":
 		return handleGenerateUploadURL(ctx, request, claims)
+	case request.Resource == "/attachments/upload-urls" && request.HTTPMethod == "POST":
+		return handleGenerateBatchUploadURLs(ctx, request, claims)
 	case request.Resource == "/attachments/confirm" && request.HTTPMethod == "POST":
 		return handleConfirmUpload(ctx, request, claims)
 
+	// Maintenance operations
+	case request.Resource == "/attachments/orphans" && request.HTTPMethod == "GET":
+		return handleGetOrphanedAttachments(ctx, claims)
+	case request.Resource == "/attachments/{id}/entity" && request.HTTPMethod == "PATCH":
+		return handleUpdateAttachmentEntity(ctx, request, claims)
+	case request.Resource == "/attachments/{id}/relink" && request.HTTPMethod == "POST":
+		return handleRelinkAttachment(ctx, request, claims)
+	case request.Resource == "/admin/recount" && request.HTTPMethod == "POST":
+		return handleRecountCounts(ctx, request, claims)
+
 	// Download operations
 	case request.Resource == "/attachments/{id}" && request.HTTPMethod == "GET":
 		return handleGetAttachment(ctx, request, claims)
 	case request.Resource == "/attachments/{id}/download-url" && request.HTTPMethod == "GET":
 		return handleGenerateDownloadURL(ctx, request, claims)
+	case request.Resource == "/attachments/{id}/thumbnail-url" && request.HTTPMethod == "GET":
+		return handleGenerateThumbnailURL(ctx, request, claims)
+	case request.Resource == "/attachments/{id}/access-log" && request.HTTPMethod == "GET":
+		return handleGetAttachmentAccessLog(ctx, request, claims)
 
 	// Delete operations
 	case request.Resource == "/attachments/{id}" && request.HTTPMethod == "DELETE":
@@ -94,6 +145,14 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	// Entity-based queries
 	case request.Resource == "/entities/{type}/{id}/attachments" && request.HTTPMethod == "GET":
 		return handleGetEntityAttachments(ctx, request, claims)
+	case request.Resource == "/entities/{type}/attachments/counts" && request.HTTPMethod == "POST":
+		return handleCountEntityAttachments(ctx, request, claims)
+
+	// Export packages
+	case request.Resource == "/projects/{projectId}/export-package" && request.HTTPMethod == "GET":
+		return handleStartExportPackage(ctx, request, claims)
+	case request.Resource == "/exports/{jobId}" && request.HTTPMethod == "GET":
+		return handleGetExportJob(ctx, request, claims)
 
 	default:
 		logger.WithFields(logrus.Fields{
@@ -110,6 +169,10 @@ func handleGenerateUploadURL(ctx context.Context, request events.APIGatewayProxy
 	var uploadReq models.AttachmentUploadRequest
 	if err := api.ParseJSONBody(request.Body, &uploadReq); err != nil {
 		logger.WithError(err).Error("Invalid request body for upload URL")
+		var numberErr *models.InvalidNumberError
+		if errors.As(err, &numberErr) {
+			return api.ErrorResponse(http.StatusBadRequest, numberErr.Error(), logger), nil
+		}
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
 	}
 
@@ -118,7 +181,8 @@ func handleGenerateUploadURL(ctx context.Context, request events.APIGatewayProxy
 
 	// Validate required fields
 	// For issue_comment and rfi_comment, entity_id can be 0 (will be updated after comment creation)
-	if uploadReq.EntityType == "" || uploadReq.ProjectID == 0 || uploadReq.LocationID == 0 || uploadReq.FileName == "" {
+	// location_id is optional; when omitted it is derived from the project below
+	if uploadReq.EntityType == "" || uploadReq.ProjectID == 0 || uploadReq.FileName == "" {
 		return api.ErrorResponse(http.StatusBadRequest, "Missing required fields", logger), nil
 	}
 
@@ -127,16 +191,16 @@ func handleGenerateUploadURL(ctx context.Context, request events.APIGatewayProxy
 		return api.ErrorResponse(http.StatusBadRequest, "entity_id is required for this entity type", logger), nil
 	}
 
-	// Validate file type
-	if !models.ValidateFileType(uploadReq.FileName) {
-		return api.ErrorResponse(http.StatusBadRequest, "File type not allowed", logger), nil
-	}
-
 	// Validate entity type is supported
 	if !isValidEntityType(uploadReq.EntityType) {
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid entity type", logger), nil
 	}
 
+	// Validate file type and size against the entity type's limit before anything is stored or presigned
+	if errMsg := validateUploadFile(uploadReq.EntityType, uploadReq.FileName, uploadReq.FileSize); errMsg != "" {
+		return api.ErrorResponse(http.StatusBadRequest, errMsg, logger), nil
+	}
+
 	// Validate entity access (entity exists, belongs to project, project belongs to org and location)
 	if uploadReq.EntityType != models.EntityTypeIssueComment && uploadReq.EntityType != models.EntityTypeRFIComment {
 		statusCode, errMsg := validateEntityAccess(ctx, uploadReq.EntityType, uploadReq.EntityID, uploadReq.ProjectID, uploadReq.LocationID, uploadReq.OrgID)
@@ -151,6 +215,29 @@ func handleGenerateUploadURL(ctx context.Context, request events.APIGatewayProxy
 		}
 	}
 
+	// Inherit the project's current location when the client didn't send one.
+	// An explicit location_id is still strictly matched by the validation above.
+	if uploadReq.LocationID == 0 {
+		locationID, err := getProjectLocationID(ctx, uploadReq.ProjectID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to resolve project location")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to validate project", logger), nil
+		}
+		uploadReq.LocationID = locationID
+	}
+
+	// Enforce the per-entity attachment limit (pending comment uploads have no entity yet)
+	if uploadReq.EntityID > 0 {
+		count, err := attachmentRepository.CountByEntity(ctx, uploadReq.EntityType, uploadReq.EntityID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to count existing attachments")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to create attachment", logger), nil
+		}
+		if count >= maxAttachmentsForEntityType(uploadReq.EntityType) {
+			return api.ErrorResponse(http.StatusBadRequest, "attachment limit reached", logger), nil
+		}
+	}
+
 	// Generate S3 key
 	s3Key := uploadReq.GenerateS3Key()
 	if s3Key == "" {
@@ -205,7 +292,173 @@ func handleGenerateUploadURL(ctx context.Context, request events.APIGatewayProxy
 	return api.SuccessResponse(http.StatusOK, response, logger), nil
 }
 
-// handleConfirmUpload handles POST /attachments/confirm
+// handleGenerateBatchUploadURLs handles POST /attachments/upload-urls.
+// Every file is validated before any row is created, and the rows are created in one transaction.
+func handleGenerateBatchUploadURLs(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	var batchReq models.AttachmentBatchUploadRequest
+	if err := api.ParseJSONBody(request.Body, &batchReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for batch upload URLs")
+		var numberErr *models.InvalidNumberError
+		if errors.As(err, &numberErr) {
+			return api.ErrorResponse(http.StatusBadRequest, numberErr.Error(), logger), nil
+		}
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	batchReq.OrgID = claims.OrgID
+
+	if batchReq.EntityType == "" || batchReq.ProjectID == 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "Missing required fields", logger), nil
+	}
+	if !isValidEntityType(batchReq.EntityType) {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid entity type", logger), nil
+	}
+	isComment := batchReq.EntityType == models.EntityTypeIssueComment || batchReq.EntityType == models.EntityTypeRFIComment
+	if !isComment && batchReq.EntityID == 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_id is required for this entity type", logger), nil
+	}
+	if len(batchReq.Files) == 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "files is required", logger), nil
+	}
+	if len(batchReq.Files) > models.MaxAttachmentBatchFiles {
+		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("a batch may contain at most %d files", models.MaxAttachmentBatchFiles), logger), nil
+	}
+
+	// S3 keys are built from the file name and a per-second timestamp, so names must be unique within a batch
+	seenNames := make(map[string]bool, len(batchReq.Files))
+	for i, file := range batchReq.Files {
+		errMsg := ""
+		switch {
+		case file.FileName == "":
+			errMsg = "file_name is required"
+		case file.AttachmentType == "":
+			errMsg = "attachment_type is required"
+		case seenNames[strings.ReplaceAll(file.FileName, " ", "_")]:
+			errMsg = "duplicate file_name in batch"
+		default:
+			errMsg = validateUploadFile(batchReq.EntityType, file.FileName, file.FileSize)
+		}
+		if errMsg != "" {
+			return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("files[%d]: %s", i, errMsg), logger), nil
+		}
+		seenNames[strings.ReplaceAll(file.FileName, " ", "_")] = true
+	}
+
+	// Validate entity access (entity exists, belongs to project, project belongs to org and location)
+	if !isComment {
+		statusCode, errMsg := validateEntityAccess(ctx, batchReq.EntityType, batchReq.EntityID, batchReq.ProjectID, batchReq.LocationID, batchReq.OrgID)
+		if errMsg != "" {
+			return api.ErrorResponse(statusCode, errMsg, logger), nil
+		}
+	} else {
+		statusCode, errMsg := validateProjectAccess(ctx, batchReq.ProjectID, batchReq.LocationID, batchReq.OrgID)
+		if errMsg != "" {
+			return api.ErrorResponse(statusCode, errMsg, logger), nil
+		}
+	}
+
+	if batchReq.LocationID == 0 {
+		locationID, err := getProjectLocationID(ctx, batchReq.ProjectID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to resolve project location")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to validate project", logger), nil
+		}
+		batchReq.LocationID = locationID
+	}
+
+	// The whole batch has to fit under the per-entity attachment limit
+	if batchReq.EntityID > 0 {
+		count, err := attachmentRepository.CountByEntity(ctx, batchReq.EntityType, batchReq.EntityID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to count existing attachments")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to create attachments", logger), nil
+		}
+		if count+len(batchReq.Files) > maxAttachmentsForEntityType(batchReq.EntityType) {
+			return api.ErrorResponse(http.StatusBadRequest, "attachment limit reached", logger), nil
+		}
+	}
+
+	attachments := make([]*models.Attachment, 0, len(batchReq.Files))
+	for i, file := range batchReq.Files {
+		uploadReq := models.AttachmentUploadRequest{
+			EntityType:     batchReq.EntityType,
+			EntityID:       batchReq.EntityID,
+			ProjectID:      batchReq.ProjectID,
+			LocationID:     batchReq.LocationID,
+			OrgID:          batchReq.OrgID,
+			FileName:       file.FileName,
+			FileSize:       file.FileSize,
+			AttachmentType: file.AttachmentType,
+		}
+		s3Key := uploadReq.GenerateS3Key()
+		if s3Key == "" {
+			return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("files[%d]: Failed to generate S3 key", i), logger), nil
+		}
+
+		fileSize := file.FileSize
+		fileType := models.GetMimeType(file.FileName)
+		attachments = append(attachments, &models.Attachment{
+			EntityType:     batchReq.EntityType,
+			EntityID:       batchReq.EntityID,
+			ProjectID:      batchReq.ProjectID,
+			LocationID:     batchReq.LocationID,
+			OrgID:          batchReq.OrgID,
+			FileName:       file.FileName,
+			FilePath:       s3Key,
+			FileSize:       &fileSize,
+			FileType:       &fileType,
+			MimeType:       &fileType,
+			AttachmentType: file.AttachmentType,
+			UploadedBy:     claims.UserID,
+			CreatedBy:      claims.UserID,
+			UpdatedBy:      claims.UserID,
+		})
+	}
+
+	if err := attachmentRepository.CreateAttachments(ctx, attachments); err != nil {
+		logger.WithError(err).Error("Failed to create attachment records")
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return api.ErrorResponse(http.StatusBadRequest, "Invalid reference: Entity or project does not exist", logger), nil
+		}
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create attachments", logger), nil
+	}
+
+	expiresAt := time.Now().Add(15 * time.Minute).Format(time.RFC3339)
+	responses := make([]models.AttachmentUploadResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		uploadURL, err := s3Client.GenerateUploadURL(attachment.FilePath, 15*time.Minute)
+		if err != nil {
+			logger.WithError(err).Error("Failed to generate upload URL")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to generate upload URL", logger), nil
+		}
+		responses = append(responses, models.AttachmentUploadResponse{
+			AttachmentID: attachment.ID,
+			UploadURL:    uploadURL,
+			S3Key:        attachment.FilePath,
+			ExpiresAt:    expiresAt,
+		})
+	}
+
+	return api.SuccessResponse(http.StatusOK, responses, logger), nil
+}
+
+// validateUploadFile checks a file's type and its size against the entity type's limit, returning
+// the error message to send, or "" when the file is acceptable
+func validateUploadFile(entityType, fileName string, fileSize int64) string {
+	if !models.ValidateFileType(fileName) {
+		return "File type not allowed"
+	}
+	if fileSize <= 0 {
+		return "file_size must be greater than 0"
+	}
+	if maxSize := models.MaxFileSizeForEntityType(entityType); fileSize > maxSize {
+		return fmt.Sprintf("file_size exceeds the %d MB limit for %s attachments", maxSize/(1024*1024), entityType)
+	}
+	return ""
+}
+
+// handleConfirmUpload handles POST /attachments/confirm. The attachment keeps scan_status pending, and
+// cannot be downloaded, until the virus scanner reports it through POST /attachments/{id}/scan-result.
 func handleConfirmUpload(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	var confirmReq models.AttachmentConfirmRequest
 	if err := api.ParseJSONBody(request.Body, &confirmReq); err != nil {
@@ -213,17 +466,164 @@ func handleConfirmUpload(ctx context.Context, request events.APIGatewayProxyRequ
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
 	}
 
-	// Note: In a more complete implementation, we would:
-	// 1. Verify the file was actually uploaded to S3
-	// 2. Update the upload status in the database
-	// 3. Possibly trigger virus scanning
+	if confirmReq.AttachmentID <= 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "attachment_id is required", logger), nil
+	}
+
+	// entity_type is optional; without it the attachment is found among the caller's uploads
+	if confirmReq.EntityType == "" {
+		entityType, err := attachmentRepository.FindAttachmentEntityType(ctx, confirmReq.AttachmentID, claims.UserID)
+		if err != nil {
+			switch err.Error() {
+			case "attachment not found":
+				return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger), nil
+			case "attachment entity type is ambiguous":
+				return api.ErrorResponse(http.StatusBadRequest, "entity_type is required for this attachment", logger), nil
+			}
+			logger.WithError(err).Error("Failed to find attachment entity type")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to find attachment", logger), nil
+		}
+		confirmReq.EntityType = entityType
+	}
+
+	// Verify access
+	hasAccess, err := attachmentRepository.VerifyAttachmentAccess(ctx, confirmReq.AttachmentID, confirmReq.EntityType, claims.OrgID)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "unsupported entity type") {
+			return api.ErrorResponse(http.StatusBadRequest, errMsg, logger), nil
+		}
+		if strings.Contains(errMsg, "attachment not found") {
+			return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger), nil
+		}
+		if strings.Contains(errMsg, "access denied") {
+			return api.ErrorResponse(http.StatusForbidden, "Access denied to this attachment", logger), nil
+		}
+		logger.WithError(err).Error("Failed to verify attachment access")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to verify attachment access", logger), nil
+	}
+	if !hasAccess {
+		return api.ErrorResponse(http.StatusForbidden, "Access denied to this attachment", logger), nil
+	}
+
+	attachment, err := attachmentRepository.GetAttachment(ctx, confirmReq.AttachmentID, confirmReq.EntityType)
+	if err != nil {
+		if err.Error() == "attachment not found" {
+			return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger), nil
+		}
+		logger.WithError(err).Error("Failed to get attachment")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachment", logger), nil
+	}
+
+	response := models.AttachmentConfirmResponse{
+		AttachmentID:    attachment.ID,
+		Status:          models.UploadStatusConfirmed,
+		ClaimedFileSize: attachment.FileSize,
+	}
+
+	// Confirming twice is harmless; the first confirm already recorded the stored size
+	if attachment.UploadStatus == models.UploadStatusConfirmed {
+		if attachment.FileSize != nil {
+			response.FileSize = *attachment.FileSize
+		}
+		response.ClaimedFileSize = nil
+		return api.SuccessResponse(http.StatusOK, response, logger), nil
+	}
+
+	// Only trust the upload once S3 has the object
+	object, err := s3Client.HeadObject(attachment.FilePath)
+	if err != nil {
+		if err.Error() == "object not found" {
+			return api.ErrorResponse(http.StatusConflict, "upload not found in storage", logger), nil
+		}
+		logger.WithError(err).WithField("s3_key", attachment.FilePath).Error("Failed to check uploaded object")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to verify upload", logger), nil
+	}
+
+	// The presigned PUT does not bind the size, so enforce the limit on what was actually stored.
+	// The oversized object is removed and the attachment stays pending.
+	if maxSize := models.MaxFileSizeForEntityType(confirmReq.EntityType); object.ContentLength > maxSize {
+		log := logger.WithFields(logrus.Fields{
+			"attachment_id": attachment.ID,
+			"s3_key":        attachment.FilePath,
+			"actual_size":   object.ContentLength,
+			"max_size":      maxSize,
+		})
+		log.Warn("Uploaded object exceeds the size limit")
+		if err := s3Client.DeleteObject(attachment.FilePath); err != nil {
+			log.WithError(err).Error("Failed to delete oversized upload")
+		}
+		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("uploaded file exceeds the %d MB limit for %s attachments",
+			maxSize/(1024*1024), confirmReq.EntityType), logger), nil
+	}
+
+	response.FileSize = object.ContentLength
+	response.SizeMismatch =attachment.FileSize != nil && *attachment.FileSize != object.ContentLength
+	if response.SizeMismatch {
+		logger.WithFields(logrus.Fields{
+			"attachment_id": attachment.ID,
+			"claimed_size":  *attachment.FileSize,
+			"actual_size":   object.ContentLength,
+		}).Warn("Uploaded object size differs from the size claimed at upload-url time")
+	}
+
+	err = attachmentRepository.ConfirmAttachmentUpload(ctx, attachment.ID, confirmReq.EntityType, object.ContentLength, claims.UserID)
+	if err != nil {
+		if err.Error() == "attachment not found or not pending" {
+			return api.ErrorResponse(http.StatusConflict, "Attachment is not pending upload", logger), nil
+		}
+		logger.WithError(err).Error("Failed to confirm attachment upload")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to confirm upload", logger), nil
+	}
 
 	logger.WithFields(logrus.Fields{
 		"attachment_id": confirmReq.AttachmentID,
 		"user_id":       claims.UserID,
+		"file_size":     object.ContentLength,
 	}).Info("Upload confirmed")
 
-	return api.SuccessResponse(http.StatusOK, map[string]string{"status": "confirmed"}, logger), nil
+	// Thumbnails are built inline, so only for images small enough to decode within the Lambda's memory
+	if attachment.FileType != nil && models.SupportsThumbnail(*attachment.FileType) && object.ContentLength <= util.MaxThumbnailSourceBytes {
+		response.ThumbnailPath = generateThumbnail(ctx, attachment)
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// generateThumbnail stores a scaled-down copy of an image attachment under thumbnails/ next to the
+// original and records its key. It is best-effort: the upload is already confirmed, so failures are
+// logged and the attachment is simply left without a thumbnail.
+func generateThumbnail(ctx context.Context, attachment *models.Attachment) *string {
+	log := logger.WithFields(logrus.Fields{
+		"attachment_id": attachment.ID,
+		"s3_key":        attachment.FilePath,
+	})
+
+	original, err := s3Client.GetObject(attachment.FilePath)
+	if err != nil {
+		log.WithError(err).Warn("Failed to read image for thumbnail")
+		return nil
+	}
+	defer original.Close()
+
+	thumbnail, contentType, err := util.GenerateThumbnail(original, models.ThumbnailMaxDimension)
+	if err != nil {
+		log.WithError(err).Warn("Failed to generate thumbnail")
+		return nil
+	}
+
+	thumbnailKey := models.ThumbnailKey(attachment.FilePath)
+	if err := s3Client.PutObject(thumbnailKey, bytes.NewReader(thumbnail), contentType); err != nil {
+		log.WithError(err).Warn("Failed to store thumbnail")
+		return nil
+	}
+
+	if err := attachmentRepository.SetAttachmentThumbnail(ctx, attachment.ID, attachment.EntityType, thumbnailKey); err != nil {
+		log.WithError(err).Warn("Failed to record thumbnail")
+		return nil
+	}
+
+	return &thumbnailKey
 }
 
 // handleGetAttachment handles GET /attachments/{id}
@@ -278,6 +678,62 @@ func handleGetAttachment(ctx context.Context, request events.APIGatewayProxyRequ
 	return api.SuccessResponse(http.StatusOK, attachment, logger), nil
 }
 
+// scanBlockedMessage returns why the attachment's file cannot be handed out, or "" when it has been
+// scanned clean
+func scanBlockedMessage(attachment *models.Attachment) string {
+	switch attachment.ScanStatus {
+	case models.ScanStatusClean:
+		return ""
+	case models.ScanStatusInfected:
+		return "Attachment is quarantined: the virus scan found a threat"
+	default:
+		return "Attachment is awaiting virus scan, please try again shortly"
+	}
+}
+
+// handleScanResult handles POST /attachments/{id}/scan-result from the virus scanner
+func handleScanResult(ctx context.Context, request events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	attachmentID, err := strconv.ParseInt(request.PathParameters["id"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid attachment ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid attachment ID", logger)
+	}
+
+	var resultReq models.AttachmentScanResultRequest
+	if err := api.ParseJSONBody(request.Body, &resultReq); err != nil {
+		logger.WithError(err).Error("Invalid request body")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
+	}
+	if resultReq.ScanStatus != models.ScanStatusClean && resultReq.ScanStatus != models.ScanStatusInfected {
+		return api.ErrorResponse(http.StatusBadRequest, "scan_status must be clean or infected", logger)
+	}
+	if !isValidEntityType(resultReq.EntityType) {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid entity type", logger)
+	}
+
+	err = attachmentRepository.SetAttachmentScanStatus(ctx, attachmentID, resultReq.EntityType, resultReq.ScanStatus)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger)
+		}
+		logger.WithError(err).Error("Failed to record scan result")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to record scan result", logger)
+	}
+
+	log := logger.WithFields(logrus.Fields{
+		"attachment_id": attachmentID,
+		"entity_type":   resultReq.EntityType,
+		"scan_status":   resultReq.ScanStatus,
+	})
+	if resultReq.ScanStatus == models.ScanStatusInfected {
+		log.Warn("Attachment quarantined by virus scan")
+	} else {
+		log.Info("Attachment scanned clean")
+	}
+
+	return api.SuccessResponse(http.StatusOK, map[string]string{"scan_status": resultReq.ScanStatus}, logger)
+}
+
 // handleGenerateDownloadURL handles GET /attachments/{id}/download-url
 func handleGenerateDownloadURL(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	attachmentIDStr := request.PathParameters["id"]
@@ -326,24 +782,304 @@ func handleGenerateDownloadURL(ctx context.Context, request events.APIGatewayPro
 		logger.WithError(err).Error("Failed to get attachment")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachment", logger), nil
 	}
+	if errMsg := scanBlockedMessage(attachment); errMsg != "" {
+		return api.ErrorResponse(http.StatusLocked, errMsg, logger), nil
+	}
 
-	// Generate presigned download URL (60 minutes expiry)
-	downloadURL, err := s3Client.GenerateDownloadURL(attachment.FilePath, 60*time.Minute)
+	// Generate presigned download URL (60 minutes expiry). The stored file name is user-supplied,
+	// so it is sanitized and encoded before it goes anywhere near a header.
+	contentDisposition := api.ContentDisposition(attachment.FileName)
+	downloadURL, err := s3Client.GenerateDownloadURLWithDisposition(attachment.FilePath, contentDisposition, 60*time.Minute)
 	if err != nil {
 		logger.WithError(err).Error("Failed to generate download URL")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to generate download URL", logger), nil
 	}
 
+	// Record the download for the audit trail; best-effort so logging failures never block downloads
+	if accessLogEnabled {
+		accessEntry := &models.AttachmentAccessLog{
+			AttachmentID: attachmentID,
+			EntityType:   entityType,
+			OrgID:        claims.OrgID,
+			UserID:       claims.UserID,
+			IPAddress:    request.RequestContext.Identity.SourceIP,
+			UserAgent:    request.RequestContext.Identity.UserAgent,
+		}
+		if err := attachmentRepository.LogAttachmentAccess(ctx, accessEntry); err != nil {
+			logger.WithError(err).WithField("attachment_id", attachmentID).Warn("Failed to record attachment access")
+		}
+	}
+
 	response := models.AttachmentDownloadResponse{
-		DownloadURL: downloadURL,
-		FileName:    attachment.FileName,
-		FileSize:    attachment.FileSize,
-		ExpiresAt:   time.Now().Add(60 * time.Minute).Format(time.RFC3339),
+		DownloadURL:        downloadURL,
+		FileName:           api.SanitizeFileName(attachment.FileName),
+		ContentDisposition: contentDisposition,
+		FileSize:           attachment.FileSize,
+		ExpiresAt:          time.Now().Add(60 * time.Minute).Format(time.RFC3339),
 	}
 
 	return api.SuccessResponse(http.StatusOK, response, logger), nil
 }
 
+// handleGenerateThumbnailURL handles GET /attachments/{id}/thumbnail-url
+func handleGenerateThumbnailURL(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	attachmentIDStr := request.PathParameters["id"]
+	attachmentID, err := strconv.ParseInt(attachmentIDStr, 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid attachment ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid attachment ID", logger), nil
+	}
+
+	entityType := request.QueryStringParameters["entity_type"]
+	if entityType == "" {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_type query parameter is required", logger), nil
+	}
+
+	// Verify access
+	hasAccess, err := attachmentRepository.VerifyAttachmentAccess(ctx, attachmentID, entityType, claims.OrgID)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "unsupported entity type") {
+			return api.ErrorResponse(http.StatusBadRequest, errMsg, logger), nil
+		}
+		if strings.Contains(errMsg, "attachment not found") {
+			return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger), nil
+		}
+		if strings.Contains(errMsg, "access denied") {
+			return api.ErrorResponse(http.StatusForbidden, "Access denied to this attachment", logger), nil
+		}
+		logger.WithError(err).Error("Failed to verify attachment access")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to verify attachment access", logger), nil
+	}
+	if !hasAccess {
+		return api.ErrorResponse(http.StatusForbidden, "Access denied to this attachment", logger), nil
+	}
+
+	attachment, err := attachmentRepository.GetAttachment(ctx, attachmentID, entityType)
+	if err != nil {
+		if err.Error() == "attachment not found" {
+			return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger), nil
+		}
+		logger.WithError(err).Error("Failed to get attachment")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachment", logger), nil
+	}
+	if errMsg := scanBlockedMessage(attachment); errMsg != "" {
+		return api.ErrorResponse(http.StatusLocked, errMsg, logger), nil
+	}
+
+	if attachment.ThumbnailPath == nil || *attachment.ThumbnailPath == "" {
+		return api.ErrorResponse(http.StatusNotFound, "no thumbnail available", logger), nil
+	}
+
+	thumbnailURL, err := s3Client.GenerateDownloadURL(*attachment.ThumbnailPath, 60*time.Minute)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate thumbnail URL")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to generate thumbnail URL", logger), nil
+	}
+
+	response := models.AttachmentThumbnailResponse{
+		ThumbnailURL: thumbnailURL,
+		ExpiresAt:    time.Now().Add(60 * time.Minute).Format(time.RFC3339),
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleGetAttachmentAccessLog handles GET /attachments/{id}/access-log
+func handleGetAttachmentAccessLog(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	if !claims.IsSuperAdmin {
+		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can view attachment access logs", logger), nil
+	}
+
+	attachmentIDStr := request.PathParameters["id"]
+	attachmentID, err := strconv.ParseInt(attachmentIDStr, 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid attachment ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid attachment ID", logger), nil
+	}
+
+	entityType := request.QueryStringParameters["entity_type"]
+	if entityType == "" {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_type query parameter is required", logger), nil
+	}
+	if !isValidEntityType(entityType) {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid entity type", logger), nil
+	}
+
+	entries, err := attachmentRepository.GetAttachmentAccessLog(ctx, attachmentID, entityType, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get attachment access log")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachment access log", logger), nil
+	}
+
+	response := models.AttachmentAccessLogResponse{
+		AttachmentID: attachmentID,
+		EntityType:   entityType,
+		Entries:      entries,
+		TotalCount:   len(entries),
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleRecountCounts handles POST /admin/recount?entity_type=&project_id=
+// Recomputes the denormalized attachment/comment counts within the caller's organization;
+// omitting entity_type recounts every type.
+func handleRecountCounts(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	if !claims.IsSuperAdmin {
+		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can rebuild counts", logger), nil
+	}
+
+	entityTypes := models.RecountEntityTypes
+	if entityType := request.QueryStringParameters["entity_type"]; entityType != "" {
+		if !slices.Contains(models.RecountEntityTypes, entityType) {
+			return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("entity_type must be one of: %s", strings.Join(models.RecountEntityTypes, ", ")), logger), nil
+		}
+		entityTypes = []string{entityType}
+	}
+
+	response := models.RecountResponse{Results: []models.RecountResult{}}
+	var projectID int64
+	if raw := request.QueryStringParameters["project_id"]; raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 {
+			return api.ErrorResponse(http.StatusBadRequest, "Invalid project_id", logger), nil
+		}
+		if statusCode, errMsg := validateProjectAccess(ctx, parsed, 0, claims.OrgID); errMsg != "" {
+			return api.ErrorResponse(statusCode, errMsg, logger), nil
+		}
+		projectID = parsed
+		response.ProjectID = &projectID
+	}
+
+	for _, entityType := range entityTypes {
+		var result models.RecountResult
+		var err error
+		switch entityType {
+		case models.EntityTypeIssue:
+			result, err = issueRepository.RecountIssueCounts(ctx, claims.OrgID, projectID)
+		case models.EntityTypeRFI:
+			result, err = rfiRepository.RecountRFICounts(ctx, claims.OrgID, projectID)
+		case models.EntityTypeSubmittal:
+			result, err = submittalRepository.RecountSubmittalCounts(ctx, claims.OrgID, projectID)
+		}
+		// Batches already committed stay corrected; report what was done before the failure
+		response.Results = append(response.Results, result)
+		response.TotalCorrected += result.RowsCorrected
+		if err != nil {
+			logger.WithError(err).WithField("entity_type", entityType).Error("Failed to rebuild counts")
+			return api.ErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Failed to rebuild %s counts after correcting %d rows", entityType, response.TotalCorrected), logger), nil
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"org_id":          claims.OrgID,
+		"project_id":      projectID,
+		"total_corrected": response.TotalCorrected,
+		"user_id":         claims.UserID,
+	}).Info("Denormalized counts rebuilt")
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleGetOrphanedAttachments handles GET /attachments/orphans
+func handleGetOrphanedAttachments(ctx context.Context, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	if !claims.IsSuperAdmin {
+		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can view orphaned attachments", logger), nil
+	}
+
+	attachments, err := attachmentRepository.FindOrphans(ctx, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to find orphaned attachments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to find orphaned attachments", logger), nil
+	}
+
+	response := models.AttachmentListResponse{
+		Attachments: attachments,
+		TotalCount:  len(attachments),
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleRelinkAttachment handles POST /attachments/{id}/relink
+func handleRelinkAttachment(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	if !claims.IsSuperAdmin {
+		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can relink attachments", logger), nil
+	}
+
+	attachmentID, err := strconv.ParseInt(request.PathParameters["id"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid attachment ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid attachment ID", logger), nil
+	}
+
+	var relinkReq models.AttachmentRelinkRequest
+	if err := api.ParseJSONBody(request.Body, &relinkReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for relink")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if relinkReq.EntityType != models.EntityTypeIssueComment && relinkReq.EntityType != models.EntityTypeRFIComment {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_type must be issue_comment or rfi_comment", logger), nil
+	}
+	if relinkReq.CommentID <= 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "comment_id is required", logger), nil
+	}
+
+	attachment, err := attachmentRepository.RelinkCommentAttachment(ctx, attachmentID, relinkReq.EntityType, relinkReq.CommentID, claims.OrgID, claims.UserID)
+	if err != nil {
+		switch err.Error() {
+		case "comment not found":
+			return api.ErrorResponse(http.StatusNotFound, "Comment not found", logger), nil
+		case "orphaned attachment not found":
+			return api.ErrorResponse(http.StatusNotFound, "Orphaned attachment not found", logger), nil
+		}
+		logger.WithError(err).Error("Failed to relink attachment")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to relink attachment", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, attachment, logger), nil
+}
+
+// handleUpdateAttachmentEntity handles PATCH /attachments/{id}/entity
+func handleUpdateAttachmentEntity(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	attachmentID, err := strconv.ParseInt(request.PathParameters["id"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid attachment ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid attachment ID", logger), nil
+	}
+
+	var updateReq models.AttachmentEntityUpdateRequest
+	if err := api.ParseJSONBody(request.Body, &updateReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for attachment entity update")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if updateReq.EntityType != models.EntityTypeIssueComment && updateReq.EntityType != models.EntityTypeRFIComment {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_type must be issue_comment or rfi_comment", logger), nil
+	}
+	if updateReq.EntityID <= 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_id is required", logger), nil
+	}
+
+	attachment, err := attachmentRepository.UpdateAttachmentEntity(ctx, attachmentID, updateReq.EntityType, updateReq.EntityID, claims.OrgID, claims.UserID)
+	if err != nil {
+		switch err.Error() {
+		case "attachment not found":
+			return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger), nil
+		case "comment not found":
+			return api.ErrorResponse(http.StatusNotFound, "Comment not found", logger), nil
+		case "attachment is already linked to an entity":
+			return api.ErrorResponse(http.StatusConflict, "Attachment is already linked to an entity", logger), nil
+		}
+		logger.WithError(err).Error("Failed to update attachment entity")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update attachment entity", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, attachment, logger), nil
+}
+
 // handleDeleteAttachment handles DELETE /attachments/{id}
 func handleDeleteAttachment(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	attachmentIDStr := request.PathParameters["id"]
@@ -417,13 +1153,6 @@ func handleGetEntityAttachments(ctx context.Context, request events.APIGatewayPr
 		filters = make(map[string]string)
 	}
 
-	attachments, err := attachmentRepository.GetAttachmentsByEntity(ctx, entityType, entityID, filters)
-	if err != nil {
-		logger.WithError(err).Error("Failed to get entity attachments")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachments", logger), nil
-	}
-
-	// Build paginated response
 	page := 1
 	if pageStr := filters["page"]; pageStr != "" {
 		if parsedPage, err := strconv.Atoi(pageStr); err == nil && parsedPage > 0 {
@@ -438,13 +1167,132 @@ func handleGetEntityAttachments(ctx context.Context, request events.APIGatewayPr
 		}
 	}
 
+	totalCount, err := attachmentRepository.CountAttachmentsByEntity(ctx, entityType, entityID, filters)
+	if err != nil {
+		logger.WithError(err).Error("Failed to count entity attachments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachments", logger), nil
+	}
+
+	attachments, err := attachmentRepository.GetAttachmentsByEntity(ctx, entityType, entityID, filters, pageSize, (page-1)*pageSize)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get entity attachments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get attachments", logger), nil
+	}
+	if attachments == nil {
+		attachments = []models.Attachment{}
+	}
+
+	pagination := api.NewPaginationMeta(page, pageSize, totalCount)
 	response := models.AttachmentListResponse{
 		Attachments: attachments,
-		TotalCount:  len(attachments),
+		TotalCount:  totalCount,
 		Page:        page,
 		PageSize:    pageSize,
-		HasNext:     len(attachments) == pageSize, // Simplified logic
-		HasPrev:     page > 1,
+		HasNext:     pagination.HasNext,
+		HasPrev:     pagination.HasPrevious,
+	}
+
+	return api.ListResponse(request, response, attachments, pagination, logger), nil
+}
+
+// handleCountEntityAttachments handles POST /entities/{type}/attachments/counts, returning the attachment
+// count of every requested entity so list views need one call instead of one per row
+func handleCountEntityAttachments(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	entityType := request.PathParameters["type"]
+	if !isValidEntityType(entityType) {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid entity type", logger), nil
+	}
+
+	var countsReq models.AttachmentCountsRequest
+	if err := api.ParseJSONBody(request.Body, &countsReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for attachment counts")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+	if len(countsReq.EntityIDs) == 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "entity_ids is required", logger), nil
+	}
+	if len(countsReq.EntityIDs) > models.MaxAttachmentCountEntities {
+		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("entity_ids may contain at most %d ids", models.MaxAttachmentCountEntities), logger), nil
+	}
+
+	// Every requested id appears in the response, with 0 unless the query finds attachments
+	counts := make(map[int64]int, len(countsReq.EntityIDs))
+	entityIDs := make([]int64, 0, len(countsReq.EntityIDs))
+	for _, entityID := range countsReq.EntityIDs {
+		if entityID <= 0 {
+			return api.ErrorResponse(http.StatusBadRequest, "entity_ids must be positive integers", logger), nil
+		}
+		if _, seen := counts[entityID]; !seen {
+			counts[entityID] = 0
+			entityIDs = append(entityIDs, entityID)
+		}
+	}
+
+	found, err := attachmentRepository.CountAttachmentsByEntities(ctx, entityType, entityIDs, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to count entity attachments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to count attachments", logger), nil
+	}
+	for entityID, count := range found {
+		counts[entityID] = count
+	}
+
+	return api.SuccessResponse(http.StatusOK, models.AttachmentCountsResponse{
+		EntityType: entityType,
+		Counts:     counts,
+	}, logger), nil
+}
+
+// handleStartExportPackage handles GET /projects/{projectId}/export-package
+// Packages are built by the export worker; this only queues the job and returns its id.
+func handleStartExportPackage(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if statusCode, errMsg := validateProjectAccess(ctx, projectID, 0, claims.OrgID); errMsg != "" {
+		return api.ErrorResponse(statusCode, errMsg, logger), nil
+	}
+
+	job, err := exportJobRepository.CreateExportJob(ctx, claims.OrgID, projectID, claims.UserID)
+	if err != nil {
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to start export", logger), nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"project_id": projectID,
+		"user_id":    claims.UserID,
+	}).Info("Project export package job queued")
+
+	return api.SuccessResponse(http.StatusAccepted, models.ExportJobResponse{ExportJob: *job}, logger), nil
+}
+
+// handleGetExportJob handles GET /exports/{jobId}
+func handleGetExportJob(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	jobID, err := strconv.ParseInt(request.PathParameters["jobId"], 10, 64)
+	if err != nil {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid export job ID", logger), nil
+	}
+
+	job, err := exportJobRepository.GetExportJob(ctx, jobID, claims.OrgID)
+	if err != nil {
+		if err.Error() == "export job not found" {
+			return api.ErrorResponse(http.StatusNotFound, "Export job not found", logger), nil
+		}
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get export job", logger), nil
+	}
+
+	response := models.ExportJobResponse{ExportJob: *job}
+	if job.Status == models.ExportJobStatusCompleted && job.FilePath != nil {
+		downloadURL, err := s3Client.GenerateDownloadURL(*job.FilePath, 60*time.Minute)
+		if err != nil {
+			logger.WithError(err).WithField("job_id", jobID).Error("Failed to generate export download URL")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to generate download URL", logger), nil
+		}
+		response.DownloadURL = downloadURL
+		response.ExpiresAt = time.Now().Add(60 * time.Minute).Format(time.RFC3339)
 	}
 
 	return api.SuccessResponse(http.StatusOK, response, logger), nil
@@ -452,7 +1300,23 @@ func handleGetEntityAttachments(ctx context.Context, request events.APIGatewayPr
 
 // Helper function to validate entity type
 func isValidEntityType(entityType string) bool {
-	validTypes := []string{
+	return slices.Contains(models.AttachmentEntityTypes, entityType)
+}
+
+// maxAttachmentsForEntityType returns the configured attachment cap for an entity type
+func maxAttachmentsForEntityType(entityType string) int {
+	if limit, ok := maxAttachmentsPerType[entityType]; ok {
+		return limit
+	}
+	return models.DefaultMaxAttachmentsPerEntity
+}
+
+// loadAttachmentLimits reads per-entity-type limits from the environment.
+// ATTACHMENT_MAX_PER_ENTITY sets the limit for every type and
+// ATTACHMENT_MAX_PER_ENTITY_<TYPE> (e.g. ATTACHMENT_MAX_PER_ENTITY_ISSUE) overrides a single type.
+func loadAttachmentLimits() map[string]int {
+	limits := make(map[string]int)
+	entityTypes := []string{
 		models.EntityTypeProject,
 		models.EntityTypeIssue,
 		models.EntityTypeRFI,
@@ -461,12 +1325,19 @@ func isValidEntityType(entityType string) bool {
 		models.EntityTypeRFIComment,
 	}
 
-	for _, validType := range validTypes {
-		if entityType == validType {
-			return true
+	defaultLimit := models.DefaultMaxAttachmentsPerEntity
+	if value, err := strconv.Atoi(os.Getenv("ATTACHMENT_MAX_PER_ENTITY")); err == nil && value > 0 {
+		defaultLimit = value
+	}
+
+	for _, entityType := range entityTypes {
+		limits[entityType] = defaultLimit
+		if value, err := strconv.Atoi(os.Getenv("ATTACHMENT_MAX_PER_ENTITY_" + strings.ToUpper(entityType))); err == nil && value > 0 {
+			limits[entityType] = value
 		}
 	}
-	return false
+
+	return limits
 }
 
 // validateProjectAccess validates that project exists, belongs to org, and optionally belongs to location
@@ -500,6 +1371,16 @@ func validateProjectAccess(ctx context.Context, projectID, locationID, orgID int
 	return 0, ""
 }
 
+// getProjectLocationID returns the project's current location
+func getProjectLocationID(ctx context.Context, projectID int64) (int64, error) {
+	var locationID int64
+	err := sqlDB.QueryRowContext(ctx, `
+		SELECT location_id FROM project.projects
+		WHERE id = $1 AND is_deleted = FALSE
+	`, projectID).Scan(&locationID)
+	return locationID, err
+}
+
 // validateEntityAccess validates that entity exists, belongs to project, project belongs to org and location
 // Returns (statusCode, errorMessage) - errorMessage is empty string if validation passes
 func validateEntityAccess(ctx context.Context, entityType string, entityID, projectID, locationID, orgID int64) (int, string) {
@@ -605,6 +1486,18 @@ func init() {
 
 	s3Client = clients.NewS3Client(isLocal, bucketName)
 
+	// Without a key the scan-result endpoint rejects every call and new uploads stay pending
+	scanAPIKey = ssmParams[constants.ATTACHMENT_SCAN_API_KEY]
+	if scanAPIKey == "" {
+		logger.WithField("operation", "init").Warn("Attachment scan API key not configured; scan results cannot be recorded")
+	}
+
+	// Download access logging is on unless explicitly disabled
+	accessLogEnabled = os.Getenv("ATTACHMENT_ACCESS_LOG_ENABLED") != "false"
+
+	// Per-entity attachment caps, configurable per entity type
+	maxAttachmentsPerType = loadAttachmentLimits()
+
 	logger.Info("Attachment management service initialized successfully")
 }
 
@@ -627,6 +1520,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -646,8 +1542,27 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		Logger: logger,
 	}
 
+	exportJobRepository = &data.ExportJobDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Entity repositories (denormalized count maintenance)
+	issueRepository = &data.IssueDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+	rfiRepository = &data.RFIDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+	submittalRepository = &data.SubmittalDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 
 	return nil
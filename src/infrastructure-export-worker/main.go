@@ -0,0 +1,373 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"infrastructure/lib/clients"
+	"infrastructure/lib/constants"
+	"infrastructure/lib/data"
+	"infrastructure/lib/models"
+	"infrastructure/lib/util"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/sirupsen/logrus"
+)
+
+// Global variables for Lambda cold start optimization
+var (
+	logger               *logrus.Logger
+	isLocal              bool
+	ssmRepository        data.SSMRepository
+	ssmParams            map[string]string
+	sqlDB                *sql.DB
+	exportJobRepository  data.ExportJobRepository
+	attachmentRepository data.AttachmentRepository
+	issueRepository      data.IssueRepository
+	rfiRepository        data.RFIRepository
+	submittalRepository  data.SubmittalRepository
+	s3Client             clients.S3ClientInterface
+)
+
+// submittalPageSize matches the maximum page size accepted by GetSubmittalsByProject
+const submittalPageSize = 100
+
+// jobTimeBuffer is the minimum remaining execution time required before claiming another job
+const jobTimeBuffer = 2 * time.Minute
+
+// Handler runs on a schedule and builds any pending project export packages.
+//
+// Each package is a ZIP containing issues.csv, rfis.csv, submittals.csv and the project's
+// attachment files under attachments/<entity type>/<entity id>/. The ZIP is written to /tmp,
+// uploaded to S3 under exports/ and the job row is marked completed with its S3 key.
+// Jobs are claimed one at a time until none are pending or the invocation is close to timing out.
+func Handler(ctx context.Context, event events.CloudWatchEvent) error {
+	for {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < jobTimeBuffer {
+			return nil
+		}
+
+		job, err := exportJobRepository.ClaimPendingExportJob(ctx)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return nil
+		}
+
+		processExportJob(ctx, job)
+	}
+}
+
+// processExportJob builds and uploads a single package, recording the outcome on the job
+func processExportJob(ctx context.Context, job *models.ExportJob) {
+	jobLogger := logger.WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"project_id": job.ProjectID,
+		"org_id":     job.OrgID,
+		"operation":  "processExportJob",
+	})
+	jobLogger.Info("Building project export package")
+
+	filePath, fileSize, err := buildExportPackage(ctx, job)
+	if err != nil {
+		jobLogger.WithError(err).Error("Failed to build project export package")
+		if failErr := exportJobRepository.FailExportJob(ctx, job.ID, "Failed to build export package"); failErr != nil {
+			jobLogger.WithError(failErr).Error("Failed to record export job failure")
+		}
+		return
+	}
+
+	if err := exportJobRepository.CompleteExportJob(ctx, job.ID, filePath, fileSize); err != nil {
+		jobLogger.WithError(err).Error("Failed to record export job completion")
+		return
+	}
+
+	jobLogger.WithFields(logrus.Fields{
+		"file_path": filePath,
+		"file_size": fileSize,
+	}).Info("Project export package completed")
+}
+
+// buildExportPackage writes the ZIP to a temp file, uploads it and returns its S3 key and size
+func buildExportPackage(ctx context.Context, job *models.ExportJob) (string, int64, error) {
+	tmpFile, err := os.CreateTemp("", fmt.Sprintf("export-%d-*.zip", job.ID))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	zipWriter := zip.NewWriter(tmpFile)
+
+	if err := writeIssuesCSV(ctx, zipWriter, job.ProjectID); err != nil {
+		return "", 0, err
+	}
+	if err := writeRFIsCSV(ctx, zipWriter, job.ProjectID, job.OrgID); err != nil {
+		return "", 0, err
+	}
+	if err := writeSubmittalsCSV(ctx, zipWriter, job.ProjectID); err != nil {
+		return "", 0, err
+	}
+	if err := writeAttachmentFiles(ctx, zipWriter, job.ProjectID); err != nil {
+		return "", 0, err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return "", 0, fmt.Errorf("failed to finalize zip: %w", err)
+	}
+
+	info, err := tmpFile.Stat()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat zip: %w", err)
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		return "", 0, fmt.Errorf("failed to rewind zip: %w", err)
+	}
+
+	key := fmt.Sprintf("exports/%d/%d/project_%d_export_%d_%s.zip",
+		job.OrgID, job.ProjectID, job.ProjectID, job.ID, time.Now().UTC().Format("20060102150405"))
+	if err := s3Client.PutObject(key, tmpFile, "application/zip"); err != nil {
+		return "", 0, fmt.Errorf("failed to upload export package: %w", err)
+	}
+
+	return key, info.Size(), nil
+}
+
+// writeCSV adds a CSV file with the given header and rows to the package
+func writeCSV(zipWriter *zip.Writer, name string, header []string, rows [][]string) error {
+	entry, err := zipWriter.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+
+	writer := csv.NewWriter(entry)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func writeIssuesCSV(ctx context.Context, zipWriter *zip.Writer, projectID int64) error {
+	issues, _, _, err := issueRepository.GetIssuesByProject(ctx, projectID, map[string]string{}, models.IssueListQuery{})
+	if err != nil {
+		return fmt.Errorf("failed to load issues: %w", err)
+	}
+
+	header := []string{
+		"ID", "Issue Number", "Title", "Description", "Category", "Issue Type", "Priority", "Severity",
+		"Status", "Location", "Reported By", "Assigned To", "Due Date", "Closed Date", "Created At",
+	}
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			strconv.FormatInt(issue.ID, 10), issue.IssueNumber, issue.Title, issue.Description,
+			issue.Category, issue.IssueType, issue.Priority, issue.Severity, issue.Status,
+			issue.LocationDescription, issue.ReportedByName, issue.AssignedToName,
+			formatDate(issue.DueDate), formatDate(issue.ClosedDate), issue.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return writeCSV(zipWriter, "issues.csv", header, rows)
+}
+
+func writeRFIsCSV(ctx context.Context, zipWriter *zip.Writer, projectID, orgID int64) error {
+	items, err := rfiRepository.GetRFIExport(ctx, projectID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to load RFIs: %w", err)
+	}
+
+	header := []string{
+		"ID", "RFI Number", "Subject", "Description", "Category", "Priority", "Status",
+		"Location", "Assigned To", "Due Date", "Closed Date", "Created At", "Created By",
+		"Official Response", "Comment Count",
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			strconv.FormatInt(item.ID, 10), item.RFINumber, item.Subject, item.Description,
+			item.Category, item.Priority, item.Status, item.LocationName, item.AssignedTo,
+			formatDate(item.DueDate), formatDate(item.ClosedDate), item.CreatedAt.Format(time.RFC3339),
+			item.CreatedByName, item.OfficialResponse, strconv.Itoa(item.CommentCount),
+		})
+	}
+
+	return writeCSV(zipWriter, "rfis.csv", header, rows)
+}
+
+func writeSubmittalsCSV(ctx context.Context, zipWriter *zip.Writer, projectID int64) error {
+	var submittals []models.SubmittalResponse
+	for page := 1; ; page++ {
+		batch, err := submittalRepository.GetSubmittalsByProject(ctx, projectID, map[string]string{
+			"page":  strconv.Itoa(page),
+			"limit": strconv.Itoa(submittalPageSize),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load submittals: %w", err)
+		}
+		submittals = append(submittals, batch...)
+		if len(batch) < submittalPageSize {
+			break
+		}
+	}
+
+	header := []string{
+		"ID", "Submittal Number", "Title", "Submittal Type", "Priority", "Status", "Workflow Status",
+		"Ball In Court", "Revision", "Location", "Assigned To", "Due Date", "Created At",
+	}
+	rows := make([][]string, 0, len(submittals))
+	for _, submittal := range submittals {
+		rows = append(rows, []string{
+			strconv.FormatInt(submittal.ID, 10), submittal.SubmittalNumber, submittal.Title,
+			submittal.SubmittalType, submittal.Priority, submittal.Status, submittal.WorkflowStatus,
+			submittal.BallInCourt, strconv.Itoa(submittal.RevisionNumber), submittal.LocationName,
+			submittal.AssignedToName, formatDate(submittal.DueDate), submittal.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return writeCSV(zipWriter, "submittals.csv", header, rows)
+}
+
+// writeAttachmentFiles copies each attachment from S3 into attachments/<entity type>/<entity id>/.
+// Files missing from S3 are skipped so one bad upload doesn't fail the whole package.
+func writeAttachmentFiles(ctx context.Context, zipWriter *zip.Writer, projectID int64) error {
+	attachments, err := attachmentRepository.GetProjectAttachmentFiles(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to load attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		body, err := s3Client.GetObject(attachment.FilePath)
+		if err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"attachment_id": attachment.ID,
+				"entity_type":   attachment.EntityType,
+				"file_path":     attachment.FilePath,
+			}).Warn("Skipping attachment missing from S3")
+			continue
+		}
+
+		name := fmt.Sprintf("attachments/%ss/%d/%d_%s",
+			attachment.EntityType, attachment.EntityID, attachment.ID, filepath.Base(strings.ReplaceAll(attachment.FileName, "\\", "/")))
+		entry, err := zipWriter.Create(name)
+		if err != nil {
+			body.Close()
+			return fmt.Errorf("failed to add %s: %w", name, err)
+		}
+		_, err = io.Copy(entry, body)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to copy attachment %d: %w", attachment.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func init() {
+	var err error
+
+	isLocal = parseIsLocal()
+
+	// Logger Setup
+	logger = setupLogger(isLocal)
+
+	// Initialize AWS SSM Parameter Store client
+	ssmClient := clients.NewSSMClient(isLocal)
+	ssmRepository = &data.SSMDao{
+		SSM:    ssmClient,
+		Logger: logger,
+	}
+
+	// Retrieve all required configuration parameters from SSM
+	ssmParams, err = ssmRepository.GetParameters()
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"operation": "init",
+			"error":     err.Error(),
+		}).Fatal("Error while getting SSM params from parameter store")
+	}
+
+	// Initialize PostgreSQL database connection
+	err = setupPostgresSQLClient(ssmParams)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"operation": "init",
+			"error":     err.Error(),
+		}).Fatal("Error setting up PostgreSQL client")
+	}
+
+	// Export packages are stored alongside attachments in the attachment bucket
+	bucketName := os.Getenv("BUCKET_NAME")
+	if bucketName == "" {
+		bucketName = "buildboard-attachments-dev"
+	}
+	s3Client = clients.NewS3Client(isLocal, bucketName)
+
+	logger.Info("Export worker initialized successfully")
+}
+
+func main() {
+	lambda.Start(Handler)
+}
+
+func parseIsLocal() bool {
+	isLocal, _ := strconv.ParseBool(os.Getenv("IS_LOCAL"))
+	return isLocal
+}
+
+func setupLogger(isLocal bool) *logrus.Logger {
+	logger := logrus.New()
+	util.SetLogLevel(logger, os.Getenv("LOG_LEVEL"))
+	logger.SetFormatter(&logrus.JSONFormatter{PrettyPrint: isLocal})
+	return logger
+}
+
+func setupPostgresSQLClient(ssmParams map[string]string) error {
+	var err error
+
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
+	// Create PostgreSQL client using RDS connection parameters from SSM
+	sqlDB, err = clients.NewPostgresSQLClient(
+		ssmParams[constants.DATABASE_RDS_ENDPOINT],
+		ssmParams[constants.DATABASE_PORT],
+		ssmParams[constants.DATABASE_NAME],
+		ssmParams[constants.DATABASE_USERNAME],
+		ssmParams[constants.DATABASE_PASSWORD],
+		ssmParams[constants.SSL_MODE],
+	)
+	if err != nil {
+		return fmt.Errorf("error creating PostgreSQL client: %w", err)
+	}
+
+	exportJobRepository = &data.ExportJobDao{DB: sqlDB, Logger: logger}
+	attachmentRepository = &data.AttachmentDao{DB: sqlDB, Logger: logger}
+	issueRepository = &data.IssueDao{DB: sqlDB, Logger: logger}
+	rfiRepository = &data.RFIDao{DB: sqlDB, Logger: logger}
+	submittalRepository = &data.SubmittalDao{DB: sqlDB, Logger: logger}
+
+	logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -16,6 +17,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -24,15 +26,35 @@ import (
 
 // Global variables for Lambda cold start optimization
 var (
-	logger          *logrus.Logger
-	isLocal         bool
-	ssmRepository   data.SSMRepository
-	ssmParams       map[string]string
-	sqlDB           *sql.DB
-	issueRepository data.IssueRepository
+	logger            *logrus.Logger
+	isLocal           bool
+	ssmRepository     data.SSMRepository
+	ssmParams         map[string]string
+	sqlDB             *sql.DB
+	issueRepository   data.IssueRepository
+	projectRepository data.ProjectRepository
+	orgRepository     data.OrgRepository
+	userRepository    data.UserManagementRepository
+	idempotencyStore  data.IdempotencyRepository
+	snsClient         clients.SNSClientInterface
+	issueEventsTopic  string
+	issueListLimiter  *api.RateLimiter
 )
 
-func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+// metricsServiceName is the Service dimension on the request metrics this Lambda emits
+const metricsServiceName = "issue-management"
+
+func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (response events.APIGatewayProxyResponse, err error) {
+	// Normalize method casing and trailing slashes before matching routes
+	request = api.NormalizeRoute(request)
+
+	// Emit latency, status class and DB time for this route once the response is known
+	metrics := util.StartRequestMetrics(metricsServiceName, request.Resource)
+	ctx = util.WithRequestMetrics(ctx, metrics)
+	defer func() {
+		metrics.Emit(response.StatusCode)
+	}()
+
 	logger.WithFields(logrus.Fields{
 		"operation": "Handler",
 		"method":    request.HTTPMethod,
@@ -40,173 +62,369 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		"resource":  request.Resource,
 	}).Info("Issue management request received")
 
+	// Health checks are unauthenticated so synthetic monitors can call them without a token
+	if request.Resource == "/issues/health" && request.HTTPMethod == http.MethodGet {
+		return api.HealthCheckResponse(ctx, sqlDB, logger), nil
+	}
+
 	// Extract claims from JWT token via API Gateway authorizer
 	claims, err := auth.ExtractClaimsFromRequest(request)
 	if err != nil {
 		logger.WithError(err).Error("Authentication failed")
-		return api.ErrorResponse(http.StatusUnauthorized, "Authentication failed", logger), nil
+		return api.ErrorResponseWithCode(http.StatusUnauthorized, api.ErrorCodeAuthenticationFailed, "Authentication failed", logger), nil
 	}
 
 	// Handle different routes
 	switch request.HTTPMethod {
 	case http.MethodPost:
+		// POST /issues/bulk-status - Move several issues to the same status
+		if request.Resource == "/issues/bulk-status" {
+			return handleBulkUpdateIssueStatus(ctx, request, claims.UserID, claims.OrgID), nil
+		}
+
+		// POST /issues/{issueId}/copy - Copy issue into another project
+		if request.Resource == "/issues/{issueId}/copy" {
+			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
+			}
+			return handleCopyIssue(ctx, issueID, claims.UserID, claims.OrgID, claims.IsSuperAdmin, request.Body), nil
+		}
+
+		// POST /issues/{issueId}/restore - Undo a soft delete
+		if request.Resource == "/issues/{issueId}/restore" {
+			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
+			}
+			return handleRestoreIssue(ctx, issueID, claims.UserID, claims.OrgID), nil
+		}
+
+		// POST /issues/{issueId}/watchers - Watch an issue (the caller, or user_id from the body)
+		if request.Resource == "/issues/{issueId}/watchers" {
+			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
+			}
+			return handleAddIssueWatcher(ctx, issueID, claims.UserID, claims.OrgID, request.Body), nil
+		}
+
 		// POST /issues/{issueId}/comments - Add comment to issue
 		if strings.Contains(request.Resource, "/issues/{issueId}/comments") {
 			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid issue ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
 			}
 			return handleCreateComment(ctx, issueID, claims.UserID, claims.OrgID, request.Body), nil
 		}
 
 		// POST /issues - Create new issue (unified structure, orgID from JWT)
 		if request.Resource == "/issues" {
-			return handleCreateIssue(ctx, claims.UserID, claims.OrgID, request.Body), nil
+			return handleCreateIssue(ctx, claims.UserID, claims.OrgID, claims.IsSuperAdmin, request.Body, api.HeaderValue(request, api.IdempotencyKeyHeader)), nil
 		}
-		return api.ErrorResponse(http.StatusNotFound, "Endpoint not found", logger), nil
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, "Endpoint not found", logger), nil
 		
 	case http.MethodGet:
 		// GET /projects/{projectId}/issues - List issues for project
 		if strings.Contains(request.Resource, "/projects/{projectId}/issues") && request.PathParameters["issueId"] == "" {
 			projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid project ID", logger), nil
+			}
+			if resp, limited := issueListLimiter.Limit(claims.OrgID, logger); limited {
+				return resp, nil
 			}
 			// Ensure filters map is not nil
 			filters := request.QueryStringParameters
 			if filters == nil {
 				filters = make(map[string]string)
 			}
-			return handleGetProjectIssues(ctx, projectID, claims.OrgID, filters), nil
+			return handleGetProjectIssues(ctx, request, projectID, claims.OrgID, filters), nil
+		}
+
+		// GET /issues - Search issues across the organization's projects
+		if request.Resource == "/issues" {
+			if resp, limited := issueListLimiter.Limit(claims.OrgID, logger); limited {
+				return resp, nil
+			}
+			return handleSearchIssues(ctx, request, claims.OrgID, request.QueryStringParameters), nil
+		}
+
+		// GET /issues/{issueId}/watchers - List the users watching an issue
+		if request.Resource == "/issues/{issueId}/watchers" {
+			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
+			}
+			return handleGetIssueWatchers(ctx, issueID, claims.OrgID), nil
+		}
+
+		// GET /issues/{issueId}/activity - Get the issue's activity log
+		if request.Resource == "/issues/{issueId}/activity" {
+			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
+			}
+			return handleGetIssueActivity(ctx, request, issueID, claims.OrgID), nil
 		}
 
 		// GET /issues/{issueId}/comments - Get comments for issue
 		if strings.Contains(request.Resource, "/issues/{issueId}/comments") {
 			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid issue ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
 			}
-			return handleGetIssueComments(ctx, issueID, claims.OrgID), nil
+			return handleGetIssueComments(ctx, request, issueID, claims.OrgID), nil
 		}
 
 		// GET /issues/{issueId} - Get specific issue
 		if strings.Contains(request.Resource, "/issues/{issueId}") {
 			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid issue ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
 			}
-			return handleGetIssue(ctx, issueID, claims.OrgID), nil
+			return handleGetIssue(ctx, request, issueID, claims.OrgID), nil
 		}
 
-		return api.ErrorResponse(http.StatusNotFound, "Endpoint not found", logger), nil
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, "Endpoint not found", logger), nil
 		
 	case http.MethodPut:
+		// PUT /issues/{issueId}/comments/{commentId} - Edit a comment (author or super admin)
+		if request.Resource == "/issues/{issueId}/comments/{commentId}" {
+			issueID, commentID, resp, ok := parseIssueCommentPath(request)
+			if !ok {
+				return resp, nil
+			}
+			return handleUpdateComment(ctx, issueID, commentID, claims.UserID, claims.OrgID, claims.IsSuperAdmin, request.Body), nil
+		}
+
 		// PUT /issues/{issueId} - Update issue (unified structure, orgID from JWT)
 		if strings.Contains(request.Resource, "/issues/{issueId}") {
 			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid issue ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
 			}
 			return handleUpdateIssue(ctx, issueID, claims.UserID, claims.OrgID, request.Body), nil
 		}
-		return api.ErrorResponse(http.StatusNotFound, "Endpoint not found", logger), nil
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, "Endpoint not found", logger), nil
 		
 	case http.MethodPatch:
 		// PATCH /issues/{issueId}/status - Update issue status
 		if strings.Contains(request.Resource, "/issues/{issueId}/status") {
 			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid issue ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
 			}
 			return handleUpdateIssueStatus(ctx, issueID, claims.UserID, claims.OrgID, request.Body), nil
 		}
-		return api.ErrorResponse(http.StatusNotFound, "Endpoint not found", logger), nil
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, "Endpoint not found", logger), nil
 		
 	case http.MethodDelete:
+		// DELETE /issues/{issueId}/watchers/{userId} - Stop a user watching an issue
+		if request.Resource == "/issues/{issueId}/watchers/{userId}" {
+			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
+			}
+			watcherID, err := strconv.ParseInt(request.PathParameters["userId"], 10, 64)
+			if err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid user ID", logger), nil
+			}
+			return handleRemoveIssueWatcher(ctx, issueID, watcherID, claims.OrgID), nil
+		}
+
+		// DELETE /issues/{issueId}/comments/{commentId} - Delete a comment (author or super admin)
+		if request.Resource == "/issues/{issueId}/comments/{commentId}" {
+			issueID, commentID, resp, ok := parseIssueCommentPath(request)
+			if !ok {
+				return resp, nil
+			}
+			return handleDeleteComment(ctx, issueID, commentID, claims.UserID, claims.OrgID, claims.IsSuperAdmin), nil
+		}
+
 		// DELETE /issues/{issueId} - Delete issue
 		if strings.Contains(request.Resource, "/issues/{issueId}") {
 			issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
 			if err != nil {
-				return api.ErrorResponse(http.StatusBadRequest, "Invalid issue ID", logger), nil
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), nil
 			}
-			return handleDeleteIssue(ctx, issueID, claims.UserID, claims.OrgID), nil
+			return handleDeleteIssue(ctx, issueID, claims.UserID, claims.OrgID, request.Body), nil
 		}
-		return api.ErrorResponse(http.StatusNotFound, "Endpoint not found", logger), nil
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, "Endpoint not found", logger), nil
 		
 	default:
-		return api.ErrorResponse(http.StatusMethodNotAllowed, "Method not allowed", logger), nil
+		return api.ErrorResponseWithCode(http.StatusMethodNotAllowed, api.ErrorCodeMethodNotAllowed, "Method not allowed", logger), nil
 	}
 }
 
-// handleCreateIssue handles POST /issues with unified structure and JWT-based orgID
-func handleCreateIssue(ctx context.Context, userID, orgID int64, body string) events.APIGatewayProxyResponse {
-	// Parse unified request structure
+// handleCreateIssue handles POST /issues with unified structure and JWT-based orgID.
+// With an Idempotency-Key header, a retry of the same request returns the issue the first attempt created.
+func handleCreateIssue(ctx context.Context, userID, orgID int64, isSuperAdmin bool, body, idempotencyKey string) events.APIGatewayProxyResponse {
+	replayID, statusCode, errCode, errMsg := api.BeginIdempotentRequest(ctx, idempotencyStore, idempotencyKey, models.IdempotencyScopeCreateIssue, orgID, userID, body)
+	if errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
+	}
+	if replayID > 0 {
+		issue, err := issueRepository.GetIssueByID(ctx, replayID)
+		if err != nil {
+			logger.WithError(err).WithField("issue_id", replayID).Error("Failed to get issue for idempotent replay")
+			return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get created issue", logger)
+		}
+		return api.SuccessResponse(http.StatusCreated, issue, logger)
+	}
+	defer func() {
+		if err := api.ReleaseIdempotentRequest(ctx, idempotencyStore, idempotencyKey, models.IdempotencyScopeCreateIssue, orgID); err != nil {
+			logger.WithError(err).Warn("Failed to release idempotency key")
+		}
+	}()
+
+	// Parse unified request structure; unknown keys are rejected so a typo is not silently ignored
 	var createReq models.CreateIssueRequest
-	if err := json.Unmarshal([]byte(body), &createReq); err != nil {
+	if err := api.ParseJSONBodyStrict(body, &createReq); err != nil {
 		logger.WithError(err).Error("Failed to parse create issue request")
-		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
+		var numberErr *models.InvalidNumberError
+		if errors.As(err, &numberErr) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, numberErr.Error(), logger)
+		}
+		if errors.Is(err, api.ErrUnknownField) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, err.Error(), logger)
+		}
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
 	}
 
 	// Extract project_id from request (should be in request body)
 	projectID := createReq.ProjectID
 	if projectID == 0 {
-		return api.ErrorResponse(http.StatusBadRequest, "Project ID is required", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Project ID is required", logger)
+	}
+
+	// Orgs that require project membership only allow project members to create issues
+	if statusCode, errCode, errMsg := api.ValidateProjectMembership(ctx, orgRepository, projectRepository, projectID, orgID, userID, isSuperAdmin); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
 	}
 
 	// Validate required fields from flatter structure
 	if createReq.Title == "" {
-		return api.ErrorResponse(http.StatusBadRequest, "Title is required", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Title is required", logger)
 	}
 	if createReq.Description == "" {
-		return api.ErrorResponse(http.StatusBadRequest, "Description is required", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Description is required", logger)
 	}
 	if createReq.Priority == "" {
-		return api.ErrorResponse(http.StatusBadRequest, "Priority is required", logger)
-	}
-	if createReq.AssignedTo == 0 {
-		return api.ErrorResponse(http.StatusBadRequest, "Assigned to is required", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Priority is required", logger)
 	}
+
+	// Without an explicit due date, derive one from the org's SLA for the priority
+	slaDueDateApplied := false
 	if createReq.DueDate == "" {
-		return api.ErrorResponse(http.StatusBadRequest, "Due date is required", logger)
+		slaDays, err := orgRepository.GetPrioritySLADays(ctx, orgID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get organization priority SLA days")
+			return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get organization SLA settings", logger)
+		}
+		dueDate, ok := slaDays.DueDate(createReq.Priority, time.Now().UTC())
+		if !ok {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Due date is required", logger)
+		}
+		createReq.DueDate = dueDate
+		slaDueDateApplied = true
 	}
 
-	// Validate assigned_to user exists and belongs to organization
-	var assignedUserOrgID int64
-	err := sqlDB.QueryRowContext(ctx, `
-		SELECT org_id FROM iam.users
-		WHERE id = $1 AND is_deleted = FALSE
-	`, createReq.AssignedTo).Scan(&assignedUserOrgID)
+	// Fall back to the project's default assignee when none is provided
+	defaultAssigneeApplied := false
+	if createReq.AssignedTo == 0 {
+		defaultAssigneeID, err := projectRepository.GetProjectDefaultAssignee(ctx, projectID, orgID)
+		if err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeOrgMismatch, "Invalid project ID. Project does not belong to your organization.", logger)
+			}
+			if errors.Is(err, data.ErrConflict) {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Assigned to is required. The project's default assignee is no longer a member of your organization.", logger)
+			}
+			logger.WithError(err).Error("Failed to get project default assignee")
+			return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get project default assignee", logger)
+		}
+		if defaultAssigneeID == 0 {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Assigned to is required", logger)
+		}
+		createReq.AssignedTo = defaultAssigneeID
+		defaultAssigneeApplied = true
+	}
 
-	if err == sql.ErrNoRows {
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid assigned_to user ID. User %d does not exist.", createReq.AssignedTo), logger)
+	// Validate assigned_to user exists and belongs to organization
+	if statusCode, errCode, errMsg := api.ValidateUserInOrg(ctx, userRepository, createReq.AssignedTo, orgID, "assigned_to"); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
 	}
+
+	// Insert the issue and its initial activity entry in one transaction so a failure leaves no partial write
+	tx, err := sqlDB.BeginTx(ctx, nil)
 	if err != nil {
-		logger.WithError(err).Error("Failed to validate assigned user")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to validate assigned user", logger)
-	}
-	if assignedUserOrgID != orgID {
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid assigned_to user ID. User %d does not belong to your organization.", createReq.AssignedTo), logger)
+		logger.WithError(err).Error("Failed to start issue creation transaction")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create issue", logger)
 	}
+	defer tx.Rollback()
 
 	// Create issue using repository with orgID from JWT (validation happens in repository)
-	issue, err := issueRepository.CreateIssue(ctx, projectID, userID, orgID, &createReq)
+	issueID, err := issueRepository.CreateIssueTx(ctx, tx, projectID, userID, orgID, &createReq)
 	if err != nil {
 		logger.WithError(err).Error("Failed to create issue")
 		// Check for specific database errors to provide better error messages
-		if strings.Contains(err.Error(), "project does not belong to your organization") {
-			return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID. Project does not belong to your organization.", logger)
+		if errors.Is(err, data.ErrAssigneeNotInOrg) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeOrgMismatch, fmt.Sprintf("Invalid assigned_to user ID. User %d does not belong to your organization.", createReq.AssignedTo), logger)
+		}
+		if errors.Is(err, data.ErrOrgMismatch) || errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeOrgMismatch, "Invalid project ID. Project does not belong to your organization.", logger)
 		}
-		if strings.Contains(err.Error(), "foreign key constraint") {
-			return api.ErrorResponse(http.StatusBadRequest, "Invalid reference data provided", logger)
+		if errors.Is(err, data.ErrInvalid) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Invalid reference data provided", logger)
 		}
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create issue", logger)
+	}
+
+	if err := issueRepository.CreateActivityLogTx(ctx, tx, issueID, userID, "Issue created", "", models.IssueStatusOpen); err != nil {
+		logger.WithError(err).Error("Failed to log issue creation activity")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create issue", logger)
+	}
+
+	if err := api.CompleteIdempotentRequest(ctx, idempotencyStore, tx, idempotencyKey, models.IdempotencyScopeCreateIssue, orgID, issueID); err != nil {
+		logger.WithError(err).Error("Failed to record idempotency key result")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create issue", logger)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("Failed to commit issue creation transaction")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create issue", logger)
 	}
 
+	issue, err := issueRepository.GetIssueByID(ctx, issueID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get created issue")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get created issue", logger)
+	}
+	issue.DefaultAssigneeApplied = defaultAssigneeApplied
+	issue.SLADueDateApplied = slaDueDateApplied
+
+	publishIssueAssigned(ctx, issueID, projectID, orgID, createReq.AssignedTo, userID)
+
 	return api.SuccessResponse(http.StatusCreated, issue, logger)
 }
 
+// issueListFilters are the list filters that accept comma-separated values (status=open,in_progress)
+var issueListFilters = map[string][]string{
+	"status":   models.IssueStatuses,
+	"priority": models.IssuePriorities,
+}
+
 // handleGetProjectIssues handles GET /projects/{projectId}/issues
-func handleGetProjectIssues(ctx context.Context, projectID, orgID int64, filters map[string]string) events.APIGatewayProxyResponse {
+func handleGetProjectIssues(ctx context.Context, request events.APIGatewayProxyRequest, projectID, orgID int64, filters map[string]string) events.APIGatewayProxyResponse {
+	if filters == nil {
+		filters = make(map[string]string)
+	}
+	listQuery, statusCode, errMsg := parseIssueListParams(filters)
+	if errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, api.ErrorCodeValidationFailed, errMsg, logger)
+	}
+
 	// Validate project belongs to org
 	var projectOrgID int64
 	err := sqlDB.QueryRowContext(ctx, `
@@ -215,21 +433,74 @@ func handleGetProjectIssues(ctx context.Context, projectID, orgID int64, filters
 	`, projectID).Scan(&projectOrgID)
 	
 	if err == sql.ErrNoRows {
-		return api.ErrorResponse(http.StatusNotFound, "Project not found", logger)
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeProjectNotFound, "Project not found", logger)
 	}
 	if err != nil {
 		logger.WithError(err).Error("Failed to validate project")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to validate project", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to validate project", logger)
 	}
 	if projectOrgID != orgID {
-		return api.ErrorResponse(http.StatusForbidden, "Project does not belong to your organization", logger)
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Project does not belong to your organization", logger)
 	}
-	
+
 	// Get issues
-	issues, err := issueRepository.GetIssuesByProject(ctx, projectID, filters)
+	issues, total, hasMore, err := issueRepository.GetIssuesByProject(ctx, projectID, filters, listQuery)
 	if err != nil {
 		logger.WithError(err).Error("Failed to get issues")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issues", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issues", logger)
+	}
+
+	return issueListResponse(request, filters, listQuery, issues, total, hasMore)
+}
+
+// handleSearchIssues handles GET /issues - issues across every project in the caller's organization.
+// Accepts the project list filters plus q (title, description or number) and an optional project_id.
+func handleSearchIssues(ctx context.Context, request events.APIGatewayProxyRequest, orgID int64, filters map[string]string) events.APIGatewayProxyResponse {
+	if filters == nil {
+		filters = make(map[string]string)
+	}
+	listQuery, statusCode, errMsg := parseIssueListParams(filters)
+	if errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, api.ErrorCodeValidationFailed, errMsg, logger)
+	}
+	if projectIDStr := filters["project_id"]; projectIDStr != "" {
+		if projectID, err := strconv.ParseInt(projectIDStr, 10, 64); err != nil || projectID <= 0 {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "project_id must be a positive integer", logger)
+		}
+	}
+	if assignedTo := filters["assigned_to"]; assignedTo != "" {
+		if _, err := strconv.ParseInt(assignedTo, 10, 64); err != nil {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "assigned_to must be a user ID", logger)
+		}
+	}
+
+	issues, total, hasMore, err := issueRepository.SearchIssues(ctx, orgID, filters, listQuery)
+	if err != nil {
+		logger.WithError(err).Error("Failed to search issues")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to search issues", logger)
+	}
+
+	return issueListResponse(request, filters, listQuery, issues, total, hasMore)
+}
+
+// parseIssueListParams validates and normalizes the issue list filters in place and reads the page,
+// page size and cursor. On failure it returns the status code and message to respond with.
+func parseIssueListParams(filters map[string]string) (models.IssueListQuery, int, string) {
+	if err := api.NormalizeMultiValueFilters(filters, issueListFilters); err != nil {
+		return models.IssueListQuery{}, http.StatusBadRequest, err.Error()
+	}
+	if err := api.NormalizeSortParams(filters, models.IssueSortFields); err != nil {
+		return models.IssueListQuery{}, http.StatusBadRequest, err.Error()
+	}
+	if overdue := filters["overdue"]; overdue != "" && overdue != "true" && overdue != "false" {
+		return models.IssueListQuery{}, http.StatusBadRequest, "overdue must be true or false"
+	}
+	if daysStr := filters["due_within_days"]; daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 1 || days > models.MaxIssueDueWithinDays {
+			return models.IssueListQuery{}, http.StatusBadRequest, fmt.Sprintf("due_within_days must be a whole number between 1 and %d", models.MaxIssueDueWithinDays)
+		}
+		filters["due_within_days"] = strconv.Itoa(days)
 	}
 
 	// Parse pagination params
@@ -245,26 +516,61 @@ func handleGetProjectIssues(ctx context.Context, projectID, orgID int64, filters
 			pageSize = ps
 		}
 	}
+	listQuery := models.IssueListQuery{Page: page, PageSize: pageSize}
+
+	// cursor=<next_cursor> switches to keyset pagination on (created_at, id)
+	if cursor := filters["cursor"]; cursor != "" {
+		if sort := filters["sort"]; sort != "" && sort != models.SortCreatedAt {
+			return models.IssueListQuery{}, http.StatusBadRequest, "cursor pagination only supports sort=created_at"
+		}
+		cursorCreatedAt, cursorID, err := api.DecodeCursor(cursor)
+		if err != nil {
+			return models.IssueListQuery{}, http.StatusBadRequest, "Invalid cursor"
+		}
+		listQuery.CursorCreatedAt = cursorCreatedAt
+		listQuery.CursorID = cursorID
+	}
+
+	return listQuery, 0, ""
+}
+
+// issueListResponse builds the paginated response shared by the issue list endpoints
+func issueListResponse(request events.APIGatewayProxyRequest, filters map[string]string, listQuery models.IssueListQuery, issues []models.IssueResponse, total int, hasMore bool) events.APIGatewayProxyResponse {
+	if issues == nil {
+		issues = []models.IssueResponse{}
+	}
 
 	response := models.IssueListResponse{
 		Issues:   issues,
-		Total:    len(issues),
-		Page:     page,
-		PageSize: pageSize,
+		Total:    total,
+		Page:     listQuery.Page,
+		PageSize: listQuery.PageSize,
+		HasNext:  hasMore,
+		HasPrev:  listQuery.Page > 1,
+	}
+	pagination := api.NewPaginationMeta(listQuery.Page, listQuery.PageSize, total)
+	if listQuery.CursorID > 0 {
+		response.HasPrev = true
+		pagination.HasPrevious = true
+	}
+	pagination.HasNext = hasMore
+	if hasMore && (filters["sort"] == "" || filters["sort"] == models.SortCreatedAt) {
+		last := issues[len(issues)-1]
+		response.NextCursor = api.EncodeCursor(last.CreatedAt, last.ID)
 	}
 
-	return api.SuccessResponse(http.StatusOK, response, logger)
+	return api.ListResponse(request, response, issues, pagination, logger)
 }
 
 // handleGetIssue handles GET /issues/{issueId}
-func handleGetIssue(ctx context.Context, issueID, orgID int64) events.APIGatewayProxyResponse {
+func handleGetIssue(ctx context.Context, request events.APIGatewayProxyRequest, issueID, orgID int64) events.APIGatewayProxyResponse {
 	issue, err := issueRepository.GetIssueByID(ctx, issueID)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to get issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger)
 	}
 
 	// Validate issue belongs to org
@@ -275,7 +581,7 @@ func handleGetIssue(ctx context.Context, issueID, orgID int64) events.APIGateway
 	`, issue.ProjectID).Scan(&projectOrgID)
 
 	if err != nil || projectOrgID != orgID {
-		return api.ErrorResponse(http.StatusForbidden, "Issue does not belong to your organization", logger)
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger)
 	}
 
 	// Fetch attachments for the issue from issue_attachments table
@@ -286,57 +592,102 @@ func handleGetIssue(ctx context.Context, issueID, orgID int64) events.APIGateway
 		issue.Attachments = attachments
 	}
 
-	// Fetch comments and activity log for the issue
-	comments, err := issueRepository.GetIssueComments(ctx, issueID)
+	// Fetch the most recent comments and activity log for the issue; older ones are paged via /comments
+	comments, totalComments, _, err := issueRepository.GetIssueComments(ctx, issueID, models.IssueCommentQuery{
+		Limit:  models.IssueDetailCommentLimit,
+		Newest: true,
+	})
 	if err != nil {
 		logger.WithError(err).Warn("Failed to fetch comments for issue")
 		issue.Comments = []models.IssueComment{}
 	} else {
 		issue.Comments = comments
+		issue.CommentCount = totalComments
 	}
 
-	return api.SuccessResponse(http.StatusOK, issue, logger)
+	return api.ConditionalResponse(request, issueETag(request, issue), func() events.APIGatewayProxyResponse {
+		return api.SparseResponse(request, issue, logger)
+	})
+}
+
+// issueETag versions an issue detail response. Comments and attachments change without touching the
+// issue's updated_at, and days open / overdue flags change daily, so all of those are part of the tag.
+func issueETag(request events.APIGatewayProxyRequest, issue *models.IssueResponse) string {
+	var latestComment time.Time
+	for _, comment := range issue.Comments {
+		if comment.UpdatedAt.After(latestComment) {
+			latestComment = comment.UpdatedAt
+		}
+	}
+	var latestAttachmentID int64
+	for _, attachment := range issue.Attachments {
+		if attachment.ID > latestAttachmentID {
+			latestAttachmentID = attachment.ID
+		}
+	}
+	return api.WeakETag(issue.ID, issue.UpdatedAt.UnixNano(),
+		issue.CommentCount, latestComment.UnixNano(),
+		len(issue.Attachments), latestAttachmentID,
+		time.Now().UTC().Format("2006-01-02"), request.QueryStringParameters["fields"])
 }
 
 // handleUpdateIssue handles PUT /issues/{issueId}
 func handleUpdateIssue(ctx context.Context, issueID, userID, orgID int64, body string) events.APIGatewayProxyResponse {
-	// Get current issue state for activity logging
+	// Get current issue state for status validation and notifications
 	oldIssue, err := issueRepository.GetIssueByID(ctx, issueID)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to get issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger)
 	}
 
 	// Parse unified request structure
 	var updateReq models.UpdateIssueRequest
 	if err := json.Unmarshal([]byte(body), &updateReq); err != nil {
 		logger.WithError(err).Error("Failed to parse update issue request")
-		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
+	}
+
+	// Status changes follow the same transition matrix as PATCH /issues/{issueId}/status
+	if updateReq.Status != "" {
+		if !models.IsValidIssueStatus(updateReq.Status) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Invalid status value", logger)
+		}
+		if err := models.ValidateIssueStatusTransition(oldIssue.Status, updateReq.Status); err != nil {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger)
+		}
 	}
 
 	// Update issue using repository with orgID from JWT (validation happens in repository)
 	updatedIssue, err := issueRepository.UpdateIssue(ctx, issueID, userID, orgID, &updateReq)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
-		if err.Error() == "issue does not belong to your organization" {
-			return api.ErrorResponse(http.StatusForbidden, "Issue does not belong to your organization", logger)
+		if errors.Is(err, data.ErrOrgMismatch) {
+			return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger)
 		}
 		logger.WithError(err).Error("Failed to update issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to update issue", logger)
 	}
 
-	// Log status change activity
-	if updateReq.Status != "" && oldIssue.Status != updateReq.Status {
-		activityMsg := fmt.Sprintf("Status changed from %s to %s", oldIssue.Status, updateReq.Status)
-		err := issueRepository.CreateActivityLog(ctx, issueID, userID, activityMsg, oldIssue.Status, updateReq.Status)
-		if err != nil {
-			logger.WithError(err).Warn("Failed to log status change activity")
-		}
+	if updatedIssue.AssignedTo != nil && (oldIssue.AssignedTo == nil || *oldIssue.AssignedTo != *updatedIssue.AssignedTo) {
+		publishIssueAssigned(ctx, issueID, updatedIssue.ProjectID, orgID, *updatedIssue.AssignedTo, userID)
+	}
+
+	if oldIssue.Status != updatedIssue.Status {
+		publishIssueStatusChanged(ctx, issueID, updatedIssue.ProjectID, orgID, updatedIssue.AssignedTo, oldIssue.Status, updatedIssue.Status, userID)
+		notifyIssueWatchers(ctx, models.IssueWatcherNotification{
+			Trigger:     models.IssueEventStatusChanged,
+			IssueID:     issueID,
+			ProjectID:   updatedIssue.ProjectID,
+			OrgID:       orgID,
+			ActorUserID: userID,
+			OldStatus:   oldIssue.Status,
+			NewStatus:   updatedIssue.Status,
+		})
 	}
 
 	return api.SuccessResponse(http.StatusOK, updatedIssue, logger)
@@ -347,11 +698,11 @@ func handleUpdateIssueStatus(ctx context.Context, issueID, userID, orgID int64,
 	// First check if issue exists and belongs to org
 	issue, err := issueRepository.GetIssueByID(ctx, issueID)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to get issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger)
 	}
 
 	// Validate issue belongs to org
@@ -362,7 +713,7 @@ func handleUpdateIssueStatus(ctx context.Context, issueID, userID, orgID int64,
 	`, issue.ProjectID).Scan(&projectOrgID)
 	
 	if err != nil || projectOrgID != orgID {
-		return api.ErrorResponse(http.StatusForbidden, "Issue does not belong to your organization", logger)
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger)
 	}
 
 	// Parse status update request
@@ -371,29 +722,15 @@ func handleUpdateIssueStatus(ctx context.Context, issueID, userID, orgID int64,
 	}
 	if err := json.Unmarshal([]byte(body), &statusReq); err != nil {
 		logger.WithError(err).Error("Failed to parse status update request")
-		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
 	}
 
-	// Validate status
-	validStatuses := []string{
-		models.IssueStatusOpen,
-		models.IssueStatusInProgress,
-		models.IssueStatusReadyForReview,
-		models.IssueStatusClosed,
-		models.IssueStatusRejected,
-		models.IssueStatusOnHold,
+	// Validate status and that the transition is allowed from the current status
+	if !models.IsValidIssueStatus(statusReq.Status) {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Invalid status value", logger)
 	}
-	
-	isValid := false
-	for _, s := range validStatuses {
-		if statusReq.Status == s {
-			isValid = true
-			break
-		}
-	}
-	
-	if !isValid {
-		return api.ErrorResponse(http.StatusBadRequest, "Invalid status value", logger)
+	if err := models.ValidateIssueStatusTransition(issue.Status, statusReq.Status); err != nil {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger)
 	}
 
 	// Store old status for activity logging
@@ -402,11 +739,11 @@ func handleUpdateIssueStatus(ctx context.Context, issueID, userID, orgID int64,
 	// Update status
 	err = issueRepository.UpdateIssueStatus(ctx, issueID, userID, statusReq.Status)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to update issue status")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update issue status", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to update issue status", logger)
 	}
 
 	// Log status change activity
@@ -416,6 +753,17 @@ func handleUpdateIssueStatus(ctx context.Context, issueID, userID, orgID int64,
 		if err != nil {
 			logger.WithError(err).Warn("Failed to log status change activity")
 		}
+
+		publishIssueStatusChanged(ctx, issueID, issue.ProjectID, orgID, issue.AssignedTo, oldStatus, statusReq.Status, userID)
+		notifyIssueWatchers(ctx, models.IssueWatcherNotification{
+			Trigger:     models.IssueEventStatusChanged,
+			IssueID:     issueID,
+			ProjectID:   issue.ProjectID,
+			OrgID:       orgID,
+			ActorUserID: userID,
+			OldStatus:   oldStatus,
+			NewStatus:   statusReq.Status,
+		})
 	}
 
 	return api.SuccessResponse(http.StatusOK, map[string]string{
@@ -424,16 +772,207 @@ func handleUpdateIssueStatus(ctx context.Context, issueID, userID, orgID int64,
 	}, logger)
 }
 
-// handleDeleteIssue handles DELETE /issues/{issueId}
-func handleDeleteIssue(ctx context.Context, issueID, userID, orgID int64) events.APIGatewayProxyResponse {
+// handleBulkUpdateIssueStatus handles POST /issues/bulk-status.
+// Items succeed or fail independently unless atomic is set in the body or as ?atomic=true.
+func handleBulkUpdateIssueStatus(ctx context.Context, request events.APIGatewayProxyRequest, userID, orgID int64) events.APIGatewayProxyResponse {
+	var bulkReq models.BulkIssueStatusRequest
+	if err := api.ParseJSONBody(request.Body, &bulkReq); err != nil {
+		logger.WithError(err).Error("Failed to parse bulk status update request")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
+	}
+
+	if len(bulkReq.IssueIDs) == 0 {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "issue_ids must contain at least one item", logger)
+	}
+	if len(bulkReq.IssueIDs) > models.MaxBulkIssueStatusUpdates {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("issue_ids cannot contain more than %d items", models.MaxBulkIssueStatusUpdates), logger)
+	}
+	if !models.IsValidIssueStatus(bulkReq.Status) {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Invalid status value", logger)
+	}
+	atomic := bulkReq.Atomic || request.QueryStringParameters["atomic"] == "true"
+
+	batchResults, err := issueRepository.BulkUpdateStatus(ctx, bulkReq.IssueIDs, orgID, userID, bulkReq.Status, atomic)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bulk update issue status")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to update issue status", logger)
+	}
+
+	results := api.NewBulkResults(len(batchResults))
+	for _, result := range batchResults {
+		if result.Err == nil {
+			results.AddSuccess(result.IssueID)
+			if result.OldStatus != bulkReq.Status {
+				publishIssueStatusChanged(ctx, result.IssueID, result.ProjectID, orgID, result.AssignedTo, result.OldStatus, bulkReq.Status, userID)
+				notifyIssueWatchers(ctx, models.IssueWatcherNotification{
+					Trigger:     models.IssueEventStatusChanged,
+					IssueID:     result.IssueID,
+					ProjectID:   result.ProjectID,
+					OrgID:       orgID,
+					ActorUserID: userID,
+					OldStatus:   result.OldStatus,
+					NewStatus:   bulkReq.Status,
+				})
+			}
+			continue
+		}
+
+		switch {
+		case errors.Is(result.Err, data.ErrNotFound):
+			results.AddError(result.IssueID, api.BulkErrorNotFound, "Issue not found")
+		case errors.Is(result.Err, data.ErrOrgMismatch):
+			results.AddError(result.IssueID, api.BulkErrorForbidden, "Issue does not belong to your organization")
+		case errors.Is(result.Err, data.ErrConflict):
+			results.AddError(result.IssueID, api.BulkErrorConflict, result.Err.Error())
+		default:
+			results.AddError(result.IssueID, api.BulkErrorInvalid, result.Err.Error())
+		}
+	}
+
+	return api.BulkResponse(results, logger)
+}
+
+// snsRecipients returns the users from userIDs, other than the actor, who have SNS on for eventType.
+// A failed lookup is logged and notifies no one rather than ignoring preferences.
+func snsRecipients(ctx context.Context, orgID int64, eventType string, actorUserID int64, userIDs ...int64) []int64 {
+	candidates := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != actorUserID {
+			candidates = append(candidates, userID)
+		}
+	}
+	if len(candidates) == 0 {
+		return []int64{}
+	}
+
+	recipientIDs, err := userRepository.GetSNSRecipientIDs(ctx, orgID, candidates, eventType)
+	if err != nil {
+		logger.WithError(err).WithField("event_type", eventType).Warn("Failed to check notification preferences")
+		return []int64{}
+	}
+	if recipientIDs == nil {
+		return []int64{}
+	}
+	return recipientIDs
+}
+
+// publishIssueAssigned sends an issue.assigned event to the issue events topic when the assignee is not the
+// actor and has SNS on for issue_assigned. Like the status event it is best-effort.
+func publishIssueAssigned(ctx context.Context, issueID, projectID, orgID, assigneeID, actorUserID int64) {
+	if snsClient == nil || issueEventsTopic == "" {
+		return
+	}
+	if len(snsRecipients(ctx, orgID, models.NotificationEventIssueAssigned, actorUserID, assigneeID)) == 0 {
+		return
+	}
+
+	event := models.IssueAssignedEvent{
+		EventType:   models.IssueEventAssigned,
+		IssueID:     issueID,
+		ProjectID:   projectID,
+		OrgID:       orgID,
+		AssigneeID:  assigneeID,
+		ActorUserID: actorUserID,
+		Timestamp:   time.Now().UTC(),
+	}
+	err := clients.PublishEvent(snsClient, issueEventsTopic, event, map[string]string{
+		"event_type": event.EventType,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("issue_id", issueID).Warn("Failed to publish issue assigned event")
+	}
+}
+
+// publishIssueStatusChanged sends an issue.status_changed event to the issue events topic. The event always
+// goes out for analytics consumers; only an assignee with SNS on for issue_status_changed is listed as a recipient.
+// Publishing is best-effort: failures are logged and never fail the API call.
+func publishIssueStatusChanged(ctx context.Context, issueID, projectID, orgID int64, assignedTo *int64, oldStatus, newStatus string, actorUserID int64) {
+	if snsClient == nil || issueEventsTopic == "" {
+		return
+	}
+
+	recipientIDs := []int64{}
+	if assignedTo != nil {
+		recipientIDs = snsRecipients(ctx, orgID, models.NotificationEventIssueStatusChanged, actorUserID, *assignedTo)
+	}
+
+	event := models.IssueStatusChangedEvent{
+		EventType:    models.IssueEventStatusChanged,
+		IssueID:      issueID,
+		ProjectID:    projectID,
+		OrgID:        orgID,
+		OldStatus:    oldStatus,
+		NewStatus:    newStatus,
+		RecipientIDs: recipientIDs,
+		ActorUserID:  actorUserID,
+		Timestamp:    time.Now().UTC(),
+	}
+	err := clients.PublishEvent(snsClient, issueEventsTopic, event, map[string]string{
+		"event_type": event.EventType,
+		"new_status": newStatus,
+	})
+	if err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"issue_id":   issueID,
+			"new_status": newStatus,
+		}).Warn("Failed to publish issue status event")
+	}
+}
+
+// notifyIssueWatchers records a notification for the issue's watchers, other than the actor, and publishes it to the
+// issue events topic for delivery to the watchers with SNS on for issue_watcher_update. Like the status event it is
+// best-effort and never fails the API call.
+func notifyIssueWatchers(ctx context.Context, notification models.IssueWatcherNotification) {
+	notification.EventType = models.IssueEventWatcherNotification
+	notification.Timestamp = time.Now().UTC()
+
+	log := logger.WithFields(logrus.Fields{
+		"issue_id": notification.IssueID,
+		"trigger":  notification.Trigger,
+	})
+	if err := issueRepository.RecordWatcherNotification(ctx, &notification); err != nil {
+		log.WithError(err).Warn("Failed to record issue watcher notification")
+		return
+	}
+	if len(notification.RecipientIDs) == 0 || snsClient == nil || issueEventsTopic == "" {
+		return
+	}
+
+	notification.RecipientIDs = snsRecipients(ctx, notification.OrgID, models.NotificationEventIssueWatcherUpdate, notification.ActorUserID, notification.RecipientIDs...)
+	if len(notification.RecipientIDs) == 0 {
+		return
+	}
+
+	err := clients.PublishEvent(snsClient, issueEventsTopic, notification, map[string]string{
+		"event_type": notification.EventType,
+		"trigger":    notification.Trigger,
+	})
+	if err != nil {
+		log.WithError(err).Warn("Failed to publish issue watcher notification")
+	}
+}
+
+// handleDeleteIssue handles DELETE /issues/{issueId}. The body is optional and may carry a delete_reason.
+func handleDeleteIssue(ctx context.Context, issueID, userID, orgID int64, body string) events.APIGatewayProxyResponse {
+	var deleteReq models.DeleteIssueRequest
+	if strings.TrimSpace(body) != "" {
+		if err := api.ParseJSONBody(body, &deleteReq); err != nil {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
+		}
+	}
+	deleteReason := strings.TrimSpace(deleteReq.DeleteReason)
+	if len(deleteReason) > models.MaxIssueDeleteReasonLength {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("delete_reason cannot be longer than %d characters", models.MaxIssueDeleteReasonLength), logger)
+	}
+
 	// First check if issue exists and belongs to org
 	issue, err := issueRepository.GetIssueByID(ctx, issueID)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to get issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger)
 	}
 
 	// Validate issue belongs to org
@@ -444,32 +983,90 @@ func handleDeleteIssue(ctx context.Context, issueID, userID, orgID int64) events
 	`, issue.ProjectID).Scan(&projectOrgID)
 	
 	if err != nil || projectOrgID != orgID {
-		return api.ErrorResponse(http.StatusForbidden, "Issue does not belong to your organization", logger)
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger)
 	}
 
 	// Delete issue
-	err = issueRepository.DeleteIssue(ctx, issueID, userID)
+	err = issueRepository.DeleteIssue(ctx, issueID, userID, deleteReason)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to delete issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to delete issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to delete issue", logger)
 	}
 
 	return api.SuccessResponse(http.StatusOK, map[string]string{"message": "Issue deleted successfully"}, logger)
 }
 
+// handleRestoreIssue handles POST /issues/{issueId}/restore and returns the restored issue
+func handleRestoreIssue(ctx context.Context, issueID, userID, orgID int64) events.APIGatewayProxyResponse {
+	err := issueRepository.RestoreIssue(ctx, issueID, userID, orgID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
+		}
+		if errors.Is(err, data.ErrOrgMismatch) {
+			return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger)
+		}
+		if errors.Is(err, data.ErrConflict) {
+			return api.ErrorResponseWithCode(http.StatusConflict, api.ErrorCodeConflict, err.Error(), logger)
+		}
+		logger.WithError(err).Error("Failed to restore issue")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to restore issue", logger)
+	}
+
+	issue, err := issueRepository.GetIssueByID(ctx, issueID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get restored issue")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger)
+	}
+
+	return api.SuccessResponse(http.StatusOK, issue, logger)
+}
+
+// handleCopyIssue handles POST /issues/{issueId}/copy
+func handleCopyIssue(ctx context.Context, issueID, userID, orgID int64, isSuperAdmin bool, body string) events.APIGatewayProxyResponse {
+	var copyReq models.CopyToProjectRequest
+	if err := api.ParseJSONBody(body, &copyReq); err != nil {
+		logger.WithError(err).Error("Failed to parse copy issue request")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, copyReq.TargetProjectID, orgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
+	}
+	if statusCode, errCode, errMsg := api.ValidateProjectMembership(ctx, orgRepository, projectRepository, copyReq.TargetProjectID, orgID, userID, isSuperAdmin); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
+	}
+
+	issue, err := issueRepository.CopyIssue(ctx, issueID, copyReq.TargetProjectID, userID, orgID, copyReq.CopyAttachments)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrTargetProjectNotFound):
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeProjectNotFound, "Target project not found", logger)
+		case errors.Is(err, data.ErrNotFound):
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
+		case errors.Is(err, data.ErrOrgMismatch):
+			return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Target project does not belong to your organization", logger)
+		}
+		logger.WithError(err).Error("Failed to copy issue")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to copy issue", logger)
+	}
+
+	return api.SuccessResponse(http.StatusCreated, issue, logger)
+}
+
 // handleCreateComment handles POST /issues/{issueId}/comments
 func handleCreateComment(ctx context.Context, issueID, userID, orgID int64, body string) events.APIGatewayProxyResponse {
 	// First validate that issue exists and belongs to user's organization
 	issue, err := issueRepository.GetIssueByID(ctx, issueID)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger)
 		}
 		logger.WithError(err).Error("Failed to get issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issue", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger)
 	}
 
 	// Validate issue belongs to org
@@ -480,63 +1077,313 @@ func handleCreateComment(ctx context.Context, issueID, userID, orgID int64, body
 	`, issue.ProjectID).Scan(&projectOrgID)
 
 	if err != nil || projectOrgID != orgID {
-		return api.ErrorResponse(http.StatusForbidden, "Issue does not belong to your organization", logger)
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger)
 	}
 
 	// Parse comment request
 	var commentReq models.CreateCommentRequest
 	if err := json.Unmarshal([]byte(body), &commentReq); err != nil {
 		logger.WithError(err).Error("Failed to parse create comment request")
-		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
 	}
 
 	// Validate required fields
 	if commentReq.Comment == "" {
-		return api.ErrorResponse(http.StatusBadRequest, "Comment is required", logger)
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Comment is required", logger)
 	}
 
 	// Create comment
 	comment, err := issueRepository.CreateComment(ctx, issueID, userID, &commentReq)
 	if err != nil {
 		logger.WithError(err).Error("Failed to create comment")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create comment", logger)
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create comment", logger)
 	}
 
+	notifyIssueWatchers(ctx, models.IssueWatcherNotification{
+		Trigger:     models.IssueEventCommentAdded,
+		IssueID:     issueID,
+		ProjectID:   issue.ProjectID,
+		OrgID:       orgID,
+		ActorUserID: userID,
+		CommentID:   comment.ID,
+	})
+	publishIssueCommentMentions(issueID, orgID, userID, comment)
+
 	return api.SuccessResponse(http.StatusCreated, comment, logger)
 }
 
-// handleGetIssueComments handles GET /issues/{issueId}/comments
-func handleGetIssueComments(ctx context.Context, issueID, orgID int64) events.APIGatewayProxyResponse {
-	// First validate that issue exists and belongs to user's organization
+// publishIssueCommentMentions sends a comment.mention event for the users mentioned in a new issue comment.
+// The mentions are already stored in project.comment_mentions, so publishing is best-effort.
+func publishIssueCommentMentions(issueID, orgID, userID int64, comment *models.IssueComment) {
+	recipients := models.MentionedUserIDs(comment.Mentions, userID)
+	if len(recipients) == 0 || snsClient == nil || issueEventsTopic == "" {
+		return
+	}
+
+	event := models.CommentMentionEvent{
+		EventType:        models.CommentEventMention,
+		EntityType:       models.EntityTypeIssue,
+		EntityID:         issueID,
+		CommentID:        comment.ID,
+		OrgID:            orgID,
+		MentionedUserIDs: recipients,
+		ActorUserID:      userID,
+		Timestamp:        time.Now().UTC(),
+	}
+	err := clients.PublishEvent(snsClient, issueEventsTopic, event, map[string]string{
+		"event_type": event.EventType,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("issue_id", issueID).Warn("Failed to publish issue comment mention event")
+	}
+}
+
+// parseIssueCommentPath reads the issueId and commentId path parameters, returning the error response to send when either is invalid
+func parseIssueCommentPath(request events.APIGatewayProxyRequest) (int64, int64, events.APIGatewayProxyResponse, bool) {
+	issueID, err := strconv.ParseInt(request.PathParameters["issueId"], 10, 64)
+	if err != nil {
+		return 0, 0, api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid issue ID", logger), false
+	}
+	commentID, err := strconv.ParseInt(request.PathParameters["commentId"], 10, 64)
+	if err != nil {
+		return 0, 0, api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid comment ID", logger), false
+	}
+	return issueID, commentID, events.APIGatewayProxyResponse{}, true
+}
+
+// commentChangeErrorResponse maps an UpdateComment or SoftDeleteComment error to its response
+func commentChangeErrorResponse(err error, fallback string) events.APIGatewayProxyResponse {
+	switch {
+	case errors.Is(err, data.ErrNotFound):
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeNotFound, "Comment not found", logger)
+	case errors.Is(err, data.ErrForbidden):
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeForbidden, "Only the comment author can change this comment", logger)
+	case errors.Is(err, data.ErrConflict):
+		return api.ErrorResponseWithCode(http.StatusConflict, api.ErrorCodeConflict, "Activity entries cannot be changed", logger)
+	}
+	logger.WithError(err).Error(fallback)
+	return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, fallback, logger)
+}
+
+// handleUpdateComment handles PUT /issues/{issueId}/comments/{commentId}
+func handleUpdateComment(ctx context.Context, issueID, commentID, userID, orgID int64, isSuperAdmin bool, body string) events.APIGatewayProxyResponse {
+	if _, resp, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return resp
+	}
+
+	var updateReq models.UpdateCommentRequest
+	if err := api.ParseJSONBody(body, &updateReq); err != nil {
+		logger.WithError(err).Error("Failed to parse update comment request")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
+	}
+	if strings.TrimSpace(updateReq.Comment) == "" {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Comment is required", logger)
+	}
+
+	comment, err := issueRepository.UpdateComment(ctx, issueID, commentID, userID, isSuperAdmin, &updateReq)
+	if err != nil {
+		return commentChangeErrorResponse(err, "Failed to update comment")
+	}
+
+	return api.SuccessResponse(http.StatusOK, comment, logger)
+}
+
+// handleDeleteComment handles DELETE /issues/{issueId}/comments/{commentId}. The comment stays in the
+// list as a "[deleted]" placeholder.
+func handleDeleteComment(ctx context.Context, issueID, commentID, userID, orgID int64, isSuperAdmin bool) events.APIGatewayProxyResponse {
+	if _, resp, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return resp
+	}
+
+	if err := issueRepository.SoftDeleteComment(ctx, issueID, commentID, userID, isSuperAdmin); err != nil {
+		return commentChangeErrorResponse(err, "Failed to delete comment")
+	}
+
+	return api.SuccessResponse(http.StatusNoContent, nil, logger)
+}
+
+// handleGetIssueComments handles GET /issues/{issueId}/comments?limit=&offset=&after_id=&before_id=
+func handleGetIssueComments(ctx context.Context, request events.APIGatewayProxyRequest, issueID, orgID int64) events.APIGatewayProxyResponse {
+	// Callers that do not pass a limit get the whole thread, so existing clients never lose comments
+	query := models.IssueCommentQuery{All: true}
+	if l, err := strconv.Atoi(request.QueryStringParameters["limit"]); err == nil && l > 0 && l <= models.MaxIssueCommentLimit {
+		query = models.IssueCommentQuery{Limit: l}
+	}
+	if o, err := strconv.Atoi(request.QueryStringParameters["offset"]); err == nil && o > 0 {
+		query.Offset = o
+	}
+	if raw := request.QueryStringParameters["after_id"]; raw != "" {
+		afterID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || afterID <= 0 {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Invalid after_id", logger)
+		}
+		query.AfterID = afterID
+	}
+	if raw := request.QueryStringParameters["before_id"]; raw != "" {
+		beforeID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "Invalid before_id", logger)
+		}
+		query.BeforeID = beforeID
+	}
+	if query.AfterID > 0 && query.BeforeID > 0 {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "after_id and before_id cannot be combined", logger)
+	}
+
+	if _, errResponse, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return errResponse
+	}
+
+	// Get comments
+	comments, totalCount, hasMore, err := issueRepository.GetIssueComments(ctx, issueID, query)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get comments")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get comments", logger)
+	}
+
+	// A whole thread is one page; cursor pages (after_id/before_id) only know whether more rows follow
+	pagination := api.SinglePageMeta(totalCount)
+	if !query.All {
+		pagination = api.NewOffsetPaginationMeta(query.Limit, query.Offset, totalCount)
+		pagination.HasNext = hasMore
+	}
+	return api.ListResponse(request, comments, comments, pagination, logger)
+}
+
+// requireIssueInOrg loads an issue and checks that its project is in the caller's organization.
+// When the check fails it returns the error response to send.
+func requireIssueInOrg(ctx context.Context, issueID, orgID int64) (*models.IssueResponse, events.APIGatewayProxyResponse, bool) {
 	issue, err := issueRepository.GetIssueByID(ctx, issueID)
 	if err != nil {
-		if err.Error() == "issue not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Issue not found", logger)
+		if errors.Is(err, data.ErrNotFound) {
+			return nil, api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeIssueNotFound, "Issue not found", logger), true
 		}
 		logger.WithError(err).Error("Failed to get issue")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get issue", logger)
+		return nil, api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger), true
 	}
 
-	// Validate issue belongs to org
 	var projectOrgID int64
 	err = sqlDB.QueryRowContext(ctx, `
 		SELECT org_id FROM project.projects
 		WHERE id = $1 AND is_deleted = FALSE
 	`, issue.ProjectID).Scan(&projectOrgID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.WithError(err).Error("Failed to get issue project organization")
+		return nil, api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue", logger), true
+	}
 
-	if err != nil || projectOrgID != orgID {
-		return api.ErrorResponse(http.StatusForbidden, "Issue does not belong to your organization", logger)
+	if err == sql.ErrNoRows || projectOrgID != orgID {
+		return nil, api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Issue does not belong to your organization", logger), true
 	}
+	return issue, events.APIGatewayProxyResponse{}, false
+}
 
-	// Get comments
-	comments, err := issueRepository.GetIssueComments(ctx, issueID)
+// handleGetIssueActivity handles GET /issues/{issueId}/activity?limit=&offset=, returning the activity log oldest first
+func handleGetIssueActivity(ctx context.Context, request events.APIGatewayProxyRequest, issueID, orgID int64) events.APIGatewayProxyResponse {
+	limit := models.DefaultIssueActivityLimit
+	if l, err := strconv.Atoi(request.QueryStringParameters["limit"]); err == nil && l > 0 && l <= models.MaxIssueActivityLimit {
+		limit = l
+	}
+	offset := 0
+	if o, err := strconv.Atoi(request.QueryStringParameters["offset"]); err == nil && o > 0 {
+		offset = o
+	}
+
+	if _, resp, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return resp
+	}
+
+	activity, totalCount, hasMore, err := issueRepository.GetActivityLog(ctx, issueID, limit, offset)
 	if err != nil {
-		logger.WithError(err).Error("Failed to get comments")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get comments", logger)
+		logger.WithError(err).WithField("issue_id", issueID).Error("Failed to get issue activity")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get issue activity", logger)
 	}
 
-	// Return comments array directly
-	return api.SuccessResponse(http.StatusOK, comments, logger)
+	response := models.IssueActivityListResponse{
+		IssueID:    issueID,
+		Activity:   activity,
+		TotalCount: totalCount,
+		Limit:      limit,
+		Offset:     offset,
+		HasMore:    hasMore,
+	}
+
+	return api.ListResponse(request, response, activity, api.NewOffsetPaginationMeta(limit, offset, totalCount), logger)
+}
+
+// handleGetIssueWatchers handles GET /issues/{issueId}/watchers
+func handleGetIssueWatchers(ctx context.Context, issueID, orgID int64) events.APIGatewayProxyResponse {
+	if _, resp, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return resp
+	}
+
+	watchers, err := issueRepository.GetIssueWatchers(ctx, issueID)
+	if err != nil {
+		logger.WithError(err).WithField("issue_id", issueID).Error("Failed to get issue watchers")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get watchers", logger)
+	}
+
+	return api.SuccessResponse(http.StatusOK, models.IssueWatchersResponse{IssueID: issueID, Watchers: watchers}, logger)
+}
+
+// handleAddIssueWatcher handles POST /issues/{issueId}/watchers. The watcher must belong to the caller's
+// organization; adding a user who is already watching succeeds with 200 instead of 201.
+func handleAddIssueWatcher(ctx context.Context, issueID, userID, orgID int64, body string) events.APIGatewayProxyResponse {
+	var addReq models.AddIssueWatcherRequest
+	if strings.TrimSpace(body) != "" {
+		if err := api.ParseJSONBodyStrict(body, &addReq); err != nil {
+			if errors.Is(err, api.ErrUnknownField) {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, err.Error(), logger)
+			}
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger)
+		}
+	}
+	watcherID := addReq.UserID
+	if watcherID == 0 {
+		watcherID = userID
+	}
+
+	if _, resp, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return resp
+	}
+	if statusCode, errCode, errMsg := api.ValidateUserInOrg(ctx, userRepository, watcherID, orgID, "user_id"); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
+	}
+
+	added, err := issueRepository.AddIssueWatcher(ctx, issueID, watcherID, userID)
+	if err != nil {
+		logger.WithError(err).WithField("issue_id", issueID).Error("Failed to add issue watcher")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to add watcher", logger)
+	}
+
+	watchers, err := issueRepository.GetIssueWatchers(ctx, issueID)
+	if err != nil {
+		logger.WithError(err).WithField("issue_id", issueID).Error("Failed to get issue watchers")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get watchers", logger)
+	}
+
+	statusCode := http.StatusOK
+	if added {
+		statusCode = http.StatusCreated
+	}
+	return api.SuccessResponse(statusCode, models.IssueWatchersResponse{IssueID: issueID, Watchers: watchers}, logger)
+}
+
+// handleRemoveIssueWatcher handles DELETE /issues/{issueId}/watchers/{userId}
+func handleRemoveIssueWatcher(ctx context.Context, issueID, watcherID, orgID int64) events.APIGatewayProxyResponse {
+	if _, resp, failed := requireIssueInOrg(ctx, issueID, orgID); failed {
+		return resp
+	}
+
+	if err := issueRepository.RemoveIssueWatcher(ctx, issueID, watcherID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeNotFound, "User is not watching this issue", logger)
+		}
+		logger.WithError(err).WithField("issue_id", issueID).Error("Failed to remove issue watcher")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to remove watcher", logger)
+	}
+
+	return api.SuccessResponse(http.StatusNoContent, nil, logger)
 }
 
 // main is the Lambda function entry point
@@ -582,6 +1429,17 @@ func init() {
 		}).Fatal("Error setting up PostgreSQL client")
 	}
 
+	// Issue lifecycle events are optional; without a topic the Lambda runs without publishing
+	issueEventsTopic = ssmParams[constants.ISSUE_EVENTS_TOPIC_ARN]
+	if issueEventsTopic != "" {
+		snsClient = clients.NewSNSClient(isLocal)
+	} else {
+		logger.WithField("operation", "init").Warn("Issue events topic not configured; status change events will not be published")
+	}
+
+	// Per-org limit on project issue lists, the heaviest query this service runs
+	issueListLimiter = api.NewRateLimiter(api.ParseRateLimit(ssmParams[constants.RATE_LIMIT_ISSUE_LIST], api.DefaultListRateLimit))
+
 	logger.WithField("operation", "init").Info("Issue Management Lambda initialization completed successfully")
 }
 
@@ -600,6 +1458,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -619,8 +1480,32 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		Logger: logger,
 	}
 
+	// Initialize project repository (default assignee lookup, membership checks)
+	projectRepository = &data.ProjectDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize org repository (project membership setting)
+	orgRepository = &data.OrgDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize user repository (assignee organization checks)
+	userRepository = &data.UserManagementDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize idempotency key store (Idempotency-Key on POST /issues)
+	idempotencyStore = &data.IdempotencyDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -61,7 +62,14 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return handleCreateLocation(ctx, claims.UserID, claims.OrgID, request.Body), nil
 		
 	case http.MethodGet:
-		if len(pathSegments) >= 2 && pathSegments[1] != "" {
+		if len(pathSegments) >= 2 && pathSegments[1] == "tree" {
+			// GET /locations/tree - Get locations nested under their parents
+			filters := request.QueryStringParameters
+			if filters == nil {
+				filters = make(map[string]string)
+			}
+			return handleGetLocationTree(ctx, request, claims.OrgID, filters), nil
+		} else if len(pathSegments) >= 2 && pathSegments[1] != "" {
 			// GET /locations/{id} - Get specific location
 			locationID, err := strconv.ParseInt(pathSegments[1], 10, 64)
 			if err != nil {
@@ -69,8 +77,12 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 			}
 			return handleGetLocation(ctx, locationID, claims.OrgID), nil
 		} else {
-			// GET /locations - Get all locations for org
-			return handleGetLocations(ctx, claims.OrgID), nil
+			// GET /locations - Get locations for org (active only unless status/include_inactive given)
+			filters := request.QueryStringParameters
+			if filters == nil {
+				filters = make(map[string]string)
+			}
+			return handleGetLocations(ctx, request, claims.OrgID, filters), nil
 		}
 		
 	case http.MethodPut:
@@ -87,12 +99,17 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		
 	case http.MethodDelete:
 		if len(pathSegments) >= 2 && pathSegments[1] != "" {
-			// DELETE /locations/{id} - Delete location
+			// DELETE /locations/{id}?cascade=true&force=true - Delete location
+			// (cascade also deletes child locations, force also deletes their projects)
 			locationID, err := strconv.ParseInt(pathSegments[1], 10, 64)
 			if err != nil {
 					return api.ErrorResponse(http.StatusBadRequest, "Invalid location ID", logger), nil
 			}
-			return handleDeleteLocation(ctx, locationID, claims.OrgID, claims.UserID), nil
+			opts := models.DeleteLocationOptions{
+				Cascade: request.QueryStringParameters["cascade"] == "true",
+				Force:   request.QueryStringParameters["force"] == "true",
+			}
+			return handleDeleteLocation(ctx, locationID, claims.OrgID, claims.UserID, opts), nil
 		} else {
 			return api.ErrorResponse(http.StatusBadRequest, "Location ID required for deletion", logger), nil
 		}
@@ -120,21 +137,25 @@ func handleCreateLocation(ctx context.Context, userID, orgID int64, body string)
 		ZipCode:      createReq.ZipCode,
 		Country:      createReq.Country,
 		Status:       createReq.Status,
+		ParentLocationID: createReq.ParentLocationID,
 	}
 
 	// Create location
 	createdLocation, err := locationRepository.CreateLocation(ctx, userID, orgID, location)
 	if err != nil {
-		logger.WithError(err).Error("Failed to create location")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create location", logger)
+		return locationErrorResponse(err, "Failed to create location")
 	}
 
 	return api.SuccessResponse(http.StatusCreated, createdLocation, logger)
 }
 
-// handleGetLocations handles GET /locations
-func handleGetLocations(ctx context.Context, orgID int64) events.APIGatewayProxyResponse {
-	locations, err := locationRepository.GetLocationsByOrg(ctx, orgID)
+// handleGetLocations handles GET /locations with optional status, include_inactive and parent_id query parameters
+func handleGetLocations(ctx context.Context, request events.APIGatewayProxyRequest, orgID int64, filters map[string]string) events.APIGatewayProxyResponse {
+	if message := validateLocationFilters(filters); message != "" {
+		return api.ErrorResponse(http.StatusBadRequest, message, logger)
+	}
+
+	locations, err := locationRepository.GetLocationsByOrg(ctx, orgID, filters)
 	if err != nil {
 		logger.WithError(err).Error("Failed to get locations")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get locations", logger)
@@ -145,14 +166,53 @@ func handleGetLocations(ctx context.Context, orgID int64) events.APIGatewayProxy
 		Total:     len(locations),
 	}
 
-	return api.SuccessResponse(http.StatusOK, response, logger)
+	return api.ListResponse(request, response, locations, api.SinglePageMeta(len(locations)), logger)
+}
+
+// handleGetLocationTree handles GET /locations/tree with the same status and include_inactive filters as GET /locations.
+// Locations whose parent is filtered out are returned at the top level.
+func handleGetLocationTree(ctx context.Context, request events.APIGatewayProxyRequest, orgID int64, filters map[string]string) events.APIGatewayProxyResponse {
+	delete(filters, "parent_id")
+	if message := validateLocationFilters(filters); message != "" {
+		return api.ErrorResponse(http.StatusBadRequest, message, logger)
+	}
+
+	locations, err := locationRepository.GetLocationsByOrg(ctx, orgID, filters)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get locations")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get locations", logger)
+	}
+
+	response := models.LocationListResponse{
+		Locations: models.BuildLocationTree(locations),
+		Total:     len(locations),
+	}
+
+	return api.ListResponse(request, response, response.Locations, api.SinglePageMeta(len(locations)), logger)
+}
+
+// validateLocationFilters checks the status and parent_id list filters and returns a message when one is invalid
+func validateLocationFilters(filters map[string]string) string {
+	if status := filters["status"]; status != "" {
+		switch status {
+		case models.LocationStatusActive, models.LocationStatusInactive, models.LocationStatusUnderConstruction, models.LocationStatusClosed:
+		default:
+			return "Invalid status. Must be one of: active, inactive, under_construction, closed"
+		}
+	}
+	if parentID := filters["parent_id"]; parentID != "" {
+		if id, err := strconv.ParseInt(parentID, 10, 64); err != nil || id <= 0 {
+			return "Invalid parent_id"
+		}
+	}
+	return ""
 }
 
 // handleGetLocation handles GET /locations/{id}
 func handleGetLocation(ctx context.Context, locationID, orgID int64) events.APIGatewayProxyResponse {
 	location, err := locationRepository.GetLocationByID(ctx, locationID, orgID)
 	if err != nil {
-		if err.Error() == "location not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Location not found", logger)
 		}
 		logger.WithError(err).Error("Failed to get location")
@@ -172,28 +232,56 @@ func handleUpdateLocation(ctx context.Context, locationID, orgID, userID int64,
 
 	updatedLocation, err := locationRepository.UpdateLocation(ctx, locationID, orgID, &updateReq, userID)
 	if err != nil {
-		if err.Error() == "location not found" {
-			return api.ErrorResponse(http.StatusNotFound, "Location not found", logger)
-		}
-		logger.WithError(err).Error("Failed to update location")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update location", logger)
+		return locationErrorResponse(err, "Failed to update location")
 	}
 
 	return api.SuccessResponse(http.StatusOK, updatedLocation, logger)
 }
 
 // handleDeleteLocation handles DELETE /locations/{id}
-func handleDeleteLocation(ctx context.Context, locationID, orgID, userID int64) events.APIGatewayProxyResponse {
-	err := locationRepository.DeleteLocation(ctx, locationID, orgID, userID)
+// A location with child locations returns 409 unless cascade is set, and a location with active projects
+// returns 409 listing them unless force is set. The response summarizes every location and project deleted.
+func handleDeleteLocation(ctx context.Context, locationID, orgID, userID int64, opts models.DeleteLocationOptions) events.APIGatewayProxyResponse {
+	summary, err := locationRepository.DeleteLocation(ctx, locationID, orgID, userID, opts)
 	if err != nil {
-		if err.Error() == "location not found" {
+		var projectsErr *models.LocationHasProjectsError
+		if errors.As(err, &projectsErr) {
+			logger.WithFields(logrus.Fields{
+				"location_id": locationID,
+				"project_ids": projectsErr.ProjectIDs,
+			}).Warn("Location delete blocked by active projects")
+			return api.ConflictResponse("Location has active projects; delete them first or pass force=true", map[string]interface{}{
+				"code":        api.ErrorCodeLocationHasProjects,
+				"project_ids": projectsErr.ProjectIDs,
+			}, logger)
+		}
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Location not found", logger)
 		}
+		if errors.Is(err, data.ErrConflict) {
+			return api.ErrorResponseWithCode(http.StatusConflict, api.ErrorCodeLocationHasChildren, "Location has child locations; delete them first or pass cascade=true", logger)
+		}
 		logger.WithError(err).Error("Failed to delete location")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to delete location", logger)
 	}
 
-	return api.SuccessResponse(http.StatusNoContent, nil, logger)
+	return api.SuccessResponse(http.StatusOK, summary, logger)
+}
+
+// locationErrorResponse maps create and update errors to API responses
+func locationErrorResponse(err error, fallbackMessage string) events.APIGatewayProxyResponse {
+	switch {
+	case errors.Is(err, data.ErrNotFound):
+		return api.ErrorResponse(http.StatusNotFound, "Location not found", logger)
+	case errors.Is(err, data.ErrConflict):
+		return api.ErrorResponse(http.StatusConflict, "A location with this name already exists in your organization", logger)
+	case errors.Is(err, data.ErrParentLocationNotFound):
+		return api.ErrorResponse(http.StatusBadRequest, "Parent location not found in your organization", logger)
+	case errors.Is(err, data.ErrInvalid):
+		return api.ErrorResponse(http.StatusBadRequest, "A location cannot be placed under itself or one of its descendants", logger)
+	}
+	logger.WithError(err).Error(fallbackMessage)
+	return api.ErrorResponse(http.StatusInternalServerError, fallbackMessage, logger)
 }
 
 // main is the Lambda function entry point
@@ -257,6 +345,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -277,7 +368,7 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 	}
 
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
\ No newline at end of file
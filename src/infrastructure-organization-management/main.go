@@ -81,6 +81,35 @@ func handleUpdateOrganization(ctx context.Context, userID, orgID int64, body str
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
 	}
 
+	if updateReq.Email != "" {
+		if err := util.ValidateEmail(updateReq.Email); err != nil {
+			return api.ErrorResponse(http.StatusBadRequest, "email "+err.Error(), logger)
+		}
+	}
+	if err := util.ValidatePhone(updateReq.Phone); err != nil {
+		return api.ErrorResponse(http.StatusBadRequest, "phone "+err.Error(), logger)
+	}
+
+	if updateReq.AllowedEmailDomains != nil {
+		domains, err := models.NormalizeEmailDomains(updateReq.AllowedEmailDomains)
+		if err != nil {
+			return api.ErrorResponse(http.StatusBadRequest, err.Error(), logger)
+		}
+		updateReq.AllowedEmailDomains = domains
+	}
+
+	if updateReq.RFIAutoCloseDays != nil && (*updateReq.RFIAutoCloseDays < 0 || *updateReq.RFIAutoCloseDays > models.MaxRFIAutoCloseDays) {
+		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("rfi_auto_close_days must be between 0 and %d", models.MaxRFIAutoCloseDays), logger)
+	}
+
+	if updateReq.PrioritySLADays != nil {
+		slaDays, err := models.NormalizePrioritySLADays(updateReq.PrioritySLADays)
+		if err != nil {
+			return api.ErrorResponse(http.StatusBadRequest, err.Error(), logger)
+		}
+		updateReq.PrioritySLADays = slaDays
+	}
+
 	updatedOrg, err := orgRepository.UpdateOrganization(ctx, userID, orgID, &updateReq)
 	if err != nil {
 		if err.Error() == "organization not found" {
@@ -169,6 +198,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	// All connection details are fetched from SSM Parameter Store for security
 	sqlDB, err = clients.NewPostgresSQLClient(
@@ -197,7 +229,7 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 	}
 
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
@@ -30,8 +30,12 @@ var (
 	ssmParams             map[string]string
 	sqlDB                 *sql.DB
 	permissionRepository  data.PermissionRepository
+	projectRepository     data.ProjectRepository
 )
 
+// myPermissionsCacheSeconds lets the browser reuse a /me/permissions result while the user stays in a project
+const myPermissionsCacheSeconds = 60
+
 func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	logger.WithFields(logrus.Fields{
 		"operation": "Handler",
@@ -46,6 +50,11 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return api.ErrorResponse(http.StatusUnauthorized, "Authentication failed", logger), nil
 	}
 
+	// GET /me/permissions is available to every authenticated user
+	if request.Resource == "/me/permissions" && request.HTTPMethod == http.MethodGet {
+		return handleGetMyPermissions(ctx, request, claims), nil
+	}
+
 	if !claims.IsSuperAdmin {
 		logger.WithField("user_id", claims.UserID).Warn("User is not a super admin")
 		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can manage permissions", logger), nil
@@ -102,6 +111,54 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	}
 }
 
+// handleGetMyPermissions handles GET /me/permissions?project_id=
+// Returns the caller's effective roles and permissions for the org, or for a project when project_id is set.
+// Roles are not carried in the JWT, so the frontend calls this once per project context for UI gating.
+func handleGetMyPermissions(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
+	response := models.EffectivePermissionsResponse{
+		UserID:       claims.UserID,
+		OrgID:        claims.OrgID,
+		IsSuperAdmin: claims.IsSuperAdmin,
+	}
+
+	var projectID int64
+	if projectIDStr := request.QueryStringParameters["project_id"]; projectIDStr != "" {
+		parsed, err := strconv.ParseInt(projectIDStr, 10, 64)
+		if err != nil {
+			return api.ErrorResponse(http.StatusBadRequest, "Invalid project_id", logger)
+		}
+		if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, parsed, claims.OrgID); errMsg != "" {
+			return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger)
+		}
+		projectID = parsed
+		response.ProjectID = &projectID
+	}
+
+	roles, permissions, err := permissionRepository.GetEffectivePermissions(ctx, claims.UserID, claims.OrgID, projectID)
+	if err != nil {
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get permissions", logger)
+	}
+
+	// Super admins implicitly hold every permission defined in their organization
+	if claims.IsSuperAdmin {
+		orgPermissions, err := permissionRepository.GetPermissionsByOrg(ctx, claims.OrgID)
+		if err != nil {
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to get permissions", logger)
+		}
+		permissions = make([]string, 0, len(orgPermissions))
+		for _, permission := range orgPermissions {
+			permissions = append(permissions, permission.PermissionName)
+		}
+	}
+
+	response.Roles = roles
+	response.Permissions = permissions
+
+	resp := api.SuccessResponse(http.StatusOK, response, logger)
+	resp.Headers["Cache-Control"] = fmt.Sprintf("private, max-age=%d", myPermissionsCacheSeconds)
+	return resp
+}
+
 // handleCreatePermission handles POST /permissions
 func handleCreatePermission(ctx context.Context, userID, orgID int64, body string) events.APIGatewayProxyResponse {
 	var createReq models.CreatePermissionRequest
@@ -262,6 +319,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -281,8 +341,13 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		Logger: logger,
 	}
 
+	projectRepository = &data.ProjectDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -12,7 +13,9 @@ import (
 	"infrastructure/lib/models"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -27,6 +30,7 @@ var (
 	sqlDB                *sql.DB
 	projectRepository    data.ProjectRepository
 	assignmentRepository data.AssignmentRepository
+	permissionRepository data.PermissionRepository
 )
 
 // Handler processes API Gateway requests for project management operations
@@ -39,6 +43,11 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		"operation":   "Handler",
 	}).Debug("Processing project management request")
 
+	// Health checks are unauthenticated so synthetic monitors can call them without a token
+	if request.Resource == "/projects/health" && request.HTTPMethod == http.MethodGet {
+		return api.HealthCheckResponse(ctx, sqlDB, logger), nil
+	}
+
 	// Extract claims from JWT token via API Gateway authorizer
 	claims, err := auth.ExtractClaimsFromRequest(request)
 	if err != nil {
@@ -63,10 +72,54 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return handleCreateProject(ctx, request, claims)
 	case request.Resource == "/projects" && request.HTTPMethod == "GET":
 		return handleGetProjects(ctx, request, claims)
+	case request.Resource == "/projects/accessible" && request.HTTPMethod == "GET":
+		return handleGetAccessibleProjects(ctx, request, claims)
+	case request.Resource == "/projects/warranty-expiring" && request.HTTPMethod == "GET":
+		return handleGetWarrantyExpiringProjects(ctx, request, claims)
 	case request.Resource == "/projects/{projectId}" && request.HTTPMethod == "GET":
 		return handleGetProject(ctx, request, claims)
 	case request.Resource == "/projects/{projectId}" && request.HTTPMethod == "PUT":
 		return handleUpdateProject(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/restore" && request.HTTPMethod == "POST":
+		return handleRestoreProject(ctx, request, claims)
+
+	// Project search across issues, RFIs and submittals
+	case request.Resource == "/projects/{projectId}/search" && request.HTTPMethod == "GET":
+		return handleSearchProject(ctx, request, claims)
+
+	// Project dashboard counts
+	case request.Resource == "/projects/{projectId}/stats" && request.HTTPMethod == "GET":
+		return handleGetProjectStats(ctx, request, claims)
+
+	// Project milestone operations
+	case request.Resource == "/projects/{projectId}/milestones" && request.HTTPMethod == "GET":
+		return handleGetProjectMilestones(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/milestones" && request.HTTPMethod == "POST":
+		return handleCreateProjectMilestone(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/milestones/{milestoneId}" && request.HTTPMethod == "PUT":
+		return handleUpdateProjectMilestone(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/milestones/{milestoneId}" && request.HTTPMethod == "DELETE":
+		return handleDeleteProjectMilestone(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/milestones/{milestoneId}/complete" && request.HTTPMethod == "POST":
+		return handleCompleteProjectMilestone(ctx, request, claims)
+
+	// Project document folders
+	case request.Resource == "/projects/{projectId}/folders" && request.HTTPMethod == "GET":
+		return handleGetProjectFolders(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/folders" && request.HTTPMethod == "POST":
+		return handleCreateProjectFolder(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/folders/{folderId}" && request.HTTPMethod == "PUT":
+		return handleUpdateProjectFolder(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/documents" && request.HTTPMethod == "GET":
+		return handleGetProjectDocuments(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/attachments/{attachmentId}/folder" && request.HTTPMethod == "PUT":
+		return handleMoveProjectAttachment(ctx, request, claims)
+
+	// Project settings
+	case request.Resource == "/projects/{projectId}/default-assignee" && request.HTTPMethod == "GET":
+		return handleGetProjectDefaultAssignee(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/default-assignee" && request.HTTPMethod == "PUT":
+		return handleSetProjectDefaultAssignee(ctx, request, claims)
 
 
 	// Project attachment endpoints removed - now handled by centralized attachment management service
@@ -76,6 +129,10 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return handleAssignUserToProject(ctx, request, claims)
 	case request.Resource == "/projects/{projectId}/users" && request.HTTPMethod == "GET":
 		return handleGetProjectUserRoles(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/users/history" && request.HTTPMethod == "GET":
+		return handleGetProjectUserRoleHistory(ctx, request, claims)
+	case request.Resource == "/projects/{projectId}/my-roles" && request.HTTPMethod == "GET":
+		return handleGetMyProjectRoles(ctx, request, claims)
 	case request.Resource == "/projects/{projectId}/users/{assignmentId}" && request.HTTPMethod == "PUT":
 		return handleUpdateProjectUserRole(ctx, request, claims)
 	case request.Resource == "/projects/{projectId}/users/{assignmentId}" && request.HTTPMethod == "DELETE":
@@ -275,7 +332,7 @@ func handleGetProjects(ctx context.Context, request events.APIGatewayProxyReques
 	}
 
 	logger.WithField("project_count", len(projects)).Debug("Returning projects")
-	return api.SuccessResponse(http.StatusOK, response, logger), nil
+	return api.ListResponse(request, response, projects, api.SinglePageMeta(len(projects)), logger), nil
 }
 
 // filterProjectsByIDs filters a list of projects to only include those with IDs in the allowed list
@@ -306,14 +363,22 @@ func handleGetProject(ctx context.Context, request events.APIGatewayProxyRequest
 
 	project, err := projectRepository.GetProjectByID(ctx, projectID, orgID)
 	if err != nil {
-		if err.Error() == "project not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Project not found", logger), nil
 		}
 		logger.WithError(err).Error("Failed to get project")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project", logger), nil
 	}
 
-	return api.SuccessResponse(http.StatusOK, project, logger), nil
+	// Include the next few open milestones in the project summary
+	upcomingMilestones, err := projectRepository.GetUpcomingProjectMilestones(ctx, projectID, orgID, 5)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get upcoming milestones")
+	} else {
+		project.UpcomingMilestones = upcomingMilestones
+	}
+
+	return api.SparseResponse(request, project, logger), nil
 }
 
 // handleUpdateProject handles PUT /projects/{projectId}
@@ -336,7 +401,7 @@ func handleUpdateProject(ctx context.Context, request events.APIGatewayProxyRequ
 
 	project, err := projectRepository.UpdateProject(ctx, projectID, orgID, &updateRequest, userID)
 	if err != nil {
-		if err.Error() == "project not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Project not found", logger), nil
 		}
 		logger.WithError(err).Error("Failed to update project")
@@ -346,7 +411,563 @@ func handleUpdateProject(ctx context.Context, request events.APIGatewayProxyRequ
 	return api.SuccessResponse(http.StatusOK, project, logger), nil
 }
 
+// handleRestoreProject handles POST /projects/{projectId}/restore
+func handleRestoreProject(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	if !claims.IsSuperAdmin {
+		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can restore projects", logger), nil
+	}
+
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	project, err := projectRepository.RestoreProject(ctx, projectID, claims.OrgID, claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrNotFound):
+			return api.ErrorResponse(http.StatusNotFound, "Project not found", logger), nil
+		case errors.Is(err, data.ErrOrgMismatch):
+			return api.ErrorResponse(http.StatusForbidden, "Access denied: project belongs to a different organization", logger), nil
+		case errors.Is(err, data.ErrProjectNotDeleted):
+			return api.ErrorResponse(http.StatusConflict, "Project is not deleted", logger), nil
+		case errors.Is(err, data.ErrConflict):
+			return api.ErrorResponse(http.StatusConflict, "Another active project now uses this project number", logger), nil
+		}
+		logger.WithError(err).Error("Failed to restore project")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to restore project", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, project, logger), nil
+}
+
+// handleGetProjectMilestones handles GET /projects/{projectId}/milestones
+func handleGetProjectMilestones(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, projectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	milestones, err := projectRepository.GetProjectMilestones(ctx, projectID, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project milestones")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project milestones", logger), nil
+	}
+
+	response := models.ProjectMilestoneListResponse{
+		Milestones: milestones,
+		Total:      len(milestones),
+	}
+
+	return api.ListResponse(request, response, milestones, api.SinglePageMeta(len(milestones)), logger), nil
+}
+
+// handleCreateProjectMilestone handles POST /projects/{projectId}/milestones
+func handleCreateProjectMilestone(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	var createRequest models.CreateProjectMilestoneRequest
+	if err := api.ParseJSONBody(request.Body, &createRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for create project milestone")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	createRequest.Name = strings.TrimSpace(createRequest.Name)
+	if createRequest.Name == "" || createRequest.TargetDate == "" {
+		return api.ErrorResponse(http.StatusBadRequest, "name and target_date are required", logger), nil
+	}
+	if createRequest.Status != "" && !models.IsValidMilestoneStatus(createRequest.Status) {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid milestone status", logger), nil
+	}
+
+	milestone, err := projectRepository.CreateProjectMilestone(ctx, projectID, claims.OrgID, &createRequest, claims.UserID)
+	if err != nil {
+		return milestoneErrorResponse(err, "Failed to create project milestone"), nil
+	}
+
+	return api.SuccessResponse(http.StatusCreated, milestone, logger), nil
+}
+
+// handleUpdateProjectMilestone handles PUT /projects/{projectId}/milestones/{milestoneId}
+func handleUpdateProjectMilestone(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	milestoneID, err := strconv.ParseInt(request.PathParameters["milestoneId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid milestone ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid milestone ID", logger), nil
+	}
+
+	var updateRequest models.UpdateProjectMilestoneRequest
+	if err := api.ParseJSONBody(request.Body, &updateRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for update project milestone")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if updateRequest.Name != nil && strings.TrimSpace(*updateRequest.Name) == "" {
+		return api.ErrorResponse(http.StatusBadRequest, "name cannot be empty", logger), nil
+	}
+	if updateRequest.Status != nil && !models.IsValidMilestoneStatus(*updateRequest.Status) {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid milestone status", logger), nil
+	}
+
+	milestone, err := projectRepository.UpdateProjectMilestone(ctx, milestoneID, projectID, claims.OrgID, &updateRequest, claims.UserID)
+	if err != nil {
+		return milestoneErrorResponse(err, "Failed to update project milestone"), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, milestone, logger), nil
+}
+
+// handleCompleteProjectMilestone handles POST /projects/{projectId}/milestones/{milestoneId}/complete
+func handleCompleteProjectMilestone(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	milestoneID, err := strconv.ParseInt(request.PathParameters["milestoneId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid milestone ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid milestone ID", logger), nil
+	}
+
+	// Body is optional; actual_date defaults to today
+	var completeRequest models.CompleteProjectMilestoneRequest
+	if request.Body != "" {
+		if err := api.ParseJSONBody(request.Body, &completeRequest); err != nil {
+			logger.WithError(err).Error("Invalid request body for complete project milestone")
+			return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+		}
+	}
+
+	milestone, err := projectRepository.CompleteProjectMilestone(ctx, milestoneID, projectID, claims.OrgID, completeRequest.ActualDate, claims.UserID)
+	if err != nil {
+		return milestoneErrorResponse(err, "Failed to complete project milestone"), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, milestone, logger), nil
+}
+
+// handleDeleteProjectMilestone handles DELETE /projects/{projectId}/milestones/{milestoneId}
+func handleDeleteProjectMilestone(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	milestoneID, err := strconv.ParseInt(request.PathParameters["milestoneId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid milestone ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid milestone ID", logger), nil
+	}
+
+	if err := projectRepository.DeleteProjectMilestone(ctx, milestoneID, projectID, claims.OrgID, claims.UserID); err != nil {
+		return milestoneErrorResponse(err, "Failed to delete project milestone"), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, map[string]string{"message": "Milestone deleted successfully"}, logger), nil
+}
+
+// milestoneErrorResponse maps milestone repository errors to API responses
+func milestoneErrorResponse(err error, fallbackMessage string) events.APIGatewayProxyResponse {
+	switch {
+	case errors.Is(err, data.ErrMilestoneNotFound):
+		return api.ErrorResponse(http.StatusNotFound, "Milestone not found", logger)
+	case errors.Is(err, data.ErrNotFound):
+		return api.ErrorResponse(http.StatusNotFound, "Project not found", logger)
+	case errors.Is(err, data.ErrInvalidMilestoneDate):
+		return api.ErrorResponse(http.StatusBadRequest, "Milestone dates must use YYYY-MM-DD format", logger)
+	case errors.Is(err, data.ErrMilestoneOutsideTimeline):
+		return api.ErrorResponse(http.StatusBadRequest, "Milestone dates must fall within the project start and finish dates", logger)
+	case errors.Is(err, data.ErrNoFieldsToUpdate):
+		return api.ErrorResponse(http.StatusBadRequest, "No fields to update", logger)
+	}
+	logger.WithError(err).Error(fallbackMessage)
+	return api.ErrorResponse(http.StatusInternalServerError, fallbackMessage, logger)
+}
 
+// handleGetProjectFolders handles GET /projects/{projectId}/folders
+func handleGetProjectFolders(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, projectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	folders, err := projectRepository.GetProjectFolders(ctx, projectID, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project folders")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project folders", logger), nil
+	}
+
+	response := models.ProjectFolderListResponse{
+		Folders: models.BuildProjectFolderTree(folders),
+		Total:   len(folders),
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleCreateProjectFolder handles POST /projects/{projectId}/folders
+func handleCreateProjectFolder(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	var createRequest models.CreateProjectFolderRequest
+	if err := api.ParseJSONBody(request.Body, &createRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for create project folder")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	createRequest.Name = strings.TrimSpace(createRequest.Name)
+	if createRequest.Name == "" {
+		return api.ErrorResponse(http.StatusBadRequest, "name is required", logger), nil
+	}
+	if len(createRequest.Name) > 255 {
+		return api.ErrorResponse(http.StatusBadRequest, "name must be 255 characters or fewer", logger), nil
+	}
+
+	folder, err := projectRepository.CreateProjectFolder(ctx, projectID, claims.OrgID, &createRequest, claims.UserID)
+	if err != nil {
+		return folderErrorResponse(err, "Failed to create project folder"), nil
+	}
+
+	return api.SuccessResponse(http.StatusCreated, folder, logger), nil
+}
+
+// handleUpdateProjectFolder handles PUT /projects/{projectId}/folders/{folderId}
+func handleUpdateProjectFolder(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	folderID, err := strconv.ParseInt(request.PathParameters["folderId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid folder ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid folder ID", logger), nil
+	}
+
+	var updateRequest models.UpdateProjectFolderRequest
+	if err := api.ParseJSONBody(request.Body, &updateRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for update project folder")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if updateRequest.Name != nil {
+		name := strings.TrimSpace(*updateRequest.Name)
+		if name == "" {
+			return api.ErrorResponse(http.StatusBadRequest, "name cannot be empty", logger), nil
+		}
+		if len(name) > 255 {
+			return api.ErrorResponse(http.StatusBadRequest, "name must be 255 characters or fewer", logger), nil
+		}
+	}
+
+	folder, err := projectRepository.UpdateProjectFolder(ctx, folderID, projectID, claims.OrgID, &updateRequest, claims.UserID)
+	if err != nil {
+		return folderErrorResponse(err, "Failed to update project folder"), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, folder, logger), nil
+}
+
+// handleGetProjectDocuments handles GET /projects/{projectId}/documents?folder_id=
+// folder_id=root lists unfiled attachments; omitting folder_id lists every attachment.
+// The full folder tree is always included so clients can render navigation.
+func handleGetProjectDocuments(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	var folderID *int64
+	if folderParam := strings.TrimSpace(request.QueryStringParameters["folder_id"]); folderParam != "" {
+		var parsed int64
+		if !strings.EqualFold(folderParam, "root") {
+			parsed, err = strconv.ParseInt(folderParam, 10, 64)
+			if err != nil || parsed <= 0 {
+				return api.ErrorResponse(http.StatusBadRequest, "Invalid folder_id", logger), nil
+			}
+		}
+		folderID = &parsed
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, projectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	folders, err := projectRepository.GetProjectFolders(ctx, projectID, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project folders")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project documents", logger), nil
+	}
+
+	if folderID != nil && *folderID != 0 && !slices.ContainsFunc(folders, func(f models.ProjectFolder) bool { return f.ID == *folderID }) {
+		return api.ErrorResponse(http.StatusNotFound, "Folder not found", logger), nil
+	}
+
+	attachments, err := projectRepository.GetProjectAttachmentsByProject(ctx, projectID, folderID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project attachments")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project documents", logger), nil
+	}
+	if attachments == nil {
+		attachments = []models.ProjectAttachment{}
+	}
+
+	response := models.ProjectDocumentsResponse{
+		FolderID:    folderID,
+		Folders:     models.BuildProjectFolderTree(folders),
+		Attachments: attachments,
+		Total:       len(attachments),
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleMoveProjectAttachment handles PUT /projects/{projectId}/attachments/{attachmentId}/folder
+func handleMoveProjectAttachment(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	attachmentID, err := strconv.ParseInt(request.PathParameters["attachmentId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid attachment ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid attachment ID", logger), nil
+	}
+
+	var moveRequest models.MoveProjectAttachmentRequest
+	if err := api.ParseJSONBody(request.Body, &moveRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for move project attachment")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	attachment, err := projectRepository.MoveProjectAttachment(ctx, attachmentID, projectID, claims.OrgID, moveRequest.FolderID, claims.UserID)
+	if err != nil {
+		return folderErrorResponse(err, "Failed to move project attachment"), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, attachment, logger), nil
+}
+
+// folderErrorResponse maps project folder repository errors to API responses
+func folderErrorResponse(err error, fallbackMessage string) events.APIGatewayProxyResponse {
+	switch {
+	case errors.Is(err, data.ErrFolderNotFound):
+		return api.ErrorResponse(http.StatusNotFound, "Folder not found", logger)
+	case errors.Is(err, data.ErrProjectAttachmentNotFound):
+		return api.ErrorResponse(http.StatusNotFound, "Attachment not found", logger)
+	case errors.Is(err, data.ErrNotFound):
+		return api.ErrorResponse(http.StatusNotFound, "Project not found", logger)
+	case errors.Is(err, data.ErrParentFolderNotFound):
+		return api.ErrorResponse(http.StatusBadRequest, "Parent folder not found in this project", logger)
+	case errors.Is(err, data.ErrNoFieldsToUpdate):
+		return api.ErrorResponse(http.StatusBadRequest, "No fields to update", logger)
+	case errors.Is(err, data.ErrInvalid):
+		return api.ErrorResponse(http.StatusBadRequest, "A folder cannot be moved into itself or one of its subfolders", logger)
+	case errors.Is(err, data.ErrConflict):
+		return api.ErrorResponse(http.StatusConflict, "A folder with this name already exists here", logger)
+	}
+	logger.WithError(err).Error(fallbackMessage)
+	return api.ErrorResponse(http.StatusInternalServerError, fallbackMessage, logger)
+}
+
+// handleGetAccessibleProjects handles GET /projects/accessible?page=&page_size=
+// Unlike GET /projects, regular users only see projects reachable through their own
+// organization, location or project assignments or project roles; super admins see every project.
+func handleGetAccessibleProjects(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	page := 1
+	if p, err := strconv.Atoi(request.QueryStringParameters["page"]); err == nil && p > 0 {
+		page = p
+	}
+	pageSize := 20
+	if ps, err := strconv.Atoi(request.QueryStringParameters["page_size"]); err == nil && ps > 0 && ps <= 100 {
+		pageSize = ps
+	}
+
+	projects, totalCount, err := projectRepository.GetAccessibleProjects(ctx, claims.UserID, claims.OrgID, claims.IsSuperAdmin, pageSize, (page-1)*pageSize)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get accessible projects")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get projects", logger), nil
+	}
+
+	response := models.AccessibleProjectsResponse{
+		Projects:   projects,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		HasNext:    page*pageSize < totalCount,
+	}
+
+	return api.ListResponse(request, response, projects, api.NewPaginationMeta(page, pageSize, totalCount), logger), nil
+}
+
+// handleGetWarrantyExpiringProjects handles GET /projects/warranty-expiring?within_days=N, listing the
+// accessible projects whose warranty ends in the next N days (default 30)
+func handleGetWarrantyExpiringProjects(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	withinDays := models.DefaultWarrantyExpiryWindowDays
+	if raw := request.QueryStringParameters["within_days"]; raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days < 0 || days > models.MaxWarrantyExpiryWindowDays {
+			return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("within_days must be a whole number between 0 and %d", models.MaxWarrantyExpiryWindowDays), logger), nil
+		}
+		withinDays = days
+	}
+
+	projects, err := projectRepository.GetProjectsByWarrantyExpiry(ctx, claims.UserID, claims.OrgID, claims.IsSuperAdmin, withinDays)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get warranty expiring projects")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get projects", logger), nil
+	}
+
+	response := models.WarrantyExpiringProjectsResponse{
+		Projects:   projects,
+		WithinDays: withinDays,
+		Total:      len(projects),
+	}
+
+	return api.ListResponse(request, response, projects, api.SinglePageMeta(len(projects)), logger), nil
+}
+
+// handleSearchProject handles GET /projects/{projectId}/search?q=&page=&page_size=
+func handleSearchProject(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	query := strings.TrimSpace(request.QueryStringParameters["q"])
+	if len(query) < 2 {
+		return api.ErrorResponse(http.StatusBadRequest, "Query parameter q must be at least 2 characters", logger), nil
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(request.QueryStringParameters["page"]); err == nil && p > 0 {
+		page = p
+	}
+	pageSize := 20
+	if ps, err := strconv.Atoi(request.QueryStringParameters["page_size"]); err == nil && ps > 0 && ps <= 100 {
+		pageSize = ps
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, projectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	results, totalCount, err := projectRepository.SearchProject(ctx, projectID, claims.OrgID, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		logger.WithError(err).Error("Failed to search project")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to search project", logger), nil
+	}
+
+	response := models.ProjectSearchResponse{
+		Query:      query,
+		Results:    results,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		HasNext:    page*pageSize < totalCount,
+	}
+
+	return api.ListResponse(request, response, results, api.NewPaginationMeta(page, pageSize, totalCount), logger), nil
+}
+
+// handleGetProjectDefaultAssignee handles GET /projects/{projectId}/default-assignee
+func handleGetProjectDefaultAssignee(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	response := models.ProjectDefaultAssigneeResponse{ProjectID: projectID}
+
+	defaultAssigneeID, err := projectRepository.GetProjectDefaultAssignee(ctx, projectID, claims.OrgID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "Project not found", logger), nil
+		}
+		// A stale default is reported as unset so it can be reconfigured
+		if !errors.Is(err, data.ErrConflict) {
+			logger.WithError(err).Error("Failed to get project default assignee")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project default assignee", logger), nil
+		}
+	}
+	if defaultAssigneeID != 0 {
+		response.DefaultAssigneeID = &defaultAssigneeID
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
+// handleSetProjectDefaultAssignee handles PUT /projects/{projectId}/default-assignee
+func handleSetProjectDefaultAssignee(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	var setRequest models.ProjectDefaultAssigneeRequest
+	if err := api.ParseJSONBody(request.Body, &setRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for set project default assignee")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if setRequest.DefaultAssigneeID < 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid default_assignee_id", logger), nil
+	}
+
+	err = projectRepository.SetProjectDefaultAssignee(ctx, projectID, claims.OrgID, setRequest.DefaultAssigneeID, claims.UserID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "Project not found", logger), nil
+		}
+		if errors.Is(err, data.ErrOrgMismatch) {
+			return api.ErrorResponse(http.StatusBadRequest, "Default assignee does not belong to your organization", logger), nil
+		}
+		logger.WithError(err).Error("Failed to set project default assignee")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to set project default assignee", logger), nil
+	}
+
+	response := models.ProjectDefaultAssigneeResponse{ProjectID: projectID}
+	if setRequest.DefaultAssigneeID != 0 {
+		response.DefaultAssigneeID = &setRequest.DefaultAssigneeID
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
 
 // Project attachment handlers removed - now handled by centralized attachment management service
 // Removed functions:
@@ -363,6 +984,10 @@ func handleAssignUserToProject(ctx context.Context, request events.APIGatewayPro
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
 	}
 
+	if resp, denied := requireProjectPermission(ctx, claims, projectID, auth.PermissionManageProjectUsers); denied {
+		return resp, nil
+	}
+
 	var createRequest models.CreateProjectUserRoleRequest
 	if err := api.ParseJSONBody(request.Body, &createRequest); err != nil {
 		logger.WithError(err).Error("Invalid request body for assign user to project")
@@ -385,6 +1010,13 @@ func handleAssignUserToProject(ctx context.Context, request events.APIGatewayPro
 
 	assignment, err := assignmentRepository.CreateAssignment(ctx, assignmentReq, userID)
 	if err != nil {
+		var conflictErr *models.AssignmentConflictError
+		if errors.As(err, &conflictErr) {
+			return api.ConflictResponse("User already has this role on the project", map[string]interface{}{
+				"code":          api.ErrorCodeAssignmentExists,
+				"assignment_id": conflictErr.AssignmentID,
+			}, logger), nil
+		}
 		logger.WithError(err).Error("Failed to assign user to project")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to assign user to project", logger), nil
 	}
@@ -412,8 +1044,112 @@ func handleGetProjectUserRoles(ctx context.Context, request events.APIGatewayPro
 	return api.SuccessResponse(http.StatusOK, result.Assignments, logger), nil
 }
 
+// handleGetProjectUserRoleHistory handles GET /projects/{projectId}/users/history
+func handleGetProjectUserRoleHistory(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if resp, denied := requireProjectPermission(ctx, claims, projectID, auth.PermissionManageProjectUsers); denied {
+		return resp, nil
+	}
+
+	history, err := projectRepository.GetProjectUserRoleHistory(ctx, projectID, claims.OrgID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "Project not found", logger), nil
+		}
+		logger.WithError(err).Error("Failed to get project user role history")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project user role history", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, history, logger), nil
+}
+
+// handleGetProjectStats handles GET /projects/{projectId}/stats - issue, RFI and submittal counts for dashboards
+func handleGetProjectStats(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, projectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	stats, err := projectRepository.GetProjectStats(ctx, projectID, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project stats")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project stats", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusOK, stats, logger), nil
+}
+
+// handleGetMyProjectRoles handles GET /projects/{projectId}/my-roles
+// Roles are not carried in the JWT, so the frontend calls this when opening a project to gate UI actions.
+func handleGetMyProjectRoles(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, projectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	roles, err := permissionRepository.GetProjectRoles(ctx, claims.UserID, claims.OrgID, projectID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project roles")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project roles", logger), nil
+	}
+
+	_, permissions, err := permissionRepository.GetEffectivePermissions(ctx, claims.UserID, claims.OrgID, projectID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get project permissions")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project permissions", logger), nil
+	}
+
+	// Super admins implicitly hold every permission defined in their organization
+	if claims.IsSuperAdmin {
+		orgPermissions, err := permissionRepository.GetPermissionsByOrg(ctx, claims.OrgID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get organization permissions")
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to get project permissions", logger), nil
+		}
+		permissions = make([]string, 0, len(orgPermissions))
+		for _, permission := range orgPermissions {
+			permissions = append(permissions, permission.PermissionName)
+		}
+	}
+
+	response := models.MyProjectRolesResponse{
+		ProjectID:    projectID,
+		UserID:       claims.UserID,
+		IsSuperAdmin: claims.IsSuperAdmin,
+		Roles:        roles,
+		Permissions:  permissions,
+	}
+
+	return api.SuccessResponse(http.StatusOK, response, logger), nil
+}
+
 // handleUpdateProjectUserRole handles PUT /projects/{projectId}/users/{assignmentId}
 func handleUpdateProjectUserRole(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if resp, denied := requireProjectPermission(ctx, claims, projectID, auth.PermissionManageProjectUsers); denied {
+		return resp, nil
+	}
+
 	assignmentID, err := strconv.ParseInt(request.PathParameters["assignmentId"], 10, 64)
 	if err != nil {
 		logger.WithError(err).Error("Invalid assignment ID")
@@ -452,6 +1188,16 @@ func handleUpdateProjectUserRole(ctx context.Context, request events.APIGatewayP
 
 // handleRemoveUserFromProject handles DELETE /projects/{projectId}/users/{assignmentId}
 func handleRemoveUserFromProject(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid project ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid project ID", logger), nil
+	}
+
+	if resp, denied := requireProjectPermission(ctx, claims, projectID, auth.PermissionManageProjectUsers); denied {
+		return resp, nil
+	}
+
 	assignmentID, err := strconv.ParseInt(request.PathParameters["assignmentId"], 10, 64)
 	if err != nil {
 		logger.WithError(err).Error("Invalid assignment ID")
@@ -469,10 +1215,34 @@ func handleRemoveUserFromProject(ctx context.Context, request events.APIGatewayP
 	return api.SuccessResponse(http.StatusNoContent, nil, logger), nil
 }
 
+// requireProjectPermission checks the caller's permission on a project and returns the error response to send when denied
+func requireProjectPermission(ctx context.Context, claims *auth.Claims, projectID int64, permissionName string) (events.APIGatewayProxyResponse, bool) {
+	err := auth.RequirePermission(ctx, claims, permissionRepository, projectID, permissionName)
+	if err == nil {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	var forbiddenErr *auth.ForbiddenError
+	if errors.As(err, &forbiddenErr) {
+		logger.WithFields(logrus.Fields{
+			"user_id":    claims.UserID,
+			"project_id": projectID,
+			"permission": permissionName,
+		}).Warn("Permission denied")
+		return api.ErrorResponse(http.StatusForbidden, "You do not have permission to perform this action", logger), true
+	}
+
+	logger.WithError(err).Error("Failed to check permissions")
+	return api.ErrorResponse(http.StatusInternalServerError, "Failed to check permissions", logger), true
+}
+
 // setupPostgresSQLClient initializes the PostgreSQL database connection
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
 		ssmParams[constants.DATABASE_PORT],
@@ -486,7 +1256,7 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 	}
 
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
@@ -543,6 +1313,7 @@ func init() {
 	// Initialize repositories
 	projectRepository = data.NewProjectRepository(sqlDB)
 	assignmentRepository = data.NewAssignmentRepository(sqlDB)
+	permissionRepository = &data.PermissionDao{DB: sqlDB, Logger: logger}
 
 	logger.WithField("operation", "init").Error("Project Management Lambda initialization completed successfully")
 }
\ No newline at end of file
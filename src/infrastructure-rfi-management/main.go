@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -13,8 +16,11 @@ import (
 	"infrastructure/lib/util"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -23,14 +29,39 @@ import (
 
 // Global variables for Lambda cold start optimization
 var (
-	logger        *logrus.Logger
-	isLocal       bool
-	ssmRepository data.SSMRepository
-	ssmParams     map[string]string
-	sqlDB         *sql.DB
-	rfiRepository data.RFIRepository
+	logger             *logrus.Logger
+	isLocal            bool
+	ssmRepository      data.SSMRepository
+	ssmParams          map[string]string
+	sqlDB              *sql.DB
+	rfiRepository      data.RFIRepository
+	projectRepository  data.ProjectRepository
+	orgRepository      data.OrgRepository
+	userRepository     data.UserManagementRepository
+	locationRepository data.LocationRepository
+	idempotencyStore   data.IdempotencyRepository
+	snsClient          clients.SNSClientInterface
+	eventsTopic        string
+	rfiListLimiter     *api.RateLimiter
+	sesClient          clients.SESClientInterface
+	emailFromAddress   string
+	appBaseURL         string
+	assignmentEmail    *template.Template
 )
 
+// rfiAutoCloseResource is the resource sent by the scheduled auto-close rule; it is not exposed through API Gateway
+const rfiAutoCloseResource = "/internal/rfis/auto-close"
+
+// rfiAutoCloseBatchSize caps how many stale RFIs a single scheduled run closes
+const rfiAutoCloseBatchSize = 500
+
+// metricsServiceName is the Service dimension on the request metrics this Lambda emits
+const metricsServiceName = "rfi-management"
+
+// assignmentEmailTimeout bounds the recipient lookup and sends for one RFI's assignment emails. They are
+// sent before the handler returns, so this must leave room inside the Lambda's 15s timeout.
+const assignmentEmailTimeout = 5 * time.Second
+
 // Handler processes API Gateway requests for RFI management operations
 //
 // SIMPLIFIED API ENDPOINTS (matching Issue Management pattern):
@@ -42,10 +73,21 @@ var (
 //
 // List Query:
 //   GET    /projects/{projectId}/rfis       - Get RFIs for project (with filters)
+//   GET    /projects/{projectId}/rfis/export - Export project RFI log (format=csv|json)
 //
 // Sub-resources:
 //   POST   /rfis/{rfiId}/comments           - Add comment
-func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+//
+// Scheduled (internal invocation only):
+//   POST   /internal/rfis/auto-close        - Close answered RFIs past the org's auto-close threshold
+func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (response events.APIGatewayProxyResponse, err error) {
+	// Emit latency, status class and DB time for this route once the response is known
+	metrics := util.StartRequestMetrics(metricsServiceName, request.Resource)
+	ctx = util.WithRequestMetrics(ctx, metrics)
+	defer func() {
+		metrics.Emit(response.StatusCode)
+	}()
+
 	logger.WithFields(logrus.Fields{
 		"method":      request.HTTPMethod,
 		"path":        request.Path,
@@ -54,6 +96,19 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		"operation":   "Handler",
 	}).Info("Processing RFI management request")
 
+	// Scheduled rules invoke the function directly with no user claims
+	if auth.IsInternalInvocation(request) {
+		if request.Resource == rfiAutoCloseResource && request.HTTPMethod == http.MethodPost {
+			return handleAutoCloseStaleRFIs(ctx), nil
+		}
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, "Endpoint not found", logger), nil
+	}
+
+	// Health checks are unauthenticated so synthetic monitors can call them without a token
+	if request.Resource == "/rfis/health" && request.HTTPMethod == http.MethodGet {
+		return api.HealthCheckResponse(ctx, sqlDB, logger), nil
+	}
+
 	// Extract claims from JWT token via API Gateway authorizer
 	claims, err := auth.ExtractClaimsFromRequest(request)
 	if err != nil {
@@ -64,7 +119,7 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 			"path":       request.Path,
 			"method":     request.HTTPMethod,
 		}).Error("Failed to extract claims from request - authentication failed")
-		return api.ErrorResponse(http.StatusUnauthorized, fmt.Sprintf("Authentication failed: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusUnauthorized, api.ErrorCodeAuthenticationFailed, fmt.Sprintf("Authentication failed: %v", err), logger), nil
 	}
 
 	// Validate required claims
@@ -73,7 +128,7 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 			"operation": "Handler",
 			"path":      request.Path,
 		}).Error("Invalid claims: user_id is 0")
-		return api.ErrorResponse(http.StatusUnauthorized, "Invalid authentication: missing user ID", logger), nil
+		return api.ErrorResponseWithCode(http.StatusUnauthorized, api.ErrorCodeAuthenticationFailed, "Invalid authentication: missing user ID", logger), nil
 	}
 
 	if claims.OrgID == 0 {
@@ -82,7 +137,7 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 			"user_id":   claims.UserID,
 			"path":      request.Path,
 		}).Error("Invalid claims: org_id is 0")
-		return api.ErrorResponse(http.StatusUnauthorized, "Invalid authentication: missing organization ID", logger), nil
+		return api.ErrorResponseWithCode(http.StatusUnauthorized, api.ErrorCodeAuthenticationFailed, "Invalid authentication: missing organization ID", logger), nil
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -96,8 +151,27 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	switch {
 	// GET /projects/{projectId}/rfis - List RFIs for project (simple, consistent with Issue API)
 	case request.Resource == "/projects/{projectId}/rfis" && request.HTTPMethod == "GET":
+		if resp, limited := rfiListLimiter.Limit(claims.OrgID, logger); limited {
+			return resp, nil
+		}
 		return handleGetProjectRFIs(ctx, request, claims)
 
+	// GET /projects/{projectId}/rfis/export - Export full RFI log for project
+	case request.Resource == "/projects/{projectId}/rfis/export" && request.HTTPMethod == "GET":
+		return handleExportProjectRFIs(ctx, request, claims)
+
+	// GET /projects/{projectId}/rfis/sla-breaches - Unanswered RFIs past their response due date
+	case request.Resource == "/projects/{projectId}/rfis/sla-breaches" && request.HTTPMethod == "GET":
+		return handleGetSLABreachedRFIs(ctx, request, claims)
+
+	// GET /projects/{projectId}/rfis/by-number/{rfiNumber} - Get RFI by its human-readable number
+	case request.Resource == "/projects/{projectId}/rfis/by-number/{rfiNumber}" && request.HTTPMethod == "GET":
+		return handleGetRFIByNumber(ctx, request, claims)
+
+	// GET /rfis/assigned-to-me - RFIs assigned to the caller across the org's projects
+	case request.Resource == "/rfis/assigned-to-me" && request.HTTPMethod == "GET":
+		return handleGetRFIsAssignedToMe(ctx, request, claims)
+
 	// GET /rfis/{rfiId} - Get single RFI
 	case request.Resource == "/rfis/{rfiId}" && request.HTTPMethod == "GET":
 		return handleGetRFI(ctx, request, claims)
@@ -110,6 +184,10 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	case request.Resource == "/rfis/{rfiId}" && request.HTTPMethod == "PUT":
 		return handleUpdateRFI(ctx, request, claims)
 
+	// POST /rfis/{rfiId}/copy - Copy RFI into another project
+	case request.Resource == "/rfis/{rfiId}/copy" && request.HTTPMethod == "POST":
+		return handleCopyRFI(ctx, request, claims)
+
 	// POST /rfis/{rfiId}/comments - Add comment
 	case request.Resource == "/rfis/{rfiId}/comments" && request.HTTPMethod == "POST":
 		return handleAddRFIComment(ctx, request, claims)
@@ -125,11 +203,12 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 			"path":      request.Path,
 			"operation": "Handler",
 		}).Warn("Endpoint not found - no matching route")
-		return api.ErrorResponse(http.StatusNotFound, fmt.Sprintf("Endpoint not found: %s %s", request.HTTPMethod, request.Resource), logger), nil
+		return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeEndpointNotFound, fmt.Sprintf("Endpoint not found: %s %s", request.HTTPMethod, request.Resource), logger), nil
 	}
 }
 
-// handleCreateRFI handles POST /rfis
+// handleCreateRFI handles POST /rfis. With an Idempotency-Key header, a retry of the same request
+// returns the RFI the first attempt created instead of creating a duplicate.
 func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	logger.WithFields(logrus.Fields{
 		"body":      request.Body,
@@ -144,12 +223,31 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation": "handleCreateRFI",
 			"user_id":   claims.UserID,
 		}).Error("Request body is empty")
-		return api.ErrorResponse(http.StatusBadRequest, "Request body cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Request body cannot be empty", logger), nil
 	}
 
-	// Parse request body
+	idempotencyKey := api.HeaderValue(request, api.IdempotencyKeyHeader)
+	replayID, statusCode, errCode, errMsg := api.BeginIdempotentRequest(ctx, idempotencyStore, idempotencyKey, models.IdempotencyScopeCreateRFI, claims.OrgID, claims.UserID, request.Body)
+	if errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+	if replayID > 0 {
+		rfi, err := rfiRepository.GetRFI(ctx, replayID)
+		if err != nil {
+			logger.WithError(err).WithField("rfi_id", replayID).Error("Failed to get RFI for idempotent replay")
+			return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get created RFI", logger), nil
+		}
+		return api.SuccessResponse(http.StatusCreated, rfi, logger), nil
+	}
+	defer func() {
+		if err := api.ReleaseIdempotentRequest(ctx, idempotencyStore, idempotencyKey, models.IdempotencyScopeCreateRFI, claims.OrgID); err != nil {
+			logger.WithError(err).Warn("Failed to release idempotency key")
+		}
+	}()
+
+	// Parse request body; unknown keys are rejected so a typo is not silently ignored
 	var createReq models.CreateRFIRequest
-	if err := api.ParseJSONBody(request.Body, &createReq); err != nil {
+	if err := api.ParseJSONBodyStrict(request.Body, &createReq); err != nil {
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"error_type": fmt.Sprintf("%T", err),
@@ -157,7 +255,14 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation":  "handleCreateRFI",
 			"user_id":    claims.UserID,
 		}).Error("Failed to parse JSON request body")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid JSON in request body: %v", err), logger), nil
+		var numberErr *models.InvalidNumberError
+		if errors.As(err, &numberErr) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, numberErr.Error(), logger), nil
+		}
+		if errors.Is(err, api.ErrUnknownField) {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, err.Error(), logger), nil
+		}
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, fmt.Sprintf("Invalid JSON in request body: %v", err), logger), nil
 	}
 
 	// Validate required fields
@@ -166,16 +271,39 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation": "handleCreateRFI",
 			"user_id":   claims.UserID,
 		}).Error("Missing required field: project_id")
-		return api.ErrorResponse(http.StatusBadRequest, "project_id is required and must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "project_id is required and must be greater than 0", logger), nil
+	}
+
+	// Orgs that require project membership only allow project members to create RFIs
+	if statusCode, errCode, errMsg := api.ValidateProjectMembership(ctx, orgRepository, projectRepository, createReq.ProjectID, claims.OrgID, claims.UserID, claims.IsSuperAdmin); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
 	}
 
-	if createReq.LocationID == 0 {
+	if createReq.LocationID <= 0 {
 		logger.WithFields(logrus.Fields{
 			"operation":  "handleCreateRFI",
 			"user_id":    claims.UserID,
 			"project_id": createReq.ProjectID,
 		}).Error("Missing required field: location_id")
-		return api.ErrorResponse(http.StatusBadRequest, "location_id is required and must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "location_id is required and must be greater than 0", logger), nil
+	}
+
+	// The location must exist in the caller's organization; cross-org IDs are reported the same as missing ones
+	if _, err := locationRepository.GetLocationByID(ctx, createReq.LocationID, claims.OrgID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			logger.WithFields(logrus.Fields{
+				"operation":   "handleCreateRFI",
+				"user_id":     claims.UserID,
+				"location_id": createReq.LocationID,
+			}).Warn("RFI create referenced an invalid location")
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeOrgMismatch, fmt.Sprintf("Invalid location_id. Location %d does not exist in your organization.", createReq.LocationID), logger), nil
+		}
+		logger.WithFields(logrus.Fields{
+			"operation":   "handleCreateRFI",
+			"location_id": createReq.LocationID,
+			"error":       err.Error(),
+		}).Error("Failed to validate RFI location")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to validate location", logger), nil
 	}
 
 	if strings.TrimSpace(createReq.Subject) == "" {
@@ -184,7 +312,7 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"user_id":    claims.UserID,
 			"project_id": createReq.ProjectID,
 		}).Error("Missing required field: subject")
-		return api.ErrorResponse(http.StatusBadRequest, "subject is required and cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "subject is required and cannot be empty", logger), nil
 	}
 
 	if strings.TrimSpace(createReq.Description) == "" {
@@ -193,7 +321,7 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"user_id":    claims.UserID,
 			"project_id": createReq.ProjectID,
 		}).Error("Missing required field: description")
-		return api.ErrorResponse(http.StatusBadRequest, "description is required and cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "description is required and cannot be empty", logger), nil
 	}
 
 	if strings.TrimSpace(createReq.Category) == "" {
@@ -202,7 +330,7 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"user_id":    claims.UserID,
 			"project_id": createReq.ProjectID,
 		}).Error("Missing required field: category")
-		return api.ErrorResponse(http.StatusBadRequest, "category is required and cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "category is required and cannot be empty", logger), nil
 	}
 
 	if strings.TrimSpace(createReq.Priority) == "" {
@@ -211,7 +339,70 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"user_id":    claims.UserID,
 			"project_id": createReq.ProjectID,
 		}).Error("Missing required field: priority")
-		return api.ErrorResponse(http.StatusBadRequest, "priority is required and cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "priority is required and cannot be empty", logger), nil
+	}
+
+	if createReq.Status != "" && !slices.Contains(models.RFIStatuses, createReq.Status) {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("status must be one of: %s", strings.Join(models.RFIStatuses, ", ")), logger), nil
+	}
+
+	if createReq.ResponseDueDate != "" {
+		if _, err := time.Parse("2006-01-02", createReq.ResponseDueDate); err != nil {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "response_due_date must be a date in YYYY-MM-DD format", logger), nil
+		}
+	}
+
+	// Without an explicit due date, derive one from the org's SLA for the priority. For-information
+	// RFIs expect no answer, and without a configured SLA the RFI simply stays undated.
+	slaDueDateApplied := false
+	if createReq.DueDate == "" && (createReq.ForInformation == nil || !*createReq.ForInformation) {
+		slaDays, err := orgRepository.GetPrioritySLADays(ctx, claims.OrgID)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"org_id":    claims.OrgID,
+				"operation": "handleCreateRFI",
+			}).Error("Failed to get organization priority SLA days")
+			return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get organization SLA settings", logger), nil
+		}
+		if dueDate, ok := slaDays.DueDate(createReq.Priority, time.Now().UTC()); ok {
+			createReq.DueDate = dueDate
+			slaDueDateApplied = true
+		}
+	}
+
+	// Fall back to the project's default assignee when none is provided; RFIs may stay unassigned
+	defaultAssigneeApplied := false
+	if len(createReq.AssignedTo) == 0 {
+		defaultAssigneeID, err := projectRepository.GetProjectDefaultAssignee(ctx, createReq.ProjectID, claims.OrgID)
+		if err != nil {
+			if errors.Is(err, data.ErrNotFound) {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeOrgMismatch, "project_id does not belong to your organization", logger), nil
+			}
+			if !errors.Is(err, data.ErrConflict) {
+				logger.WithFields(logrus.Fields{
+					"error":      err.Error(),
+					"project_id": createReq.ProjectID,
+					"operation":  "handleCreateRFI",
+				}).Error("Failed to get project default assignee")
+				return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get project default assignee", logger), nil
+			}
+			logger.WithFields(logrus.Fields{
+				"project_id": createReq.ProjectID,
+				"operation":  "handleCreateRFI",
+			}).Warn("Project default assignee is stale, leaving RFI unassigned")
+		}
+		if defaultAssigneeID != 0 {
+			createReq.AssignedTo = []int64{defaultAssigneeID}
+			defaultAssigneeApplied = true
+		}
+	}
+
+	// Validate every assignee belongs to the organization
+	for _, assigneeID := range createReq.AssignedTo {
+		if statusCode, errCode, errMsg := api.ValidateUserInOrg(ctx, userRepository, assigneeID, claims.OrgID, "assigned_to"); errMsg != "" {
+			return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+		}
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -226,9 +417,17 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 		"org_id":      claims.OrgID,
 	}).Info("Request validation passed, creating RFI")
 
-	// Create RFI via repository
+	// Insert the RFI and record the Idempotency-Key result in one transaction, so a retry after a
+	// failed commit creates the RFI again instead of replaying one that does not exist
 	userID := claims.UserID
-	createdRFI, err := rfiRepository.CreateRFI(ctx, createReq.ProjectID, userID, claims.OrgID, &createReq)
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start RFI creation transaction")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create RFI", logger), nil
+	}
+	defer tx.Rollback()
+
+	rfiID, err := rfiRepository.CreateRFITx(ctx, tx, createReq.ProjectID, userID, claims.OrgID, &createReq)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -241,7 +440,23 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 
 		// Return detailed error message for better debugging
 		errorMsg := fmt.Sprintf("Failed to create RFI: %v", err)
-		return api.ErrorResponse(http.StatusInternalServerError, errorMsg, logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, errorMsg, logger), nil
+	}
+
+	if err := api.CompleteIdempotentRequest(ctx, idempotencyStore, tx, idempotencyKey, models.IdempotencyScopeCreateRFI, claims.OrgID, rfiID); err != nil {
+		logger.WithError(err).Error("Failed to record idempotency key result")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create RFI", logger), nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("Failed to commit RFI creation transaction")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to create RFI", logger), nil
+	}
+
+	createdRFI, err := rfiRepository.GetRFI(ctx, rfiID)
+	if err != nil {
+		logger.WithError(err).WithField("rfi_id", rfiID).Error("Failed to get created RFI")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get created RFI", logger), nil
 	}
 
 	// Validate created RFI is not nil
@@ -252,8 +467,10 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"org_id":     claims.OrgID,
 			"operation":  "handleCreateRFI",
 		}).Error("Repository returned nil RFI after creation")
-		return api.ErrorResponse(http.StatusInternalServerError, "RFI creation failed: repository returned nil", logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "RFI creation failed: repository returned nil", logger), nil
 	}
+	createdRFI.DefaultAssigneeApplied = defaultAssigneeApplied
+	createdRFI.SLADueDateApplied = slaDueDateApplied
 
 	logger.WithFields(logrus.Fields{
 		"rfi_id":     createdRFI.ID,
@@ -263,6 +480,8 @@ func handleCreateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 		"user_id":    userID,
 	}).Info("RFI created successfully")
 
+	sendRFIAssignmentEmails(ctx, claims.OrgID, userID, createdRFI, createReq.AssignedTo)
+
 	return api.SuccessResponse(http.StatusCreated, createdRFI, logger), nil
 }
 
@@ -275,7 +494,7 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 			"operation": "handleGetRFI",
 			"user_id":   claims.UserID,
 		}).Error("Missing rfiId in path parameters")
-		return api.ErrorResponse(http.StatusBadRequest, "rfiId is required in path", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "rfiId is required in path", logger), nil
 	}
 
 	rfiID, err := strconv.ParseInt(rfiIDStr, 10, 64)
@@ -287,7 +506,7 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 			"operation":  "handleGetRFI",
 			"user_id":    claims.UserID,
 		}).Error("Failed to parse rfiId as integer")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid RFI ID format: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, fmt.Sprintf("Invalid RFI ID format: %v", err), logger), nil
 	}
 
 	if rfiID <= 0 {
@@ -296,7 +515,7 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 			"operation": "handleGetRFI",
 			"user_id":   claims.UserID,
 		}).Error("RFI ID must be positive")
-		return api.ErrorResponse(http.StatusBadRequest, "RFI ID must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "RFI ID must be greater than 0", logger), nil
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -309,13 +528,13 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 	// Fetch RFI from repository
 	rfi, err := rfiRepository.GetRFI(ctx, rfiID)
 	if err != nil {
-		if err.Error() == "RFI not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			logger.WithFields(logrus.Fields{
 				"rfi_id":    rfiID,
 				"operation": "handleGetRFI",
 				"user_id":   claims.UserID,
 			}).Warn("RFI not found in database")
-			return api.ErrorResponse(http.StatusNotFound, "RFI not found", logger), nil
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeRFINotFound, "RFI not found", logger), nil
 		}
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -324,7 +543,7 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 			"operation":  "handleGetRFI",
 			"user_id":    claims.UserID,
 		}).Error("Repository failed to fetch RFI")
-		return api.ErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Failed to get RFI: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, fmt.Sprintf("Failed to get RFI: %v", err), logger), nil
 	}
 
 	// Validate fetched RFI
@@ -334,7 +553,7 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 			"operation": "handleGetRFI",
 			"user_id":   claims.UserID,
 		}).Error("Repository returned nil RFI without error")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get RFI: repository returned nil", logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get RFI: repository returned nil", logger), nil
 	}
 
 	// Verify organization access
@@ -346,18 +565,136 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 			"operation":   "handleGetRFI",
 			"user_id":     claims.UserID,
 		}).Warn("User attempted to access RFI from different organization")
-		return api.ErrorResponse(http.StatusForbidden, "Access denied: RFI belongs to a different organization", logger), nil
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Access denied: RFI belongs to a different organization", logger), nil
 	}
 
-	// Fetch comments for RFI
-	comments, err := rfiRepository.GetRFIComments(ctx, rfiID)
+	loadRFIDetails(ctx, rfi, "handleGetRFI", claims.UserID)
+
+	logger.WithFields(logrus.Fields{
+		"rfi_id":           rfiID,
+		"rfi_number":       rfi.RFINumber,
+		"status":           rfi.Status,
+		"comments_count":   len(rfi.Comments),
+		"attachments_count": len(rfi.Attachments),
+		"operation":        "handleGetRFI",
+		"user_id":          claims.UserID,
+	}).Info("RFI fetched successfully")
+
+	return api.ConditionalResponse(request, rfiETag(request, rfi), func() events.APIGatewayProxyResponse {
+		return api.SparseResponse(request, rfi, logger)
+	}), nil
+}
+
+// rfiETag versions an RFI detail response. Comments and attachments change without touching the
+// RFI's updated_at, and days open / overdue flags change daily, so all of those are part of the tag.
+func rfiETag(request events.APIGatewayProxyRequest, rfi *models.RFIResponse) string {
+	var latestComment time.Time
+	for _, comment := range rfi.Comments {
+		if comment.UpdatedAt.After(latestComment) {
+			latestComment = comment.UpdatedAt
+		}
+	}
+	var latestAttachmentID int64
+	for _, attachment := range rfi.Attachments {
+		if attachment.ID > latestAttachmentID {
+			latestAttachmentID = attachment.ID
+		}
+	}
+	return api.WeakETag(rfi.ID, rfi.UpdatedAt.UnixNano(),
+		len(rfi.Comments), latestComment.UnixNano(),
+		len(rfi.Attachments), latestAttachmentID,
+		time.Now().UTC().Format("2006-01-02"), request.QueryStringParameters["fields"])
+}
+
+// handleGetRFIByNumber handles GET /projects/{projectId}/rfis/by-number/{rfiNumber}
+// Looks up an RFI by the human-readable number users quote from emails and drawings
+func handleGetRFIByNumber(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil || projectID <= 0 {
+		logger.WithFields(logrus.Fields{
+			"project_id_str": request.PathParameters["projectId"],
+			"operation":      "handleGetRFIByNumber",
+			"user_id":        claims.UserID,
+		}).Error("Invalid projectId in path parameters")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid project ID", logger), nil
+	}
+
+	rfiNumber := strings.TrimSpace(request.PathParameters["rfiNumber"])
+	if rfiNumber == "" {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "rfiNumber is required in path", logger), nil
+	}
+
+	rfi, err := rfiRepository.GetRFIByNumber(ctx, projectID, claims.OrgID, rfiNumber)
 	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeRFINotFound, "RFI not found", logger), nil
+		}
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
-			"error_type": fmt.Sprintf("%T", err),
-			"rfi_id":     rfiID,
-			"operation":  "handleGetRFI",
+			"project_id": projectID,
+			"rfi_number": rfiNumber,
+			"operation":  "handleGetRFIByNumber",
 			"user_id":    claims.UserID,
+		}).Error("Repository failed to fetch RFI by number")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get RFI", logger), nil
+	}
+
+	loadRFIDetails(ctx, rfi, "handleGetRFIByNumber", claims.UserID)
+
+	return api.SparseResponse(request, rfi, logger), nil
+}
+
+// handleAutoCloseStaleRFIs closes answered RFIs that have gone quiet for longer than their
+// organization's rfi_auto_close_days. Each close is logged to the RFI's comment history.
+func handleAutoCloseStaleRFIs(ctx context.Context) events.APIGatewayProxyResponse {
+	staleRFIs, err := rfiRepository.GetStaleAnsweredRFIs(ctx, rfiAutoCloseBatchSize)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get stale answered RFIs")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get stale answered RFIs", logger)
+	}
+
+	result := models.RFIAutoCloseResult{Scanned: len(staleRFIs)}
+	for _, rfi := range staleRFIs {
+		closed, err := rfiRepository.AutoCloseRFI(ctx, rfi)
+		switch {
+		case err != nil:
+			result.Failed++
+			logger.WithFields(logrus.Fields{
+				"rfi_id":    rfi.ID,
+				"org_id":    rfi.OrgID,
+				"error":     err.Error(),
+				"operation": "handleAutoCloseStaleRFIs",
+			}).Error("Failed to auto-close RFI")
+		case closed:
+			result.Closed++
+		default:
+			// A comment or status change landed after the RFI was selected
+			result.Skipped++
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"scanned":   result.Scanned,
+		"closed":    result.Closed,
+		"skipped":   result.Skipped,
+		"failed":    result.Failed,
+		"operation": "handleAutoCloseStaleRFIs",
+	}).Info("RFI auto-close run completed")
+
+	return api.SuccessResponse(http.StatusOK, result, logger)
+}
+
+// loadRFIDetails attaches comments and attachments to an RFI. Lookup failures are logged
+// and leave the lists empty so the RFI itself can still be returned.
+func loadRFIDetails(ctx context.Context, rfi *models.RFIResponse, operation string, userID int64) {
+	comments, err := rfiRepository.GetRFIComments(ctx, rfi.ID)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"error_type": fmt.Sprintf("%T", err),
+			"rfi_id":     rfi.ID,
+			"operation":  operation,
+			"user_id":    userID,
 		}).Warn("Failed to fetch RFI comments, continuing with empty comments")
 		rfi.Comments = []models.RFIComment{}
 	} else if comments == nil {
@@ -366,15 +703,14 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 		rfi.Comments = comments
 	}
 
-	// Fetch attachments for RFI
-	attachments, err := rfiRepository.GetRFIAttachments(ctx, rfiID)
+	attachments, err := rfiRepository.GetRFIAttachments(ctx, rfi.ID)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"error_type": fmt.Sprintf("%T", err),
-			"rfi_id":     rfiID,
-			"operation":  "handleGetRFI",
-			"user_id":    claims.UserID,
+			"rfi_id":     rfi.ID,
+			"operation":  operation,
+			"user_id":    userID,
 		}).Warn("Failed to fetch RFI attachments, continuing with empty attachments")
 		rfi.Attachments = []models.RFIAttachment{}
 	} else if attachments == nil {
@@ -382,18 +718,6 @@ func handleGetRFI(ctx context.Context, request events.APIGatewayProxyRequest, cl
 	} else {
 		rfi.Attachments = attachments
 	}
-
-	logger.WithFields(logrus.Fields{
-		"rfi_id":           rfiID,
-		"rfi_number":       rfi.RFINumber,
-		"status":           rfi.Status,
-		"comments_count":   len(rfi.Comments),
-		"attachments_count": len(rfi.Attachments),
-		"operation":        "handleGetRFI",
-		"user_id":          claims.UserID,
-	}).Info("RFI fetched successfully")
-
-	return api.SuccessResponse(http.StatusOK, rfi, logger), nil
 }
 
 // handleUpdateRFI handles PUT /rfis/{rfiId} - supports action field for status changes
@@ -405,7 +729,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation": "handleUpdateRFI",
 			"user_id":   claims.UserID,
 		}).Error("Missing rfiId in path parameters")
-		return api.ErrorResponse(http.StatusBadRequest, "rfiId is required in path", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "rfiId is required in path", logger), nil
 	}
 
 	rfiID, err := strconv.ParseInt(rfiIDStr, 10, 64)
@@ -417,7 +741,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation":  "handleUpdateRFI",
 			"user_id":    claims.UserID,
 		}).Error("Failed to parse rfiId as integer")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid RFI ID format: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, fmt.Sprintf("Invalid RFI ID format: %v", err), logger), nil
 	}
 
 	if rfiID <= 0 {
@@ -426,7 +750,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation": "handleUpdateRFI",
 			"user_id":   claims.UserID,
 		}).Error("RFI ID must be positive")
-		return api.ErrorResponse(http.StatusBadRequest, "RFI ID must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "RFI ID must be greater than 0", logger), nil
 	}
 
 	// Validate request body is not empty
@@ -436,7 +760,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"rfi_id":    rfiID,
 			"user_id":   claims.UserID,
 		}).Error("Request body is empty")
-		return api.ErrorResponse(http.StatusBadRequest, "Request body cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Request body cannot be empty", logger), nil
 	}
 
 	// Parse request body
@@ -450,7 +774,17 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"operation":  "handleUpdateRFI",
 			"user_id":    claims.UserID,
 		}).Error("Failed to parse JSON request body")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid JSON in request body: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, fmt.Sprintf("Invalid JSON in request body: %v", err), logger), nil
+	}
+
+	if updateReq.Status != "" && !slices.Contains(models.RFIStatuses, updateReq.Status) {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("status must be one of: %s", strings.Join(models.RFIStatuses, ", ")), logger), nil
+	}
+
+	if updateReq.ResponseDueDate != "" {
+		if _, err := time.Parse("2006-01-02", updateReq.ResponseDueDate); err != nil {
+			return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "response_due_date must be a date in YYYY-MM-DD format", logger), nil
+		}
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -465,16 +799,30 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 	userID := claims.UserID
 	updatedRFI, err := rfiRepository.UpdateRFI(ctx, rfiID, userID, claims.OrgID, &updateReq)
 	if err != nil {
-		if strings.Contains(err.Error(), "RFI not found") || strings.Contains(err.Error(), "not found") {
+		var conflictErr *models.RFIVersionConflictError
+		if errors.As(err, &conflictErr) {
+			logger.WithFields(logrus.Fields{
+				"rfi_id":           rfiID,
+				"expected_version": *updateReq.Version,
+				"current_version":  conflictErr.CurrentVersion,
+				"operation":        "handleUpdateRFI",
+				"user_id":          userID,
+			}).Warn("RFI update rejected due to version conflict")
+			return api.ConflictResponse("RFI was modified by someone else. Reload it and try again.", map[string]interface{}{
+				"code":            api.ErrorCodeVersionConflict,
+				"current_version": conflictErr.CurrentVersion,
+			}, logger), nil
+		}
+		if errors.Is(err, data.ErrNotFound) {
 			logger.WithFields(logrus.Fields{
 				"error":     err.Error(),
 				"rfi_id":    rfiID,
 				"operation": "handleUpdateRFI",
 				"user_id":   userID,
 			}).Warn("RFI not found during update")
-			return api.ErrorResponse(http.StatusNotFound, "RFI not found", logger), nil
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeRFINotFound, "RFI not found", logger), nil
 		}
-		if strings.Contains(err.Error(), "does not belong") || strings.Contains(err.Error(), "organization") {
+		if errors.Is(err, data.ErrOrgMismatch) {
 			logger.WithFields(logrus.Fields{
 				"error":     err.Error(),
 				"rfi_id":    rfiID,
@@ -482,7 +830,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 				"user_id":   userID,
 				"org_id":    claims.OrgID,
 			}).Warn("User attempted to update RFI from different organization")
-			return api.ErrorResponse(http.StatusForbidden, "Access denied: RFI belongs to a different organization", logger), nil
+			return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Access denied: RFI belongs to a different organization", logger), nil
 		}
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -495,7 +843,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 
 		// Return detailed error message for better debugging
 		errorMsg := fmt.Sprintf("Failed to update RFI: %v", err)
-		return api.ErrorResponse(http.StatusInternalServerError, errorMsg, logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, errorMsg, logger), nil
 	}
 
 	// Validate updated RFI
@@ -506,7 +854,7 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 			"org_id":    claims.OrgID,
 			"operation": "handleUpdateRFI",
 		}).Error("Repository returned nil RFI after update")
-		return api.ErrorResponse(http.StatusInternalServerError, "RFI update failed: repository returned nil", logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "RFI update failed: repository returned nil", logger), nil
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -517,10 +865,129 @@ func handleUpdateRFI(ctx context.Context, request events.APIGatewayProxyRequest,
 		"user_id":    userID,
 	}).Info("RFI updated successfully")
 
+	if updatedRFI.AssigneeChange != nil {
+		publishRFIAssigneeChanged(ctx, updatedRFI.AssigneeChange)
+		sendRFIAssignmentEmails(ctx, claims.OrgID, userID, updatedRFI, newlyAssigned(updatedRFI.AssigneeChange))
+	}
+
 	return api.SuccessResponse(http.StatusOK, updatedRFI, logger), nil
 }
 
-// handleGetProjectRFIs handles GET /projects/{projectId}/rfis - Simple, consistent with Issue API
+// publishRFIAssigneeChanged sends an rfi.assignee_changed event to the events topic, listing as recipients
+// only the new assignees with SNS on for rfi_assigned. The change is already recorded in
+// project.rfi_notifications, so publishing is best-effort.
+func publishRFIAssigneeChanged(ctx context.Context, event *models.RFIAssigneeChangedEvent) {
+	if snsClient == nil || eventsTopic == "" {
+		return
+	}
+
+	event.RecipientIDs = []int64{}
+	if candidates := slices.DeleteFunc(newlyAssigned(event), func(id int64) bool { return id == event.ChangedBy }); len(candidates) > 0 {
+		recipientIDs, err := userRepository.GetSNSRecipientIDs(ctx, event.OrgID, candidates, models.NotificationEventRFIAssigned)
+		if err != nil {
+			logger.WithError(err).WithField("rfi_id", event.RFIID).Warn("Failed to check notification preferences")
+		} else if recipientIDs != nil {
+			event.RecipientIDs = recipientIDs
+		}
+	}
+
+	err := clients.PublishEvent(snsClient, eventsTopic, event, map[string]string{
+		"event_type": event.EventType,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("rfi_id", event.RFIID).Warn("Failed to publish RFI assignee event")
+	}
+}
+
+// newlyAssigned returns the users in the change's new assignees who were not already assigned
+func newlyAssigned(change *models.RFIAssigneeChangedEvent) []int64 {
+	var added []int64
+	for _, userID := range change.NewAssignee {
+		if !slices.Contains(change.PreviousAssignee, userID) {
+			added = append(added, userID)
+		}
+	}
+	return added
+}
+
+// rfiAssignmentEmailData is the data the SSM-configured assignment email template is executed with
+type rfiAssignmentEmailData struct {
+	RecipientName string
+	RFINumber     string
+	Subject       string
+	ProjectName   string
+	DueDate       string
+	Link          string
+}
+
+// sendRFIAssignmentEmails emails each assignee (other than the user who made the assignment) who has
+// email on for rfi_assigned in their notification preferences. It runs before the handler returns, because
+// Lambda freezes the sandbox afterwards, and is bounded by assignmentEmailTimeout; failures are logged and
+// never reach the API caller.
+func sendRFIAssignmentEmails(ctx context.Context, orgID, actorID int64, rfi *models.RFIResponse, assigneeIDs []int64) {
+	if sesClient == nil || assignmentEmail == nil {
+		return
+	}
+	assigneeIDs = slices.DeleteFunc(slices.Clone(assigneeIDs), func(id int64) bool { return id == actorID })
+	if len(assigneeIDs) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, assignmentEmailTimeout)
+	defer cancel()
+
+	log := logger.WithFields(logrus.Fields{
+		"rfi_id":    rfi.ID,
+		"operation": "sendRFIAssignmentEmails",
+	})
+
+	recipients, err := userRepository.GetEmailRecipients(ctx, orgID, assigneeIDs, models.NotificationEventRFIAssigned)
+	if err != nil {
+		log.WithError(err).Warn("Failed to look up RFI assignment email recipients")
+		return
+	}
+
+	data := rfiAssignmentEmailData{
+		Subject:     rfi.Subject,
+		ProjectName: rfi.ProjectName,
+		DueDate:     "No due date",
+		Link:        fmt.Sprintf("%s/projects/%d/rfis/%d", strings.TrimRight(appBaseURL, "/"), rfi.ProjectID, rfi.ID),
+	}
+	if rfi.RFINumber != nil {
+		data.RFINumber = *rfi.RFINumber
+	}
+	if rfi.DueDate != nil {
+		data.DueDate = rfi.DueDate.Format("2006-01-02")
+	}
+
+	for _, recipient := range recipients {
+		data.RecipientName = recipient.Name
+		var body strings.Builder
+		if err := assignmentEmail.Execute(&body, data); err != nil {
+			log.WithError(err).WithField("user_id", recipient.UserID).Warn("Failed to render RFI assignment email")
+			continue
+		}
+
+		err := sesClient.SendEmail(ctx, clients.EmailMessage{
+			From:    emailFromAddress,
+			To:      recipient.Email,
+			Subject: fmt.Sprintf("RFI %s assigned to you: %s", data.RFINumber, data.Subject),
+			Body:    body.String(),
+		})
+		if err != nil {
+			log.WithError(err).WithField("user_id", recipient.UserID).Warn("Failed to send RFI assignment email")
+		}
+	}
+}
+
+// rfiListFilters are the list filters that accept comma-separated values (status=a,b)
+var rfiListFilters = map[string][]string{
+	"status":   models.RFIStatuses,
+	"priority": models.RFIPriorities,
+}
+
+// handleGetProjectRFIs handles GET /projects/{projectId}/rfis - Simple, consistent with Issue API.
+// q= searches subject, description and RFI number and ranks matches by relevance.
 func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	// Extract and validate project ID
 	projectIDStr, exists := request.PathParameters["projectId"]
@@ -529,7 +996,7 @@ func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation": "handleGetProjectRFIs",
 			"user_id":   claims.UserID,
 		}).Error("Missing projectId in path parameters")
-		return api.ErrorResponse(http.StatusBadRequest, "projectId is required in path", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "projectId is required in path", logger), nil
 	}
 
 	projectID, err := strconv.ParseInt(projectIDStr, 10, 64)
@@ -541,7 +1008,7 @@ func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":      "handleGetProjectRFIs",
 			"user_id":        claims.UserID,
 		}).Error("Failed to parse projectId as integer")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid project ID format: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, fmt.Sprintf("Invalid project ID format: %v", err), logger), nil
 	}
 
 	if projectID <= 0 {
@@ -550,7 +1017,7 @@ func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":  "handleGetProjectRFIs",
 			"user_id":    claims.UserID,
 		}).Error("Project ID must be positive")
-		return api.ErrorResponse(http.StatusBadRequest, "Project ID must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Project ID must be greater than 0", logger), nil
 	}
 
 	// Get query string filters
@@ -558,6 +1025,15 @@ func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyReq
 	if filters == nil {
 		filters = make(map[string]string)
 	}
+	if err := api.NormalizeMultiValueFilters(filters, rfiListFilters); err != nil {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger), nil
+	}
+	if err := api.NormalizeSortParams(filters, models.RFISortFields); err != nil {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger), nil
+	}
+	if len(filters["q"]) > models.MaxRFISearchLength {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("q cannot be longer than %d characters", models.MaxRFISearchLength), logger), nil
+	}
 
 	logger.WithFields(logrus.Fields{
 		"project_id": projectID,
@@ -578,7 +1054,7 @@ func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":  "handleGetProjectRFIs",
 			"user_id":    claims.UserID,
 		}).Error("Repository failed to fetch project RFIs")
-		return api.ErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Failed to get RFIs: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, fmt.Sprintf("Failed to get RFIs: %v", err), logger), nil
 	}
 
 	// Ensure we return an empty array instead of null
@@ -593,7 +1069,176 @@ func handleGetProjectRFIs(ctx context.Context, request events.APIGatewayProxyReq
 		"user_id":    claims.UserID,
 	}).Info("Project RFIs fetched successfully")
 
-	return api.SuccessResponse(http.StatusOK, rfis, logger), nil
+	return api.ListResponse(request, rfis, rfis, api.SinglePageMeta(len(rfis)), logger), nil
+}
+
+// handleGetRFIsAssignedToMe handles GET /rfis/assigned-to-me for "my RFIs" dashboards.
+// Supports status (comma-separated) and due_before/due_after (YYYY-MM-DD); sorted by due date ascending.
+func handleGetRFIsAssignedToMe(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	filters := map[string]string{}
+	for _, name := range []string{"status", "due_before", "due_after"} {
+		if value := strings.TrimSpace(request.QueryStringParameters[name]); value != "" {
+			filters[name] = value
+		}
+	}
+	if err := api.NormalizeMultiValueFilters(filters, map[string][]string{"status": models.RFIStatuses}); err != nil {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger), nil
+	}
+	for _, name := range []string{"due_before", "due_after"} {
+		if value, ok := filters[name]; ok {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("%s must be a date in YYYY-MM-DD format", name), logger), nil
+			}
+		}
+	}
+
+	rfis, err := rfiRepository.GetRFIsAssignedToUser(ctx, claims.UserID, claims.OrgID, filters)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"filters":   filters,
+			"operation": "handleGetRFIsAssignedToMe",
+			"user_id":   claims.UserID,
+		}).Error("Repository failed to fetch assigned RFIs")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get RFIs", logger), nil
+	}
+
+	// Ensure we return an empty array instead of null
+	if rfis == nil {
+		rfis = []models.RFIResponse{}
+	}
+
+	return api.ListResponse(request, rfis, rfis, api.SinglePageMeta(len(rfis)), logger), nil
+}
+
+// handleGetSLABreachedRFIs handles GET /projects/{projectId}/rfis/sla-breaches: RFIs with no response
+// whose response_due_date has passed, most overdue first.
+func handleGetSLABreachedRFIs(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil || projectID <= 0 {
+		logger.WithFields(logrus.Fields{
+			"project_id_str": request.PathParameters["projectId"],
+			"operation":      "handleGetSLABreachedRFIs",
+			"user_id":        claims.UserID,
+		}).Error("Invalid projectId in path parameters")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid project ID", logger), nil
+	}
+
+	rfis, err := rfiRepository.GetSLABreachedRFIs(ctx, projectID, claims.OrgID)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+			"operation":  "handleGetSLABreachedRFIs",
+			"user_id":    claims.UserID,
+		}).Error("Repository failed to fetch SLA-breached RFIs")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to get RFIs", logger), nil
+	}
+
+	// Ensure we return an empty array instead of null
+	if rfis == nil {
+		rfis = []models.RFIResponse{}
+	}
+
+	return api.ListResponse(request, rfis, rfis, api.SinglePageMeta(len(rfis)), logger), nil
+}
+
+// handleExportProjectRFIs handles GET /projects/{projectId}/rfis/export?format=csv|json
+func handleExportProjectRFIs(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	projectID, err := strconv.ParseInt(request.PathParameters["projectId"], 10, 64)
+	if err != nil || projectID <= 0 {
+		logger.WithFields(logrus.Fields{
+			"project_id_str": request.PathParameters["projectId"],
+			"operation":      "handleExportProjectRFIs",
+			"user_id":        claims.UserID,
+		}).Error("Invalid projectId in path parameters")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid project ID", logger), nil
+	}
+
+	format := strings.ToLower(strings.TrimSpace(request.QueryStringParameters["format"]))
+	if format == "" {
+		format = models.RFIExportFormatCSV
+	}
+	if format != models.RFIExportFormatCSV && format != models.RFIExportFormatJSON {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "format must be one of: csv, json", logger), nil
+	}
+
+	items, err := rfiRepository.GetRFIExport(ctx, projectID, claims.OrgID)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+			"operation":  "handleExportProjectRFIs",
+			"user_id":    claims.UserID,
+		}).Error("Repository failed to export project RFIs")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to export RFIs", logger), nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"format":     format,
+		"count":      len(items),
+		"operation":  "handleExportProjectRFIs",
+		"user_id":    claims.UserID,
+	}).Info("Project RFI log exported")
+
+	if format == models.RFIExportFormatJSON {
+		return api.SuccessResponse(http.StatusOK, models.RFIExportResponse{
+			ProjectID:  projectID,
+			ExportedAt: time.Now().UTC(),
+			TotalCount: len(items),
+			RFIs:       items,
+		}, logger), nil
+	}
+
+	content, err := buildRFIExportCSV(items)
+	if err != nil {
+		logger.WithError(err).Error("Failed to build RFI export CSV")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to export RFIs", logger), nil
+	}
+
+	fileName := fmt.Sprintf("project-%d-rfi-log-%s.csv", projectID, time.Now().UTC().Format("20060102"))
+	return api.FileResponse(http.StatusOK, content, "text/csv", fileName), nil
+}
+
+// buildRFIExportCSV renders the RFI log as CSV with one row per RFI
+func buildRFIExportCSV(items []models.RFIExportItem) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{
+		"RFI Number", "Subject", "Description", "Category", "Priority", "Status",
+		"Location", "Assigned To", "Due Date", "Closed Date", "Created At", "Created By",
+		"Official Response", "Comment Count",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	formatDate := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+
+	for _, item := range items {
+		row := []string{
+			item.RFINumber, item.Subject, item.Description, item.Category, item.Priority, item.Status,
+			item.LocationName, item.AssignedTo, formatDate(item.DueDate), formatDate(item.ClosedDate),
+			item.CreatedAt.Format(time.RFC3339), item.CreatedByName,
+			item.OfficialResponse, strconv.Itoa(item.CommentCount),
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 // handleGetContextRFIs handles GET /contexts/{contextType}/{contextId}/rfis
@@ -607,7 +1252,7 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation": "handleGetContextRFIs",
 			"user_id":   claims.UserID,
 		}).Error("Missing contextType in path parameters")
-		return api.ErrorResponse(http.StatusBadRequest, "contextType is required in path", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "contextType is required in path", logger), nil
 	}
 
 	// Extract and validate context ID
@@ -618,7 +1263,7 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"context_type": contextType,
 			"user_id":      claims.UserID,
 		}).Error("Missing contextId in path parameters")
-		return api.ErrorResponse(http.StatusBadRequest, "contextId is required in path", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "contextId is required in path", logger), nil
 	}
 
 	contextID, err := strconv.ParseInt(contextIDStr, 10, 64)
@@ -631,7 +1276,7 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":      "handleGetContextRFIs",
 			"user_id":        claims.UserID,
 		}).Error("Failed to parse contextId as integer")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid context ID format: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, fmt.Sprintf("Invalid context ID format: %v", err), logger), nil
 	}
 
 	if contextID <= 0 {
@@ -641,7 +1286,7 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":    "handleGetContextRFIs",
 			"user_id":      claims.UserID,
 		}).Error("Context ID must be positive")
-		return api.ErrorResponse(http.StatusBadRequest, "Context ID must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Context ID must be greater than 0", logger), nil
 	}
 
 	// For now, only support project context
@@ -652,7 +1297,7 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":    "handleGetContextRFIs",
 			"user_id":      claims.UserID,
 		}).Warn("Unsupported context type requested")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Only project context is supported, received: %s", contextType), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, fmt.Sprintf("Only project context is supported, received: %s", contextType), logger), nil
 	}
 
 	// Get query string filters
@@ -660,6 +1305,12 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 	if filters == nil {
 		filters = make(map[string]string)
 	}
+	if err := api.NormalizeMultiValueFilters(filters, rfiListFilters); err != nil {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger), nil
+	}
+	if err := api.NormalizeSortParams(filters, models.RFISortFields); err != nil {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, err.Error(), logger), nil
+	}
 
 	logger.WithFields(logrus.Fields{
 		"context_type": contextType,
@@ -682,7 +1333,7 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 			"operation":    "handleGetContextRFIs",
 			"user_id":      claims.UserID,
 		}).Error("Repository failed to fetch context RFIs")
-		return api.ErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Failed to get RFIs: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, fmt.Sprintf("Failed to get RFIs: %v", err), logger), nil
 	}
 
 	// Ensure we return an empty array instead of null
@@ -707,6 +1358,43 @@ func handleGetContextRFIs(ctx context.Context, request events.APIGatewayProxyReq
 	return api.SuccessResponse(http.StatusOK, response, logger), nil
 }
 
+// handleCopyRFI handles POST /rfis/{rfiId}/copy
+func handleCopyRFI(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	rfiID, err := strconv.ParseInt(request.PathParameters["rfiId"], 10, 64)
+	if err != nil || rfiID <= 0 {
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "Invalid RFI ID", logger), nil
+	}
+
+	var copyReq models.CopyToProjectRequest
+	if err := api.ParseJSONBody(request.Body, &copyReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for RFI copy")
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Invalid request body", logger), nil
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, copyReq.TargetProjectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+	if statusCode, errCode, errMsg := api.ValidateProjectMembership(ctx, orgRepository, projectRepository, copyReq.TargetProjectID, claims.OrgID, claims.UserID, claims.IsSuperAdmin); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	rfi, err := rfiRepository.CopyRFI(ctx, rfiID, copyReq.TargetProjectID, claims.UserID, claims.OrgID, copyReq.CopyAttachments)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrTargetProjectNotFound):
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeProjectNotFound, "Target project not found", logger), nil
+		case errors.Is(err, data.ErrNotFound):
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeRFINotFound, "RFI not found", logger), nil
+		case errors.Is(err, data.ErrOrgMismatch):
+			return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Target project does not belong to your organization", logger), nil
+		}
+		logger.WithError(err).WithField("rfi_id", rfiID).Error("Failed to copy RFI")
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to copy RFI", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusCreated, rfi, logger), nil
+}
+
 // handleAddRFIComment handles POST /rfis/{rfiId}/comments
 func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	// Extract and validate RFI ID
@@ -716,7 +1404,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation": "handleAddRFIComment",
 			"user_id":   claims.UserID,
 		}).Error("Missing rfiId in path parameters")
-		return api.ErrorResponse(http.StatusBadRequest, "rfiId is required in path", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "rfiId is required in path", logger), nil
 	}
 
 	rfiID, err := strconv.ParseInt(rfiIDStr, 10, 64)
@@ -728,7 +1416,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation":  "handleAddRFIComment",
 			"user_id":    claims.UserID,
 		}).Error("Failed to parse rfiId as integer")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid RFI ID format: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, fmt.Sprintf("Invalid RFI ID format: %v", err), logger), nil
 	}
 
 	if rfiID <= 0 {
@@ -737,20 +1425,20 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation": "handleAddRFIComment",
 			"user_id":   claims.UserID,
 		}).Error("RFI ID must be positive")
-		return api.ErrorResponse(http.StatusBadRequest, "RFI ID must be greater than 0", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidID, "RFI ID must be greater than 0", logger), nil
 	}
 
 	// Validate that RFI exists and belongs to user's organization
 	rfi, err := rfiRepository.GetRFI(ctx, rfiID)
 	if err != nil {
-		if strings.Contains(err.Error(), "RFI not found") || strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, data.ErrNotFound) {
 			logger.WithFields(logrus.Fields{
 				"error":     err.Error(),
 				"rfi_id":    rfiID,
 				"operation": "handleAddRFIComment",
 				"user_id":   claims.UserID,
 			}).Warn("RFI not found when adding comment")
-			return api.ErrorResponse(http.StatusNotFound, "RFI not found", logger), nil
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeRFINotFound, "RFI not found", logger), nil
 		}
 		logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -759,7 +1447,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation":  "handleAddRFIComment",
 			"user_id":    claims.UserID,
 		}).Error("Repository failed to fetch RFI for comment validation")
-		return api.ErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Failed to validate RFI: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, fmt.Sprintf("Failed to validate RFI: %v", err), logger), nil
 	}
 
 	// Validate RFI is not nil
@@ -769,7 +1457,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation": "handleAddRFIComment",
 			"user_id":   claims.UserID,
 		}).Error("Repository returned nil RFI without error")
-		return api.ErrorResponse(http.StatusInternalServerError, "Failed to validate RFI: repository returned nil", logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Failed to validate RFI: repository returned nil", logger), nil
 	}
 
 	// Verify organization access
@@ -781,7 +1469,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation":   "handleAddRFIComment",
 			"user_id":     claims.UserID,
 		}).Warn("User attempted to add comment to RFI from different organization")
-		return api.ErrorResponse(http.StatusForbidden, "Access denied: RFI belongs to a different organization", logger), nil
+		return api.ErrorResponseWithCode(http.StatusForbidden, api.ErrorCodeOrgMismatch, "Access denied: RFI belongs to a different organization", logger), nil
 	}
 
 	// Validate request body is not empty
@@ -791,7 +1479,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"rfi_id":    rfiID,
 			"user_id":   claims.UserID,
 		}).Error("Request body is empty")
-		return api.ErrorResponse(http.StatusBadRequest, "Request body cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, "Request body cannot be empty", logger), nil
 	}
 
 	// Parse request body
@@ -805,7 +1493,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"operation":  "handleAddRFIComment",
 			"user_id":    claims.UserID,
 		}).Error("Failed to parse JSON request body")
-		return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid JSON in request body: %v", err), logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeInvalidBody, fmt.Sprintf("Invalid JSON in request body: %v", err), logger), nil
 	}
 
 	// Validate required fields
@@ -815,7 +1503,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"rfi_id":    rfiID,
 			"user_id":   claims.UserID,
 		}).Error("Missing required field: comment")
-		return api.ErrorResponse(http.StatusBadRequest, "comment is required and cannot be empty", logger), nil
+		return api.ErrorResponseWithCode(http.StatusBadRequest, api.ErrorCodeValidationFailed, "comment is required and cannot be empty", logger), nil
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -838,7 +1526,13 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"user_id":    userID,
 			"operation":  "handleAddRFIComment",
 		}).Error("Repository failed to add RFI comment")
-		return api.ErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Failed to add comment: %v", err), logger), nil
+		if errors.Is(err, data.ErrConflict) {
+			return api.ErrorResponseWithCode(http.StatusConflict, api.ErrorCodeRFIDeleted, "RFI has been deleted; comment was not added", logger), nil
+		}
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponseWithCode(http.StatusNotFound, api.ErrorCodeRFINotFound, "RFI not found", logger), nil
+		}
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, fmt.Sprintf("Failed to add comment: %v", err), logger), nil
 	}
 
 	// Validate comment is not nil
@@ -848,7 +1542,7 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 			"user_id":   userID,
 			"operation": "handleAddRFIComment",
 		}).Error("Repository returned nil comment after creation")
-		return api.ErrorResponse(http.StatusInternalServerError, "Comment creation failed: repository returned nil", logger), nil
+		return api.ErrorResponseWithCode(http.StatusInternalServerError, api.ErrorCodeInternal, "Comment creation failed: repository returned nil", logger), nil
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -858,9 +1552,37 @@ func handleAddRFIComment(ctx context.Context, request events.APIGatewayProxyRequ
 		"user_id":    userID,
 	}).Info("RFI comment added successfully")
 
+	publishRFICommentMentions(rfiID, claims.OrgID, comment)
+
 	return api.SuccessResponse(http.StatusCreated, comment, logger), nil
 }
 
+// publishRFICommentMentions sends a comment.mention event for the users mentioned in a new RFI comment.
+// The mentions are already stored in project.comment_mentions, so publishing is best-effort.
+func publishRFICommentMentions(rfiID, orgID int64, comment *models.RFIComment) {
+	recipients := models.MentionedUserIDs(comment.Mentions, comment.CreatedBy)
+	if len(recipients) == 0 || snsClient == nil || eventsTopic == "" {
+		return
+	}
+
+	event := models.CommentMentionEvent{
+		EventType:        models.CommentEventMention,
+		EntityType:       models.EntityTypeRFI,
+		EntityID:         rfiID,
+		CommentID:        comment.ID,
+		OrgID:            orgID,
+		MentionedUserIDs: recipients,
+		ActorUserID:      comment.CreatedBy,
+		Timestamp:        time.Now().UTC(),
+	}
+	err := clients.PublishEvent(snsClient, eventsTopic, event, map[string]string{
+		"event_type": event.EventType,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("rfi_id", rfiID).Warn("Failed to publish RFI comment mention event")
+	}
+}
+
 func init() {
 	var err error
 
@@ -921,6 +1643,31 @@ func init() {
 		logger.WithField("operation", "init").Fatal("RFI repository is nil after initialization")
 	}
 
+	// Events share the issue events topic; without it the Lambda runs without publishing
+	eventsTopic = ssmParams[constants.ISSUE_EVENTS_TOPIC_ARN]
+	if eventsTopic != "" {
+		snsClient = clients.NewSNSClient(isLocal)
+	} else {
+		logger.WithField("operation", "init").Warn("Events topic not configured; RFI assignee events will not be published")
+	}
+
+	// Assignment emails need a sender and a template; without either the Lambda runs without emailing
+	emailFromAddress = ssmParams[constants.SES_FROM_ADDRESS]
+	appBaseURL = ssmParams[constants.APP_BASE_URL]
+	if templateText := ssmParams[constants.RFI_ASSIGNMENT_EMAIL_TEMPLATE]; emailFromAddress != "" && templateText != "" {
+		assignmentEmail, err = template.New("rfi-assignment").Option("missingkey=error").Parse(templateText)
+		if err != nil {
+			logger.WithError(err).WithField("operation", "init").Error("Invalid RFI assignment email template; assignment emails will not be sent")
+		} else {
+			sesClient = clients.NewSESClient(isLocal)
+		}
+	} else {
+		logger.WithField("operation", "init").Warn("Email sender or template not configured; RFI assignment emails will not be sent")
+	}
+
+	// Per-org limit on project RFI lists, the heaviest query this service runs
+	rfiListLimiter = api.NewRateLimiter(api.ParseRateLimit(ssmParams[constants.RATE_LIMIT_RFI_LIST], api.DefaultListRateLimit))
+
 	logger.Info("RFI management service initialized successfully")
 }
 
@@ -962,6 +1709,9 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		}
 	}
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -990,10 +1740,41 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		Logger: logger,
 	}
 
+	// Initialize project repository (default assignee lookup, membership checks)
+	projectRepository = &data.ProjectDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize org repository (project membership setting)
+	orgRepository = &data.OrgDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize user repository (assignee organization checks)
+	userRepository = &data.UserManagementDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize location repository (location validation on create)
+	locationRepository = &data.LocationDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize idempotency key store (Idempotency-Key on POST /rfis)
+	idempotencyStore = &data.IdempotencyDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
 	if rfiRepository == nil {
 		return fmt.Errorf("failed to initialize RFI repository: repository is nil")
 	}
 
+	logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL pool configured")
 	logger.WithField("operation", "setupPostgresSQLClient").Info("PostgreSQL client and RFI repository initialized successfully")
 
 	return nil
@@ -52,13 +52,14 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can manage roles", logger), nil
 	}
 
-	// Route based on HTTP method and path
-	pathSegments := strings.Split(strings.Trim(request.Path, "/"), "/")
+	// Route based on HTTP method and path; normalize method casing and stray slashes first
+	request = api.NormalizeRoute(request)
+	pathSegments := api.PathSegments(request.Path)
 	
 	// Handle different routes
 	switch request.HTTPMethod {
 	case http.MethodPost:
-		if len(pathSegments) >= 3 && pathSegments[2] == "permissions" {
+		if len(pathSegments) >= 3 && strings.EqualFold(pathSegments[2], "permissions") {
 			// POST /roles/{id}/permissions - Assign permission to role
 			roleID, err := strconv.ParseInt(pathSegments[1], 10, 64)
 			if err != nil {
@@ -96,7 +97,7 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		}
 		
 	case http.MethodDelete:
-		if len(pathSegments) >= 3 && pathSegments[2] == "permissions" {
+		if len(pathSegments) >= 3 && strings.EqualFold(pathSegments[2], "permissions") {
 			// DELETE /roles/{id}/permissions - Unassign permission from role
 			roleID, err := strconv.ParseInt(pathSegments[1], 10, 64)
 			if err != nil {
@@ -344,6 +345,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -369,7 +373,7 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 	}
 
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
@@ -14,6 +15,8 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -28,6 +31,11 @@ var (
 	ssmParams            map[string]string
 	sqlDB                *sql.DB
 	submittalRepository  data.SubmittalRepository
+	projectRepository    data.ProjectRepository
+	orgRepository        data.OrgRepository
+	userRepository       data.UserManagementRepository
+	snsClient            clients.SNSClientInterface
+	eventsTopic          string
 )
 
 // Handler processes API Gateway requests for Submittal management operations
@@ -87,6 +95,9 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	case request.Resource == "/submittals/{submittalId}" && request.HTTPMethod == "PUT":
 		return handleUpdateSubmittal(ctx, request, claims)
 
+	case request.Resource == "/submittals/{submittalId}/copy" && request.HTTPMethod == "POST":
+		return handleCopySubmittal(ctx, request, claims)
+
 	// Context-based submittal queries
 	case request.Resource == "/contexts/{contextType}/{contextId}/submittals" && request.HTTPMethod == "GET":
 		return handleGetContextSubmittals(ctx, request, claims)
@@ -94,6 +105,12 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	// Workflow operations
 	case request.Resource == "/submittals/{submittalId}/workflow" && request.HTTPMethod == "POST":
 		return handleWorkflowAction(ctx, request, claims)
+	case request.Resource == "/submittals/{submittalId}/revisions" && request.HTTPMethod == "GET":
+		return handleGetSubmittalRevisions(ctx, request, claims)
+	case request.Resource == "/submittals/{submittalId}/distribution" && request.HTTPMethod == "PUT":
+		return handleReorderDistribution(ctx, request, claims)
+	case request.Resource == "/submittals/{submittalId}/distribution/{reviewerId}/skip" && request.HTTPMethod == "POST":
+		return handleSkipReviewer(ctx, request, claims)
 
 	// Statistics and export
 	case request.Resource == "/contexts/{contextType}/{contextId}/submittals/stats" && request.HTTPMethod == "GET":
@@ -126,6 +143,16 @@ func handleCreateSubmittal(ctx context.Context, request events.APIGatewayProxyRe
 		return api.ErrorResponse(http.StatusBadRequest, "Missing required fields: title and submittal_type are required", logger), nil
 	}
 
+	// Validate project exists and belongs to the user's organization
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, createReq.ProjectID, claims.OrgID); errMsg != "" {
+		logger.WithFields(logrus.Fields{
+			"project_id": createReq.ProjectID,
+			"org_id":     claims.OrgID,
+			"status":     statusCode,
+		}).Warn("Project validation failed for create submittal")
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
 	userID := claims.UserID
 	createdSubmittal, err := submittalRepository.CreateSubmittal(ctx, createReq.ProjectID, userID, claims.OrgID, &createReq)
 	if err != nil {
@@ -146,7 +173,7 @@ func handleGetSubmittal(ctx context.Context, request events.APIGatewayProxyReque
 
 	submittal, err := submittalRepository.GetSubmittal(ctx, submittalID)
 	if err != nil {
-		if err.Error() == "submittal not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
 		}
 		logger.WithError(err).Error("Failed to get submittal")
@@ -161,7 +188,7 @@ func handleGetSubmittal(ctx context.Context, request events.APIGatewayProxyReque
 		submittal.Attachments = attachments
 	}
 
-	return api.SuccessResponse(http.StatusOK, submittal, logger), nil
+	return api.SparseResponse(request, submittal, logger), nil
 }
 
 // handleUpdateSubmittal handles PUT /submittals/{submittalId}
@@ -181,9 +208,16 @@ func handleUpdateSubmittal(ctx context.Context, request events.APIGatewayProxyRe
 	userID := claims.UserID
 	updatedSubmittal, err := submittalRepository.UpdateSubmittal(ctx, submittalID, userID, claims.OrgID, &updateReq)
 	if err != nil {
-		if err.Error() == "submittal not found" {
+		if errors.Is(err, data.ErrNotFound) {
 			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
 		}
+		if errors.Is(err, data.ErrInvalid) {
+			return api.ErrorResponse(http.StatusBadRequest, "Comments are required when rejecting or requesting revision", logger), nil
+		}
+		var transitionErr *models.InvalidWorkflowTransitionError
+		if errors.As(err, &transitionErr) {
+			return api.ErrorResponse(http.StatusConflict, transitionErr.Error(), logger), nil
+		}
 		logger.WithError(err).Error("Failed to update submittal")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update submittal", logger), nil
 	}
@@ -192,6 +226,12 @@ func handleUpdateSubmittal(ctx context.Context, request events.APIGatewayProxyRe
 }
 
 
+// submittalListFilters are the list filters that accept comma-separated values (status=a,b)
+var submittalListFilters = map[string][]string{
+	"status":   models.SubmittalStatuses,
+	"priority": models.SubmittalPriorities,
+}
+
 // handleGetContextSubmittals handles GET /contexts/{contextType}/{contextId}/submittals
 func handleGetContextSubmittals(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	contextType := request.PathParameters["contextType"]
@@ -210,6 +250,9 @@ func handleGetContextSubmittals(ctx context.Context, request events.APIGatewayPr
 	if filters == nil {
 		filters = make(map[string]string)
 	}
+	if err := api.NormalizeMultiValueFilters(filters, submittalListFilters); err != nil {
+		return api.ErrorResponse(http.StatusBadRequest, err.Error(), logger), nil
+	}
 
 	submittals, err := submittalRepository.GetSubmittalsByProject(ctx, contextID, filters)
 	if err != nil {
@@ -241,7 +284,53 @@ func handleGetContextSubmittals(ctx context.Context, request events.APIGatewayPr
 		HasPrev:    page > 1,
 	}
 
-	return api.SuccessResponse(http.StatusOK, response, logger), nil
+	pagination := &api.PaginationMeta{
+		Page:        response.Page,
+		PageSize:    response.PageSize,
+		TotalCount:  response.TotalCount,
+		HasNext:     response.HasNext,
+		HasPrevious: response.HasPrev,
+	}
+
+	return api.ListResponse(request, response, submittals, pagination, logger), nil
+}
+
+// handleCopySubmittal handles POST /submittals/{submittalId}/copy
+func handleCopySubmittal(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	submittalID, err := strconv.ParseInt(request.PathParameters["submittalId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid submittal ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid submittal ID", logger), nil
+	}
+
+	var copyReq models.CopyToProjectRequest
+	if err := api.ParseJSONBody(request.Body, &copyReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for submittal copy")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	if statusCode, errCode, errMsg := api.ValidateProjectAccess(ctx, projectRepository, copyReq.TargetProjectID, claims.OrgID); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+	if statusCode, errCode, errMsg := api.ValidateProjectMembership(ctx, orgRepository, projectRepository, copyReq.TargetProjectID, claims.OrgID, claims.UserID, claims.IsSuperAdmin); errMsg != "" {
+		return api.ErrorResponseWithCode(statusCode, errCode, errMsg, logger), nil
+	}
+
+	submittal, err := submittalRepository.CopySubmittal(ctx, submittalID, copyReq.TargetProjectID, claims.UserID, claims.OrgID, copyReq.CopyAttachments)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrTargetProjectNotFound):
+			return api.ErrorResponse(http.StatusNotFound, "Target project not found", logger), nil
+		case errors.Is(err, data.ErrNotFound):
+			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
+		case errors.Is(err, data.ErrOrgMismatch):
+			return api.ErrorResponse(http.StatusForbidden, "Target project does not belong to your organization", logger), nil
+		}
+		logger.WithError(err).Error("Failed to copy submittal")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to copy submittal", logger), nil
+	}
+
+	return api.SuccessResponse(http.StatusCreated, submittal, logger), nil
 }
 
 // handleWorkflowAction handles POST /submittals/{submittalId}/workflow
@@ -280,16 +369,218 @@ func handleWorkflowAction(ctx context.Context, request events.APIGatewayProxyReq
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid workflow action", logger), nil
 	}
 
+	if models.WorkflowActionRequiresComment(action.Action) && (action.Comments == nil || strings.TrimSpace(*action.Comments) == "") {
+		return api.ErrorResponse(http.StatusBadRequest, "Comments are required when rejecting or requesting revision", logger), nil
+	}
+
 	userID := claims.UserID
 	updatedSubmittal, err := submittalRepository.ExecuteWorkflowAction(ctx, submittalID, userID, &action)
 	if err != nil {
+		if errors.Is(err, data.ErrInvalid) {
+			return api.ErrorResponse(http.StatusBadRequest, "Comments are required when rejecting or requesting revision", logger), nil
+		}
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
+		}
+		var transitionErr *models.InvalidWorkflowTransitionError
+		if errors.As(err, &transitionErr) {
+			return api.ErrorResponse(http.StatusConflict, transitionErr.Error(), logger), nil
+		}
 		logger.WithError(err).Error("Failed to execute workflow action")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to execute workflow action", logger), nil
 	}
 
+	// Submitting, or an approval that routed the submittal on to the next reviewer, puts the ball in a reviewer's court
+	routedToReviewer := action.Action == models.WorkflowActionSubmitForReview ||
+		updatedSubmittal.WorkflowStatus == models.SubmittalStatusUnderReview
+	if routedToReviewer && updatedSubmittal.Reviewer != nil {
+		publishSubmittalReviewRequested(ctx, &updatedSubmittal.Submittal, claims.OrgID, *updatedSubmittal.Reviewer, userID)
+	}
+
 	return api.SuccessResponse(http.StatusOK, updatedSubmittal, logger), nil
 }
 
+// handleGetSubmittalRevisions handles GET /submittals/{submittalId}/revisions
+func handleGetSubmittalRevisions(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	submittalID, err := strconv.ParseInt(request.PathParameters["submittalId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid submittal ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid submittal ID", logger), nil
+	}
+
+	revisions, err := submittalRepository.GetSubmittalRevisions(ctx, submittalID, claims.OrgID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
+		}
+		logger.WithError(err).Error("Failed to get submittal revisions")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get submittal revisions", logger), nil
+	}
+
+	return api.ListResponse(request, revisions, revisions, api.SinglePageMeta(len(revisions)), logger), nil
+}
+
+// handleReorderDistribution handles PUT /submittals/{submittalId}/distribution
+func handleReorderDistribution(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	submittalID, err := strconv.ParseInt(request.PathParameters["submittalId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid submittal ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid submittal ID", logger), nil
+	}
+
+	var distributionReq models.SubmittalDistributionRequest
+	if err := api.ParseJSONBody(request.Body, &distributionReq); err != nil {
+		logger.WithError(err).Error("Invalid request body for submittal distribution")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+	}
+
+	submittal, statusCode, errMsg := validateDistributionManager(ctx, submittalID, claims)
+	if errMsg != "" {
+		return api.ErrorResponse(statusCode, errMsg, logger), nil
+	}
+
+	distribution, err := submittalRepository.ReorderSubmittalDistribution(ctx, submittalID, claims.UserID, claims.OrgID, distributionReq.ReviewerIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrNotFound):
+			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
+		case errors.Is(err, data.ErrInvalid), errors.Is(err, data.ErrOrgMismatch), errors.Is(err, data.ErrConflict):
+			return api.ErrorResponse(http.StatusBadRequest, err.Error(), logger), nil
+		}
+		logger.WithError(err).Error("Failed to reorder submittal distribution")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to reorder submittal distribution", logger), nil
+	}
+
+	publishReviewerChange(ctx, &submittal.Submittal, distribution, claims)
+
+	return api.SuccessResponse(http.StatusOK, distribution, logger), nil
+}
+
+// handleSkipReviewer handles POST /submittals/{submittalId}/distribution/{reviewerId}/skip
+func handleSkipReviewer(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
+	submittalID, err := strconv.ParseInt(request.PathParameters["submittalId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid submittal ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid submittal ID", logger), nil
+	}
+
+	reviewerID, err := strconv.ParseInt(request.PathParameters["reviewerId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid reviewer ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid reviewer ID", logger), nil
+	}
+
+	var skipReq models.SkipSubmittalReviewerRequest
+	if strings.TrimSpace(request.Body) != "" {
+		if err := api.ParseJSONBody(request.Body, &skipReq); err != nil {
+			logger.WithError(err).Error("Invalid request body for reviewer skip")
+			return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger), nil
+		}
+	}
+
+	submittal, statusCode, errMsg := validateDistributionManager(ctx, submittalID, claims)
+	if errMsg != "" {
+		return api.ErrorResponse(statusCode, errMsg, logger), nil
+	}
+
+	distribution, err := submittalRepository.SkipSubmittalReviewer(ctx, submittalID, reviewerID, claims.UserID, skipReq.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrNotFound):
+			return api.ErrorResponse(http.StatusNotFound, "Submittal not found", logger), nil
+		case errors.Is(err, data.ErrConflict):
+			return api.ErrorResponse(http.StatusConflict, err.Error(), logger), nil
+		}
+		logger.WithError(err).Error("Failed to skip submittal reviewer")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to skip submittal reviewer", logger), nil
+	}
+
+	publishReviewerChange(ctx, &submittal.Submittal, distribution, claims)
+
+	return api.SuccessResponse(http.StatusOK, distribution, logger), nil
+}
+
+// publishReviewerChange requests a review from the distribution's current reviewer when a routing change
+// passed the ball in court to someone new
+func publishReviewerChange(ctx context.Context, submittal *models.Submittal, distribution *models.SubmittalDistributionResponse, claims *auth.Claims) {
+	if distribution.CurrentReviewer == nil {
+		return
+	}
+	if submittal.Reviewer != nil && *submittal.Reviewer == *distribution.CurrentReviewer {
+		return
+	}
+	publishSubmittalReviewRequested(ctx, submittal, claims.OrgID, *distribution.CurrentReviewer, claims.UserID)
+}
+
+// publishSubmittalReviewRequested sends a submittal.review_requested event to the events topic when the reviewer
+// is not the actor and has SNS on for submittal_review_requested. Publishing is best-effort: failures are logged
+// and never fail the API call.
+func publishSubmittalReviewRequested(ctx context.Context, submittal *models.Submittal, orgID, reviewerID, actorUserID int64) {
+	if snsClient == nil || eventsTopic == "" || reviewerID == actorUserID {
+		return
+	}
+
+	log := logger.WithFields(logrus.Fields{
+		"submittal_id": submittal.ID,
+		"reviewer_id":  reviewerID,
+	})
+	recipientIDs, err := userRepository.GetSNSRecipientIDs(ctx, orgID, []int64{reviewerID}, models.NotificationEventSubmittalReviewRequested)
+	if err != nil {
+		log.WithError(err).Warn("Failed to check notification preferences")
+		return
+	}
+	if len(recipientIDs) == 0 {
+		return
+	}
+
+	event := models.SubmittalReviewRequestedEvent{
+		EventType:       models.SubmittalEventReviewRequested,
+		SubmittalID:     submittal.ID,
+		SubmittalNumber: submittal.SubmittalNumber,
+		ProjectID:       submittal.ProjectID,
+		OrgID:           orgID,
+		ReviewerID:      reviewerID,
+		ActorUserID:     actorUserID,
+		Timestamp:       time.Now().UTC(),
+	}
+	err = clients.PublishEvent(snsClient, eventsTopic, event, map[string]string{
+		"event_type": event.EventType,
+	})
+	if err != nil {
+		log.WithError(err).Warn("Failed to publish submittal review requested event")
+	}
+}
+
+// validateDistributionManager checks that the submittal is in the caller's organization and that the caller
+// is a super admin or holds a management/admin role on the submittal's project.
+// Returns (submittal, statusCode, errorMessage); errorMessage is empty when the caller may change the routing.
+func validateDistributionManager(ctx context.Context, submittalID int64, claims *auth.Claims) (*models.SubmittalResponse, int, string) {
+	submittal, err := submittalRepository.GetSubmittal(ctx, submittalID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return nil, http.StatusNotFound, "Submittal not found"
+		}
+		logger.WithError(err).Error("Failed to get submittal")
+		return nil, http.StatusInternalServerError, "Failed to get submittal"
+	}
+	if submittal.OrgID == nil || *submittal.OrgID != claims.OrgID {
+		return nil, http.StatusNotFound, "Submittal not found"
+	}
+
+	if claims.IsSuperAdmin {
+		return submittal, 0, ""
+	}
+
+	isManager, err := projectRepository.HasManagementRole(ctx, submittal.ProjectID, claims.UserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, "Failed to verify project role"
+	}
+	if !isManager {
+		return nil, http.StatusForbidden, "Forbidden: Only the project manager or an admin can change the review routing"
+	}
+	return submittal, 0, ""
+}
+
 // handleGetSubmittalStats handles GET /contexts/{contextType}/{contextId}/submittals/stats
 func handleGetSubmittalStats(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (events.APIGatewayProxyResponse, error) {
 	contextType := request.PathParameters["contextType"]
@@ -362,6 +653,14 @@ func init() {
 		}).Fatal("Error setting up PostgreSQL client")
 	}
 
+	// Review requests share the issue events topic; without it the Lambda runs without publishing
+	eventsTopic = ssmParams[constants.ISSUE_EVENTS_TOPIC_ARN]
+	if eventsTopic != "" {
+		snsClient = clients.NewSNSClient(isLocal)
+	} else {
+		logger.WithField("operation", "init").Warn("Events topic not configured; submittal review requests will not be published")
+	}
+
 	logger.Info("Submittal management service initialized successfully")
 }
 
@@ -384,6 +683,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -403,8 +705,26 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 		Logger: logger,
 	}
 
+	// Initialize project repository (project access validation)
+	projectRepository = &data.ProjectDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize org repository (project-membership policy for copies)
+	orgRepository = &data.OrgDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
+	// Initialize user repository (notification preferences)
+	userRepository = &data.UserManagementDao{
+		DB:     sqlDB,
+		Logger: logger,
+	}
+
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 
 	return nil
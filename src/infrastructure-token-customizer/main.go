@@ -51,6 +51,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -172,6 +173,11 @@ func Handler(ctx context.Context, event events.CognitoEventUserPoolsPreTokenGenV
 		return event, errors.New("username cannot be empty")
 	}
 
+	// Record the sign-in before building the token; the write is short and bounded so it cannot stall sign-in
+	if isCredentialSignIn(event.TriggerSource) {
+		recordLastLogin(ctx, cognitoID)
+	}
+
 	// Fetch complete user profile from IAM database
 	// This single query retrieves all user data, organization, locations, and roles
 	userProfile, err := userRepository.GetUserProfile(cognitoID)
@@ -364,6 +370,31 @@ func isValidTriggerSourceV2(triggerSource string) bool {
 	return false // Unsupported or V1.0 trigger
 }
 
+// lastLoginUpdateTimeout bounds the last_login_at write; Cognito allows the whole trigger only 5 seconds
+const lastLoginUpdateTimeout = 1 * time.Second
+
+// isCredentialSignIn reports whether the trigger is a real sign-in. Refresh-token grants, device
+// authentication and password challenges are excluded so they do not count as logins.
+func isCredentialSignIn(triggerSource string) bool {
+	return triggerSource == "TokenGeneration_Authentication" || triggerSource == "TokenGeneration_HostedAuth"
+}
+
+// recordLastLogin updates last_login_at on a best-effort basis. It runs synchronously, because Lambda
+// freezes the sandbox once the handler returns, and is bounded by lastLoginUpdateTimeout; failures are
+// logged and otherwise ignored.
+func recordLastLogin(ctx context.Context, cognitoID string) {
+	ctx, cancel := context.WithTimeout(ctx, lastLoginUpdateTimeout)
+	defer cancel()
+
+	if err := userMgmtRepo.RecordLastLogin(ctx, cognitoID, time.Now()); err != nil {
+		logger.WithFields(logrus.Fields{
+			"cognito_id": cognitoID,
+			"operation":  "recordLastLogin",
+			"error":      err.Error(),
+		}).Warn("Failed to record last login, token generation unaffected")
+	}
+}
+
 // Note: extractAllRoles function removed - roles are no longer included in JWT tokens.
 // Roles are now fetched per-project when needed, keeping JWT tokens smaller and more focused.
 // Only accessible locations are included in the JWT for better architecture.
@@ -526,6 +557,9 @@ func buildCustomClaims(profile *models.UserProfile) (*CustomClaims, error) {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	// All connection details are fetched from SSM Parameter Store for security
 	sqlDB, err = clients.NewPostgresSQLClient(
@@ -559,7 +593,7 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 	}
 
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
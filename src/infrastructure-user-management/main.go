@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"infrastructure/lib/api"
 	"infrastructure/lib/auth"
 	"infrastructure/lib/clients"
@@ -14,7 +18,11 @@ import (
 	"infrastructure/lib/util"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -51,8 +59,9 @@ func LambdaHandler(ctx context.Context, request events.APIGatewayProxyRequest) (
 	}
 
 	// Check authorization based on the endpoint being accessed
-	// Allow any user to update their own selected location, otherwise require super admin
-	if request.Resource != "/users/{userId}/location" && request.Resource != "/user/selected-location/{locationId}" && !claims.IsSuperAdmin {
+	// Allow any user to update their own selected location or read their own projects and notification
+	// preferences, otherwise require super admin
+	if request.Resource != "/users/{userId}/location" && request.Resource != "/user/selected-location/{locationId}" && !isOwnUserResourceRequest(request, claims) && !claims.IsSuperAdmin {
 		logger.WithField("user_id", claims.UserID).Warn("User is not a super admin")
 		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can manage users", logger), nil
 	}
@@ -60,8 +69,18 @@ func LambdaHandler(ctx context.Context, request events.APIGatewayProxyRequest) (
 	// Route based on HTTP method
 	switch request.HTTPMethod {
 	case http.MethodPost:
+		if request.Resource == "/users/import" {
+			return handleImportUsers(ctx, request, claims), nil
+		}
 		return handleCreateUser(ctx, request, claims), nil
 	case http.MethodGet:
+		// Handle user project listing via GET /users/{userId}/projects
+		if request.PathParameters["userId"] != "" && request.Resource == "/users/{userId}/projects" {
+			return handleGetUserProjects(ctx, request, claims), nil
+		}
+		if request.Resource == "/users/{userId}/notification-prefs" {
+			return handleGetNotificationPrefs(ctx, request, claims), nil
+		}
 		if userID := request.PathParameters["userId"]; userID != "" {
 			return handleGetUser(ctx, request, claims), nil
 		}
@@ -71,6 +90,9 @@ func LambdaHandler(ctx context.Context, request events.APIGatewayProxyRequest) (
 		if request.PathParameters["userId"] != "" && request.PathParameters["locationId"] != "" && request.Resource == "/users/{userId}/selected-location/{locationId}" {
 			return handleUserSelectedLocationUpdate(ctx, request, claims), nil
 		}
+		if request.Resource == "/users/{userId}/notification-prefs" {
+			return handleUpdateNotificationPrefs(ctx, request, claims), nil
+		}
 		return handleUpdateUser(ctx, request, claims), nil
 	case http.MethodDelete:
 		return handleDeleteUser(ctx, request, claims), nil
@@ -97,16 +119,299 @@ func handleCreateUser(ctx context.Context, request events.APIGatewayProxyRequest
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
 	}
 
+	if createRequest.BypassDomainCheck && !claims.IsSuperAdmin {
+		return api.ErrorResponse(http.StatusForbidden, "Forbidden: Only super admins can bypass the email domain allow-list", logger)
+	}
+
+	// Reject duplicates here; Cognito would otherwise fail later with an opaque UsernameExistsException
+	createRequest.Email = models.NormalizeEmail(createRequest.Email)
+	if msg := validateContactFields(createRequest.Email, createRequest.Phone, createRequest.Mobile, true); msg != "" {
+		return api.ErrorResponse(http.StatusBadRequest, msg, logger)
+	}
+	exists, err := userRepository.EmailExists(ctx, claims.OrgID, createRequest.Email)
+	if err != nil {
+		logger.WithError(err).Error("Failed to check email for create user")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create user", logger)
+	}
+	if exists {
+		return api.ErrorResponseWithCode(http.StatusConflict, api.ErrorCodeEmailInUse, "email already in use", logger)
+	}
+
 	// Create user with Cognito integration
 	response, err := userRepository.CreateNormalUser(ctx, claims.OrgID, &createRequest, claims.UserID)
 	if err != nil {
+		if errors.Is(err, data.ErrEmailDomainNotAllowed) {
+			return api.ErrorResponse(http.StatusBadRequest, "Email domain is not allowed for this organization", logger)
+		}
+		if errors.Is(err, data.ErrDatabaseUnavailable) {
+			logger.WithError(err).Error("Database unavailable while creating user")
+			return api.ErrorResponse(http.StatusServiceUnavailable, "Database is temporarily unavailable, please try again", logger)
+		}
+		if errors.Is(err, clients.ErrCognitoUnavailable) {
+			logger.WithError(err).Error("Cognito unavailable while creating user")
+			return api.ErrorResponse(http.StatusServiceUnavailable, "User directory is temporarily unavailable, please try again", logger)
+		}
 		logger.WithError(err).Error("Failed to create user")
+		switch {
+		case errors.Is(err, data.ErrCognitoCreateFailed):
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to create user in the user directory; no user record was saved", logger)
+		case errors.Is(err, data.ErrCognitoUserNotRemoved):
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to save user record and the user directory account could not be removed; contact support", logger)
+		case errors.Is(err, data.ErrCognitoUserDisabled):
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to save user record; the user directory account was disabled but could not be removed; contact support", logger)
+		case errors.Is(err, data.ErrUserRecordUnconfirmed):
+			return api.ErrorResponse(http.StatusInternalServerError, "Could not confirm the user record was saved; check the user list before retrying", logger)
+		case errors.Is(err, data.ErrUserRecordFailed):
+			return api.ErrorResponse(http.StatusInternalServerError, "Failed to save user record; the user directory account was rolled back", logger)
+		}
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to create user", logger)
 	}
 
 	return api.SuccessResponse(http.StatusCreated, response, logger)
 }
 
+// userImportRowReserve is the time left before the Lambda deadline below which an import stops starting
+// new batches: enough for one batch whose Cognito calls need their retries
+const userImportRowReserve = 8 * time.Second
+
+// userImportBatchSize is how many rows an import creates concurrently. Each row takes about half a second,
+// so batches of 10 import a few hundred users within the Lambda timeout while staying well under
+// Cognito's AdminCreateUser request quota.
+const userImportBatchSize = 10
+
+// handleImportUsers handles POST /users/import. The body is a CSV file, base64-encoded (API Gateway
+// does this for text/csv uploads), with a header row naming the models.UserImportColumns in any order.
+// Each row goes through CreateNormalUser independently, so one bad row does not stop the rest; rows are
+// imported in concurrent batches of userImportBatchSize. Rows that cannot be started before the Lambda
+// deadline are reported as errors so the summary is always returned; re-sending the file imports them,
+// since rows already imported are skipped.
+func handleImportUsers(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(request.Body))
+	if err != nil {
+		return api.ErrorResponse(http.StatusBadRequest, "Request body must be a base64-encoded CSV file", logger)
+	}
+
+	rows, err := parseUserImportCSV(content)
+	if err != nil {
+		return api.ErrorResponse(http.StatusBadRequest, err.Error(), logger)
+	}
+
+	// Validate every row and drop in-file duplicates up front; emails already in the org (or earlier in
+	// the file) are skipped rather than failed so a partly imported file can be re-sent
+	outcomes := make([]userImportOutcome, len(rows))
+	seenEmails := make(map[string]bool, len(rows))
+	var pending []int
+	for i := range rows {
+		createRequest := &rows[i].request
+		createRequest.Email = models.NormalizeEmail(createRequest.Email)
+
+		if reason := validateUserImportRow(createRequest); reason != "" {
+			outcomes[i].reason = reason
+			continue
+		}
+		if seenEmails[createRequest.Email] {
+			outcomes[i].skipped = true
+			continue
+		}
+		seenEmails[createRequest.Email] = true
+		pending = append(pending, i)
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	for start := 0; start < len(pending); start += userImportBatchSize {
+		if hasDeadline && time.Until(deadline) < userImportRowReserve {
+			for _, i := range pending[start:] {
+				outcomes[i].reason = "Not imported: time limit reached, re-send the file to import the remaining rows"
+			}
+			logger.WithField("remaining_rows", len(pending)-start).Warn("User import stopped before the Lambda deadline")
+			break
+		}
+
+		var wg sync.WaitGroup
+		for _, i := range pending[start:min(start+userImportBatchSize, len(pending))] {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				outcomes[i] = importUserRow(ctx, rows[i], claims)
+			}()
+		}
+		wg.Wait()
+	}
+
+	result := models.UserImportResult{Errors: []models.UserImportRowError{}}
+	for i, row := range rows {
+		switch {
+		case outcomes[i].created:
+			result.Created++
+		case outcomes[i].skipped:
+			result.Skipped++
+		default:
+			result.Errors = append(result.Errors, models.UserImportRowError{Row: row.line, Email: row.request.Email, Reason: outcomes[i].reason})
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"org_id":  claims.OrgID,
+		"created": result.Created,
+		"skipped": result.Skipped,
+		"failed":  len(result.Errors),
+	}).Info("User import completed")
+
+	return api.SuccessResponse(http.StatusOK, result, logger)
+}
+
+// userImportOutcome is what happened to one CSV row; reason is set when the row was not imported
+type userImportOutcome struct {
+	created bool
+	skipped bool
+	reason  string
+}
+
+// importUserRow creates the user for one validated row, skipping it when the email is already in the org
+func importUserRow(ctx context.Context, row userImportRow, claims *auth.Claims) userImportOutcome {
+	createRequest := row.request
+
+	exists, err := userRepository.EmailExists(ctx, claims.OrgID, createRequest.Email)
+	if err != nil {
+		logger.WithError(err).WithField("row", row.line).Error("Failed to check email for import row")
+		return userImportOutcome{reason: "Failed to create user"}
+	}
+	if exists {
+		return userImportOutcome{skipped: true}
+	}
+
+	if _, err := userRepository.CreateNormalUser(ctx, claims.OrgID, &createRequest, claims.UserID); err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"row":   row.line,
+			"email": createRequest.Email,
+		}).Warn("Failed to import user row")
+		return userImportOutcome{reason: userImportFailureReason(err)}
+	}
+	return userImportOutcome{created: true}
+}
+
+// userImportRow is one parsed CSV data row and its line number in the file
+type userImportRow struct {
+	line    int
+	request models.CreateUserRequest
+}
+
+// parseUserImportCSV reads the header to locate the known columns, then one CreateUserRequest per data row.
+// Unknown columns are ignored; blank rows are skipped.
+func parseUserImportCSV(content []byte) ([]userImportRow, error) {
+	// Spreadsheet exports often start with a UTF-8 byte order mark, which would corrupt the first header name
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(content), "\ufeff")))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"email", "first_name", "last_name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header must include %s", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []userImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		// Report the row's line in the file; the reader silently skips blank lines
+		line, _ := reader.FieldPos(0)
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		rows = append(rows, userImportRow{
+			line: line,
+			request: models.CreateUserRequest{
+				Email:     field(record, "email"),
+				FirstName: field(record, "first_name"),
+				LastName:  field(record, "last_name"),
+				Phone:     field(record, "phone"),
+				JobTitle:  field(record, "job_title"),
+			},
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("CSV file has no user rows")
+	}
+	return rows, nil
+}
+
+// validateUserImportRow returns why a row cannot be imported, or "" when it is valid
+func validateUserImportRow(request *models.CreateUserRequest) string {
+	if msg := validateContactFields(request.Email, request.Phone, request.Mobile, true); msg != "" {
+		return msg
+	}
+	switch {
+	case request.FirstName == "":
+		return "first_name is required"
+	case request.LastName == "":
+		return "last_name is required"
+	}
+	return ""
+}
+
+// validateContactFields returns a "<field> <problem>" message for the first invalid email, phone
+// or mobile, or "" when all are valid. An empty email only passes when it is not required.
+func validateContactFields(email, phone, mobile string, emailRequired bool) string {
+	if email != "" || emailRequired {
+		if err := util.ValidateEmail(email); err != nil {
+			return "email " + err.Error()
+		}
+	}
+	if err := util.ValidatePhone(phone); err != nil {
+		return "phone " + err.Error()
+	}
+	if err := util.ValidatePhone(mobile); err != nil {
+		return "mobile " + err.Error()
+	}
+	return ""
+}
+
+// userImportFailureReason maps a CreateNormalUser error to the reason reported for the row
+func userImportFailureReason(err error) string {
+	switch {
+	case errors.Is(err, data.ErrEmailDomainNotAllowed):
+		return "Email domain is not allowed for this organization"
+	case errors.Is(err, data.ErrDatabaseUnavailable):
+		return "Database is temporarily unavailable"
+	case errors.Is(err, clients.ErrCognitoUnavailable):
+		return "User directory is temporarily unavailable"
+	case errors.Is(err, data.ErrCognitoUserExists):
+		return "A user with this email already exists in another organization"
+	case errors.Is(err, data.ErrCognitoCreateFailed):
+		return "Failed to create user in the user directory"
+	case errors.Is(err, data.ErrUserRecordUnconfirmed):
+		return "Could not confirm the user was saved; check the user list before re-sending"
+	}
+	return "Failed to create user"
+}
+
 // handleGetUsers handles GET /users
 func handleGetUsers(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
 	users, err := userRepository.GetUsersByOrg(ctx, claims.OrgID)
@@ -120,7 +425,7 @@ func handleGetUsers(ctx context.Context, request events.APIGatewayProxyRequest,
 		Total: len(users),
 	}
 
-	return api.SuccessResponse(http.StatusOK, response, logger)
+	return api.ListResponse(request, response, users, api.SinglePageMeta(len(users)), logger)
 }
 
 // handleGetUser handles GET /users/{userId}
@@ -140,6 +445,135 @@ func handleGetUser(ctx context.Context, request events.APIGatewayProxyRequest, c
 	return api.SuccessResponse(http.StatusOK, user, logger)
 }
 
+// handleGetUserProjects handles GET /users/{userId}/projects?page=&page_size=
+func handleGetUserProjects(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
+	userID, err := strconv.ParseInt(request.PathParameters["userId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid user ID")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid user ID", logger)
+	}
+
+	// Confirm the user belongs to the caller's organization
+	if _, err := userRepository.GetUserByID(ctx, userID, claims.OrgID); err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return api.ErrorResponse(http.StatusNotFound, "User not found", logger)
+		}
+		logger.WithError(err).Error("Failed to get user")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get user", logger)
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(request.QueryStringParameters["page"]); err == nil && p > 0 {
+		page = p
+	}
+	pageSize := 50
+	if ps, err := strconv.Atoi(request.QueryStringParameters["page_size"]); err == nil && ps > 0 && ps <= 100 {
+		pageSize = ps
+	}
+
+	projects, totalCount, err := userRepository.GetUserProjects(ctx, userID, claims.OrgID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get user projects")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get user projects", logger)
+	}
+
+	response := models.UserProjectListResponse{
+		UserID:     userID,
+		Projects:   projects,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		HasNext:    page*pageSize < totalCount,
+	}
+
+	return api.ListResponse(request, response, projects, api.NewPaginationMeta(page, pageSize, totalCount), logger)
+}
+
+// isOwnUserResourceRequest reports whether the caller is listing their own project assignments or
+// reading or changing their own notification preferences
+func isOwnUserResourceRequest(request events.APIGatewayProxyRequest, claims *auth.Claims) bool {
+	switch {
+	case request.Resource == "/users/{userId}/projects" && request.HTTPMethod == http.MethodGet:
+	case request.Resource == "/users/{userId}/notification-prefs" && (request.HTTPMethod == http.MethodGet || request.HTTPMethod == http.MethodPut):
+	default:
+		return false
+	}
+	userID, err := strconv.ParseInt(request.PathParameters["userId"], 10, 64)
+	return err == nil && userID == claims.UserID
+}
+
+// notificationPrefsUserID parses the path user ID and confirms the user belongs to the caller's
+// organization. On failure it returns the status code and message to respond with.
+func notificationPrefsUserID(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) (int64, int, string) {
+	userID, err := strconv.ParseInt(request.PathParameters["userId"], 10, 64)
+	if err != nil {
+		logger.WithError(err).Error("Invalid user ID")
+		return 0, http.StatusBadRequest, "Invalid user ID"
+	}
+
+	belongs, err := userRepository.UserBelongsToOrg(ctx, userID, claims.OrgID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to check user organization")
+		return 0, http.StatusInternalServerError, "Failed to get notification preferences"
+	}
+	if !belongs {
+		return 0, http.StatusNotFound, "User not found"
+	}
+	return userID, 0, ""
+}
+
+// handleGetNotificationPrefs handles GET /users/{userId}/notification-prefs
+func handleGetNotificationPrefs(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
+	userID, statusCode, errMsg := notificationPrefsUserID(ctx, request, claims)
+	if errMsg != "" {
+		return api.ErrorResponse(statusCode, errMsg, logger)
+	}
+
+	prefs, err := userRepository.GetNotificationPrefs(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get notification preferences")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to get notification preferences", logger)
+	}
+
+	return api.SuccessResponse(http.StatusOK, models.NotificationPrefsResponse{UserID: userID, Preferences: prefs}, logger)
+}
+
+// handleUpdateNotificationPrefs handles PUT /users/{userId}/notification-prefs. Only the listed event
+// types change; each returns its full resulting settings.
+func handleUpdateNotificationPrefs(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
+	userID, statusCode, errMsg := notificationPrefsUserID(ctx, request, claims)
+	if errMsg != "" {
+		return api.ErrorResponse(statusCode, errMsg, logger)
+	}
+
+	var updateRequest models.UpdateNotificationPrefsRequest
+	if err := json.Unmarshal([]byte(request.Body), &updateRequest); err != nil {
+		logger.WithError(err).Error("Invalid request body for update notification preferences")
+		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
+	}
+	if len(updateRequest.Preferences) == 0 {
+		return api.ErrorResponse(http.StatusBadRequest, "preferences is required", logger)
+	}
+	seen := make(map[string]bool, len(updateRequest.Preferences))
+	for _, update := range updateRequest.Preferences {
+		if !slices.Contains(models.NotificationEventTypes, update.EventType) {
+			return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("event_type must be one of: %s", strings.Join(models.NotificationEventTypes, ", ")), logger)
+		}
+		if seen[update.EventType] {
+			return api.ErrorResponse(http.StatusBadRequest, fmt.Sprintf("event_type %s is listed more than once", update.EventType), logger)
+		}
+		seen[update.EventType] = true
+	}
+
+	prefs, err := userRepository.UpdateNotificationPrefs(ctx, userID, updateRequest.Preferences, claims.UserID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to update notification preferences")
+		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update notification preferences", logger)
+	}
+
+	return api.SuccessResponse(http.StatusOK, models.NotificationPrefsResponse{UserID: userID, Preferences: prefs}, logger)
+}
+
 // handleUpdateUser handles PUT /users/{userId}
 func handleUpdateUser(ctx context.Context, request events.APIGatewayProxyRequest, claims *auth.Claims) events.APIGatewayProxyResponse {
 	userID, err := strconv.ParseInt(request.PathParameters["userId"], 10, 64)
@@ -154,6 +588,11 @@ func handleUpdateUser(ctx context.Context, request events.APIGatewayProxyRequest
 		return api.ErrorResponse(http.StatusBadRequest, "Invalid request body", logger)
 	}
 
+	updateRequest.Email = models.NormalizeEmail(updateRequest.Email)
+	if msg := validateContactFields(updateRequest.Email, updateRequest.Phone, updateRequest.Mobile, false); msg != "" {
+		return api.ErrorResponse(http.StatusBadRequest, msg, logger)
+	}
+
 	// Convert to User model for repository
 	user := &models.User{
 		Email:             updateRequest.Email,
@@ -170,6 +609,10 @@ func handleUpdateUser(ctx context.Context, request events.APIGatewayProxyRequest
 
 	updatedUser, err := userRepository.UpdateUser(ctx, userID, claims.OrgID, user, claims.UserID)
 	if err != nil {
+		if errors.Is(err, clients.ErrCognitoUnavailable) {
+			logger.WithError(err).Error("Cognito unavailable while updating user")
+			return api.ErrorResponse(http.StatusServiceUnavailable, "User directory is temporarily unavailable, please try again", logger)
+		}
 		logger.WithError(err).Error("Failed to update user")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to update user", logger)
 	}
@@ -212,6 +655,10 @@ func handlePasswordReset(ctx context.Context, request events.APIGatewayProxyRequ
 	// Send password reset email
 	err = userRepository.SendPasswordResetEmail(ctx, user.Email)
 	if err != nil {
+		if errors.Is(err, clients.ErrCognitoUnavailable) {
+			logger.WithError(err).Error("Cognito unavailable while resetting password")
+			return api.ErrorResponse(http.StatusServiceUnavailable, "User directory is temporarily unavailable, please try again", logger)
+		}
 		logger.WithError(err).Error("Failed to send password reset email")
 		return api.ErrorResponse(http.StatusInternalServerError, "Failed to send password reset email", logger)
 	}
@@ -380,11 +827,25 @@ func init() {
 		CognitoClient: cognitoClient,
 		UserPoolID:    userPoolID,
 		ClientID:      clientID,
+		RetryConfig:   loadCognitoRetryConfig(),
 	}
 
 	logger.WithField("operation", "init").Info("User Management Lambda initialization completed successfully")
 }
 
+// loadCognitoRetryConfig reads Cognito retry settings from the environment, falling back to defaults.
+// COGNITO_MAX_ATTEMPTS sets total attempts and COGNITO_CALL_TIMEOUT_MS the per-attempt timeout.
+func loadCognitoRetryConfig() clients.CognitoRetryConfig {
+	cfg := clients.DefaultCognitoRetryConfig()
+	if attempts, err := strconv.Atoi(os.Getenv("COGNITO_MAX_ATTEMPTS")); err == nil && attempts > 0 {
+		cfg.MaxAttempts = attempts
+	}
+	if timeoutMs, err := strconv.Atoi(os.Getenv("COGNITO_CALL_TIMEOUT_MS")); err == nil && timeoutMs > 0 {
+		cfg.CallTimeout = time.Duration(timeoutMs) * time.Millisecond
+	}
+	return cfg
+}
+
 func parseIsLocal() bool {
 	isLocal, _ := strconv.ParseBool(os.Getenv("IS_LOCAL"))
 	return isLocal
@@ -400,6 +861,9 @@ func setupLogger(isLocal bool) *logrus.Logger {
 func setupPostgresSQLClient(ssmParams map[string]string) error {
 	var err error
 
+	clients.ConfigurePostgresRetry(ssmParams[constants.DATABASE_MAX_RETRIES])
+	poolConfig := clients.ConfigurePostgresPool(ssmParams)
+
 	// Create PostgreSQL client using RDS connection parameters from SSM
 	sqlDB, err = clients.NewPostgresSQLClient(
 		ssmParams[constants.DATABASE_RDS_ENDPOINT],
@@ -414,7 +878,7 @@ func setupPostgresSQLClient(ssmParams map[string]string) error {
 	}
 
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
-		logger.WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
+		logger.WithFields(poolConfig.LogFields()).WithField("operation", "setupPostgresSQLClient").Debug("PostgreSQL client initialized successfully")
 	}
 	return nil
 }
\ No newline at end of file
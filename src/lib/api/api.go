@@ -1,8 +1,8 @@
 package api
 
 import (
+	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -29,10 +29,17 @@ func SuccessResponse(statusCode int, data interface{}, logger *logrus.Logger) ev
 	}
 }
 
-// ErrorResponse creates an error API Gateway response
+// ErrorResponse creates an error API Gateway response with the generic code for statusCode
 func ErrorResponse(statusCode int, message string, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	return ErrorResponseWithCode(statusCode, DefaultErrorCode(statusCode), message, logger)
+}
+
+// ErrorResponseWithCode creates an error API Gateway response carrying a machine-readable code
+// (one of the ErrorCode constants) alongside the human-readable message
+func ErrorResponseWithCode(statusCode int, code, message string, logger *logrus.Logger) events.APIGatewayProxyResponse {
 	errorData := map[string]interface{}{
 		"error":   true,
+		"code":    code,
 		"message": message,
 		"status":  statusCode,
 	}
@@ -40,7 +47,7 @@ func ErrorResponse(statusCode int, message string, logger *logrus.Logger) events
 	body, err := json.Marshal(errorData)
 	if err != nil {
 		logger.WithError(err).Error("Failed to marshal error response")
-		body = []byte(`{"error":true,"message":"Internal server error","status":500}`)
+		body = []byte(`{"error":true,"code":"INTERNAL_ERROR","message":"Internal server error","status":500}`)
 	}
 
 	return events.APIGatewayProxyResponse{
@@ -55,10 +62,28 @@ func ErrorResponse(statusCode int, message string, logger *logrus.Logger) events
 	}
 }
 
+// FileResponse creates a base64-encoded API Gateway response for a file download
+func FileResponse(statusCode int, content []byte, contentType, fileName string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode:      statusCode,
+		Body:            base64.StdEncoding.EncodeToString(content),
+		IsBase64Encoded: true,
+		Headers: map[string]string{
+			"Content-Type":                  contentType,
+			"Content-Disposition":           ContentDisposition(fileName),
+			"Access-Control-Allow-Origin":   "*",
+			"Access-Control-Allow-Headers":  "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token",
+			"Access-Control-Allow-Methods":  "GET,POST,PUT,DELETE,OPTIONS",
+			"Access-Control-Expose-Headers": "Content-Disposition",
+		},
+	}
+}
+
 // ValidationErrorResponse creates a validation error response
 func ValidationErrorResponse(message string, errors []string, logger *logrus.Logger) events.APIGatewayProxyResponse {
 	errorData := map[string]interface{}{
 		"error":      true,
+		"code":       ErrorCodeValidationFailed,
 		"message":    message,
 		"status":     http.StatusBadRequest,
 		"validation": errors,
@@ -82,15 +107,34 @@ func ValidationErrorResponse(message string, errors []string, logger *logrus.Log
 	}
 }
 
-// ParseJSONBody parses JSON request body into a struct
-func ParseJSONBody(body string, target interface{}) error {
-	if body == "" {
-		return fmt.Errorf("empty request body")
+// ConflictResponse creates a 409 error response carrying extra fields (e.g. the current server version)
+// so clients can tell the user what changed and offer to reload. A "code" entry in details overrides
+// the generic CONFLICT code.
+func ConflictResponse(message string, details map[string]interface{}, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	errorData := map[string]interface{}{
+		"error":   true,
+		"code":    ErrorCodeConflict,
+		"message": message,
+		"status":  http.StatusConflict,
 	}
-	
-	if err := json.Unmarshal([]byte(body), target); err != nil {
-		return fmt.Errorf("invalid JSON: %w", err)
+	for key, value := range details {
+		errorData[key] = value
 	}
-	
-	return nil
-}
\ No newline at end of file
+
+	body, err := json.Marshal(errorData)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal conflict error response")
+		return ErrorResponse(http.StatusInternalServerError, "Internal server error", logger)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusConflict,
+		Body:       string(body),
+		Headers: map[string]string{
+			"Content-Type":                 "application/json",
+			"Access-Control-Allow-Origin":  "*",
+			"Access-Control-Allow-Headers": "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token",
+			"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,OPTIONS",
+		},
+	}
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxJSONBodyBytes is the default size limit ParseJSONBody applies to request bodies.
+// API Gateway allows up to 10MB, far more than any JSON payload this API accepts.
+var MaxJSONBodyBytes = 1 << 20
+
+// Sentinels matched (through errors.Is) by the *BodyError ParseJSONBody returns
+var (
+	ErrEmptyBody    = errors.New("empty request body")
+	ErrBodyTooLarge = errors.New("request body too large")
+	ErrInvalidJSON  = errors.New("invalid JSON")
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// BodyError reports a request body that could not be decoded. Handlers surface it as a 400.
+type BodyError struct {
+	Kind  error  // ErrEmptyBody, ErrBodyTooLarge, ErrInvalidJSON or ErrUnknownField
+	Err   error  // underlying decode error, if any
+	Field string // offending key when Kind is ErrUnknownField
+}
+
+func (e *BodyError) Error() string {
+	if e.Kind == ErrUnknownField {
+		return fmt.Sprintf("unknown field %q", e.Field)
+	}
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap exposes both the kind and the decode error, so errors.As still finds errors such as
+// *models.InvalidNumberError raised by a target's UnmarshalJSON
+func (e *BodyError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// ParseOption adjusts how ParseJSONBody decodes a single request body
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	maxBytes              int
+	disallowUnknownFields bool
+}
+
+// WithMaxBodyBytes overrides MaxJSONBodyBytes for one call
+func WithMaxBodyBytes(maxBytes int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxBytes = maxBytes
+	}
+}
+
+// WithDisallowUnknownFields rejects object keys that do not match a field of the target.
+// Top-level keys are also checked against the target's fields directly, because a target with its
+// own UnmarshalJSON (such as models.CreateRFIRequest) decodes without the decoder's setting.
+func WithDisallowUnknownFields() ParseOption {
+	return func(o *parseOptions) {
+		o.disallowUnknownFields = true
+	}
+}
+
+// ParseJSONBody parses JSON request body into a struct. Empty and oversized bodies are rejected
+// before decoding, and anything other than whitespace after the JSON value is treated as invalid.
+// Every failure is a *BodyError.
+func ParseJSONBody(body string, target interface{}, opts ...ParseOption) error {
+	options := parseOptions{maxBytes: MaxJSONBodyBytes}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return &BodyError{Kind: ErrEmptyBody}
+	}
+	if options.maxBytes > 0 && len(body) > options.maxBytes {
+		return &BodyError{Kind: ErrBodyTooLarge, Err: fmt.Errorf("%d bytes exceeds the %d byte limit", len(body), options.maxBytes)}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	if options.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(target); err != nil {
+		if field, ok := unknownFieldFromDecodeError(err); ok {
+			return &BodyError{Kind: ErrUnknownField, Err: err, Field: field}
+		}
+		return &BodyError{Kind: ErrInvalidJSON, Err: err}
+	}
+
+	var trailing json.RawMessage
+	if err := decoder.Decode(&trailing); !errors.Is(err, io.EOF) {
+		return &BodyError{Kind: ErrInvalidJSON, Err: fmt.Errorf("unexpected data after JSON value")}
+	}
+
+	if options.disallowUnknownFields {
+		if field, ok := unknownTopLevelField(body, target); ok {
+			return &BodyError{Kind: ErrUnknownField, Field: field}
+		}
+	}
+
+	return nil
+}
+
+// ParseJSONBodyStrict is ParseJSONBody with unknown fields rejected, so a typo such as
+// "assigned_too" fails with `unknown field "assigned_too"` instead of silently leaving a zero value
+func ParseJSONBodyStrict(body string, target interface{}, opts ...ParseOption) error {
+	return ParseJSONBody(body, target, append(opts, WithDisallowUnknownFields())...)
+}
+
+// unknownFieldFromDecodeError extracts the key from the decoder's DisallowUnknownFields error,
+// which encoding/json only reports as text
+func unknownFieldFromDecodeError(err error) (string, bool) {
+	quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	field, unquoteErr := strconv.Unquote(quoted)
+	if unquoteErr != nil {
+		return quoted, true
+	}
+	return field, true
+}
+
+// unknownTopLevelField returns the first (alphabetically) key of a JSON object body that matches no
+// field of target. Matching is case-insensitive, as in encoding/json. Non-struct targets are not checked.
+func unknownTopLevelField(body string, target interface{}) (string, bool) {
+	targetType := reflect.TypeOf(target)
+	for targetType != nil && targetType.Kind() == reflect.Pointer {
+		targetType = targetType.Elem()
+	}
+	if targetType == nil || targetType.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &object); err != nil {
+		return "", false
+	}
+
+	known := make(map[string]bool)
+	collectJSONFieldNames(targetType, known)
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !known[strings.ToLower(key)] {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// collectJSONFieldNames adds the lower-cased JSON names of structType's fields to names, following
+// untagged embedded structs the way encoding/json promotes their fields
+func collectJSONFieldNames(structType reflect.Type, names map[string]bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectJSONFieldNames(embedded, names)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parseBodyTarget struct {
+	Title    string `json:"title"`
+	Priority string `json:"priority"`
+}
+
+func Test_ParseJSONBody_DecodesValidObject(t *testing.T) {
+	//Arrange
+	var target parseBodyTarget
+
+	//Act
+	err := ParseJSONBody(`{"title": "Leak at grid B4", "priority": "high"}`+"\n", &target)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, parseBodyTarget{Title: "Leak at grid B4", Priority: "high"}, target)
+}
+
+func Test_ParseJSONBody_RejectsInvalidBodies(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"empty", "", ErrEmptyBody},
+		{"whitespace only", " \n\t", ErrEmptyBody},
+		{"truncated object", `{"title": "Leak at grid`, ErrInvalidJSON},
+		{"missing closing brace", `{"title": "Leak"`, ErrInvalidJSON},
+		{"trailing garbage", `{"title": "Leak"}garbage`, ErrInvalidJSON},
+		{"second object", `{"title": "Leak"} {"title": "Crack"}`, ErrInvalidJSON},
+		{"wrong type", `{"title": 42}`, ErrInvalidJSON},
+		{"huge payload", `{"title": "` + strings.Repeat("a", MaxJSONBodyBytes) + `"}`, ErrBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			err := ParseJSONBody(tt.body, &parseBodyTarget{})
+
+			//Assert
+			var bodyErr *BodyError
+			assert.True(t, errors.As(err, &bodyErr))
+			assert.True(t, errors.Is(err, tt.want), err)
+		})
+	}
+}
+
+func Test_ParseJSONBody_WithMaxBodyBytesOverridesDefault(t *testing.T) {
+	//Arrange
+	body := `{"title": "Leak at grid B4"}`
+
+	//Act
+	tooLarge := ParseJSONBody(body, &parseBodyTarget{}, WithMaxBodyBytes(len(body)-1))
+	fits := ParseJSONBody(body, &parseBodyTarget{}, WithMaxBodyBytes(len(body)))
+
+	//Assert
+	assert.True(t, errors.Is(tooLarge, ErrBodyTooLarge))
+	assert.NoError(t, fits)
+}
+
+func Test_ParseJSONBody_UnknownFieldsAllowedUnlessDisallowed(t *testing.T) {
+	//Arrange
+	body := `{"title": "Leak", "priorty": "high"}`
+
+	//Act
+	lenient := ParseJSONBody(body, &parseBodyTarget{})
+	strict := ParseJSONBody(body, &parseBodyTarget{}, WithDisallowUnknownFields())
+
+	//Assert
+	assert.NoError(t, lenient)
+	assert.True(t, errors.Is(strict, ErrUnknownField))
+	assert.EqualError(t, strict, `unknown field "priorty"`)
+}
+
+type parseBodyFieldError struct{}
+
+func (parseBodyFieldError) Error() string { return "priority must be low, medium or high" }
+
+type parseBodyStrictPriority string
+
+func (p *parseBodyStrictPriority) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value != "low" && value != "medium" && value != "high" {
+		return parseBodyFieldError{}
+	}
+	*p = parseBodyStrictPriority(value)
+	return nil
+}
+
+func Test_ParseJSONBody_KeepsTargetUnmarshalErrorReachable(t *testing.T) {
+	//Arrange
+	var target struct {
+		Priority parseBodyStrictPriority `json:"priority"`
+	}
+
+	//Act
+	err := ParseJSONBody(`{"priority": "urgent"}`, &target)
+
+	//Assert
+	var fieldErr parseBodyFieldError
+	assert.True(t, errors.Is(err, ErrInvalidJSON))
+	assert.True(t, errors.As(err, &fieldErr))
+}
+
+type parseBodyCustomTarget struct {
+	Title      string `json:"title"`
+	AssignedTo int64  `json:"assigned_to"`
+}
+
+func (p *parseBodyCustomTarget) UnmarshalJSON(data []byte) error {
+	type plain parseBodyCustomTarget
+	aux := struct {
+		*plain
+		AssignedTo json.RawMessage `json:"assigned_to"`
+	}{plain: (*plain)(p)}
+	return json.Unmarshal(data, &aux)
+}
+
+func Test_ParseJSONBodyStrict(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantField string
+	}{
+		{"known fields", `{"title": "Leak", "priority": "high"}`, ""},
+		{"case-insensitive match", `{"Title": "Leak"}`, ""},
+		{"typo", `{"title": "Leak", "priorty": "high"}`, "priorty"},
+		{"first unknown key in body", `{"zone": 1, "title": "Leak", "area": 2}`, "zone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			err := ParseJSONBodyStrict(tt.body, &parseBodyTarget{})
+
+			//Assert
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var bodyErr *BodyError
+			assert.True(t, errors.As(err, &bodyErr))
+			assert.True(t, errors.Is(err, ErrUnknownField))
+			assert.Equal(t, tt.wantField, bodyErr.Field)
+		})
+	}
+}
+
+func Test_ParseJSONBodyStrict_ChecksTargetsWithCustomUnmarshal(t *testing.T) {
+	//Arrange
+	var target parseBodyCustomTarget
+
+	//Act
+	typo := ParseJSONBodyStrict(`{"title": "Leak", "assigned_too": 7}`, &target)
+	known := ParseJSONBodyStrict(`{"title": "Leak"}`, &target)
+
+	//Assert
+	assert.EqualError(t, typo, `unknown field "assigned_too"`)
+	assert.NoError(t, known)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+)
+
+// Bulk operation response contract
+//
+// Bulk endpoints process each item independently and always return 200 once the request
+// itself is valid, even when some items fail. The body reports every item in request order:
+//
+//	{
+//	  "results": [
+//	    {"id": 12, "status": "ok"},
+//	    {"id": 1, "status": "ok", "data": {...}},
+//	    {"id": 13, "status": "error", "error_code": "not_found", "message": "Issue not found"}
+//	  ],
+//	  "total": 2,
+//	  "succeeded": 1,
+//	  "failed": 1
+//	}
+//
+// Request-level problems (bad JSON, empty item list, auth) still use ErrorResponse with a 4xx.
+// Clients should inspect "failed" (or each item's status) rather than the HTTP status code.
+
+// Bulk item status values
+const (
+	BulkStatusOK    = "ok"
+	BulkStatusError = "error"
+)
+
+// Bulk item error codes
+const (
+	BulkErrorNotFound      = "not_found"
+	BulkErrorForbidden     = "forbidden"
+	BulkErrorInvalid       = "invalid"
+	BulkErrorConflict      = "conflict"
+	BulkErrorInternalError = "internal_error"
+)
+
+// BulkItemResult is the outcome of a single item in a bulk operation
+type BulkItemResult struct {
+	ID        interface{} `json:"id"`
+	Status    string      `json:"status"`
+	ErrorCode string      `json:"error_code,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Data      interface{} `json:"data,omitempty"` // Created resource, for bulk creates
+}
+
+// BulkResults collects per-item outcomes for a bulk operation
+type BulkResults struct {
+	Results   []BulkItemResult `json:"results"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
+
+// NewBulkResults creates an empty result set sized for the given number of items
+func NewBulkResults(size int) *BulkResults {
+	return &BulkResults{Results: make([]BulkItemResult, 0, size)}
+}
+
+// AddSuccess records a successfully processed item
+func (b *BulkResults) AddSuccess(id interface{}) {
+	b.Results = append(b.Results, BulkItemResult{ID: id, Status: BulkStatusOK})
+	b.Total++
+	b.Succeeded++
+}
+
+// AddSuccessWithData records a successfully processed item along with the resource it produced
+func (b *BulkResults) AddSuccessWithData(id interface{}, data interface{}) {
+	b.Results = append(b.Results, BulkItemResult{ID: id, Status: BulkStatusOK, Data: data})
+	b.Total++
+	b.Succeeded++
+}
+
+// AddError records a failed item with a machine-readable code and a human-readable message
+func (b *BulkResults) AddError(id interface{}, errorCode, message string) {
+	b.Results = append(b.Results, BulkItemResult{
+		ID:        id,
+		Status:    BulkStatusError,
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+	b.Total++
+	b.Failed++
+}
+
+// BulkResponse creates the standard 200 response for a bulk operation
+func BulkResponse(results *BulkResults, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	return SuccessResponse(http.StatusOK, results, logger)
+}
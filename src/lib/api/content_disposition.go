@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// defaultDownloadFileName is used when nothing usable is left of a file name after sanitizing
+const defaultDownloadFileName = "download"
+
+// SanitizeFileName makes a stored file name safe to show to clients and to place in a header:
+// control characters and quotes are dropped, path separators become underscores and
+// surrounding whitespace and dots are trimmed. Non-ASCII characters are kept.
+func SanitizeFileName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsControl(r), r == '"', r == unicode.ReplacementChar:
+			continue
+		case r == '/', r == '\\':
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	sanitized := strings.Trim(b.String(), " .")
+	if sanitized == "" {
+		return defaultDownloadFileName
+	}
+	return sanitized
+}
+
+// ContentDisposition builds an attachment Content-Disposition value for fileName. The quoted
+// filename is an ASCII-only fallback for old clients; filename* carries the full UTF-8 name
+// percent-encoded per RFC 5987 so unusual names survive without breaking the header.
+func ContentDisposition(fileName string) string {
+	sanitized := SanitizeFileName(fileName)
+
+	var fallback strings.Builder
+	for _, r := range sanitized {
+		if r > unicode.MaxASCII {
+			fallback.WriteRune('_')
+		} else {
+			fallback.WriteRune(r)
+		}
+	}
+
+	return fmt.Sprintf("attachment; filename=\"%s\"; filename*=UTF-8''%s", fallback.String(), encodeRFC5987(sanitized))
+}
+
+// encodeRFC5987 percent-encodes every byte that is not an RFC 5987 attr-char
+func encodeRFC5987(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isRFC5987AttrChar(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// isRFC5987AttrChar reports whether c may appear unencoded in an RFC 5987 ext-value
+func isRFC5987AttrChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
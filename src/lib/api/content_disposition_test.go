@@ -0,0 +1,31 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SanitizeFileName_StripsControlCharsQuotesAndSeparators(t *testing.T) {
+	//Act
+	sanitized := SanitizeFileName("../plans\r\nSet-Cookie: x=\"1\"\\final.pdf")
+
+	//Assert
+	assert.Equal(t, "_plansSet-Cookie: x=1_final.pdf", sanitized)
+}
+
+func Test_SanitizeFileName_FallsBackWhenNothingRemains(t *testing.T) {
+	//Act
+	sanitized := SanitizeFileName(" \x00\"..")
+
+	//Assert
+	assert.Equal(t, "download", sanitized)
+}
+
+func Test_ContentDisposition_EncodesNonASCIIName(t *testing.T) {
+	//Act
+	header := ContentDisposition("Plan Ü 1.pdf")
+
+	//Assert
+	assert.Equal(t, "attachment; filename=\"Plan _ 1.pdf\"; filename*=UTF-8''Plan%20%C3%9C%201.pdf", header)
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeCursor builds an opaque keyset cursor for a (created_at, id) position in a list
+func EncodeCursor(createdAt time.Time, id int64) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor back into its (created_at, id) position
+func DecodeCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor")
+	}
+
+	createdAtPart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtPart)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id <= 0 {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor")
+	}
+
+	return createdAt, id, nil
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeCursor_RoundTripsEncodedPosition(t *testing.T) {
+	//Arrange
+	createdAt := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.UTC)
+	cursor := EncodeCursor(createdAt, 42)
+
+	//Act
+	decodedAt, decodedID, err := DecodeCursor(cursor)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, createdAt.Equal(decodedAt))
+	assert.Equal(t, int64(42), decodedID)
+}
+
+func Test_DecodeCursor_RejectsMalformedCursor(t *testing.T) {
+	//Arrange
+	cursors := []string{"not base64!", "bm8tc2VwYXJhdG9y", EncodeCursor(time.Now(), 0)}
+
+	//Act
+	var errs []error
+	for _, cursor := range cursors {
+		_, _, err := DecodeCursor(cursor)
+		errs = append(errs, err)
+	}
+
+	//Assert
+	for i, err := range errs {
+		assert.EqualError(t, err, "invalid cursor", cursors[i])
+	}
+}
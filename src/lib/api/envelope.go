@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+)
+
+// EnvelopeMediaType is the Accept header value clients send to opt into enveloped responses
+const EnvelopeMediaType = "application/vnd.buildboard.envelope+json"
+
+// PaginationMeta describes the page of results returned in an enveloped response
+type PaginationMeta struct {
+	Page        int  `json:"page"`
+	PageSize    int  `json:"page_size"`
+	TotalCount  int  `json:"total_count"`
+	TotalPages  int  `json:"total_pages"`
+	HasNext     bool `json:"has_next"`
+	HasPrevious bool `json:"has_previous"`
+}
+
+// ResponseMeta carries metadata alongside enveloped response data
+type ResponseMeta struct {
+	RequestID  string          `json:"request_id,omitempty"`
+	Pagination *PaginationMeta `json:"pagination,omitempty"`
+}
+
+// Envelope is the standardized success shape: {data, meta}
+type Envelope struct {
+	Data interface{}  `json:"data"`
+	Meta ResponseMeta `json:"meta"`
+}
+
+// NewPaginationMeta builds pagination metadata from the page, page size and total result count
+func NewPaginationMeta(page, pageSize, totalCount int) *PaginationMeta {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (totalCount + pageSize - 1) / pageSize
+	}
+
+	return &PaginationMeta{
+		Page:        page,
+		PageSize:    pageSize,
+		TotalCount:  totalCount,
+		TotalPages:  totalPages,
+		HasNext:     page < totalPages,
+		HasPrevious: page > 1,
+	}
+}
+
+// NewOffsetPaginationMeta builds pagination metadata for endpoints paged by limit and offset. Page is the
+// page the offset falls on; HasNext is set while rows remain after this page.
+func NewOffsetPaginationMeta(limit, offset, totalCount int) *PaginationMeta {
+	meta := NewPaginationMeta(1, limit, totalCount)
+	if limit > 0 {
+		meta.Page = offset/limit + 1
+	}
+	meta.HasNext = offset+limit < totalCount
+	meta.HasPrevious = offset > 0
+	return meta
+}
+
+// SinglePageMeta builds pagination metadata for list endpoints that return every result in one page
+func SinglePageMeta(totalCount int) *PaginationMeta {
+	return NewPaginationMeta(1, totalCount, totalCount)
+}
+
+// SuccessEnvelope creates a successful API Gateway response wrapped in the standard envelope
+func SuccessEnvelope(statusCode int, data interface{}, meta ResponseMeta, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	body, err := json.Marshal(Envelope{Data: data, Meta: meta})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal envelope response data")
+		return ErrorResponse(http.StatusInternalServerError, "Internal server error", logger)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Headers: map[string]string{
+			"Content-Type":                 EnvelopeMediaType,
+			"Access-Control-Allow-Origin":  "*",
+			"Access-Control-Allow-Headers": "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token",
+			"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,OPTIONS",
+		},
+	}
+}
+
+// WantsEnvelope reports whether the client opted into enveloped responses via the Accept header
+func WantsEnvelope(request events.APIGatewayProxyRequest) bool {
+	for name, value := range request.Headers {
+		if strings.EqualFold(name, "Accept") && strings.Contains(value, EnvelopeMediaType) {
+			return true
+		}
+	}
+	for name, values := range request.MultiValueHeaders {
+		if !strings.EqualFold(name, "Accept") {
+			continue
+		}
+		for _, value := range values {
+			if strings.Contains(value, EnvelopeMediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ListResponse returns a list endpoint response. Clients that opt in via the Accept header get
+// the items enveloped with request id and pagination metadata; everyone else gets the existing
+// direct payload unchanged. A fields query parameter trims each item to the requested fields.
+func ListResponse(request events.APIGatewayProxyRequest, payload interface{}, items interface{}, pagination *PaginationMeta, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	// Sparse fieldsets (?fields=) trim each item while keeping list metadata intact
+	if fields := ParseFields(request); len(fields) > 0 {
+		var err error
+		if payload, err = ProjectListFields(payload, fields); err == nil {
+			items, err = ProjectFields(items, fields)
+		}
+		if err != nil {
+			logger.WithError(err).Error("Failed to project response fields")
+			return ErrorResponse(http.StatusInternalServerError, "Internal server error", logger)
+		}
+	}
+
+	if !WantsEnvelope(request) {
+		return SuccessResponse(http.StatusOK, payload, logger)
+	}
+
+	meta := ResponseMeta{
+		RequestID:  request.RequestContext.RequestID,
+		Pagination: pagination,
+	}
+	return SuccessEnvelope(http.StatusOK, items, meta, logger)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewOffsetPaginationMeta(t *testing.T) {
+	tests := []struct {
+		name         string
+		limit        int
+		offset       int
+		total        int
+		wantPage     int
+		wantPages    int
+		wantNext     bool
+		wantPrevious bool
+	}{
+		{"first page with more", 10, 0, 25, 1, 3, true, false},
+		{"middle page", 10, 10, 25, 2, 3, true, true},
+		{"last partial page", 10, 20, 25, 3, 3, false, true},
+		{"exactly one full page", 10, 0, 10, 1, 1, false, false},
+		{"last full page", 10, 10, 20, 2, 2, false, true},
+		{"offset between pages", 10, 15, 25, 2, 3, false, true},
+		{"offset past the end", 10, 30, 25, 4, 3, false, true},
+		{"no results", 10, 0, 0, 1, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			meta := NewOffsetPaginationMeta(tt.limit, tt.offset, tt.total)
+
+			//Assert
+			assert.Equal(t, tt.wantPage, meta.Page)
+			assert.Equal(t, tt.limit, meta.PageSize)
+			assert.Equal(t, tt.total, meta.TotalCount)
+			assert.Equal(t, tt.wantPages, meta.TotalPages)
+			assert.Equal(t, tt.wantNext, meta.HasNext)
+			assert.Equal(t, tt.wantPrevious, meta.HasPrevious)
+		})
+	}
+}
+
+func Test_SinglePageMeta(t *testing.T) {
+	//Act
+	meta := SinglePageMeta(7)
+
+	//Assert
+	assert.Equal(t, &PaginationMeta{Page: 1, PageSize: 7, TotalCount: 7, TotalPages: 1}, meta)
+}
@@ -0,0 +1,73 @@
+package api
+
+import "net/http"
+
+// Error codes returned in the `code` field of error responses. Clients should branch on these
+// rather than on the human-readable message, which may change.
+const (
+	// Generic codes, used when a handler does not pass a more specific one
+	ErrorCodeBadRequest         = "BAD_REQUEST"
+	ErrorCodeUnauthorized       = "UNAUTHORIZED"
+	ErrorCodeForbidden          = "FORBIDDEN"
+	ErrorCodeNotFound           = "NOT_FOUND"
+	ErrorCodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	ErrorCodeConflict           = "CONFLICT"
+	ErrorCodeTooManyRequests    = "TOO_MANY_REQUESTS"
+	ErrorCodeInternal           = "INTERNAL_ERROR"
+	ErrorCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+
+	// Request validation
+	ErrorCodeValidationFailed = "VALIDATION_FAILED"
+	ErrorCodeInvalidBody      = "INVALID_REQUEST_BODY"
+	ErrorCodeInvalidID        = "INVALID_ID"
+
+	// Authentication and tenancy
+	ErrorCodeAuthenticationFailed = "AUTHENTICATION_FAILED"
+	ErrorCodeOrgMismatch          = "ORG_MISMATCH"
+	ErrorCodeNotProjectMember     = "NOT_PROJECT_MEMBER"
+
+	// Missing resources
+	ErrorCodeEndpointNotFound = "ENDPOINT_NOT_FOUND"
+	ErrorCodeProjectNotFound  = "PROJECT_NOT_FOUND"
+	ErrorCodeIssueNotFound    = "ISSUE_NOT_FOUND"
+	ErrorCodeRFINotFound      = "RFI_NOT_FOUND"
+
+	// State conflicts
+	ErrorCodeVersionConflict     = "VERSION_CONFLICT"
+	ErrorCodeRFIDeleted          = "RFI_DELETED"
+	ErrorCodeEmailInUse          = "EMAIL_IN_USE"
+	ErrorCodeAssignmentExists    = "ASSIGNMENT_EXISTS"
+	ErrorCodeLocationHasChildren = "LOCATION_HAS_CHILDREN"
+	ErrorCodeLocationHasProjects = "LOCATION_HAS_PROJECTS"
+
+	// Idempotency-Key handling
+	ErrorCodeIdempotencyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ErrorCodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+)
+
+// DefaultErrorCode returns the generic code for an HTTP status; ErrorResponse uses it so every
+// error body carries a code even where the handler has not been given a specific one yet
+func DefaultErrorCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrorCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrorCodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return ErrorCodeServiceUnavailable
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return ErrorCodeBadRequest
+	}
+	return ErrorCodeInternal
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ErrorResponseWithCode_IncludesCodeAndMessage(t *testing.T) {
+	//Arrange
+	logger := logrus.New()
+
+	//Act
+	response := ErrorResponseWithCode(http.StatusNotFound, ErrorCodeRFINotFound, "RFI not found", logger)
+
+	//Assert
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &body))
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Equal(t, ErrorCodeRFINotFound, body["code"])
+	assert.Equal(t, "RFI not found", body["message"])
+}
+
+func Test_ErrorResponse_UsesDefaultCode(t *testing.T) {
+	//Arrange
+	logger := logrus.New()
+
+	//Act
+	response := ErrorResponse(http.StatusForbidden, "Access denied", logger)
+
+	//Assert
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &body))
+	assert.Equal(t, ErrorCodeForbidden, body["code"])
+}
+
+func Test_DefaultErrorCode(t *testing.T) {
+	assert.Equal(t, ErrorCodeBadRequest, DefaultErrorCode(http.StatusBadRequest))
+	assert.Equal(t, ErrorCodeNotFound, DefaultErrorCode(http.StatusNotFound))
+	assert.Equal(t, ErrorCodeBadRequest, DefaultErrorCode(http.StatusUnprocessableEntity))
+	assert.Equal(t, ErrorCodeInternal, DefaultErrorCode(http.StatusInternalServerError))
+	assert.Equal(t, ErrorCodeInternal, DefaultErrorCode(http.StatusBadGateway))
+}
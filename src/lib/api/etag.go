@@ -0,0 +1,66 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// WeakETag builds a weak entity tag from the values that identify one version of a resource,
+// typically its id and updated_at plus a version of anything bundled into the response
+// (e.g. comment count and latest comment update).
+func WeakETag(parts ...interface{}) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%v\x00", part)
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// ConditionalResponse answers a GET with 304 Not Modified and no body when the request's
+// If-None-Match matches etag. Otherwise it returns build()'s response, tagged with etag when
+// successful. build is only called when the body is actually needed.
+func ConditionalResponse(request events.APIGatewayProxyRequest, etag string, build func() events.APIGatewayProxyResponse) events.APIGatewayProxyResponse {
+	if etagMatches(HeaderValue(request, "If-None-Match"), etag) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusNotModified,
+			Headers: map[string]string{
+				"ETag":                          etag,
+				"Access-Control-Allow-Origin":   "*",
+				"Access-Control-Allow-Headers":  "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token",
+				"Access-Control-Allow-Methods":  "GET,POST,PUT,DELETE,OPTIONS",
+				"Access-Control-Expose-Headers": "ETag",
+			},
+		}
+	}
+
+	response := build()
+	if response.StatusCode == http.StatusOK {
+		if response.Headers == nil {
+			response.Headers = map[string]string{}
+		}
+		response.Headers["ETag"] = etag
+		response.Headers["Access-Control-Expose-Headers"] = "ETag"
+	}
+	return response
+}
+
+// etagMatches applies the weak comparison If-None-Match uses: W/ prefixes are ignored, and the
+// header may list several tags or be "*"
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
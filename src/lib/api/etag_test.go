@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeakETag_ChangesWithAnyPart(t *testing.T) {
+	//Arrange
+	updatedAt := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	//Act
+	etag := WeakETag(int64(42), updatedAt)
+
+	//Assert
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, WeakETag(int64(42), updatedAt))
+	assert.NotEqual(t, etag, WeakETag(int64(43), updatedAt))
+	assert.NotEqual(t, etag, WeakETag(int64(42), updatedAt.Add(time.Microsecond)))
+	assert.NotEqual(t, WeakETag("1", "23"), WeakETag("12", "3"))
+}
+
+func TestConditionalResponse(t *testing.T) {
+	etag := WeakETag(int64(42), "2026-10-16T09:30:00Z")
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+		wantBuilt   bool
+	}{
+		{"no header", "", http.StatusOK, true},
+		{"matching tag", etag, http.StatusNotModified, false},
+		{"strong form of tag", etag[2:], http.StatusNotModified, false},
+		{"tag in list", `W/"stale", ` + etag, http.StatusNotModified, false},
+		{"wildcard", "*", http.StatusNotModified, false},
+		{"stale tag", `W/"stale"`, http.StatusOK, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			request := events.APIGatewayProxyRequest{Headers: map[string]string{}}
+			if tt.ifNoneMatch != "" {
+				request.Headers["if-none-match"] = tt.ifNoneMatch
+			}
+			built := false
+
+			//Act
+			response := ConditionalResponse(request, etag, func() events.APIGatewayProxyResponse {
+				built = true
+				return SuccessResponse(http.StatusOK, map[string]int64{"id": 42}, logrus.New())
+			})
+
+			//Assert
+			assert.Equal(t, tt.wantStatus, response.StatusCode)
+			assert.Equal(t, tt.wantBuilt, built)
+			assert.Equal(t, etag, response.Headers["ETag"])
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, response.Body)
+			}
+		})
+	}
+}
+
+func TestConditionalResponse_DoesNotTagErrors(t *testing.T) {
+	//Arrange
+	request := events.APIGatewayProxyRequest{}
+
+	//Act
+	response := ConditionalResponse(request, WeakETag(int64(42)), func() events.APIGatewayProxyResponse {
+		return ErrorResponse(http.StatusNotFound, "Issue not found", logrus.New())
+	})
+
+	//Assert
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.NotContains(t, response.Headers, "ETag")
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+)
+
+// FieldsQueryParam is the query parameter clients use to request a sparse fieldset (fields=id,status,subject)
+const FieldsQueryParam = "fields"
+
+// ParseFields returns the field names requested via ?fields=, or nil when the full response is wanted
+func ParseFields(request events.APIGatewayProxyRequest) []string {
+	raw := request.QueryStringParameters[FieldsQueryParam]
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ProjectFields trims a response to the requested JSON fields. Objects keep only the requested
+// top-level keys and arrays are projected element by element. Unknown field names are ignored.
+// With no fields the data is returned unchanged.
+func ProjectFields(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return projectValue(generic, fieldSet(fields)), nil
+}
+
+// ProjectListFields trims a list payload such as {"issues": [...], "total": 10}. Arrays of objects
+// inside the payload are projected item by item; the surrounding metadata keys are kept as-is.
+func ProjectListFields(payload interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return payload, nil
+	}
+
+	generic, err := toGenericJSON(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	set := fieldSet(fields)
+	object, ok := generic.(map[string]interface{})
+	if !ok {
+		return projectValue(generic, set), nil
+	}
+	for key, value := range object {
+		if items, isArray := value.([]interface{}); isArray {
+			object[key] = projectValue(items, set)
+		}
+	}
+	return object, nil
+}
+
+// SparseResponse returns a 200 response trimmed to the fields requested on the request, if any
+func SparseResponse(request events.APIGatewayProxyRequest, data interface{}, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	projected, err := ProjectFields(data, ParseFields(request))
+	if err != nil {
+		logger.WithError(err).Error("Failed to project response fields")
+		return ErrorResponse(http.StatusInternalServerError, "Internal server error", logger)
+	}
+	return SuccessResponse(http.StatusOK, projected, logger)
+}
+
+func fieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	return set
+}
+
+func toGenericJSON(data interface{}) (interface{}, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(body, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+func projectValue(value interface{}, set map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		projected := make(map[string]interface{}, len(set))
+		for key, fieldValue := range typed {
+			if set[key] {
+				projected[key] = fieldValue
+			}
+		}
+		return projected
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = projectValue(item, set)
+		}
+		return typed
+	default:
+		return value
+	}
+}
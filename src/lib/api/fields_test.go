@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldsTestItem struct {
+	ID      int64    `json:"id"`
+	Status  string   `json:"status"`
+	Subject string   `json:"subject"`
+	Notes   []string `json:"notes"`
+}
+
+func Test_ProjectFields_KeepsRequestedFieldsAndIgnoresUnknown(t *testing.T) {
+	//Arrange
+	item := fieldsTestItem{ID: 7, Status: "open", Subject: "Leak", Notes: []string{"a"}}
+
+	//Act
+	projected, err := ProjectFields(item, []string{"id", "status", "does_not_exist"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": float64(7), "status": "open"}, projected)
+}
+
+func Test_ListResponse_ProjectsItemsAndKeepsMetadata(t *testing.T) {
+	//Arrange
+	items := []fieldsTestItem{{ID: 1, Status: "open", Subject: "A"}, {ID: 2, Status: "closed", Subject: "B"}}
+	payload := map[string]interface{}{"issues": items, "total": 2}
+	request := events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"fields": "id, subject"}}
+
+	//Act
+	response := ListResponse(request, payload, items, nil, nil)
+
+	//Assert
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &body))
+	assert.Equal(t, float64(2), body["total"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": float64(1), "subject": "A"},
+		map[string]interface{}{"id": float64(2), "subject": "B"},
+	}, body["issues"])
+}
+
+func Test_ParseFields_EmptyMeansFullResponse(t *testing.T) {
+	//Arrange
+	request := events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"fields": " , "}}
+
+	//Act
+	fields := ParseFields(request)
+
+	//Assert
+	assert.Nil(t, fields)
+}
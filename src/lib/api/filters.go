@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseMultiValueFilter splits a comma-separated query value (e.g. status=open,in_progress) and checks
+// each entry against the allowed values. Matching is case-insensitive and values are returned in their
+// canonical form with duplicates removed. An empty raw value yields no values.
+func ParseMultiValueFilter(name, raw string, allowed []string) ([]string, error) {
+	var values []string
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		canonical := ""
+		for _, candidate := range allowed {
+			if strings.EqualFold(part, candidate) {
+				canonical = candidate
+				break
+			}
+		}
+		if canonical == "" {
+			return nil, fmt.Errorf("invalid %s value '%s'. Allowed values: %s", name, part, strings.Join(allowed, ", "))
+		}
+
+		if !seen[canonical] {
+			seen[canonical] = true
+			values = append(values, canonical)
+		}
+	}
+
+	return values, nil
+}
+
+// NormalizeMultiValueFilters validates each named filter present in filters and rewrites it to its
+// canonical comma-separated form, ready for the repositories to expand into an IN/ANY clause.
+func NormalizeMultiValueFilters(filters map[string]string, allowed map[string][]string) error {
+	for name, allowedValues := range allowed {
+		raw, ok := filters[name]
+		if !ok || raw == "" {
+			continue
+		}
+
+		values, err := ParseMultiValueFilter(name, raw, allowedValues)
+		if err != nil {
+			return err
+		}
+		filters[name] = strings.Join(values, ",")
+	}
+	return nil
+}
+
+// sortOrders are the accepted values of the order query parameter
+var sortOrders = []string{"asc", "desc"}
+
+// NormalizeSortParams validates the single-valued sort and order query parameters against the allowed
+// sort fields and rewrites them to canonical form. Missing parameters are left for the repository defaults.
+func NormalizeSortParams(filters map[string]string, allowedSorts []string) error {
+	for name, allowed := range map[string][]string{"sort": allowedSorts, "order": sortOrders} {
+		raw, ok := filters[name]
+		if !ok || raw == "" {
+			continue
+		}
+
+		values, err := ParseMultiValueFilter(name, raw, allowed)
+		if err != nil {
+			return err
+		}
+		if len(values) > 1 {
+			return fmt.Errorf("%s accepts a single value", name)
+		}
+		if len(values) == 1 {
+			filters[name] = values[0]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseMultiValueFilter_CanonicalizesAndDedupes(t *testing.T) {
+	//Arrange
+	allowed := []string{"open", "in_progress", "closed"}
+
+	//Act
+	values, err := ParseMultiValueFilter("status", " OPEN, in_progress,,open ", allowed)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"open", "in_progress"}, values)
+}
+
+func Test_ParseMultiValueFilter_RejectsUnknownValue(t *testing.T) {
+	//Arrange
+	allowed := []string{"high", "low"}
+
+	//Act
+	values, err := ParseMultiValueFilter("priority", "high,urgent", allowed)
+
+	//Assert
+	assert.Nil(t, values)
+	assert.EqualError(t, err, "invalid priority value 'urgent'. Allowed values: high, low")
+}
+
+func Test_NormalizeMultiValueFilters_RewritesOnlyConfiguredFilters(t *testing.T) {
+	//Arrange
+	filters := map[string]string{"status": "DRAFT,open", "category": "Design"}
+	allowed := map[string][]string{
+		"status":   {"DRAFT", "OPEN", "CLOSE"},
+		"priority": {"LOW", "HIGH"},
+	}
+
+	//Act
+	err := NormalizeMultiValueFilters(filters, allowed)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "DRAFT,OPEN", filters["status"])
+	assert.Equal(t, "Design", filters["category"])
+	_, hasPriority := filters["priority"]
+	assert.False(t, hasPriority)
+}
+
+func Test_NormalizeSortParams_CanonicalizesSortAndOrder(t *testing.T) {
+	//Arrange
+	filters := map[string]string{"sort": "AGE_DAYS", "order": "Asc"}
+
+	//Act
+	err := NormalizeSortParams(filters, []string{"created_at", "age_days"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "age_days", filters["sort"])
+	assert.Equal(t, "asc", filters["order"])
+}
+
+func Test_NormalizeSortParams_RejectsMultipleSortFields(t *testing.T) {
+	//Arrange
+	filters := map[string]string{"sort": "created_at,age_days"}
+
+	//Act
+	err := NormalizeSortParams(filters, []string{"created_at", "age_days"})
+
+	//Assert
+	assert.EqualError(t, err, "sort accepts a single value")
+}
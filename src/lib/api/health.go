@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+)
+
+// HealthCheckTimeout bounds the database ping so a synthetic check fails fast instead of hanging
+const HealthCheckTimeout = 2 * time.Second
+
+// Health check statuses
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// DBPinger is the database handle a health check pings; *sql.DB satisfies it
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// HealthResponse is the body every service returns from its health check
+type HealthResponse struct {
+	Status      string `json:"status"`
+	DBLatencyMS int64  `json:"db_latency_ms"`
+}
+
+// HealthCheckResponse pings the database with HealthCheckTimeout and returns 200 with the ping
+// latency, or 503 when the database is unreachable. Services route it before extracting claims
+// so monitors can call it without a token.
+func HealthCheckResponse(ctx context.Context, db DBPinger, logger *logrus.Logger) events.APIGatewayProxyResponse {
+	pingCtx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := db.PingContext(pingCtx)
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		logger.WithError(err).WithField("db_latency_ms", latency).Warn("Health check failed to reach the database")
+		return SuccessResponse(http.StatusServiceUnavailable, HealthResponse{Status: HealthStatusUnavailable, DBLatencyMS: latency}, logger)
+	}
+	return SuccessResponse(http.StatusOK, HealthResponse{Status: HealthStatusOK, DBLatencyMS: latency}, logger)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (p fakePinger) PingContext(ctx context.Context) error {
+	return p.err
+}
+
+func Test_HealthCheckResponse_OK(t *testing.T) {
+	//Arrange
+	pinger := fakePinger{}
+
+	//Act
+	response := HealthCheckResponse(context.Background(), pinger, logrus.New())
+
+	//Assert
+	var body HealthResponse
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &body))
+	assert.Equal(t, HealthStatusOK, body.Status)
+}
+
+func Test_HealthCheckResponse_DatabaseUnreachable(t *testing.T) {
+	//Arrange
+	pinger := fakePinger{err: errors.New("dial tcp: connection refused")}
+
+	//Act
+	response := HealthCheckResponse(context.Background(), pinger, logrus.New())
+
+	//Assert
+	var body HealthResponse
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &body))
+	assert.Equal(t, HealthStatusUnavailable, body.Status)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"infrastructure/lib/data"
+	"infrastructure/lib/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// IdempotencyKeyHeader lets clients retry a create safely: a repeat with the same key returns the
+// resource the first request created instead of creating another
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKeyTTL is how long a key is remembered before it can be reused
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// MaxIdempotencyKeyLength caps the Idempotency-Key header value
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyStore persists idempotency keys per organization and scope
+type IdempotencyStore interface {
+	// ReserveIdempotencyKey claims the key for a new request; false means an unexpired record already exists
+	ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, orgID int64, scope, key string) (*models.IdempotencyKey, error)
+	// CompleteIdempotencyKeyTx records the created resource in the transaction that created it
+	CompleteIdempotencyKeyTx(ctx context.Context, tx *sql.Tx, orgID int64, scope, key string, resourceID int64) error
+	// ReleaseIdempotencyKey forgets a reservation that never produced a resource
+	ReleaseIdempotencyKey(ctx context.Context, orgID int64, scope, key string) error
+}
+
+// HeaderValue returns a request header, matching the name case-insensitively
+func HeaderValue(request events.APIGatewayProxyRequest, name string) string {
+	for headerName, value := range request.Headers {
+		if strings.EqualFold(headerName, name) {
+			return value
+		}
+	}
+	for headerName, values := range request.MultiValueHeaders {
+		if strings.EqualFold(headerName, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// IdempotencyRequestHash fingerprints a request body. JSON bodies are compared by content, so
+// whitespace and key order differences between retries do not count as a different request.
+func IdempotencyRequestHash(body string) string {
+	canonical := []byte(body)
+	var parsed interface{}
+	if err := json.Unmarshal(canonical, &parsed); err == nil {
+		if encoded, err := json.Marshal(parsed); err == nil {
+			canonical = encoded
+		}
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// BeginIdempotentRequest claims an Idempotency-Key for the request. An empty key means the client did
+// not send one and the request proceeds as usual.
+// Returns (replayResourceID, statusCode, errorCode, errorMessage):
+//   - errorMessage is non-empty when the request must be rejected with statusCode
+//   - replayResourceID is set when the key already created a resource, which should be returned as-is
+//   - otherwise the key is reserved: call CompleteIdempotentRequest in the transaction that creates the
+//     resource, and ReleaseIdempotentRequest (deferring it is fine) so a failed attempt can be retried
+//     with the same key. A reservation that is never completed or released lapses after IdempotencyClaimLease.
+func BeginIdempotentRequest(ctx context.Context, store IdempotencyStore, key, scope string, orgID, userID int64, body string) (int64, int, string, string) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return 0, 0, "", ""
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return 0, http.StatusBadRequest, ErrorCodeValidationFailed, "Idempotency-Key cannot be longer than 255 characters"
+	}
+
+	requestHash := IdempotencyRequestHash(body)
+	reserved, err := store.ReserveIdempotencyKey(ctx, &models.IdempotencyKey{
+		OrgID:       orgID,
+		Scope:       scope,
+		Key:         key,
+		UserID:      userID,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(IdempotencyKeyTTL),
+	})
+	if err != nil {
+		return 0, http.StatusInternalServerError, ErrorCodeInternal, "Failed to check Idempotency-Key"
+	}
+	if reserved {
+		return 0, 0, "", ""
+	}
+
+	existing, err := store.GetIdempotencyKey(ctx, orgID, scope, key)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return 0, http.StatusConflict, ErrorCodeIdempotencyInProgress, "A request with this Idempotency-Key is still being processed"
+		}
+		return 0, http.StatusInternalServerError, ErrorCodeInternal, "Failed to check Idempotency-Key"
+	}
+	if existing.UserID != userID || existing.RequestHash != requestHash {
+		return 0, http.StatusUnprocessableEntity, ErrorCodeIdempotencyKeyReused, "Idempotency-Key was already used with a different request"
+	}
+	if existing.ResourceID == nil {
+		return 0, http.StatusConflict, ErrorCodeIdempotencyInProgress, "A request with this Idempotency-Key is still being processed"
+	}
+
+	return *existing.ResourceID, 0, "", ""
+}
+
+// CompleteIdempotentRequest records the resource created under a reserved key. Pass the transaction
+// that creates the resource, so a commit that records the resource also records the key.
+func CompleteIdempotentRequest(ctx context.Context, store IdempotencyStore, tx *sql.Tx, key, scope string, orgID, resourceID int64) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil
+	}
+	return store.CompleteIdempotencyKeyTx(ctx, tx, orgID, scope, key, resourceID)
+}
+
+// ReleaseIdempotentRequest drops the reservation if no resource was recorded for it; completed keys are kept
+func ReleaseIdempotentRequest(ctx context.Context, store IdempotencyStore, key, scope string, orgID int64) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil
+	}
+	return store.ReleaseIdempotencyKey(ctx, orgID, scope, key)
+}
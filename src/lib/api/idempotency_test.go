@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"infrastructure/lib/data"
+	"infrastructure/lib/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockIdempotencyStore struct {
+	Records map[string]*models.IdempotencyKey
+}
+
+func (m *MockIdempotencyStore) ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyKey) (bool, error) {
+	if _, ok := m.Records[record.Key]; ok {
+		return false, nil
+	}
+	m.Records[record.Key] = record
+	return true, nil
+}
+
+func (m *MockIdempotencyStore) GetIdempotencyKey(ctx context.Context, orgID int64, scope, key string) (*models.IdempotencyKey, error) {
+	record, ok := m.Records[key]
+	if !ok {
+		return nil, fmt.Errorf("idempotency key not found: %w", data.ErrNotFound)
+	}
+	return record, nil
+}
+
+func (m *MockIdempotencyStore) CompleteIdempotencyKeyTx(ctx context.Context, tx *sql.Tx, orgID int64, scope, key string, resourceID int64) error {
+	m.Records[key].ResourceID = &resourceID
+	return nil
+}
+
+func (m *MockIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, orgID int64, scope, key string) error {
+	if record, ok := m.Records[key]; ok && record.ResourceID == nil {
+		delete(m.Records, key)
+	}
+	return nil
+}
+
+func Test_BeginIdempotentRequest_ReplaysCompletedKey(t *testing.T) {
+	//Arrange
+	store := &MockIdempotencyStore{Records: map[string]*models.IdempotencyKey{}}
+	ctx := context.Background()
+	BeginIdempotentRequest(ctx, store, "key-1", "create_issue", 1, 7, `{"title":"Leak","priority":"high"}`)
+	CompleteIdempotentRequest(ctx, store, nil, "key-1", "create_issue", 1, 42)
+
+	//Act
+	replayID, statusCode, _, errMsg := BeginIdempotentRequest(ctx, store, "key-1", "create_issue", 1, 7, `{"priority": "high", "title": "Leak"}`)
+
+	//Assert
+	assert.Equal(t, int64(42), replayID)
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, errMsg)
+}
+
+func Test_BeginIdempotentRequest_DifferentBody(t *testing.T) {
+	//Arrange
+	store := &MockIdempotencyStore{Records: map[string]*models.IdempotencyKey{}}
+	ctx := context.Background()
+	BeginIdempotentRequest(ctx, store, "key-1", "create_issue", 1, 7, `{"title":"Leak"}`)
+	CompleteIdempotentRequest(ctx, store, nil, "key-1", "create_issue", 1, 42)
+
+	//Act
+	replayID, statusCode, errCode, _ := BeginIdempotentRequest(ctx, store, "key-1", "create_issue", 1, 7, `{"title":"Crack"}`)
+
+	//Assert
+	assert.Equal(t, int64(0), replayID)
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+	assert.Equal(t, ErrorCodeIdempotencyKeyReused, errCode)
+}
+
+func Test_BeginIdempotentRequest_ReleasedKeyCanRetry(t *testing.T) {
+	//Arrange
+	store := &MockIdempotencyStore{Records: map[string]*models.IdempotencyKey{}}
+	ctx := context.Background()
+	BeginIdempotentRequest(ctx, store, "key-1", "create_rfi", 1, 7, `{"subject":"Beam"}`)
+	ReleaseIdempotentRequest(ctx, store, "key-1", "create_rfi", 1)
+
+	//Act
+	replayID, statusCode, _, errMsg := BeginIdempotentRequest(ctx, store, "key-1", "create_rfi", 1, 7, `{"subject":"Beam"}`)
+
+	//Assert
+	assert.Equal(t, int64(0), replayID)
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, errMsg)
+	assert.Contains(t, store.Records, "key-1")
+}
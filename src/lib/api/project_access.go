@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"infrastructure/lib/data"
+	"net/http"
+)
+
+// ProjectOrgResolver resolves the organization that owns a project
+type ProjectOrgResolver interface {
+	GetProjectOrgID(ctx context.Context, projectID int64) (int64, error)
+}
+
+// ValidateProjectAccess checks that a project ID was provided, the project exists (and is not deleted)
+// and it belongs to the caller's organization.
+// Returns (statusCode, errorCode, errorMessage) - errorMessage is empty string if validation passes
+func ValidateProjectAccess(ctx context.Context, resolver ProjectOrgResolver, projectID, orgID int64) (int, string, string) {
+	if projectID <= 0 {
+		return http.StatusBadRequest, ErrorCodeValidationFailed, "project_id is required and must be greater than 0"
+	}
+
+	projectOrgID, err := resolver.GetProjectOrgID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return http.StatusNotFound, ErrorCodeProjectNotFound, "Project not found"
+		}
+		return http.StatusInternalServerError, ErrorCodeInternal, "Failed to validate project"
+	}
+
+	if projectOrgID != orgID {
+		return http.StatusForbidden, ErrorCodeOrgMismatch, "Project does not belong to your organization"
+	}
+
+	return 0, "", ""
+}
+
+// ProjectMembershipChecker reports whether a user holds a role on a project
+type ProjectMembershipChecker interface {
+	IsUserMember(ctx context.Context, projectID, userID int64) (bool, error)
+}
+
+// OrgMembershipPolicy reports whether an organization restricts creation to project members
+type OrgMembershipPolicy interface {
+	RequiresProjectMembership(ctx context.Context, orgID int64) (bool, error)
+}
+
+// ValidateProjectMembership enforces the organization's project-membership setting: when enabled,
+// the caller must hold a role on the project (super admins are exempt). Orgs without the setting
+// keep the permissive behavior.
+// Returns (statusCode, errorCode, errorMessage) - errorMessage is empty string if validation passes
+func ValidateProjectMembership(ctx context.Context, policy OrgMembershipPolicy, checker ProjectMembershipChecker, projectID, orgID, userID int64, isSuperAdmin bool) (int, string, string) {
+	if isSuperAdmin {
+		return 0, "", ""
+	}
+
+	required, err := policy.RequiresProjectMembership(ctx, orgID)
+	if err != nil {
+		return http.StatusInternalServerError, ErrorCodeInternal, "Failed to validate project membership"
+	}
+	if !required {
+		return 0, "", ""
+	}
+
+	isMember, err := checker.IsUserMember(ctx, projectID, userID)
+	if err != nil {
+		return http.StatusInternalServerError, ErrorCodeInternal, "Failed to validate project membership"
+	}
+	if !isMember {
+		return http.StatusForbidden, ErrorCodeNotProjectMember, "You must be a member of this project to perform this action"
+	}
+
+	return 0, "", ""
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"infrastructure/lib/data"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockProjectOrgResolver struct {
+	ProjectOrgs map[int64]int64
+	Err         error
+}
+
+func (m *MockProjectOrgResolver) GetProjectOrgID(ctx context.Context, projectID int64) (int64, error) {
+	if m.Err != nil {
+		return 0, m.Err
+	}
+	orgID, ok := m.ProjectOrgs[projectID]
+	if !ok {
+		return 0, fmt.Errorf("project not found: %w", data.ErrNotFound)
+	}
+	return orgID, nil
+}
+
+func Test_ValidateProjectAccess_SameOrg(t *testing.T) {
+	//Arrange
+	resolver := &MockProjectOrgResolver{ProjectOrgs: map[int64]int64{10: 1}}
+
+	//Act
+	statusCode, _, errMsg := ValidateProjectAccess(context.Background(), resolver, 10, 1)
+
+	//Assert
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, errMsg)
+}
+
+func Test_ValidateProjectAccess_CrossOrg(t *testing.T) {
+	//Arrange
+	resolver := &MockProjectOrgResolver{ProjectOrgs: map[int64]int64{10: 2}}
+
+	//Act
+	statusCode, errCode, errMsg := ValidateProjectAccess(context.Background(), resolver, 10, 1)
+
+	//Assert
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, ErrorCodeOrgMismatch, errCode)
+	assert.Equal(t, "Project does not belong to your organization", errMsg)
+}
+
+func Test_ValidateProjectAccess_NotFound(t *testing.T) {
+	//Arrange
+	resolver := &MockProjectOrgResolver{ProjectOrgs: map[int64]int64{}}
+
+	//Act
+	statusCode, errCode, _ := ValidateProjectAccess(context.Background(), resolver, 10, 1)
+
+	//Assert
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Equal(t, ErrorCodeProjectNotFound, errCode)
+}
+
+func Test_ValidateProjectAccess_MissingProjectID(t *testing.T) {
+	//Arrange
+	resolver := &MockProjectOrgResolver{}
+
+	//Act
+	statusCode, _, _ := ValidateProjectAccess(context.Background(), resolver, 0, 1)
+
+	//Assert
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func Test_ValidateProjectAccess_LookupError(t *testing.T) {
+	//Arrange
+	resolver := &MockProjectOrgResolver{Err: errors.New("connection refused")}
+
+	//Act
+	statusCode, _, _ := ValidateProjectAccess(context.Background(), resolver, 10, 1)
+
+	//Assert
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+}
+
+type MockMembershipPolicy struct {
+	Required bool
+}
+
+func (m *MockMembershipPolicy) RequiresProjectMembership(ctx context.Context, orgID int64) (bool, error) {
+	return m.Required, nil
+}
+
+type MockMembershipChecker struct {
+	Members map[int64]bool
+}
+
+func (m *MockMembershipChecker) IsUserMember(ctx context.Context, projectID, userID int64) (bool, error) {
+	return m.Members[userID], nil
+}
+
+func Test_ValidateProjectMembership_PermissiveOrg(t *testing.T) {
+	//Arrange
+	policy := &MockMembershipPolicy{Required: false}
+	checker := &MockMembershipChecker{Members: map[int64]bool{}}
+
+	//Act
+	statusCode, _, errMsg := ValidateProjectMembership(context.Background(), policy, checker, 10, 1, 5, false)
+
+	//Assert
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, errMsg)
+}
+
+func Test_ValidateProjectMembership_StrictOrgNonMember(t *testing.T) {
+	//Arrange
+	policy := &MockMembershipPolicy{Required: true}
+	checker := &MockMembershipChecker{Members: map[int64]bool{6: true}}
+
+	//Act
+	statusCode, errCode, errMsg := ValidateProjectMembership(context.Background(), policy, checker, 10, 1, 5, false)
+
+	//Assert
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, ErrorCodeNotProjectMember, errCode)
+	assert.NotEmpty(t, errMsg)
+}
+
+func Test_ValidateProjectMembership_StrictOrgSuperAdmin(t *testing.T) {
+	//Arrange
+	policy := &MockMembershipPolicy{Required: true}
+	checker := &MockMembershipChecker{Members: map[int64]bool{}}
+
+	//Act
+	statusCode, _, errMsg := ValidateProjectMembership(context.Background(), policy, checker, 10, 1, 5, true)
+
+	//Assert
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, errMsg)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimit configures a token bucket: Burst requests may be made at once, then PerSecond tokens
+// are added back each second up to Burst
+type RateLimit struct {
+	Burst     int
+	PerSecond float64
+}
+
+// DefaultListRateLimit applies to list endpoints whose SSM rate limit parameter is missing or invalid
+var DefaultListRateLimit = RateLimit{Burst: 30, PerSecond: 5}
+
+// ParseRateLimit reads a "<burst>,<per_second>" SSM value such as "30,5". Empty or invalid values
+// return fallback so a bad parameter never blocks traffic entirely.
+func ParseRateLimit(value string, fallback RateLimit) RateLimit {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return fallback
+	}
+	burst, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || burst <= 0 {
+		return fallback
+	}
+	perSecond, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || perSecond <= 0 {
+		return fallback
+	}
+	return RateLimit{Burst: burst, PerSecond: perSecond}
+}
+
+// RateLimiter keeps one token bucket per organization. Buckets live in the Lambda container, so the
+// effective limit scales with concurrency; it protects the database from one org's bursts rather than
+// enforcing an exact quota. A new bucket (and so every cold container) starts full.
+type RateLimiter struct {
+	limit   RateLimit
+	now     func() time.Time
+	mu      sync.Mutex
+	buckets map[int64]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter that applies limit to each organization independently
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{limit: limit, now: time.Now, buckets: make(map[int64]*tokenBucket)}
+}
+
+// Allow takes a token from the org's bucket. When the bucket is empty it returns false and how long
+// until a token is available.
+func (l *RateLimiter) Allow(orgID int64) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket, ok := l.buckets[orgID]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[orgID] = bucket
+	}
+
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(float64(l.limit.Burst), bucket.tokens+elapsed*l.limit.PerSecond)
+		bucket.last = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := (1 - bucket.tokens) / l.limit.PerSecond
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// Limit is the handler-side check: it returns a 429 response with a Retry-After header and true when
+// the org has exhausted its bucket, or false when the request may proceed
+func (l *RateLimiter) Limit(orgID int64, logger *logrus.Logger) (events.APIGatewayProxyResponse, bool) {
+	allowed, retryAfter := l.Allow(orgID)
+	if allowed {
+		return events.APIGatewayProxyResponse{}, false
+	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	logger.WithFields(logrus.Fields{
+		"org_id":      orgID,
+		"retry_after": seconds,
+	}).Warn("Rate limit exceeded")
+
+	response := ErrorResponseWithCode(http.StatusTooManyRequests, ErrorCodeTooManyRequests, "Too many requests, please retry later", logger)
+	response.Headers["Retry-After"] = strconv.Itoa(seconds)
+	return response, true
+}
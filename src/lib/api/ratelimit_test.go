@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRateLimiter(limit RateLimit, now *time.Time) *RateLimiter {
+	limiter := NewRateLimiter(limit)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func Test_RateLimiter_StartsFullAndRefills(t *testing.T) {
+	//Arrange
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(RateLimit{Burst: 3, PerSecond: 1}, &now)
+
+	//Act
+	var burst []bool
+	for i := 0; i < 4; i++ {
+		allowed, _ := limiter.Allow(1)
+		burst = append(burst, allowed)
+	}
+	_, retryAfter := limiter.Allow(1)
+	now = now.Add(time.Second)
+	allowedAfterRefill, _ := limiter.Allow(1)
+
+	//Assert
+	assert.Equal(t, []bool{true, true, true, false}, burst)
+	assert.Equal(t, time.Second, retryAfter)
+	assert.True(t, allowedAfterRefill)
+}
+
+func Test_RateLimiter_OrgsHaveSeparateBuckets(t *testing.T) {
+	//Arrange
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(RateLimit{Burst: 1, PerSecond: 1}, &now)
+	limiter.Allow(1)
+
+	//Act
+	allowed, _ := limiter.Allow(2)
+
+	//Assert
+	assert.True(t, allowed)
+}
+
+func Test_RateLimiter_Limit_Returns429WithRetryAfter(t *testing.T) {
+	//Arrange
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(RateLimit{Burst: 1, PerSecond: 0.5}, &now)
+	limiter.Allow(7)
+
+	//Act
+	response, limited := limiter.Limit(7, logrus.New())
+
+	//Assert
+	assert.True(t, limited)
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, "2", response.Headers["Retry-After"])
+}
+
+func Test_ParseRateLimit(t *testing.T) {
+	fallback := RateLimit{Burst: 30, PerSecond: 5}
+	assert.Equal(t, RateLimit{Burst: 10, PerSecond: 2.5}, ParseRateLimit("10, 2.5", fallback))
+	assert.Equal(t, fallback, ParseRateLimit("", fallback))
+	assert.Equal(t, fallback, ParseRateLimit("10", fallback))
+	assert.Equal(t, fallback, ParseRateLimit("0,5", fallback))
+	assert.Equal(t, fallback, ParseRateLimit("10,-1", fallback))
+}
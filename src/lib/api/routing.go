@@ -0,0 +1,37 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NormalizeRoute returns the request with its routing fields normalized so minor discrepancies
+// don't fall through to "Endpoint not found": the HTTP method is upper-cased and trailing
+// slashes are trimmed from the resource and path (the root "/" is kept).
+func NormalizeRoute(request events.APIGatewayProxyRequest) events.APIGatewayProxyRequest {
+	request.HTTPMethod = strings.ToUpper(strings.TrimSpace(request.HTTPMethod))
+	request.Resource = trimTrailingSlash(request.Resource)
+	request.Path = trimTrailingSlash(request.Path)
+	return request
+}
+
+// PathSegments splits a request path into its non-empty segments,
+// so "/roles/12/" and "/roles//12" both yield ["roles", "12"]
+func PathSegments(path string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func trimTrailingSlash(path string) string {
+	trimmed := strings.TrimRight(path, "/")
+	if trimmed == "" && path != "" {
+		return "/"
+	}
+	return trimmed
+}
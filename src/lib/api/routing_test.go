@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormalizeRoute_TrimsTrailingSlashAndUppercasesMethod(t *testing.T) {
+	//Arrange
+	request := events.APIGatewayProxyRequest{
+		HTTPMethod: "post",
+		Resource:   "/issues/{issueId}/comments/",
+		Path:       "/issues/42/comments/",
+	}
+
+	//Act
+	normalized := NormalizeRoute(request)
+
+	//Assert
+	assert.Equal(t, "POST", normalized.HTTPMethod)
+	assert.Equal(t, "/issues/{issueId}/comments", normalized.Resource)
+	assert.Equal(t, "/issues/42/comments", normalized.Path)
+}
+
+func Test_NormalizeRoute_KeepsRoot(t *testing.T) {
+	//Arrange
+	request := events.APIGatewayProxyRequest{HTTPMethod: "GET", Resource: "/", Path: "/"}
+
+	//Act
+	normalized := NormalizeRoute(request)
+
+	//Assert
+	assert.Equal(t, "/", normalized.Resource)
+	assert.Equal(t, "/", normalized.Path)
+}
+
+func Test_PathSegments_SkipsEmptySegments(t *testing.T) {
+	//Act
+	segments := PathSegments("/roles//12/permissions/")
+
+	//Assert
+	assert.Equal(t, []string{"roles", "12", "permissions"}, segments)
+}
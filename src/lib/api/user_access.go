@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UserOrgChecker reports whether a user belongs to an organization
+type UserOrgChecker interface {
+	UserBelongsToOrg(ctx context.Context, userID, orgID int64) (bool, error)
+}
+
+// ValidateUserInOrg checks that a user referenced by a request (e.g. assigned_to) exists in the caller's organization.
+// fieldName is used in the error message. Returns (statusCode, errorCode, errorMessage); errorMessage is empty when valid.
+func ValidateUserInOrg(ctx context.Context, checker UserOrgChecker, userID, orgID int64, fieldName string) (int, string, string) {
+	if userID <= 0 {
+		return http.StatusBadRequest, ErrorCodeValidationFailed, fmt.Sprintf("%s must be a valid user ID", fieldName)
+	}
+
+	belongs, err := checker.UserBelongsToOrg(ctx, userID, orgID)
+	if err != nil {
+		return http.StatusInternalServerError, ErrorCodeInternal, "Failed to validate user"
+	}
+	if !belongs {
+		return http.StatusBadRequest, ErrorCodeValidationFailed, fmt.Sprintf("Invalid %s user ID. User %d does not belong to your organization.", fieldName, userID)
+	}
+
+	return 0, "", ""
+}
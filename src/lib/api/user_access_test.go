@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockUserOrgChecker struct {
+	UserOrgs map[int64]int64
+	Err      error
+}
+
+func (m *MockUserOrgChecker) UserBelongsToOrg(ctx context.Context, userID, orgID int64) (bool, error) {
+	if m.Err != nil {
+		return false, m.Err
+	}
+	userOrgID, ok := m.UserOrgs[userID]
+	return ok && userOrgID == orgID, nil
+}
+
+func Test_ValidateUserInOrg_SameOrg(t *testing.T) {
+	//Arrange
+	checker := &MockUserOrgChecker{UserOrgs: map[int64]int64{5: 1}}
+
+	//Act
+	statusCode, _, errMsg := ValidateUserInOrg(context.Background(), checker, 5, 1, "assigned_to")
+
+	//Assert
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, errMsg)
+}
+
+func Test_ValidateUserInOrg_CrossOrg(t *testing.T) {
+	//Arrange
+	checker := &MockUserOrgChecker{UserOrgs: map[int64]int64{5: 2}}
+
+	//Act
+	statusCode, _, errMsg := ValidateUserInOrg(context.Background(), checker, 5, 1, "assigned_to")
+
+	//Assert
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, "Invalid assigned_to user ID. User 5 does not belong to your organization.", errMsg)
+}
+
+func Test_ValidateUserInOrg_CheckerError(t *testing.T) {
+	//Arrange
+	checker := &MockUserOrgChecker{Err: errors.New("connection refused")}
+
+	//Act
+	statusCode, _, _ := ValidateUserInOrg(context.Background(), checker, 5, 1, "assigned_to")
+
+	//Assert
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+}
@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -15,6 +17,9 @@ type Claims struct {
 	CognitoID    string `json:"sub"`
 	OrgID        int64  `json:"org_id"`
 	IsSuperAdmin bool   `json:"isSuperAdmin"`
+
+	// permissions caches RequirePermission lookups by project ID (0 for org-level)
+	permissions map[int64]map[string]bool
 }
 
 // ExtractClaimsFromRequest extracts and parses JWT claims from API Gateway request
@@ -110,6 +115,42 @@ func ExtractClaimsFromRequest(request events.APIGatewayProxyRequest) (*Claims, e
 	}, nil
 }
 
+// InternalInvocationHeader marks events sent to a Lambda by a scheduled rule rather than API Gateway
+const InternalInvocationHeader = "X-Internal-Invocation"
+
+// IsInternalInvocation reports whether the request was sent by a scheduled rule invoking the Lambda
+// directly. API Gateway always sets a request ID and authorizer context, so a client cannot reach this
+// path by adding the header to an API call; only principals allowed to invoke the function can.
+func IsInternalInvocation(request events.APIGatewayProxyRequest) bool {
+	if request.RequestContext.RequestID != "" || len(request.RequestContext.Authorizer) > 0 {
+		return false
+	}
+	for name, value := range request.Headers {
+		if strings.EqualFold(name, InternalInvocationHeader) {
+			return value == "scheduled"
+		}
+	}
+	return false
+}
+
+// InternalAPIKeyHeader carries the shared key that service-to-service endpoints such as the virus
+// scanner callback are protected with instead of a Cognito token
+const InternalAPIKeyHeader = "X-Internal-Api-Key"
+
+// HasInternalAPIKey reports whether the request carries expectedKey in InternalAPIKeyHeader. An empty
+// expectedKey (not configured) never matches.
+func HasInternalAPIKey(request events.APIGatewayProxyRequest, expectedKey string) bool {
+	if expectedKey == "" {
+		return false
+	}
+	for name, value := range request.Headers {
+		if strings.EqualFold(name, InternalAPIKeyHeader) {
+			return subtle.ConstantTimeCompare([]byte(value), []byte(expectedKey)) == 1
+		}
+	}
+	return false
+}
+
 // ToJSON converts claims to JSON string for logging
 func (c *Claims) ToJSON() string {
 	data, _ := json.Marshal(c)
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePermissionLookup struct {
+	permissions map[int64][]string
+	err         error
+	calls       int
+}
+
+func (f *fakePermissionLookup) GetEffectivePermissions(ctx context.Context, userID, orgID, projectID int64) ([]string, []string, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return nil, f.permissions[projectID], nil
+}
+
+func TestRequirePermission_Granted(t *testing.T) {
+	//Arrange
+	claims := &Claims{UserID: 1, OrgID: 10}
+	lookup := &fakePermissionLookup{permissions: map[int64][]string{5: {"view_project", PermissionManageProjectUsers}}}
+
+	//Act
+	err := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+
+	//Assert
+	assert.NoError(t, err)
+}
+
+func TestRequirePermission_Denied(t *testing.T) {
+	//Arrange
+	claims := &Claims{UserID: 1, OrgID: 10}
+	lookup := &fakePermissionLookup{permissions: map[int64][]string{5: {"view_project"}}}
+
+	//Act
+	err := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+
+	//Assert
+	var forbidden *ForbiddenError
+	assert.True(t, errors.As(err, &forbidden))
+	assert.Equal(t, PermissionManageProjectUsers, forbidden.Permission)
+}
+
+func TestRequirePermission_PermissionOnAnotherProjectDenied(t *testing.T) {
+	//Arrange
+	claims := &Claims{UserID: 1, OrgID: 10}
+	lookup := &fakePermissionLookup{permissions: map[int64][]string{6: {PermissionManageProjectUsers}}}
+
+	//Act
+	err := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+
+	//Assert
+	assert.IsType(t, &ForbiddenError{}, err)
+}
+
+func TestRequirePermission_SuperAdminBypassesLookup(t *testing.T) {
+	//Arrange
+	claims := &Claims{UserID: 1, OrgID: 10, IsSuperAdmin: true}
+	lookup := &fakePermissionLookup{err: errors.New("should not be called")}
+
+	//Act
+	err := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, lookup.calls)
+}
+
+func TestRequirePermission_LookupError(t *testing.T) {
+	//Arrange
+	claims := &Claims{UserID: 1, OrgID: 10}
+	lookup := &fakePermissionLookup{err: errors.New("connection refused")}
+
+	//Act
+	err := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+
+	//Assert
+	var forbidden *ForbiddenError
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &forbidden))
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestRequirePermission_CachesLookupPerProject(t *testing.T) {
+	//Arrange
+	claims := &Claims{UserID: 1, OrgID: 10}
+	lookup := &fakePermissionLookup{permissions: map[int64][]string{
+		5: {PermissionManageProjectUsers},
+		0: {"view_org"},
+	}}
+
+	//Act
+	first := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+	second := RequirePermission(context.Background(), claims, lookup, 5, PermissionManageProjectUsers)
+	denied := RequirePermission(context.Background(), claims, lookup, 5, "delete_project")
+	orgLevel := RequirePermission(context.Background(), claims, lookup, 0, PermissionManageProjectUsers)
+
+	//Assert
+	assert.NoError(t, first)
+	assert.NoError(t, second)
+	assert.IsType(t, &ForbiddenError{}, denied)
+	assert.IsType(t, &ForbiddenError{}, orgLevel)
+	assert.Equal(t, 2, lookup.calls)
+}
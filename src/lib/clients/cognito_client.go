@@ -2,10 +2,15 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/sirupsen/logrus"
 )
 
 // NewCognitoIdentityProviderClient creates a new Cognito Identity Provider client
@@ -22,4 +27,91 @@ func NewCognitoIdentityProviderClient(isLocal bool) *cognitoidentityprovider.Cli
 	}
 
 	return cognitoidentityprovider.NewFromConfig(cfg)
-}
\ No newline at end of file
+}
+
+// ErrCognitoUnavailable is wrapped by WithCognitoRetry when every attempt failed with a transient error
+var ErrCognitoUnavailable = errors.New("cognito is unavailable")
+
+// CognitoRetryConfig controls retries and per-call timeouts for Cognito admin operations
+type CognitoRetryConfig struct {
+	MaxAttempts int           // Total attempts including the first call
+	BaseDelay   time.Duration // Backoff before the second attempt; doubles each retry
+	MaxDelay    time.Duration // Upper bound for a single backoff
+	CallTimeout time.Duration // Timeout applied to each individual attempt
+}
+
+// DefaultCognitoRetryConfig returns the retry settings used when none are configured
+func DefaultCognitoRetryConfig() CognitoRetryConfig {
+	return CognitoRetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		CallTimeout: 5 * time.Second,
+	}
+}
+
+// IsRetryableCognitoError reports whether a Cognito error is transient (throttling, limits,
+// internal errors, or a per-attempt timeout) rather than a hard failure such as invalid input
+func IsRetryableCognitoError(err error) bool {
+	var tooManyRequests *types.TooManyRequestsException
+	var limitExceeded *types.LimitExceededException
+	var internalError *types.InternalErrorException
+
+	switch {
+	case errors.As(err, &tooManyRequests), errors.As(err, &limitExceeded), errors.As(err, &internalError):
+		return true
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	}
+	return false
+}
+
+// WithCognitoRetry runs a Cognito admin call with a per-attempt timeout, retrying transient
+// failures with exponential backoff. Hard errors are returned immediately. When every attempt
+// fails the returned error wraps ErrCognitoUnavailable and the last error.
+func WithCognitoRetry(ctx context.Context, cfg CognitoRetryConfig, operation string, logger *logrus.Logger, call func(ctx context.Context) error) error {
+	if cfg.MaxAttempts <= 0 {
+		cfg = DefaultCognitoRetryConfig()
+	}
+
+	delay := cfg.BaseDelay
+	var lastErr error
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.CallTimeout)
+		lastErr = call(attemptCtx)
+		cancel()
+
+		if lastErr == nil {
+			return nil
+		}
+		// Stop when the caller's context is done or the error is not worth retrying
+		if ctx.Err() != nil || !IsRetryableCognitoError(lastErr) {
+			return lastErr
+		}
+		if attempt == cfg.MaxAttempts {
+			break
+		}
+
+		if logger != nil {
+			logger.WithFields(logrus.Fields{
+				"operation": operation,
+				"attempt":   attempt,
+				"delay_ms":  delay.Milliseconds(),
+				"error":     lastErr.Error(),
+			}).Warn("Retrying Cognito operation after transient error")
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return lastErr
+		}
+
+		delay *= 2
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+
+	return fmt.Errorf("%w after %d attempts: %w", ErrCognitoUnavailable, cfg.MaxAttempts, lastErr)
+}
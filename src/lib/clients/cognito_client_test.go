@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryConfig() CognitoRetryConfig {
+	return CognitoRetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		CallTimeout: time.Second,
+	}
+}
+
+func TestWithCognitoRetry_RetriesThrottling(t *testing.T) {
+	//Arrange
+	calls := 0
+	call := func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return &types.TooManyRequestsException{}
+		}
+		return nil
+	}
+
+	//Act
+	err := WithCognitoRetry(context.Background(), testRetryConfig(), "test", nil, call)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWithCognitoRetry_HardErrorNotRetried(t *testing.T) {
+	//Arrange
+	calls := 0
+	call := func(ctx context.Context) error {
+		calls++
+		return &types.UsernameExistsException{}
+	}
+
+	//Act
+	err := WithCognitoRetry(context.Background(), testRetryConfig(), "test", nil, call)
+
+	//Assert
+	var usernameExists *types.UsernameExistsException
+	assert.True(t, errors.As(err, &usernameExists))
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithCognitoRetry_ExhaustedAttempts(t *testing.T) {
+	//Arrange
+	calls := 0
+	call := func(ctx context.Context) error {
+		calls++
+		return &types.LimitExceededException{}
+	}
+
+	//Act
+	err := WithCognitoRetry(context.Background(), testRetryConfig(), "test", nil, call)
+
+	//Assert
+	assert.ErrorContains(t, err, "cognito is unavailable after 3 attempts")
+	assert.True(t, errors.Is(err, ErrCognitoUnavailable))
+	assert.Equal(t, 3, calls)
+}
@@ -1,13 +1,82 @@
 package clients
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"infrastructure/lib/constants"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
 )
 
+// PostgresPoolConfig holds the connection pool settings NewPostgresSQLClient applies
+type PostgresPoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPostgresPoolConfig suits Lambda: a container serves one request at a time, so two connections
+// are plenty, and short lifetimes hand back connections held by frozen or recycled containers
+var DefaultPostgresPoolConfig = PostgresPoolConfig{
+	MaxOpenConns:    2,
+	MaxIdleConns:    1,
+	ConnMaxLifetime: 5 * time.Minute,
+	ConnMaxIdleTime: time.Minute,
+}
+
+// PostgresPool is the pool configuration NewPostgresSQLClient applies. Lambdas set it from SSM
+// with ConfigurePostgresPool before creating the client.
+var PostgresPool = DefaultPostgresPoolConfig
+
+// ConfigurePostgresPool sets PostgresPool from the DATABASE_* pool parameters in SSM and returns the
+// result. Missing or invalid values keep the defaults, and idle connections never exceed open ones.
+func ConfigurePostgresPool(ssmParams map[string]string) PostgresPoolConfig {
+	pool := DefaultPostgresPoolConfig
+	if value, ok := positiveIntParam(ssmParams, constants.DATABASE_MAX_OPEN_CONNS); ok {
+		pool.MaxOpenConns = value
+	}
+	if value, ok := positiveIntParam(ssmParams, constants.DATABASE_MAX_IDLE_CONNS); ok {
+		pool.MaxIdleConns = value
+	}
+	if value, ok := positiveIntParam(ssmParams, constants.DATABASE_CONN_MAX_LIFETIME_SECONDS); ok {
+		pool.ConnMaxLifetime = time.Duration(value) * time.Second
+	}
+	if value, ok := positiveIntParam(ssmParams, constants.DATABASE_CONN_MAX_IDLE_TIME_SECONDS); ok {
+		pool.ConnMaxIdleTime = time.Duration(value) * time.Second
+	}
+	if pool.MaxIdleConns > pool.MaxOpenConns {
+		pool.MaxIdleConns = pool.MaxOpenConns
+	}
+
+	PostgresPool = pool
+	return pool
+}
+
+// LogFields returns the pool settings as log fields
+func (pool PostgresPoolConfig) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"max_open_conns":     pool.MaxOpenConns,
+		"max_idle_conns":     pool.MaxIdleConns,
+		"conn_max_lifetime":  pool.ConnMaxLifetime.String(),
+		"conn_max_idle_time": pool.ConnMaxIdleTime.String(),
+	}
+}
+
+// positiveIntParam parses an SSM parameter as a positive integer
+func positiveIntParam(ssmParams map[string]string, name string) (int, bool) {
+	value, err := strconv.Atoi(strings.TrimSpace(ssmParams[name]))
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
+
 // NewPostgresSQLClient creates a new PostgreSQL client with connection pooling optimized for Lambda
 func NewPostgresSQLClient(host, port, dbname, user, password, sslMode string) (*sql.DB, error) {
 	connStr := fmt.Sprintf(
@@ -22,12 +91,14 @@ func NewPostgresSQLClient(host, port, dbname, user, password, sslMode string) (*
 		return nil, fmt.Errorf("failed to open db connection: %w", err)
 	}
 
-	// Lambda-optimized connection settings
-	db.SetMaxOpenConns(2) // Max 2 open connections for Lambda
-	db.SetMaxIdleConns(1) // Keep 1 idle connection
+	// Lambda-optimized connection settings (see DefaultPostgresPoolConfig)
+	db.SetMaxOpenConns(PostgresPool.MaxOpenConns)
+	db.SetMaxIdleConns(PostgresPool.MaxIdleConns)
+	db.SetConnMaxLifetime(PostgresPool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(PostgresPool.ConnMaxIdleTime)
 
-	// Validate connection
-	if err := db.Ping(); err != nil {
+	// Validate connection, retrying while RDS is scaling or briefly refusing connections
+	if err := RetryPostgres(context.Background(), db.Ping); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
@@ -0,0 +1,41 @@
+package clients
+
+import (
+	"infrastructure/lib/constants"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurePostgresPool_Defaults(t *testing.T) {
+	//Arrange
+	ssmParams := map[string]string{}
+
+	//Act
+	pool := ConfigurePostgresPool(ssmParams)
+
+	//Assert
+	assert.Equal(t, DefaultPostgresPoolConfig, pool)
+}
+
+func TestConfigurePostgresPool_FromSSM(t *testing.T) {
+	//Arrange
+	ssmParams := map[string]string{
+		constants.DATABASE_MAX_OPEN_CONNS:             "4",
+		constants.DATABASE_MAX_IDLE_CONNS:             "8",
+		constants.DATABASE_CONN_MAX_LIFETIME_SECONDS:  "120",
+		constants.DATABASE_CONN_MAX_IDLE_TIME_SECONDS: "bad",
+	}
+	defer func() { PostgresPool = DefaultPostgresPoolConfig }()
+
+	//Act
+	pool := ConfigurePostgresPool(ssmParams)
+
+	//Assert
+	assert.Equal(t, 4, pool.MaxOpenConns)
+	assert.Equal(t, 4, pool.MaxIdleConns)
+	assert.Equal(t, 2*time.Minute, pool.ConnMaxLifetime)
+	assert.Equal(t, DefaultPostgresPoolConfig.ConnMaxIdleTime, pool.ConnMaxIdleTime)
+	assert.Equal(t, pool, PostgresPool)
+}
@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"infrastructure/lib/util"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresMaxAttempts is how many times RetryPostgres runs an operation that keeps failing with
+// transient errors. Lambdas override it from the DATABASE_MAX_RETRIES SSM parameter.
+var PostgresMaxAttempts = 3
+
+const (
+	postgresRetryBaseDelay = 100 * time.Millisecond
+	postgresRetryMaxDelay  = 2 * time.Second
+)
+
+// ConfigurePostgresRetry sets PostgresMaxAttempts from an SSM parameter value. Empty or invalid
+// values keep the default so a missing parameter never disables queries.
+func ConfigurePostgresRetry(maxAttempts string) {
+	if attempts, err := strconv.Atoi(strings.TrimSpace(maxAttempts)); err == nil && attempts > 0 {
+		PostgresMaxAttempts = attempts
+	}
+}
+
+// transientPostgresCodes are SQLSTATEs worth retrying: the server was overloaded, restarting or
+// picked this transaction to abort. Constraint violations and other logical errors are never retried.
+var transientPostgresCodes = map[pq.ErrorCode]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// IsTransientPostgresError reports whether err is a connection or contention failure that may
+// succeed if the operation is run again
+func IsTransientPostgresError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Class 08 covers connection exceptions
+		return transientPostgresCodes[pqErr.Code] || pqErr.Code.Class() == "08"
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "connection reset by peer")
+}
+
+// RetryPostgres runs operation until it succeeds, fails with a non-transient error or has run
+// PostgresMaxAttempts times. Waits between attempts grow exponentially with jitter up to a cap, and
+// a wait that would outlast the context deadline is not started, so retries never push a Lambda
+// past its timeout. Only use it for statements that are safe to repeat, and never inside a transaction.
+func RetryPostgres(ctx context.Context, operation func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		stop := util.TimeDB(ctx)
+		err = operation()
+		stop()
+		if err == nil || !IsTransientPostgresError(err) || attempt >= PostgresMaxAttempts {
+			return err
+		}
+
+		delay := postgresRetryDelay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// QueryRowContextRetry runs a single-row query and scans it into dest, retrying transient failures.
+// sql.ErrNoRows is returned unchanged.
+func QueryRowContextRetry(ctx context.Context, db *sql.DB, query string, args []interface{}, dest ...interface{}) error {
+	return RetryPostgres(ctx, func() error {
+		return db.QueryRowContext(ctx, query, args...).Scan(dest...)
+	})
+}
+
+// postgresRetryDelay returns the wait before the next attempt: full jitter over an exponentially
+// growing window capped at postgresRetryMaxDelay
+func postgresRetryDelay(attempt int) time.Duration {
+	window := postgresRetryBaseDelay << (attempt - 1)
+	if window <= 0 || window > postgresRetryMaxDelay {
+		window = postgresRetryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(window))) + time.Millisecond
+}
@@ -0,0 +1,83 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientPostgresError_TooManyConnections(t *testing.T) {
+	//Arrange
+	err := &pq.Error{Code: "53300"}
+
+	//Act
+	actual := IsTransientPostgresError(err)
+
+	//Assert
+	assert.True(t, actual)
+}
+
+func TestIsTransientPostgresError_UniqueViolation(t *testing.T) {
+	//Arrange
+	err := &pq.Error{Code: "23505"}
+
+	//Act
+	actual := IsTransientPostgresError(err)
+
+	//Assert
+	assert.False(t, actual)
+}
+
+func TestRetryPostgres_StopsAfterMaxAttempts(t *testing.T) {
+	//Arrange
+	attempts := 0
+	operation := func() error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	}
+
+	//Act
+	err := RetryPostgres(context.Background(), operation)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, PostgresMaxAttempts, attempts)
+}
+
+func TestRetryPostgres_DoesNotRetryLogicalErrors(t *testing.T) {
+	//Arrange
+	attempts := 0
+	operation := func() error {
+		attempts++
+		return errors.New("project not found")
+	}
+
+	//Act
+	err := RetryPostgres(context.Background(), operation)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPostgres_RespectsDeadline(t *testing.T) {
+	//Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond)
+	defer cancel()
+	attempts := 0
+	operation := func() error {
+		attempts++
+		return &pq.Error{Code: "53300"}
+	}
+
+	//Act
+	err := RetryPostgres(ctx, operation)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
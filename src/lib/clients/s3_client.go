@@ -2,19 +2,33 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
+// ObjectMetadata is the subset of S3 object headers the services act on
+type ObjectMetadata struct {
+	ContentLength int64
+	ContentType   string
+}
+
 // S3ClientInterface defines the interface for S3 operations
 type S3ClientInterface interface {
 	GenerateUploadURL(key string, expiry time.Duration) (string, error)
 	GenerateDownloadURL(key string, expiry time.Duration) (string, error)
+	GenerateDownloadURLWithDisposition(key, contentDisposition string, expiry time.Duration) (string, error)
 	DeleteObject(key string) error
 	ObjectExists(key string) (bool, error)
+	HeadObject(key string) (*ObjectMetadata, error)
+	GetObject(key string) (io.ReadCloser, error)
+	PutObject(key string, body io.Reader, contentType string) error
 }
 
 // S3Client wraps the AWS S3 client with our custom methods
@@ -92,6 +106,24 @@ func (client *S3Client) GenerateDownloadURL(key string, expiry time.Duration) (s
 	return presignResult.URL, nil
 }
 
+// GenerateDownloadURLWithDisposition creates a presigned download URL that makes S3 respond with
+// the given Content-Disposition header, so browsers save the file under its original name
+func (client *S3Client) GenerateDownloadURLWithDisposition(key, contentDisposition string, expiry time.Duration) (string, error) {
+	ctx := context.Background()
+
+	presignResult, err := client.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket:                     aws.String(client.bucket),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(contentDisposition),
+	}, s3.WithPresignExpires(expiry))
+
+	if err != nil {
+		return "", err
+	}
+
+	return presignResult.URL, nil
+}
+
 // DeleteObject deletes an object from S3
 func (client *S3Client) DeleteObject(key string) error {
 	ctx := context.Background()
@@ -118,4 +150,59 @@ func (client *S3Client) ObjectExists(key string) (bool, error) {
 	}
 
 	return true, nil
-}
\ No newline at end of file
+}
+
+// HeadObject fetches an object's metadata without downloading it.
+// Returns an "object not found" error when the key does not exist.
+func (client *S3Client) HeadObject(key string) (*ObjectMetadata, error) {
+	ctx := context.Background()
+
+	result, err := client.svc.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(client.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		var notFound *types.NotFound
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &notFound) || errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("object not found")
+		}
+		return nil, err
+	}
+
+	return &ObjectMetadata{
+		ContentLength: aws.ToInt64(result.ContentLength),
+		ContentType:   aws.ToString(result.ContentType),
+	}, nil
+}
+
+// GetObject opens an object for reading; the caller must close the returned body
+func (client *S3Client) GetObject(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+
+	result, err := client.svc.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(client.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Body, nil
+}
+
+// PutObject uploads an object to S3
+func (client *S3Client) PutObject(key string, body io.Reader, contentType string) error {
+	ctx := context.Background()
+
+	_, err := client.svc.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(client.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+
+	return err
+}
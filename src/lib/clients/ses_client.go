@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// EmailMessage is a plain-text email to a single recipient
+type EmailMessage struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// SESClientInterface defines the interface for SES operations
+type SESClientInterface interface {
+	SendEmail(ctx context.Context, message EmailMessage) error
+}
+
+// SESClient wraps the AWS SES v2 client with our custom methods
+type SESClient struct {
+	svc *sesv2.Client
+}
+
+// NewSESClient creates a new SES client instance
+func NewSESClient(isLocal bool) SESClientInterface {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion("us-east-2"),
+	)
+	if err != nil {
+		panic("failed to load AWS configuration: " + err.Error())
+	}
+
+	svc := sesv2.NewFromConfig(cfg, func(o *sesv2.Options) {
+		if isLocal {
+			// LocalStack configuration
+			o.BaseEndpoint = aws.String("http://docker.for.mac.host.internal:4566")
+		}
+	})
+
+	return &SESClient{svc: svc}
+}
+
+// SendEmail sends message as a simple UTF-8 text email and returns an error when SES rejects it
+func (client *SESClient) SendEmail(ctx context.Context, message EmailMessage) error {
+	_, err := client.svc.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(message.From),
+		Destination: &types.Destination{
+			ToAddresses: []string{message.To},
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(message.Subject), Charset: aws.String("UTF-8")},
+				Body: &types.Body{
+					Text: &types.Content{Data: aws.String(message.Body), Charset: aws.String("UTF-8")},
+				},
+			},
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSESClient points an SES client at server with static credentials and no retries
+func testSESClient(server *httptest.Server) *SESClient {
+	return &SESClient{svc: sesv2.New(sesv2.Options{
+		Region:       "us-east-2",
+		BaseEndpoint: aws.String(server.URL),
+		HTTPClient:   server.Client(),
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+		RetryMaxAttempts: 1,
+	})}
+}
+
+func TestNewSESClient(t *testing.T) {
+	//Act
+	sesClient := NewSESClient(true)
+
+	//Assert
+	assert.NotNil(t, sesClient)
+}
+
+func TestSESClientSendEmail_PostsSignedSimpleMessage(t *testing.T) {
+	//Arrange
+	var gotPath, gotAuth string
+	var gotBody struct {
+		FromEmailAddress string
+		Destination      struct{ ToAddresses []string }
+		Content          struct {
+			Simple struct {
+				Subject struct{ Data, Charset string }
+				Body    struct{ Text struct{ Data, Charset string } }
+			}
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"MessageId":"0100-abc"}`))
+	}))
+	defer server.Close()
+	client := testSESClient(server)
+
+	//Act
+	err := client.SendEmail(context.Background(), EmailMessage{
+		From:    "no-reply@buildboard.example",
+		To:      "jane+rfi@example.com",
+		Subject: "RFI-0001 assigned to you",
+		Body:    "Please respond",
+	})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "/v2/email/outbound-emails", gotPath)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+	assert.Contains(t, gotAuth, "/us-east-2/ses/aws4_request")
+	assert.Equal(t, "no-reply@buildboard.example", gotBody.FromEmailAddress)
+	assert.Equal(t, []string{"jane+rfi@example.com"}, gotBody.Destination.ToAddresses)
+	assert.Equal(t, "RFI-0001 assigned to you", gotBody.Content.Simple.Subject.Data)
+	assert.Equal(t, "UTF-8", gotBody.Content.Simple.Subject.Charset)
+	assert.Equal(t, "Please respond", gotBody.Content.Simple.Body.Text.Data)
+}
+
+func TestSESClientSendEmail_ReturnsErrorOnRejection(t *testing.T) {
+	//Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Amzn-ErrorType", "MessageRejected")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Email address is not verified."}`))
+	}))
+	defer server.Close()
+	client := testSESClient(server)
+
+	//Act
+	err := client.SendEmail(context.Background(), EmailMessage{From: "a@example.com", To: "b@example.com"})
+
+	//Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "MessageRejected")
+	assert.Contains(t, err.Error(), "not verified")
+}
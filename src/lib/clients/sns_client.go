@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// SNSClientInterface defines the interface for SNS operations
+type SNSClientInterface interface {
+	Publish(topicARN, message string, attributes map[string]string) error
+}
+
+// SNSClient wraps the AWS SNS client with our custom methods
+type SNSClient struct {
+	svc *sns.Client
+}
+
+// NewSNSClient creates a new SNS client instance
+func NewSNSClient(isLocal bool) SNSClientInterface {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion("us-east-2"),
+	)
+	if err != nil {
+		panic("failed to load AWS configuration: " + err.Error())
+	}
+
+	svc := sns.NewFromConfig(cfg, func(o *sns.Options) {
+		if isLocal {
+			// LocalStack configuration
+			o.BaseEndpoint = aws.String("http://docker.for.mac.host.internal:4566")
+		}
+	})
+
+	return &SNSClient{svc: svc}
+}
+
+// PublishEvent marshals event to JSON and publishes it to the topic
+func PublishEvent(client SNSClientInterface, topicARN string, event interface{}, attributes map[string]string) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return client.Publish(topicARN, string(message), attributes)
+}
+
+// Publish sends a message to the topic; attributes become string message attributes subscribers can filter on
+func (client *SNSClient) Publish(topicARN, message string, attributes map[string]string) error {
+	messageAttributes := make(map[string]types.MessageAttributeValue, len(attributes))
+	for name, value := range attributes {
+		messageAttributes[name] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+
+	_, err := client.svc.Publish(context.Background(), &sns.PublishInput{
+		TopicArn:          aws.String(topicARN),
+		Message:           aws.String(message),
+		MessageAttributes: messageAttributes,
+	})
+
+	return err
+}
@@ -1,15 +1,27 @@
 package constants
 
 const (
-	ALLOWED_ORIGINS          = "/infrastructure/ALLOWED_ORIGINS"
-	DATABASE_RDS_PROXY_URL   = "/infrastructure/DATABASE_RDS_PROXY_URL"
-	DATABASE_RDS_ENDPOINT    = "/infrastructure/DATABASE_RDS_ENDPOINT"
-	DATABASE_PORT            = "/infrastructure/DATABASE_PORT"
-	DATABASE_NAME            = "/infrastructure/DATABASE_NAME"
-	DATABASE_USERNAME        = "/infrastructure/DATABASE_USERNAME"
-	DATABASE_PASSWORD        = "/infrastructure/DATABASE_PASSWORD"
-	SSL_MODE                 = "/infrastructure/SSL_MODE"
-	COGNITO_USER_POOL_ID     = "/infrastructure/COGNITO_USER_POOL_ID"
-	COGNITO_CLIENT_ID        = "/infrastructure/COGNITO_CLIENT_ID"
-	DRIVER_NAME              = "postgres"
+	ALLOWED_ORIGINS                     = "/infrastructure/ALLOWED_ORIGINS"
+	DATABASE_RDS_PROXY_URL              = "/infrastructure/DATABASE_RDS_PROXY_URL"
+	DATABASE_RDS_ENDPOINT               = "/infrastructure/DATABASE_RDS_ENDPOINT"
+	DATABASE_PORT                       = "/infrastructure/DATABASE_PORT"
+	DATABASE_NAME                       = "/infrastructure/DATABASE_NAME"
+	DATABASE_USERNAME                   = "/infrastructure/DATABASE_USERNAME"
+	DATABASE_PASSWORD                   = "/infrastructure/DATABASE_PASSWORD"
+	SSL_MODE                            = "/infrastructure/SSL_MODE"
+	COGNITO_USER_POOL_ID                = "/infrastructure/COGNITO_USER_POOL_ID"
+	COGNITO_CLIENT_ID                   = "/infrastructure/COGNITO_CLIENT_ID"
+	ISSUE_EVENTS_TOPIC_ARN              = "/infrastructure/ISSUE_EVENTS_TOPIC_ARN"
+	DATABASE_MAX_RETRIES                = "/infrastructure/DATABASE_MAX_RETRIES"
+	DATABASE_MAX_OPEN_CONNS             = "/infrastructure/DATABASE_MAX_OPEN_CONNS"
+	DATABASE_MAX_IDLE_CONNS             = "/infrastructure/DATABASE_MAX_IDLE_CONNS"
+	DATABASE_CONN_MAX_LIFETIME_SECONDS  = "/infrastructure/DATABASE_CONN_MAX_LIFETIME_SECONDS"
+	DATABASE_CONN_MAX_IDLE_TIME_SECONDS = "/infrastructure/DATABASE_CONN_MAX_IDLE_TIME_SECONDS"
+	RATE_LIMIT_ISSUE_LIST               = "/infrastructure/RATE_LIMIT_ISSUE_LIST"
+	RATE_LIMIT_RFI_LIST                 = "/infrastructure/RATE_LIMIT_RFI_LIST"
+	SES_FROM_ADDRESS                    = "/infrastructure/SES_FROM_ADDRESS"
+	RFI_ASSIGNMENT_EMAIL_TEMPLATE       = "/infrastructure/RFI_ASSIGNMENT_EMAIL_TEMPLATE"
+	APP_BASE_URL                        = "/infrastructure/APP_BASE_URL"
+	ATTACHMENT_SCAN_API_KEY             = "/infrastructure/ATTACHMENT_SCAN_API_KEY"
+	DRIVER_NAME                         = "postgres"
 )
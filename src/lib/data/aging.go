@@ -0,0 +1,41 @@
+package data
+
+import (
+	"fmt"
+	"infrastructure/lib/models"
+)
+
+// wholeDaysSinceSQL returns a SQL expression for the whole days elapsed since the timestamp
+// expression, computed by the database relative to now and never negative.
+func wholeDaysSinceSQL(timestamp string) string {
+	return fmt.Sprintf("GREATEST(FLOOR(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - %s)) / 86400), 0)::int", timestamp)
+}
+
+// agingColumnsSQL returns the age_days and days_since_last_activity select columns for an entity table
+// alias. Last activity is the later of the entity's updated_at and its newest comment.
+func agingColumnsSQL(alias, commentTable, commentFK string) string {
+	lastActivity := fmt.Sprintf(
+		"GREATEST(%[1]s.updated_at, COALESCE((SELECT MAX(c.created_at) FROM %[2]s c WHERE c.%[3]s = %[1]s.id), %[1]s.updated_at))",
+		alias, commentTable, commentFK)
+	return fmt.Sprintf("%s as age_days,\n\t\t\t%s as days_since_last_activity",
+		wholeDaysSinceSQL(alias+".created_at"), wholeDaysSinceSQL(lastActivity))
+}
+
+// agingOrderBySQL builds the ORDER BY clause for the sort and order filters (validated by the handler).
+// Defaults to newest first.
+func agingOrderBySQL(filters map[string]string, alias string) string {
+	column := alias + ".created_at"
+	switch filters["sort"] {
+	case models.SortAgeDays:
+		column = "age_days"
+	case models.SortDaysSinceLastActivity:
+		column = "days_since_last_activity"
+	}
+
+	direction := "DESC"
+	if filters["order"] == "asc" {
+		direction = "ASC"
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s, %s.id %s", column, direction, alias, direction)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"hash/fnv"
 	"infrastructure/lib/models"
 	"strconv"
 	"strings"
@@ -22,11 +23,13 @@ type AssignmentRepository interface {
 
 	// Bulk operations
 	CreateBulkAssignments(ctx context.Context, req *models.BulkAssignmentRequest, userID int64) ([]models.AssignmentResponse, error)
+	CreateAssignmentBatch(ctx context.Context, reqs []models.CreateAssignmentRequest, orgID, userID int64) ([]AssignmentBatchResult, error)
 	TransferAssignments(ctx context.Context, req *models.AssignmentTransferRequest, userID int64) error
 
 	// Query operations
 	GetAssignments(ctx context.Context, filters *models.AssignmentFilters, orgID int64) (*models.AssignmentListResponse, error)
 	GetUserAssignments(ctx context.Context, userID int64, orgID int64) (*models.UserAssignmentSummary, error)
+	GetAssignmentsByUser(ctx context.Context, userID, orgID int64, contextType string) ([]models.AssignmentResponse, error)
 	GetContextAssignments(ctx context.Context, contextType string, contextID int64, orgID int64) (*models.ContextAssignmentSummary, error)
 
 	// Permission checking
@@ -44,6 +47,12 @@ type AssignmentDao struct {
 	Logger *logrus.Logger
 }
 
+// AssignmentBatchResult is the outcome of one item of CreateAssignmentBatch; Err is nil on success
+type AssignmentBatchResult struct {
+	Assignment *models.AssignmentResponse
+	Err        error
+}
+
 // NewAssignmentRepository creates a new AssignmentRepository instance
 func NewAssignmentRepository(db *sql.DB) AssignmentRepository {
 	return &AssignmentDao{
@@ -52,7 +61,8 @@ func NewAssignmentRepository(db *sql.DB) AssignmentRepository {
 	}
 }
 
-// CreateAssignment creates a new user assignment
+// CreateAssignment creates a new user assignment. A live assignment of the same user, role and context
+// whose dates overlap the new one is reported as *models.AssignmentConflictError.
 func (dao *AssignmentDao) CreateAssignment(ctx context.Context, req *models.CreateAssignmentRequest, userID int64) (*models.AssignmentResponse, error) {
 	// Validate the context exists and belongs to the organization
 	err := dao.ValidateAssignmentContext(ctx, req.ContextType, req.ContextID, 0) // Will be validated in the method
@@ -82,6 +92,20 @@ func (dao *AssignmentDao) CreateAssignment(ctx context.Context, req *models.Crea
 	var assignmentID int64
 	var createdAt, updatedAt time.Time
 
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	conflictID, err := findConflictingAssignment(ctx, tx, req, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if conflictID != 0 {
+		return nil, &models.AssignmentConflictError{AssignmentID: conflictID}
+	}
+
 	query := `
 		INSERT INTO iam.user_assignments (
 			user_id, role_id, context_type, context_id, trade_type, is_primary,
@@ -91,7 +115,7 @@ func (dao *AssignmentDao) CreateAssignment(ctx context.Context, req *models.Crea
 		RETURNING id, created_at, updated_at
 	`
 
-	err = dao.DB.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		req.UserID, req.RoleID, req.ContextType, req.ContextID, tradeType,
 		req.IsPrimary, startDate, endDate, userID, userID,
 	).Scan(&assignmentID, &createdAt, &updatedAt)
@@ -107,6 +131,16 @@ func (dao *AssignmentDao) CreateAssignment(ctx context.Context, req *models.Crea
 		return nil, fmt.Errorf("failed to create assignment: %w", err)
 	}
 
+	if req.ContextType == models.ContextTypeProject {
+		if err := recordProjectUserRoleHistory(ctx, tx, req.ContextID, req.UserID, req.RoleID, sql.NullInt64{}, models.ProjectUserRoleActionAssigned, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	dao.Logger.WithFields(logrus.Fields{
 		"assignment_id": assignmentID,
 		"user_id":       req.UserID,
@@ -152,7 +186,7 @@ func (dao *AssignmentDao) GetAssignment(ctx context.Context, assignmentID int64,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("assignment not found")
+		return nil, notFoundError("assignment not found")
 	}
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to get assignment")
@@ -232,6 +266,27 @@ func (dao *AssignmentDao) UpdateAssignment(ctx context.Context, assignmentID int
 	args = append(args, assignmentID)
 	whereClause := fmt.Sprintf("WHERE id = $%d AND is_deleted = FALSE", argIndex)
 
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the live row so a project role change records the role it replaced
+	var assignedUserID, previousRoleID, contextID int64
+	var contextType string
+	err = tx.QueryRowContext(ctx, `
+		SELECT user_id, role_id, context_type, context_id FROM iam.user_assignments
+		WHERE id = $1 AND is_deleted = FALSE
+		FOR UPDATE
+	`, assignmentID).Scan(&assignedUserID, &previousRoleID, &contextType, &contextID)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("assignment not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+
 	query := fmt.Sprintf(`
 		UPDATE iam.user_assignments
 		SET %s
@@ -240,34 +295,64 @@ func (dao *AssignmentDao) UpdateAssignment(ctx context.Context, assignmentID int
 	`, strings.Join(setParts, ", "), whereClause)
 
 	var updatedID int64
-	err := dao.DB.QueryRowContext(ctx, query, args...).Scan(&updatedID)
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&updatedID)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("assignment not found")
+		return nil, notFoundError("assignment not found")
 	}
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to update assignment")
 		return nil, fmt.Errorf("failed to update assignment: %w", err)
 	}
 
+	if contextType == models.ContextTypeProject && req.RoleID != nil && *req.RoleID != previousRoleID {
+		if err := recordProjectUserRoleHistory(ctx, tx, contextID, assignedUserID, *req.RoleID,
+			sql.NullInt64{Int64: previousRoleID, Valid: true}, models.ProjectUserRoleActionRoleChanged, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return dao.GetAssignment(ctx, assignmentID, 0)
 }
 
 // DeleteAssignment soft deletes an assignment
 func (dao *AssignmentDao) DeleteAssignment(ctx context.Context, assignmentID int64, userID int64) error {
-	result, err := dao.DB.ExecContext(ctx, `
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var assignedUserID, roleID, contextID int64
+	var contextType string
+	err = tx.QueryRowContext(ctx, `
 		UPDATE iam.user_assignments
 		SET is_deleted = TRUE, updated_by = $1
 		WHERE id = $2 AND is_deleted = FALSE
-	`, userID, assignmentID)
+		RETURNING user_id, role_id, context_type, context_id
+	`, userID, assignmentID).Scan(&assignedUserID, &roleID, &contextType, &contextID)
+
+	if err == sql.ErrNoRows {
+		return notFoundError("assignment not found")
+	}
 
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to delete assignment")
 		return fmt.Errorf("failed to delete assignment: %w", err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("assignment not found")
+	// The soft delete leaves no trace of who removed the user, so project removals go in the history
+	if contextType == models.ContextTypeProject {
+		if err := recordProjectUserRoleHistory(ctx, tx, contextID, assignedUserID, roleID, sql.NullInt64{}, models.ProjectUserRoleActionRemoved, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	dao.Logger.WithFields(logrus.Fields{
@@ -396,6 +481,54 @@ func (dao *AssignmentDao) ValidateAssignmentContext(ctx context.Context, context
 	return nil
 }
 
+// assignmentResponseColumns is the enriched select list shared by assignment list queries.
+// It expects iam.user_assignments as ua, iam.users as u and iam.roles as r.
+const assignmentResponseColumns = `
+	ua.id, ua.user_id, ua.role_id, ua.context_type, ua.context_id,
+	ua.trade_type, ua.is_primary, ua.start_date, ua.end_date,
+	ua.created_at, ua.created_by, ua.updated_at, ua.updated_by, ua.is_deleted,
+	COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '') as user_name,
+	u.email as user_email,
+	r.name as role_name,
+	CASE ua.context_type
+		WHEN 'project' THEN (SELECT name FROM project.projects WHERE id = ua.context_id)
+		WHEN 'location' THEN (SELECT name FROM iam.locations WHERE id = ua.context_id)
+		WHEN 'organization' THEN (SELECT name FROM iam.organizations WHERE id = ua.context_id)
+		ELSE 'Unknown'
+	END as context_name`
+
+// scanAssignmentResponse scans a row selected with assignmentResponseColumns
+func scanAssignmentResponse(scanner interface{ Scan(...interface{}) error }) (*models.AssignmentResponse, error) {
+	var assignment models.AssignmentResponse
+	var tradeType sql.NullString
+	var startDate, endDate sql.NullTime
+
+	err := scanner.Scan(
+		&assignment.ID, &assignment.UserID, &assignment.RoleID, &assignment.ContextType, &assignment.ContextID,
+		&tradeType, &assignment.IsPrimary, &startDate, &endDate,
+		&assignment.CreatedAt, &assignment.CreatedBy, &assignment.UpdatedAt, &assignment.UpdatedBy, &assignment.IsDeleted,
+		&assignment.UserName, &assignment.UserEmail, &assignment.RoleName, &assignment.ContextName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle nullable fields
+	if tradeType.Valid {
+		assignment.TradeType = &tradeType.String
+	}
+	if startDate.Valid {
+		dateStr := startDate.Time.Format("2006-01-02")
+		assignment.StartDate = &dateStr
+	}
+	if endDate.Valid {
+		dateStr := endDate.Time.Format("2006-01-02")
+		assignment.EndDate = &dateStr
+	}
+
+	return &assignment, nil
+}
+
 // GetAssignments retrieves assignments with filters
 func (dao *AssignmentDao) GetAssignments(ctx context.Context, filters *models.AssignmentFilters, orgID int64) (*models.AssignmentListResponse, error) {
 	whereConditions := []string{"ua.is_deleted = FALSE"}
@@ -483,26 +616,14 @@ func (dao *AssignmentDao) GetAssignments(ctx context.Context, filters *models.As
 
 	// Main query with enriched data
 	query := fmt.Sprintf(`
-		SELECT
-			ua.id, ua.user_id, ua.role_id, ua.context_type, ua.context_id,
-			ua.trade_type, ua.is_primary, ua.start_date, ua.end_date,
-			ua.created_at, ua.created_by, ua.updated_at, ua.updated_by, ua.is_deleted,
-			COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '') as user_name,
-			u.email as user_email,
-			r.name as role_name,
-			CASE ua.context_type
-				WHEN 'project' THEN (SELECT name FROM project.projects WHERE id = ua.context_id)
-				WHEN 'location' THEN (SELECT name FROM iam.locations WHERE id = ua.context_id)
-				WHEN 'organization' THEN (SELECT name FROM iam.organizations WHERE id = ua.context_id)
-				ELSE 'Unknown'
-			END as context_name
+		SELECT %s
 		FROM iam.user_assignments ua
 		LEFT JOIN iam.users u ON ua.user_id = u.id
 		LEFT JOIN iam.roles r ON ua.role_id = r.id
 		WHERE %s
 		ORDER BY ua.created_at DESC
 		LIMIT $%d OFFSET $%d
-	`, strings.Join(whereConditions, " AND "), argIndex, argIndex+1)
+	`, assignmentResponseColumns, strings.Join(whereConditions, " AND "), argIndex, argIndex+1)
 
 	args = append(args, pageSize, offset)
 
@@ -514,35 +635,12 @@ func (dao *AssignmentDao) GetAssignments(ctx context.Context, filters *models.As
 
 	var assignments []models.AssignmentResponse
 	for rows.Next() {
-		var assignment models.AssignmentResponse
-		var tradeType sql.NullString
-		var startDate, endDate sql.NullTime
-
-		err := rows.Scan(
-			&assignment.ID, &assignment.UserID, &assignment.RoleID, &assignment.ContextType, &assignment.ContextID,
-			&tradeType, &assignment.IsPrimary, &startDate, &endDate,
-			&assignment.CreatedAt, &assignment.CreatedBy, &assignment.UpdatedAt, &assignment.UpdatedBy, &assignment.IsDeleted,
-			&assignment.UserName, &assignment.UserEmail, &assignment.RoleName, &assignment.ContextName,
-		)
+		assignment, err := scanAssignmentResponse(rows)
 		if err != nil {
 			dao.Logger.WithError(err).Error("Failed to scan assignment row")
 			continue
 		}
-
-		// Handle nullable fields
-		if tradeType.Valid {
-			assignment.TradeType = &tradeType.String
-		}
-		if startDate.Valid {
-			dateStr := startDate.Time.Format("2006-01-02")
-			assignment.StartDate = &dateStr
-		}
-		if endDate.Valid {
-			dateStr := endDate.Time.Format("2006-01-02")
-			assignment.EndDate = &dateStr
-		}
-
-		assignments = append(assignments, assignment)
+		assignments = append(assignments, *assignment)
 	}
 
 	return &models.AssignmentListResponse{
@@ -602,6 +700,52 @@ func (dao *AssignmentDao) GetUserAssignments(ctx context.Context, userID int64,
 	}, nil
 }
 
+// GetAssignmentsByUser returns every active assignment a user holds in the organization across all
+// contexts, unpaginated. A non-empty contextType limits the result to that context type.
+// An empty slice (never nil) is returned when the user has no assignments.
+func (dao *AssignmentDao) GetAssignmentsByUser(ctx context.Context, userID, orgID int64, contextType string) ([]models.AssignmentResponse, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM iam.user_assignments ua
+		JOIN iam.users u ON ua.user_id = u.id
+		LEFT JOIN iam.roles r ON ua.role_id = r.id
+		WHERE ua.user_id = $1
+			AND u.org_id = $2
+			AND ua.is_deleted = FALSE
+			AND (ua.start_date IS NULL OR ua.start_date <= NOW())
+			AND (ua.end_date IS NULL OR ua.end_date >= NOW())
+			AND ($3 = '' OR ua.context_type = $3)
+		ORDER BY ua.context_type, ua.context_id, ua.id
+	`, assignmentResponseColumns)
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, orgID, contextType)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id":      userID,
+			"org_id":       orgID,
+			"context_type": contextType,
+			"error":        err.Error(),
+		}).Error("Failed to query user assignments")
+		return nil, fmt.Errorf("failed to query user assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []models.AssignmentResponse{}
+	for rows.Next() {
+		assignment, err := scanAssignmentResponse(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, *assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
 // GetContextAssignments gets all assignments for a specific context
 func (dao *AssignmentDao) GetContextAssignments(ctx context.Context, contextType string, contextID int64, orgID int64) (*models.ContextAssignmentSummary, error) {
 	filters := &models.AssignmentFilters{
@@ -778,4 +922,197 @@ func (dao *AssignmentDao) GetActiveAssignments(ctx context.Context, userID int64
 	}
 
 	return assignmentList.Assignments, nil
-}
\ No newline at end of file
+}
+
+// CreateAssignmentBatch creates independent assignments in a single transaction. Each item runs under its own
+// savepoint, so an invalid or conflicting item is reported and skipped without losing the others.
+// Users and contexts must belong to orgID. Results are returned in request order.
+func (dao *AssignmentDao) CreateAssignmentBatch(ctx context.Context, reqs []models.CreateAssignmentRequest, orgID, userID int64) ([]AssignmentBatchResult, error) {
+	results := make([]AssignmentBatchResult, len(reqs))
+
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Overlaps within the request are caught like any other: findConflictingAssignment sees the
+	// items this transaction has already inserted
+	createdIDs := make([]int64, len(reqs))
+	for i := range reqs {
+		req := &reqs[i]
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_assignment"); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		assignmentID, itemErr := dao.createAssignmentInTx(ctx, tx, req, orgID, userID)
+		if itemErr != nil {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_assignment"); err != nil {
+				return nil, fmt.Errorf("failed to roll back savepoint: %w", err)
+			}
+			results[i].Err = itemErr
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_assignment"); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		createdIDs[i] = assignmentID
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	created := 0
+	for i, assignmentID := range createdIDs {
+		if assignmentID == 0 {
+			continue
+		}
+		created++
+		assignment, err := dao.GetAssignment(ctx, assignmentID, orgID)
+		if err != nil {
+			// The row is committed; fall back to the bare id rather than reporting a failure
+			assignment = &models.AssignmentResponse{ID: assignmentID, UserID: reqs[i].UserID, RoleID: reqs[i].RoleID,
+				ContextType: reqs[i].ContextType, ContextID: reqs[i].ContextID}
+		}
+		results[i].Assignment = assignment
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"org_id":    orgID,
+		"requested": len(reqs),
+		"created":   created,
+	}).Info("Bulk assignment creation completed")
+
+	return results, nil
+}
+
+// assignmentLockID derives the advisory lock key for a user, role and context. Collisions only cause
+// unrelated assignments to briefly serialize.
+func assignmentLockID(req *models.CreateAssignmentRequest) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "user_assignment:%d:%d:%s:%d", req.UserID, req.RoleID, req.ContextType, req.ContextID)
+	return int64(h.Sum64())
+}
+
+// findConflictingAssignment returns the id of a live assignment of the same user, role and context whose
+// dates overlap the requested ones (missing dates are open-ended), or 0 when there is none.
+// It first takes a transaction-scoped advisory lock for the user, role and context: locking existing rows
+// cannot stop two transactions that both find no conflict from inserting overlapping assignments, so
+// concurrent creates of the same assignment wait here until the first one commits or rolls back.
+func findConflictingAssignment(ctx context.Context, tx *sql.Tx, req *models.CreateAssignmentRequest, startDate, endDate sql.NullTime) (int64, error) {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, assignmentLockID(req)); err != nil {
+		return 0, fmt.Errorf("failed to acquire assignment lock: %w", err)
+	}
+
+	var conflictID int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM iam.user_assignments
+		WHERE user_id = $1 AND role_id = $2 AND context_type = $3 AND context_id = $4
+		AND is_deleted = FALSE
+		AND (start_date IS NULL OR $6::date IS NULL OR start_date <= $6::date)
+		AND (end_date IS NULL OR $5::date IS NULL OR end_date >= $5::date)
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE
+	`, req.UserID, req.RoleID, req.ContextType, req.ContextID, startDate, endDate).Scan(&conflictID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check assignment conflicts: %w", err)
+	}
+	return conflictID, nil
+}
+
+// createAssignmentInTx validates and inserts one assignment of a batch
+func (dao *AssignmentDao) createAssignmentInTx(ctx context.Context, tx *sql.Tx, req *models.CreateAssignmentRequest, orgID, userID int64) (int64, error) {
+	if req.UserID <= 0 || req.RoleID <= 0 || req.ContextID <= 0 || req.ContextType == "" {
+		return 0, invalidError("user_id, role_id, context_type and context_id are required")
+	}
+
+	var startDate, endDate sql.NullTime
+	if req.StartDate != "" {
+		t, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return 0, invalidError("invalid start_date format, expected YYYY-MM-DD")
+		}
+		startDate = sql.NullTime{Time: t, Valid: true}
+	}
+	if req.EndDate != "" {
+		t, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return 0, invalidError("invalid end_date format, expected YYYY-MM-DD")
+		}
+		endDate = sql.NullTime{Time: t, Valid: true}
+	}
+
+	var userInOrg bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM iam.users WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE)
+	`, req.UserID, orgID).Scan(&userInOrg); err != nil {
+		return 0, fmt.Errorf("failed to validate user: %w", err)
+	}
+	if !userInOrg {
+		return 0, orgMismatchError("user does not belong to your organization")
+	}
+
+	var contextQuery string
+	switch req.ContextType {
+	case models.ContextTypeOrganization:
+		contextQuery = "SELECT EXISTS(SELECT 1 FROM iam.organizations WHERE id = $1 AND id = $2 AND is_deleted = FALSE)"
+	case models.ContextTypeProject:
+		contextQuery = "SELECT EXISTS(SELECT 1 FROM project.projects WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE)"
+	case models.ContextTypeLocation:
+		contextQuery = "SELECT EXISTS(SELECT 1 FROM iam.locations WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE)"
+	default:
+		return 0, invalidError(fmt.Sprintf("unsupported context type: %s", req.ContextType))
+	}
+	var contextInOrg bool
+	if err := tx.QueryRowContext(ctx, contextQuery, req.ContextID, orgID).Scan(&contextInOrg); err != nil {
+		return 0, fmt.Errorf("failed to validate context: %w", err)
+	}
+	if !contextInOrg {
+		return 0, orgMismatchError("context does not belong to your organization")
+	}
+
+	conflictID, err := findConflictingAssignment(ctx, tx, req, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	if conflictID != 0 {
+		return 0, &models.AssignmentConflictError{AssignmentID: conflictID}
+	}
+
+	tradeType := sql.NullString{String: req.TradeType, Valid: req.TradeType != ""}
+	var assignmentID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO iam.user_assignments (
+			user_id, role_id, context_type, context_id, trade_type, is_primary,
+			start_date, end_date, created_by, updated_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`, req.UserID, req.RoleID, req.ContextType, req.ContextID, tradeType,
+		req.IsPrimary, startDate, endDate, userID, userID,
+	).Scan(&assignmentID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id":      req.UserID,
+			"role_id":      req.RoleID,
+			"context_type": req.ContextType,
+			"context_id":   req.ContextID,
+			"error":        err.Error(),
+		}).Error("Failed to create assignment in batch")
+		return 0, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	if req.ContextType == models.ContextTypeProject {
+		if err := recordProjectUserRoleHistory(ctx, tx, req.ContextID, req.UserID, req.RoleID, sql.NullInt64{}, models.ProjectUserRoleActionAssigned, userID); err != nil {
+			return 0, err
+		}
+	}
+
+	return assignmentID, nil
+}
@@ -5,19 +5,35 @@ import (
 	"database/sql"
 	"fmt"
 	"infrastructure/lib/models"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 // AttachmentRepository defines the interface for attachment operations
 type AttachmentRepository interface {
 	CreateAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error)
+	CreateAttachments(ctx context.Context, attachments []*models.Attachment) error
 	GetAttachment(ctx context.Context, attachmentID int64, entityType string) (*models.Attachment, error)
-	GetAttachmentsByEntity(ctx context.Context, entityType string, entityID int64, filters map[string]string) ([]models.Attachment, error)
+	GetAttachmentsByEntity(ctx context.Context, entityType string, entityID int64, filters map[string]string, limit, offset int) ([]models.Attachment, error)
+	CountAttachmentsByEntity(ctx context.Context, entityType string, entityID int64, filters map[string]string) (int, error)
+	CountByEntity(ctx context.Context, entityType string, entityID int64) (int, error)
+	CountAttachmentsByEntities(ctx context.Context, entityType string, entityIDs []int64, orgID int64) (map[int64]int, error)
+	GetProjectAttachmentFiles(ctx context.Context, projectID int64) ([]models.Attachment, error)
+	FindOrphans(ctx context.Context, orgID int64) ([]models.Attachment, error)
+	RelinkCommentAttachment(ctx context.Context, attachmentID int64, entityType string, commentID, orgID, userID int64) (*models.Attachment, error)
+	UpdateAttachmentEntity(ctx context.Context, attachmentID int64, entityType string, entityID, orgID, userID int64) (*models.Attachment, error)
 	UpdateAttachmentStatus(ctx context.Context, attachmentID int64, entityType string, status string) error
+	FindAttachmentEntityType(ctx context.Context, attachmentID, userID int64) (string, error)
+	ConfirmAttachmentUpload(ctx context.Context, attachmentID int64, entityType string, fileSize, userID int64) error
+	SetAttachmentThumbnail(ctx context.Context, attachmentID int64, entityType string, thumbnailPath string) error
+	SetAttachmentScanStatus(ctx context.Context, attachmentID int64, entityType string, scanStatus string) error
 	SoftDeleteAttachment(ctx context.Context, attachmentID int64, entityType string, userID int64) error
 	VerifyAttachmentAccess(ctx context.Context, attachmentID int64, entityType string, orgID int64) (bool, error)
+	LogAttachmentAccess(ctx context.Context, entry *models.AttachmentAccessLog) error
+	GetAttachmentAccessLog(ctx context.Context, attachmentID int64, entityType string, orgID int64) ([]models.AttachmentAccessLog, error)
 }
 
 // AttachmentDao implements the AttachmentRepository interface
@@ -28,19 +44,49 @@ type AttachmentDao struct {
 
 // CreateAttachment creates a new attachment record in the appropriate table
 func (dao *AttachmentDao) CreateAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	if err := dao.insertAttachment(ctx, dao.DB, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// CreateAttachments creates several attachment records in one transaction, so either every row is
+// created or none are
+func (dao *AttachmentDao) CreateAttachments(ctx context.Context, attachments []*models.Attachment) error {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, attachment := range attachments {
+		if err := dao.insertAttachment(ctx, tx, attachment); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// insertAttachment inserts an attachment row and fills in its generated ID and timestamps
+func (dao *AttachmentDao) insertAttachment(ctx context.Context, q dbtx, attachment *models.Attachment) error {
 	tableName := models.GetTableName(attachment.EntityType)
 	entityIDColumn := models.GetEntityIDColumn(attachment.EntityType)
 
 	if tableName == "" || entityIDColumn == "" {
-		return nil, fmt.Errorf("unsupported entity type: %s", attachment.EntityType)
+		return fmt.Errorf("unsupported entity type: %s", attachment.EntityType)
 	}
 
+	// Rows created here get an upload URL and stay pending, hidden from listings, until confirmed
 	query := fmt.Sprintf(`
 		INSERT INTO %s (
 			%s, file_name, file_path, file_size, file_type, attachment_type,
-			uploaded_by, created_by, updated_by, created_at, updated_at, is_deleted
+			uploaded_by, created_by, updated_by, created_at, updated_at, is_deleted, upload_status
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 		) RETURNING id, created_at, updated_at
 	`, tableName, entityIDColumn)
 
@@ -56,7 +102,7 @@ func (dao *AttachmentDao) CreateAttachment(ctx context.Context, attachment *mode
 		entityIDValue = attachment.EntityID
 	}
 
-	err := dao.DB.QueryRowContext(ctx, query,
+	err := q.QueryRowContext(ctx, query,
 		entityIDValue,
 		attachment.FileName,
 		attachment.FilePath,
@@ -69,6 +115,7 @@ func (dao *AttachmentDao) CreateAttachment(ctx context.Context, attachment *mode
 		now,
 		now,
 		false,
+		models.UploadStatusPending,
 	).Scan(&id, &createdAt, &updatedAt)
 
 	if err != nil {
@@ -77,13 +124,14 @@ func (dao *AttachmentDao) CreateAttachment(ctx context.Context, attachment *mode
 			"entity_id":   attachment.EntityID,
 			"file_name":   attachment.FileName,
 		}).Error("Failed to create attachment")
-		return nil, err
+		return err
 	}
 
 	attachment.ID = id
 	attachment.CreatedAt = createdAt
 	attachment.UpdatedAt = updatedAt
 	attachment.IsDeleted = false
+	attachment.UploadStatus = models.UploadStatusPending
 
 	dao.Logger.WithFields(logrus.Fields{
 		"attachment_id": id,
@@ -92,7 +140,7 @@ func (dao *AttachmentDao) CreateAttachment(ctx context.Context, attachment *mode
 		"file_name":     attachment.FileName,
 	}).Info("Attachment created successfully")
 
-	return attachment, nil
+	return nil
 }
 
 // GetAttachment retrieves a specific attachment by ID
@@ -107,7 +155,7 @@ func (dao *AttachmentDao) GetAttachment(ctx context.Context, attachmentID int64,
 	query := fmt.Sprintf(`
 		SELECT
 			id, %s, file_name, file_path, file_size, file_type, attachment_type,
-			uploaded_by, created_at, created_by, updated_at, updated_by, is_deleted
+			uploaded_by, upload_status, scan_status, thumbnail_path, created_at, created_by, updated_at, updated_by, is_deleted
 		FROM %s
 		WHERE id = $1 AND is_deleted = false
 	`, entityIDColumn, tableName)
@@ -124,6 +172,9 @@ func (dao *AttachmentDao) GetAttachment(ctx context.Context, attachmentID int64,
 		&attachment.FileType,
 		&attachment.AttachmentType,
 		&attachment.UploadedBy,
+		&attachment.UploadStatus,
+		&attachment.ScanStatus,
+		&attachment.ThumbnailPath,
 		&attachment.CreatedAt,
 		&attachment.CreatedBy,
 		&attachment.UpdatedAt,
@@ -145,8 +196,8 @@ func (dao *AttachmentDao) GetAttachment(ctx context.Context, attachmentID int64,
 	return &attachment, nil
 }
 
-// GetAttachmentsByEntity retrieves all attachments for a specific entity
-func (dao *AttachmentDao) GetAttachmentsByEntity(ctx context.Context, entityType string, entityID int64, filters map[string]string) ([]models.Attachment, error) {
+// GetAttachmentsByEntity retrieves one page of attachments for a specific entity, newest first
+func (dao *AttachmentDao) GetAttachmentsByEntity(ctx context.Context, entityType string, entityID int64, filters map[string]string, limit, offset int) ([]models.Attachment, error) {
 	tableName := models.GetTableName(entityType)
 	entityIDColumn := models.GetEntityIDColumn(entityType)
 
@@ -158,21 +209,21 @@ func (dao *AttachmentDao) GetAttachmentsByEntity(ctx context.Context, entityType
 	baseQuery := fmt.Sprintf(`
 		SELECT
 			id, %s, file_name, file_path, file_size, file_type, attachment_type,
-			uploaded_by, created_at, created_by, updated_at, updated_by, is_deleted
+			uploaded_by, upload_status, scan_status, created_at, created_by, updated_at, updated_by, is_deleted
 		FROM %s
-		WHERE %s = $1 AND is_deleted = false
+		WHERE %s = $1 AND is_deleted = false AND upload_status = 'confirmed'
 	`, entityIDColumn, tableName, entityIDColumn)
 
 	var query string
 	var args []interface{}
-	args = append(args, entityID)
+	args = append(args, entityID, limit, offset)
 
 	// Add attachment_type filter if provided
 	if attachmentType, exists := filters["attachment_type"]; exists && attachmentType != "" {
-		query = baseQuery + " AND attachment_type = $2 ORDER BY created_at DESC"
+		query = baseQuery + " AND attachment_type = $4 ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3"
 		args = append(args, attachmentType)
 	} else {
-		query = baseQuery + " ORDER BY created_at DESC"
+		query = baseQuery + " ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3"
 	}
 
 	rows, err := dao.DB.QueryContext(ctx, query, args...)
@@ -200,6 +251,8 @@ func (dao *AttachmentDao) GetAttachmentsByEntity(ctx context.Context, entityType
 			&attachment.FileType,
 			&attachment.AttachmentType,
 			&attachment.UploadedBy,
+			&attachment.UploadStatus,
+			&attachment.ScanStatus,
 			&attachment.CreatedAt,
 			&attachment.CreatedBy,
 			&attachment.UpdatedAt,
@@ -235,6 +288,411 @@ func (dao *AttachmentDao) GetAttachmentsByEntity(ctx context.Context, entityType
 	return attachments, nil
 }
 
+// CountByEntity returns the number of non-deleted attachments for a specific entity, including
+// uploads that are still pending, so the per-entity limit cannot be bypassed by never confirming
+func (dao *AttachmentDao) CountByEntity(ctx context.Context, entityType string, entityID int64) (int, error) {
+	return dao.countAttachments(ctx, entityType, entityID, nil, false)
+}
+
+// CountAttachmentsByEntity returns the number of non-deleted attachments for a specific entity
+// matching the same filters as GetAttachmentsByEntity
+func (dao *AttachmentDao) CountAttachmentsByEntity(ctx context.Context, entityType string, entityID int64, filters map[string]string) (int, error) {
+	return dao.countAttachments(ctx, entityType, entityID, filters, true)
+}
+
+// countAttachments counts an entity's non-deleted attachments, optionally only confirmed uploads
+func (dao *AttachmentDao) countAttachments(ctx context.Context, entityType string, entityID int64, filters map[string]string, confirmedOnly bool) (int, error) {
+	tableName := models.GetTableName(entityType)
+	entityIDColumn := models.GetEntityIDColumn(entityType)
+
+	if tableName == "" || entityIDColumn == "" {
+		return 0, fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM %s
+		WHERE %s = $1 AND is_deleted = false
+	`, tableName, entityIDColumn)
+	if confirmedOnly {
+		query += " AND upload_status = 'confirmed'"
+	}
+
+	args := []interface{}{entityID}
+	if attachmentType := filters["attachment_type"]; attachmentType != "" {
+		query += " AND attachment_type = $2"
+		args = append(args, attachmentType)
+	}
+
+	var count int
+	err := dao.DB.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"entity_type": entityType,
+			"entity_id":   entityID,
+		}).Error("Failed to count attachments by entity")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// attachmentEntityOrgFilter returns a condition, on attachment alias a and org id parameter $2, that
+// holds when the attachment's entity belongs to the organization
+func attachmentEntityOrgFilter(entityType string) string {
+	switch entityType {
+	case models.EntityTypeProject:
+		return `EXISTS (SELECT 1 FROM project.projects p WHERE p.id = a.project_id AND p.org_id = $2)`
+	case models.EntityTypeIssue:
+		return `EXISTS (
+			SELECT 1 FROM project.issues i
+			JOIN project.projects p ON p.id = i.project_id
+			WHERE i.id = a.issue_id AND p.org_id = $2
+		)`
+	case models.EntityTypeRFI:
+		return `EXISTS (SELECT 1 FROM project.rfis r WHERE r.id = a.rfi_id AND r.org_id = $2)`
+	case models.EntityTypeSubmittal:
+		return `EXISTS (SELECT 1 FROM project.submittals s WHERE s.id = a.submittal_id AND s.org_id = $2)`
+	case models.EntityTypeIssueComment:
+		return `EXISTS (
+			SELECT 1 FROM project.issue_comments c
+			JOIN project.issues i ON i.id = c.issue_id
+			JOIN project.projects p ON p.id = i.project_id
+			WHERE c.id = a.comment_id AND p.org_id = $2
+		)`
+	case models.EntityTypeRFIComment:
+		return `EXISTS (
+			SELECT 1 FROM project.rfi_comments c
+			JOIN project.rfis r ON r.id = c.rfi_id
+			WHERE c.id = a.comment_id AND r.org_id = $2
+		)`
+	}
+	return ""
+}
+
+// CountAttachmentsByEntities returns the number of non-deleted attachments for each of the given
+// entities in one grouped query. Entities with no attachments, or that belong to another
+// organization, are absent from the map.
+func (dao *AttachmentDao) CountAttachmentsByEntities(ctx context.Context, entityType string, entityIDs []int64, orgID int64) (map[int64]int, error) {
+	tableName := models.GetTableName(entityType)
+	entityIDColumn := models.GetEntityIDColumn(entityType)
+	orgFilter := attachmentEntityOrgFilter(entityType)
+
+	if tableName == "" || entityIDColumn == "" || orgFilter == "" {
+		return nil, fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	counts := make(map[int64]int, len(entityIDs))
+	if len(entityIDs) == 0 {
+		return counts, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.%[2]s, COUNT(*)
+		FROM %[1]s a
+		WHERE a.%[2]s = ANY($1) AND a.is_deleted = false AND a.upload_status = 'confirmed' AND %[3]s
+		GROUP BY a.%[2]s
+	`, tableName, entityIDColumn, orgFilter)
+
+	rows, err := dao.DB.QueryContext(ctx, query, pq.Array(entityIDs), orgID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"entity_type":  entityType,
+			"entity_count": len(entityIDs),
+		}).Error("Failed to count attachments by entities")
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entityID int64
+		var count int
+		if err := rows.Scan(&entityID, &count); err != nil {
+			dao.Logger.WithError(err).WithField("entity_type", entityType).Error("Failed to scan attachment count row")
+			return nil, err
+		}
+		counts[entityID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetProjectAttachmentFiles returns every non-deleted project, issue, RFI and submittal attachment
+// belonging to a project, ordered by entity. Attachments on deleted entities, and files that have not
+// passed virus scanning, are excluded.
+func (dao *AttachmentDao) GetProjectAttachmentFiles(ctx context.Context, projectID int64) ([]models.Attachment, error) {
+	query := `
+		SELECT 'project' AS entity_type, a.project_id AS entity_id, a.id, a.file_name, a.file_path, a.file_size
+		FROM project.project_attachments a
+		WHERE a.project_id = $1 AND a.is_deleted = false AND a.upload_status = 'confirmed' AND a.scan_status = 'clean'
+		UNION ALL
+		SELECT 'issue', a.issue_id, a.id, a.file_name, a.file_path, a.file_size
+		FROM project.issue_attachments a
+		JOIN project.issues i ON i.id = a.issue_id
+		WHERE i.project_id = $1 AND i.is_deleted = false AND a.is_deleted = false AND a.upload_status = 'confirmed' AND a.scan_status = 'clean'
+		UNION ALL
+		SELECT 'rfi', a.rfi_id, a.id, a.file_name, a.file_path, a.file_size
+		FROM project.rfi_attachments a
+		JOIN project.rfis r ON r.id = a.rfi_id
+		WHERE r.project_id = $1 AND r.is_deleted = false AND a.is_deleted = false AND a.upload_status = 'confirmed' AND a.scan_status = 'clean'
+		UNION ALL
+		SELECT 'submittal', a.submittal_id, a.id, a.file_name, a.file_path, a.file_size
+		FROM project.submittal_attachments a
+		JOIN project.submittals s ON s.id = a.submittal_id
+		WHERE s.project_id = $1 AND s.is_deleted = false AND a.is_deleted = false AND a.upload_status = 'confirmed' AND a.scan_status = 'clean'
+		ORDER BY entity_type, entity_id, id
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, projectID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("project_id", projectID).Error("Failed to get project attachment files")
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment := models.Attachment{ProjectID: projectID}
+		err := rows.Scan(
+			&attachment.EntityType,
+			&attachment.EntityID,
+			&attachment.ID,
+			&attachment.FileName,
+			&attachment.FilePath,
+			&attachment.FileSize,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).WithField("project_id", projectID).Error("Failed to scan project attachment file row")
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// orphanGracePeriod keeps attachments uploaded for a comment that is still being written out of the orphan list
+const orphanGracePeriod = "1 hour"
+
+// commentTableForAttachment returns the comment table a comment attachment entity type links to
+func commentTableForAttachment(entityType string) string {
+	switch entityType {
+	case models.EntityTypeIssueComment:
+		return "project.issue_comments"
+	case models.EntityTypeRFIComment:
+		return "project.rfi_comments"
+	}
+	return ""
+}
+
+// FindOrphans returns comment attachments in the org that are not linked to a live comment.
+// These come from uploads whose comment was never created or whose link step failed.
+func (dao *AttachmentDao) FindOrphans(ctx context.Context, orgID int64) ([]models.Attachment, error) {
+	query := fmt.Sprintf(`
+		SELECT 'issue_comment' AS entity_type, a.id, COALESCE(a.comment_id, 0), a.file_name, a.file_path, a.file_size,
+			a.file_type, a.attachment_type, a.uploaded_by, a.scan_status, a.created_at, a.created_by, a.updated_at, a.updated_by
+		FROM project.issue_comment_attachments a
+		JOIN iam.users u ON u.id = a.uploaded_by
+		LEFT JOIN project.issue_comments c ON c.id = a.comment_id AND c.is_deleted = false
+		WHERE u.org_id = $1 AND a.is_deleted = false AND c.id IS NULL
+		AND a.created_at < NOW() - INTERVAL '%[1]s'
+		UNION ALL
+		SELECT 'rfi_comment', a.id, COALESCE(a.comment_id, 0), a.file_name, a.file_path, a.file_size,
+			a.file_type, a.attachment_type, a.uploaded_by, a.scan_status, a.created_at, a.created_by, a.updated_at, a.updated_by
+		FROM project.rfi_comment_attachments a
+		JOIN iam.users u ON u.id = a.uploaded_by
+		LEFT JOIN project.rfi_comments c ON c.id = a.comment_id AND c.is_deleted = false
+		WHERE u.org_id = $1 AND a.is_deleted = false AND c.id IS NULL
+		AND a.created_at < NOW() - INTERVAL '%[1]s'
+		ORDER BY created_at DESC
+	`, orphanGracePeriod)
+
+	rows, err := dao.DB.QueryContext(ctx, query, orgID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("org_id", orgID).Error("Failed to find orphaned attachments")
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment := models.Attachment{OrgID: orgID}
+		err := rows.Scan(
+			&attachment.EntityType,
+			&attachment.ID,
+			&attachment.EntityID,
+			&attachment.FileName,
+			&attachment.FilePath,
+			&attachment.FileSize,
+			&attachment.FileType,
+			&attachment.AttachmentType,
+			&attachment.UploadedBy,
+			&attachment.ScanStatus,
+			&attachment.CreatedAt,
+			&attachment.CreatedBy,
+			&attachment.UpdatedAt,
+			&attachment.UpdatedBy,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).WithField("org_id", orgID).Error("Failed to scan orphaned attachment row")
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// RelinkCommentAttachment attaches an orphaned comment attachment to an existing comment in the same org
+func (dao *AttachmentDao) RelinkCommentAttachment(ctx context.Context, attachmentID int64, entityType string, commentID, orgID, userID int64) (*models.Attachment, error) {
+	tableName := models.GetTableName(entityType)
+	commentTable := commentTableForAttachment(entityType)
+	if tableName == "" || commentTable == "" {
+		return nil, fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	exists, err := commentExistsInOrg(ctx, dao.DB, entityType, commentID, orgID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"comment_id":  commentID,
+			"entity_type": entityType,
+			"org_id":      orgID,
+		}).Error("Failed to verify relink target comment")
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	result, err := dao.DB.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %[1]s a
+		SET comment_id = $1, updated_by = $2, updated_at = NOW()
+		FROM iam.users u
+		WHERE a.id = $3 AND a.is_deleted = false
+		AND u.id = a.uploaded_by AND u.org_id = $4
+		AND NOT EXISTS (
+			SELECT 1 FROM %[2]s c WHERE c.id = a.comment_id AND c.is_deleted = false
+		)
+	`, tableName, commentTable), commentID, userID, attachmentID, orgID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+			"comment_id":    commentID,
+		}).Error("Failed to relink attachment")
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("orphaned attachment not found")
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"attachment_id": attachmentID,
+		"entity_type":   entityType,
+		"comment_id":    commentID,
+		"user_id":       userID,
+	}).Info("Orphaned attachment relinked")
+
+	return dao.GetAttachment(ctx, attachmentID, entityType)
+}
+
+// UpdateAttachmentEntity links a comment attachment uploaded with entity_id 0 to the comment created
+// afterwards. Attachments that are already linked are never re-pointed.
+func (dao *AttachmentDao) UpdateAttachmentEntity(ctx context.Context, attachmentID int64, entityType string, entityID, orgID, userID int64) (*models.Attachment, error) {
+	tableName := models.GetTableName(entityType)
+	entityIDColumn := models.GetEntityIDColumn(entityType)
+	if tableName == "" || commentTableForAttachment(entityType) == "" {
+		return nil, fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var currentEntityID, attachmentOrgID int64
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT COALESCE(a.%s, 0), u.org_id
+		FROM %s a
+		JOIN iam.users u ON u.id = a.uploaded_by
+		WHERE a.id = $1 AND a.is_deleted = false
+		FOR UPDATE OF a
+	`, entityIDColumn, tableName), attachmentID).Scan(&currentEntityID, &attachmentOrgID)
+	if err == sql.ErrNoRows || (err == nil && attachmentOrgID != orgID) {
+		return nil, fmt.Errorf("attachment not found")
+	}
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+		}).Error("Failed to get attachment for entity update")
+		return nil, err
+	}
+	if currentEntityID != 0 {
+		return nil, fmt.Errorf("attachment is already linked to an entity")
+	}
+
+	exists, err := commentExistsInOrg(ctx, tx, entityType, entityID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %s
+		SET %s = $1, updated_by = $2, updated_at = NOW()
+		WHERE id = $3
+	`, tableName, entityIDColumn), entityID, userID, attachmentID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+			"entity_id":     entityID,
+		}).Error("Failed to update attachment entity")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"attachment_id": attachmentID,
+		"entity_type":   entityType,
+		"entity_id":     entityID,
+		"user_id":       userID,
+	}).Info("Attachment linked to entity")
+
+	return dao.GetAttachment(ctx, attachmentID, entityType)
+}
+
+// commentExistsInOrg reports whether a live comment of the attachment entity type belongs to a project in the org
+func commentExistsInOrg(ctx context.Context, q dbtx, entityType string, commentID, orgID int64) (bool, error) {
+	parentJoin := "JOIN project.issues e ON e.id = c.issue_id"
+	if entityType == models.EntityTypeRFIComment {
+		parentJoin = "JOIN project.rfis e ON e.id = c.rfi_id"
+	}
+
+	var exists bool
+	err := q.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT EXISTS(
+			SELECT 1 FROM %s c
+			%s
+			JOIN project.projects p ON p.id = e.project_id
+			WHERE c.id = $1 AND c.is_deleted = false AND p.org_id = $2
+		)
+	`, commentTableForAttachment(entityType), parentJoin), commentID, orgID).Scan(&exists)
+	return exists, err
+}
+
 // UpdateAttachmentStatus updates the upload status of an attachment
 func (dao *AttachmentDao) UpdateAttachmentStatus(ctx context.Context, attachmentID int64, entityType string, status string) error {
 	tableName := models.GetTableName(entityType)
@@ -243,13 +701,188 @@ func (dao *AttachmentDao) UpdateAttachmentStatus(ctx context.Context, attachment
 		return fmt.Errorf("unsupported entity type: %s", entityType)
 	}
 
-	// Note: Since upload_status column doesn't exist in current schema,
-	// we'll skip this for now and assume uploads are successful when confirmed
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET upload_status = $2, updated_at = $3
+		WHERE id = $1 AND is_deleted = false
+	`, tableName)
+
+	result, err := dao.DB.ExecContext(ctx, query, attachmentID, status, time.Now())
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+			"status":        status,
+		}).Error("Failed to update attachment status")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment not found")
+	}
+
+	return nil
+}
+
+// FindAttachmentEntityType returns the entity type of the attachment with the given ID that userID
+// uploaded. IDs are only unique per attachment table, so a pending upload is preferred over confirmed
+// ones, and the lookup fails with "attachment entity type is ambiguous" when that still leaves more
+// than one table; the caller must then name the entity type.
+func (dao *AttachmentDao) FindAttachmentEntityType(ctx context.Context, attachmentID, userID int64) (string, error) {
+	var branches []string
+	for _, entityType := range models.AttachmentEntityTypes {
+		branches = append(branches, fmt.Sprintf(
+			"SELECT '%s', upload_status FROM %s WHERE id = $1 AND uploaded_by = $2 AND is_deleted = false",
+			entityType, models.GetTableName(entityType)))
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, strings.Join(branches, " UNION ALL "), attachmentID, userID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("attachment_id", attachmentID).Error("Failed to find attachment entity type")
+		return "", err
+	}
+	defer rows.Close()
+
+	var pending, all []string
+	for rows.Next() {
+		var entityType, uploadStatus string
+		if err := rows.Scan(&entityType, &uploadStatus); err != nil {
+			return "", err
+		}
+		all = append(all, entityType)
+		if uploadStatus == models.UploadStatusPending {
+			pending = append(pending, entityType)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	matches := all
+	if len(pending) > 0 {
+		matches = pending
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("attachment not found")
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("attachment entity type is ambiguous")
+}
+
+// ConfirmAttachmentUpload moves a pending attachment to confirmed once its object has been found in S3,
+// replacing the client-claimed file size with the size S3 actually stored
+func (dao *AttachmentDao) ConfirmAttachmentUpload(ctx context.Context, attachmentID int64, entityType string, fileSize, userID int64) error {
+	tableName := models.GetTableName(entityType)
+
+	if tableName == "" {
+		return fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET upload_status = $2, file_size = $3, updated_by = $4, updated_at = $5
+		WHERE id = $1 AND is_deleted = false AND upload_status = $6
+	`, tableName)
+
+	result, err := dao.DB.ExecContext(ctx, query,
+		attachmentID, models.UploadStatusConfirmed, fileSize, userID, time.Now(), models.UploadStatusPending)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+		}).Error("Failed to confirm attachment upload")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment not found or not pending")
+	}
+
 	dao.Logger.WithFields(logrus.Fields{
 		"attachment_id": attachmentID,
 		"entity_type":   entityType,
-		"status":        status,
-	}).Debug("Attachment status update requested (not implemented in current schema)")
+		"file_size":     fileSize,
+		"user_id":       userID,
+	}).Info("Attachment upload confirmed")
+
+	return nil
+}
+
+// SetAttachmentThumbnail records the S3 key of the thumbnail generated for an image attachment
+func (dao *AttachmentDao) SetAttachmentThumbnail(ctx context.Context, attachmentID int64, entityType string, thumbnailPath string) error {
+	tableName := models.GetTableName(entityType)
+
+	if tableName == "" {
+		return fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET thumbnail_path = $2, updated_at = $3
+		WHERE id = $1 AND is_deleted = false
+	`, tableName)
+
+	result, err := dao.DB.ExecContext(ctx, query, attachmentID, thumbnailPath, time.Now())
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+		}).Error("Failed to set attachment thumbnail")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment not found")
+	}
+
+	return nil
+}
+
+// SetAttachmentScanStatus records the virus scanner's verdict for an attachment
+func (dao *AttachmentDao) SetAttachmentScanStatus(ctx context.Context, attachmentID int64, entityType string, scanStatus string) error {
+	tableName := models.GetTableName(entityType)
+
+	if tableName == "" {
+		return fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET scan_status = $2, updated_at = $3
+		WHERE id = $1 AND is_deleted = false
+	`, tableName)
+
+	result, err := dao.DB.ExecContext(ctx, query, attachmentID, scanStatus, time.Now())
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+			"scan_status":   scanStatus,
+		}).Error("Failed to set attachment scan status")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFoundError("attachment not found")
+	}
 
 	return nil
 }
@@ -398,4 +1031,86 @@ func (dao *AttachmentDao) VerifyAttachmentAccess(ctx context.Context, attachment
 	}
 
 	return true, nil
-}
\ No newline at end of file
+}
+
+// LogAttachmentAccess records a download of an attachment in the access log
+func (dao *AttachmentDao) LogAttachmentAccess(ctx context.Context, entry *models.AttachmentAccessLog) error {
+	query := `
+		INSERT INTO project.attachment_access_log (
+			attachment_id, entity_type, org_id, user_id, ip_address, user_agent
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, accessed_at
+	`
+
+	err := dao.DB.QueryRowContext(ctx, query,
+		entry.AttachmentID,
+		entry.EntityType,
+		entry.OrgID,
+		entry.UserID,
+		sql.NullString{String: entry.IPAddress, Valid: entry.IPAddress != ""},
+		sql.NullString{String: entry.UserAgent, Valid: entry.UserAgent != ""},
+	).Scan(&entry.ID, &entry.AccessedAt)
+
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": entry.AttachmentID,
+			"entity_type":   entry.EntityType,
+			"user_id":       entry.UserID,
+		}).Error("Failed to log attachment access")
+		return fmt.Errorf("failed to log attachment access: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachmentAccessLog retrieves the access history of an attachment, most recent first
+func (dao *AttachmentDao) GetAttachmentAccessLog(ctx context.Context, attachmentID int64, entityType string, orgID int64) ([]models.AttachmentAccessLog, error) {
+	query := `
+		SELECT
+			l.id, l.attachment_id, l.entity_type, l.org_id, l.user_id,
+			CONCAT(u.first_name, ' ', u.last_name), COALESCE(u.email, ''),
+			COALESCE(l.ip_address, ''), COALESCE(l.user_agent, ''), l.accessed_at
+		FROM project.attachment_access_log l
+		LEFT JOIN iam.users u ON l.user_id = u.id
+		WHERE l.attachment_id = $1 AND l.entity_type = $2 AND l.org_id = $3
+		ORDER BY l.accessed_at DESC
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, attachmentID, entityType, orgID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"entity_type":   entityType,
+		}).Error("Failed to get attachment access log")
+		return nil, fmt.Errorf("failed to get attachment access log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AttachmentAccessLog{}
+	for rows.Next() {
+		var entry models.AttachmentAccessLog
+		err := rows.Scan(
+			&entry.ID,
+			&entry.AttachmentID,
+			&entry.EntityType,
+			&entry.OrgID,
+			&entry.UserID,
+			&entry.UserName,
+			&entry.UserEmail,
+			&entry.IPAddress,
+			&entry.UserAgent,
+			&entry.AccessedAt,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan attachment access log row")
+			return nil, fmt.Errorf("failed to scan attachment access log: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachment access log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"infrastructure/lib/models"
+)
+
+// ErrTargetProjectNotFound is returned by the copy DAOs when the target project does not exist.
+// It also matches ErrNotFound; handlers check it first to tell it apart from a missing source entity.
+var ErrTargetProjectNotFound = notFoundError("target project not found")
+
+// validateCopyProjects checks that the source entity and the target project both belong to the organization.
+// sourceTable must be a trusted table name; entityName is used in the "not found" error (e.g. "issue").
+// Returns the target project's location so the copy can be filed under it.
+func validateCopyProjects(ctx context.Context, tx *sql.Tx, sourceTable, entityName string, sourceID, targetProjectID, orgID int64) (int64, error) {
+	var sourceOrgID int64
+	err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT p.org_id
+		FROM %s e
+		JOIN project.projects p ON p.id = e.project_id
+		WHERE e.id = $1 AND e.is_deleted = FALSE
+	`, sourceTable), sourceID).Scan(&sourceOrgID)
+	if err == sql.ErrNoRows {
+		return 0, notFoundError(fmt.Sprintf("%s not found", entityName))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to validate source %s: %w", entityName, err)
+	}
+	if sourceOrgID != orgID {
+		return 0, notFoundError(fmt.Sprintf("%s not found", entityName))
+	}
+
+	var targetOrgID, targetLocationID int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT org_id, location_id FROM project.projects
+		WHERE id = $1 AND is_deleted = FALSE
+	`, targetProjectID).Scan(&targetOrgID, &targetLocationID)
+	if err == sql.ErrNoRows {
+		return 0, ErrTargetProjectNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to validate target project: %w", err)
+	}
+	if targetOrgID != orgID {
+		return 0, orgMismatchError("project does not belong to your organization")
+	}
+
+	return targetLocationID, nil
+}
+
+// copyEntityAttachments duplicates the attachment rows of one entity onto another of the same type.
+// The copies reference the same S3 objects as the originals.
+func copyEntityAttachments(ctx context.Context, tx *sql.Tx, entityType string, sourceID, targetID, userID int64) error {
+	tableName := models.GetTableName(entityType)
+	entityIDColumn := models.GetEntityIDColumn(entityType)
+	if tableName == "" || entityIDColumn == "" {
+		return fmt.Errorf("unsupported entity type: %s", entityType)
+	}
+
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %[1]s (
+			%[2]s, file_name, file_path, file_size, file_type, attachment_type,
+			uploaded_by, created_by, updated_by
+		)
+		SELECT $1, file_name, file_path, file_size, file_type, attachment_type,
+			uploaded_by, $3, $3
+		FROM %[1]s
+		WHERE %[2]s = $2 AND is_deleted = FALSE
+	`, tableName, entityIDColumn), targetID, sourceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to copy attachments: %w", err)
+	}
+	return nil
+}
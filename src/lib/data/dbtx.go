@@ -0,0 +1,22 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+)
+
+// dbtx is the query surface shared by *sql.DB and *sql.Tx so DAO methods can run
+// either standalone or inside a transaction opened by the caller
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txOrDB returns tx when the caller supplied one, otherwise db
+func txOrDB(db *sql.DB, tx *sql.Tx) dbtx {
+	if tx != nil {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,58 @@
+package data
+
+import "errors"
+
+// Sentinel errors returned by the DAOs. Handlers branch on them with errors.Is instead of matching
+// message text, so an unrelated error that happens to mention "not found" is never mistaken for one.
+var (
+	// ErrNotFound means the requested row does not exist (or has been soft-deleted)
+	ErrNotFound = errors.New("not found")
+	// ErrOrgMismatch means the row exists but belongs to a different organization than the caller
+	ErrOrgMismatch = errors.New("organization mismatch")
+	// ErrConflict means the row is in a state that does not allow the requested change
+	ErrConflict = errors.New("conflict")
+	// ErrForbidden means the caller is in the right organization but may not change this row
+	ErrForbidden = errors.New("forbidden")
+	// ErrInvalid means the request failed a validation the DAO performs; the message says which
+	ErrInvalid = errors.New("invalid")
+)
+
+// dataError carries a descriptive message while matching one of the sentinels through errors.Is.
+// The message is kept unchanged so existing logs and err.Error() comparisons keep working.
+type dataError struct {
+	message string
+	kind    error
+}
+
+func (e *dataError) Error() string {
+	return e.message
+}
+
+func (e *dataError) Unwrap() error {
+	return e.kind
+}
+
+// notFoundError returns an error with message that matches ErrNotFound
+func notFoundError(message string) error {
+	return &dataError{message: message, kind: ErrNotFound}
+}
+
+// orgMismatchError returns an error with message that matches ErrOrgMismatch
+func orgMismatchError(message string) error {
+	return &dataError{message: message, kind: ErrOrgMismatch}
+}
+
+// conflictError returns an error with message that matches ErrConflict
+func conflictError(message string) error {
+	return &dataError{message: message, kind: ErrConflict}
+}
+
+// forbiddenError returns an error with message that matches ErrForbidden
+func forbiddenError(message string) error {
+	return &dataError{message: message, kind: ErrForbidden}
+}
+
+// invalidError returns an error with message that matches ErrInvalid
+func invalidError(message string) error {
+	return &dataError{message: message, kind: ErrInvalid}
+}
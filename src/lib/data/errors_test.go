@@ -0,0 +1,38 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFoundError_MatchesSentinelAndKeepsMessage(t *testing.T) {
+	//Arrange
+	err := notFoundError("RFI not found")
+
+	//Act
+	wrapped := fmt.Errorf("failed to load RFI: %w", err)
+
+	//Assert
+	assert.Equal(t, "RFI not found", err.Error())
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(wrapped, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrOrgMismatch))
+}
+
+func TestSentinels_IgnoreMatchingMessageText(t *testing.T) {
+	//Arrange
+	err := errors.New("pq: relation \"project.rfis\" not found")
+
+	//Act
+	isNotFound := errors.Is(err, ErrNotFound)
+
+	//Assert
+	assert.False(t, isNotFound)
+	assert.True(t, errors.Is(orgMismatchError("RFI does not belong to your organization"), ErrOrgMismatch))
+	assert.True(t, errors.Is(conflictError("RFI has been deleted"), ErrConflict))
+	assert.True(t, errors.Is(forbiddenError("only the author can edit this comment"), ErrForbidden))
+	assert.True(t, errors.Is(invalidError("invalid start_date format, expected YYYY-MM-DD"), ErrInvalid))
+}
@@ -0,0 +1,158 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"infrastructure/lib/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExportJobRepository defines the interface for project export job operations
+type ExportJobRepository interface {
+	CreateExportJob(ctx context.Context, orgID, projectID, userID int64) (*models.ExportJob, error)
+	GetExportJob(ctx context.Context, jobID, orgID int64) (*models.ExportJob, error)
+	ClaimPendingExportJob(ctx context.Context) (*models.ExportJob, error)
+	CompleteExportJob(ctx context.Context, jobID int64, filePath string, fileSize int64) error
+	FailExportJob(ctx context.Context, jobID int64, errorMessage string) error
+}
+
+// ExportJobDao implements ExportJobRepository
+type ExportJobDao struct {
+	DB     *sql.DB
+	Logger *logrus.Logger
+}
+
+const exportJobColumns = `id, org_id, project_id, status, file_path, file_size, error_message,
+	attempts, requested_by, created_at, started_at, completed_at`
+
+func scanExportJob(row interface{ Scan(...interface{}) error }) (*models.ExportJob, error) {
+	var job models.ExportJob
+	err := row.Scan(
+		&job.ID, &job.OrgID, &job.ProjectID, &job.Status, &job.FilePath, &job.FileSize, &job.ErrorMessage,
+		&job.Attempts, &job.RequestedBy, &job.CreatedAt, &job.StartedAt, &job.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CreateExportJob queues a new pending export job for a project
+func (dao *ExportJobDao) CreateExportJob(ctx context.Context, orgID, projectID, userID int64) (*models.ExportJob, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO project.export_jobs (org_id, project_id, status, requested_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING %s`, exportJobColumns)
+
+	job, err := scanExportJob(dao.DB.QueryRowContext(ctx, query, orgID, projectID, models.ExportJobStatusPending, userID))
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"project_id": projectID,
+			"org_id":     orgID,
+		}).Error("Failed to create export job")
+		return nil, fmt.Errorf("failed to create export job: %w", err)
+	}
+	return job, nil
+}
+
+// GetExportJob retrieves an export job scoped to the caller's organization
+func (dao *ExportJobDao) GetExportJob(ctx context.Context, jobID, orgID int64) (*models.ExportJob, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM project.export_jobs
+		WHERE id = $1 AND org_id = $2`, exportJobColumns)
+
+	job, err := scanExportJob(dao.DB.QueryRowContext(ctx, query, jobID, orgID))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("export job not found")
+	}
+	if err != nil {
+		dao.Logger.WithError(err).WithField("job_id", jobID).Error("Failed to get export job")
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimPendingExportJob marks the oldest pending job as processing and returns it. A processing job
+// whose lease (models.ExportJobLease) has expired belongs to a worker that crashed or timed out, so it is
+// claimed again until it reaches models.MaxExportJobAttempts, after which it is marked failed.
+// Returns nil when there is nothing to do. SKIP LOCKED lets concurrent workers claim different jobs.
+func (dao *ExportJobDao) ClaimPendingExportJob(ctx context.Context) (*models.ExportJob, error) {
+	leaseSeconds := int64(models.ExportJobLease / time.Second)
+
+	result, err := dao.DB.ExecContext(ctx, `
+		UPDATE project.export_jobs
+		SET status = $1, error_message = $2, completed_at = CURRENT_TIMESTAMP
+		WHERE status = $3
+		  AND started_at < CURRENT_TIMESTAMP - make_interval(secs => $4)
+		  AND attempts >= $5`,
+		models.ExportJobStatusFailed, "Export did not finish after repeated attempts",
+		models.ExportJobStatusProcessing, leaseSeconds, models.MaxExportJobAttempts)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to expire abandoned export jobs")
+		return nil, fmt.Errorf("failed to expire abandoned export jobs: %w", err)
+	}
+	if expired, _ := result.RowsAffected(); expired > 0 {
+		dao.Logger.WithField("expired_count", expired).Warn("Marked abandoned export jobs failed")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE project.export_jobs
+		SET status = $1, started_at = CURRENT_TIMESTAMP, attempts = attempts + 1
+		WHERE id = (
+			SELECT id FROM project.export_jobs
+			WHERE status = $2
+			   OR (status = $1 AND started_at < CURRENT_TIMESTAMP - make_interval(secs => $3) AND attempts < $4)
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s`, exportJobColumns)
+
+	job, err := scanExportJob(dao.DB.QueryRowContext(ctx, query,
+		models.ExportJobStatusProcessing, models.ExportJobStatusPending, leaseSeconds, models.MaxExportJobAttempts))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to claim pending export job")
+		return nil, fmt.Errorf("failed to claim export job: %w", err)
+	}
+	if job.Attempts > 1 {
+		dao.Logger.WithFields(logrus.Fields{
+			"job_id":   job.ID,
+			"attempts": job.Attempts,
+		}).Warn("Reclaimed export job after its lease expired")
+	}
+	return job, nil
+}
+
+// CompleteExportJob records the generated package location and marks the job completed
+func (dao *ExportJobDao) CompleteExportJob(ctx context.Context, jobID int64, filePath string, fileSize int64) error {
+	_, err := dao.DB.ExecContext(ctx, `
+		UPDATE project.export_jobs
+		SET status = $1, file_path = $2, file_size = $3, error_message = NULL, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $4`, models.ExportJobStatusCompleted, filePath, fileSize, jobID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("job_id", jobID).Error("Failed to complete export job")
+		return fmt.Errorf("failed to complete export job: %w", err)
+	}
+	return nil
+}
+
+// FailExportJob marks the job failed with the reason shown to the caller when polling
+func (dao *ExportJobDao) FailExportJob(ctx context.Context, jobID int64, errorMessage string) error {
+	_, err := dao.DB.ExecContext(ctx, `
+		UPDATE project.export_jobs
+		SET status = $1, error_message = $2, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $3`, models.ExportJobStatusFailed, errorMessage, jobID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("job_id", jobID).Error("Failed to mark export job failed")
+		return fmt.Errorf("failed to mark export job failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"infrastructure/lib/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// IdempotencyRepository defines the interface for Idempotency-Key storage; it satisfies api.IdempotencyStore
+type IdempotencyRepository interface {
+	ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, orgID int64, scope, key string) (*models.IdempotencyKey, error)
+	CompleteIdempotencyKeyTx(ctx context.Context, tx *sql.Tx, orgID int64, scope, key string, resourceID int64) error
+	ReleaseIdempotencyKey(ctx context.Context, orgID int64, scope, key string) error
+}
+
+// IdempotencyDao implements IdempotencyRepository
+type IdempotencyDao struct {
+	DB     *sql.DB
+	Logger *logrus.Logger
+}
+
+// ReserveIdempotencyKey inserts the key, or takes over an expired record with the same key or a
+// claim that never completed within IdempotencyClaimLease. Returns false when the key is held.
+func (dao *IdempotencyDao) ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyKey) (bool, error) {
+	// Expiry is computed by the database so it compares consistently with CURRENT_TIMESTAMP below
+	ttlSeconds := time.Until(record.ExpiresAt).Seconds()
+
+	var reserved bool
+	err := dao.DB.QueryRowContext(ctx, `
+		INSERT INTO project.idempotency_keys (org_id, scope, idempotency_key, user_id, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP + make_interval(secs => $6))
+		ON CONFLICT (org_id, scope, idempotency_key) DO UPDATE
+		SET user_id = EXCLUDED.user_id, request_hash = EXCLUDED.request_hash, resource_id = NULL,
+		    created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+		WHERE project.idempotency_keys.expires_at < CURRENT_TIMESTAMP
+		   OR (project.idempotency_keys.resource_id IS NULL
+		       AND project.idempotency_keys.created_at < CURRENT_TIMESTAMP - make_interval(secs => $7))
+		RETURNING true
+	`, record.OrgID, record.Scope, record.Key, record.UserID, record.RequestHash, ttlSeconds,
+		models.IdempotencyClaimLease.Seconds()).Scan(&reserved)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		dao.Logger.WithError(err).WithField("scope", record.Scope).Error("Failed to reserve idempotency key")
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return reserved, nil
+}
+
+// GetIdempotencyKey retrieves an unexpired idempotency key
+func (dao *IdempotencyDao) GetIdempotencyKey(ctx context.Context, orgID int64, scope, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	var resourceID sql.NullInt64
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT org_id, scope, idempotency_key, user_id, request_hash, resource_id, created_at, expires_at
+		FROM project.idempotency_keys
+		WHERE org_id = $1 AND scope = $2 AND idempotency_key = $3 AND expires_at >= CURRENT_TIMESTAMP
+	`, orgID, scope, key).Scan(
+		&record.OrgID, &record.Scope, &record.Key, &record.UserID, &record.RequestHash,
+		&resourceID, &record.CreatedAt, &record.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("idempotency key not found")
+	}
+	if err != nil {
+		dao.Logger.WithError(err).WithField("scope", scope).Error("Failed to get idempotency key")
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if resourceID.Valid {
+		record.ResourceID = &resourceID.Int64
+	}
+	return &record, nil
+}
+
+// CompleteIdempotencyKeyTx records the resource created by the request that reserved the key, using the
+// transaction that creates the resource so the two commit together
+func (dao *IdempotencyDao) CompleteIdempotencyKeyTx(ctx context.Context, tx *sql.Tx, orgID int64, scope, key string, resourceID int64) error {
+	_, err := txOrDB(dao.DB, tx).ExecContext(ctx, `
+		UPDATE project.idempotency_keys
+		SET resource_id = $4
+		WHERE org_id = $1 AND scope = $2 AND idempotency_key = $3
+	`, orgID, scope, key, resourceID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("scope", scope).Error("Failed to complete idempotency key")
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a reservation that never recorded a resource
+func (dao *IdempotencyDao) ReleaseIdempotencyKey(ctx context.Context, orgID int64, scope, key string) error {
+	_, err := dao.DB.ExecContext(ctx, `
+		DELETE FROM project.idempotency_keys
+		WHERE org_id = $1 AND scope = $2 AND idempotency_key = $3 AND resource_id IS NULL
+	`, orgID, scope, key)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("scope", scope).Error("Failed to release idempotency key")
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
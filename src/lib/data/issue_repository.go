@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"infrastructure/lib/models"
+	"infrastructure/lib/util"
 	"strings"
 	"time"
 
@@ -12,22 +13,38 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ErrAssigneeNotInOrg is returned when an issue is created for a user outside the caller's organization.
+// It also matches ErrOrgMismatch; handlers check it first to tell it apart from a project in another organization.
+var ErrAssigneeNotInOrg = orgMismatchError("assignee does not belong to your organization")
+
 // IssueRepository defines the interface for issue data operations
 type IssueRepository interface {
 	// CreateIssue creates a new issue in the project (unified structure, orgID from JWT)
 	CreateIssue(ctx context.Context, projectID, userID, orgID int64, issue *models.CreateIssueRequest) (*models.IssueResponse, error)
 
+	// CreateIssueTx inserts a new issue using the caller's transaction (nil runs without one) and returns its ID.
+	// The assignee is re-checked and locked inside the transaction so it cannot be removed mid-create.
+	CreateIssueTx(ctx context.Context, tx *sql.Tx, projectID, userID, orgID int64, issue *models.CreateIssueRequest) (int64, error)
+
 	// GetIssueByID retrieves a specific issue by ID
 	GetIssueByID(ctx context.Context, issueID int64) (*models.IssueResponse, error)
 
-	// GetIssuesByProject retrieves all issues for a specific project
-	GetIssuesByProject(ctx context.Context, projectID int64, filters map[string]string) ([]models.IssueResponse, error)
+	// GetIssuesByProject retrieves a page of issues for a specific project.
+	// Returns the page, the total number of issues matching the filters and whether more issues exist past the page.
+	GetIssuesByProject(ctx context.Context, projectID int64, filters map[string]string, query models.IssueListQuery) ([]models.IssueResponse, int, bool, error)
 
-	// UpdateIssue updates an existing issue (unified structure)
+	// SearchIssues retrieves a page of issues across the organization's projects with the same filters
+	// as GetIssuesByProject plus an optional project_id.
+	SearchIssues(ctx context.Context, orgID int64, filters map[string]string, query models.IssueListQuery) ([]models.IssueResponse, int, bool, error)
+
+	// UpdateIssue updates an existing issue (unified structure), logging one activity entry per changed field
 	UpdateIssue(ctx context.Context, issueID, userID, orgID int64, updateReq *models.UpdateIssueRequest) (*models.IssueResponse, error)
 
 	// DeleteIssue soft deletes an issue
-	DeleteIssue(ctx context.Context, issueID, userID int64) error
+	DeleteIssue(ctx context.Context, issueID, userID int64, reason string) error
+
+	// RestoreIssue undoes a soft delete for an issue in the organization whose project is not deleted
+	RestoreIssue(ctx context.Context, issueID, userID, orgID int64) error
 
 	// GetIssueAttachments retrieves all attachments for an issue
 	GetIssueAttachments(ctx context.Context, issueID int64) ([]models.IssueAttachment, error)
@@ -35,14 +52,53 @@ type IssueRepository interface {
 	// UpdateIssueStatus updates only the status of an issue
 	UpdateIssueStatus(ctx context.Context, issueID, userID int64, status string) error
 
+	// BulkUpdateStatus moves several issues to the same status in one transaction, logging one activity entry per changed issue.
+	// Results are returned in request order; when atomic is set, any failed item leaves every issue unchanged.
+	BulkUpdateStatus(ctx context.Context, issueIDs []int64, orgID, userID int64, status string, atomic bool) ([]IssueStatusBatchResult, error)
+
 	// CreateComment creates a new comment on an issue
 	CreateComment(ctx context.Context, issueID, userID int64, req *models.CreateCommentRequest) (*models.IssueComment, error)
 
-	// GetIssueComments retrieves all comments for an issue
-	GetIssueComments(ctx context.Context, issueID int64) ([]models.IssueComment, error)
+	// UpdateComment replaces the text of a comment, keeping the previous text in the edit history.
+	// Only the author or a super admin may edit a comment.
+	UpdateComment(ctx context.Context, issueID, commentID, userID int64, isSuperAdmin bool, req *models.UpdateCommentRequest) (*models.IssueComment, error)
+
+	// SoftDeleteComment deletes a comment, leaving a placeholder in the issue's comment list.
+	// Only the author or a super admin may delete a comment.
+	SoftDeleteComment(ctx context.Context, issueID, commentID, userID int64, isSuperAdmin bool) error
+
+	// GetIssueComments retrieves a page of comments for an issue in chronological order.
+	// Returns the page, the total number of comments on the issue and whether more comments exist past the page.
+	GetIssueComments(ctx context.Context, issueID int64, query models.IssueCommentQuery) ([]models.IssueComment, int, bool, error)
+
+	// GetActivityLog retrieves a page of the issue's activity log in chronological order.
+	// Returns the page, the total number of entries and whether more entries exist past the page.
+	GetActivityLog(ctx context.Context, issueID int64, limit, offset int) ([]models.IssueActivity, int, bool, error)
 
 	// CreateActivityLog creates an activity log entry for status changes
 	CreateActivityLog(ctx context.Context, issueID, userID int64, activityMsg, previousValue, newValue string) error
+
+	// CreateActivityLogTx creates an activity log entry using the caller's transaction (nil runs without one)
+	CreateActivityLogTx(ctx context.Context, tx *sql.Tx, issueID, userID int64, activityMsg, previousValue, newValue string) error
+
+	// AddIssueWatcher subscribes a user to an issue; it reports false when the user was already watching
+	AddIssueWatcher(ctx context.Context, issueID, userID, addedBy int64) (bool, error)
+
+	// RemoveIssueWatcher unsubscribes a user from an issue
+	RemoveIssueWatcher(ctx context.Context, issueID, userID int64) error
+
+	// GetIssueWatchers lists the users watching an issue in the order they were added
+	GetIssueWatchers(ctx context.Context, issueID int64) ([]models.IssueWatcher, error)
+
+	// RecordWatcherNotification fills in the notification's recipients from the issue's watchers and, when there
+	// are any, records it in project.issue_notifications
+	RecordWatcherNotification(ctx context.Context, notification *models.IssueWatcherNotification) error
+
+	// CopyIssue duplicates an issue into another project in the same organization
+	CopyIssue(ctx context.Context, issueID, targetProjectID, userID, orgID int64, copyAttachments bool) (*models.IssueResponse, error)
+
+	// RecountIssueCounts rebuilds the denormalized attachment and comment counts (projectID 0 = all projects)
+	RecountIssueCounts(ctx context.Context, orgID, projectID int64) (models.RecountResult, error)
 }
 
 // IssueDao implements IssueRepository interface using PostgreSQL
@@ -51,13 +107,22 @@ type IssueDao struct {
 	Logger *logrus.Logger
 }
 
+// IssueStatusBatchResult is the outcome of one item of BulkUpdateStatus; Err is nil on success
+type IssueStatusBatchResult struct {
+	IssueID    int64
+	ProjectID  int64
+	AssignedTo *int64
+	OldStatus  string
+	Err        error
+}
+
 // generateIssueNumber generates a unique issue number for the project
-func (dao *IssueDao) generateIssueNumber(ctx context.Context, projectID int64, category string) (string, error) {
+func (dao *IssueDao) generateIssueNumber(ctx context.Context, q dbtx, projectID int64, category string) (string, error) {
 	var projectCode string
 	var count int
 	
 	// Get project code
-	err := dao.DB.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		SELECT COALESCE(project_number, 'PRJ-' || id) 
 		FROM project.projects 
 		WHERE id = $1
@@ -69,7 +134,7 @@ func (dao *IssueDao) generateIssueNumber(ctx context.Context, projectID int64, c
 	
 	// Get the count of issues for this project and category
 	categoryPrefix := strings.ToUpper(string(category[0:2]))
-	err = dao.DB.QueryRowContext(ctx, `
+	err = q.QueryRowContext(ctx, `
 		SELECT COUNT(*) + 1
 		FROM project.issues 
 		WHERE project_id = $1 AND category = $2
@@ -85,37 +150,76 @@ func (dao *IssueDao) generateIssueNumber(ctx context.Context, projectID int64, c
 
 // CreateIssue creates a new issue in the project with unified structure
 func (dao *IssueDao) CreateIssue(ctx context.Context, projectID, userID, orgID int64, req *models.CreateIssueRequest) (*models.IssueResponse, error) {
+	// Start transaction
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to start transaction for issue creation")
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	issueID, err := dao.CreateIssueTx(ctx, tx, projectID, userID, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		dao.Logger.WithError(err).Error("Failed to commit issue creation transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Get the created issue with full details
+	return dao.GetIssueByID(ctx, issueID)
+}
+
+// CreateIssueTx inserts a new issue using the caller's transaction and returns its ID.
+// The caller commits and loads the full issue afterwards.
+func (dao *IssueDao) CreateIssueTx(ctx context.Context, tx *sql.Tx, projectID, userID, orgID int64, req *models.CreateIssueRequest) (int64, error) {
+	defer util.TimeDB(ctx)()
+	q := txOrDB(dao.DB, tx)
+
 	// Validate project belongs to organization
 	var projectOrgID int64
-	err := dao.DB.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		SELECT org_id FROM project.projects
 		WHERE id = $1 AND is_deleted = FALSE
 	`, projectID).Scan(&projectOrgID)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("project not found")
+		return 0, notFoundError("project not found")
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate project: %w", err)
+		return 0, fmt.Errorf("failed to validate project: %w", err)
 	}
 	if projectOrgID != orgID {
-		return nil, fmt.Errorf("project does not belong to your organization")
+		return 0, orgMismatchError("project does not belong to your organization")
+	}
+
+	// Lock the assignee row so the user cannot be removed before the issue is committed
+	if req.AssignedTo != 0 {
+		var assigneeID int64
+		err = q.QueryRowContext(ctx, `
+			SELECT id FROM iam.users
+			WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+			FOR SHARE
+		`, req.AssignedTo, orgID).Scan(&assigneeID)
+		if err == sql.ErrNoRows {
+			return 0, ErrAssigneeNotInOrg
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to validate assignee: %w", err)
+		}
 	}
+
 	// Handle template ID (not in current request structure)
 	var templateID sql.NullInt64
-	// Start transaction
-	tx, err := dao.DB.BeginTx(ctx, nil)
-	if err != nil {
-		dao.Logger.WithError(err).Error("Failed to start transaction for issue creation")
-		return nil, fmt.Errorf("failed to start transaction: %w", err)
-	}
-	defer tx.Rollback()
-	
+
 	// Generate issue number using the flatter structure
-	issueNumber, err := dao.generateIssueNumber(ctx, projectID, req.Category)
+	issueNumber, err := dao.generateIssueNumber(ctx, q, projectID, req.Category)
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to generate issue number")
-		return nil, err
+		return 0, err
 	}
 
 	// Set defaults from flatter structure
@@ -131,7 +235,7 @@ func (dao *IssueDao) CreateIssue(ctx context.Context, projectID, userID, orgID i
 	// Get location_id from project if not provided in request
 	locationID := req.LocationID
 	if locationID == 0 {
-		err = dao.DB.QueryRowContext(ctx, `
+		err = q.QueryRowContext(ctx, `
 			SELECT location_id FROM project.projects
 			WHERE id = $1
 		`, projectID).Scan(&locationID)
@@ -145,7 +249,7 @@ func (dao *IssueDao) CreateIssue(ctx context.Context, projectID, userID, orgID i
 	if req.DueDate != "" {
 		parsedDate, err := time.Parse("2006-01-02", req.DueDate)
 		if err != nil {
-			return nil, fmt.Errorf("invalid due date format: %w", err)
+			return 0, fmt.Errorf("invalid due date format: %w", err)
 		}
 		dueDate = &parsedDate
 	}
@@ -183,7 +287,7 @@ func (dao *IssueDao) CreateIssue(ctx context.Context, projectID, userID, orgID i
 		longitude = sql.NullFloat64{Float64: req.Location.GPSCoordinates.Longitude, Valid: true}
 	}
 	
-	err = tx.QueryRowContext(ctx, `
+	err = q.QueryRowContext(ctx, `
 		INSERT INTO project.issues (
 			project_id, issue_number, template_id,
 			title, description, 
@@ -247,28 +351,36 @@ func (dao *IssueDao) CreateIssue(ctx context.Context, projectID, userID, orgID i
 			"user_id":    userID,
 			"error":      err.Error(),
 		}).Error("Failed to create issue")
-		return nil, fmt.Errorf("failed to create issue: %w", err)
+		if isForeignKeyViolation(err) {
+			return 0, invalidError("invalid reference data provided")
+		}
+		return 0, fmt.Errorf("failed to create issue: %w", err)
 	}
-	
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		dao.Logger.WithError(err).Error("Failed to commit issue creation transaction")
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+
+	// The creator and the assignee watch the issue from the start
+	if err := dao.addIssueWatchers(ctx, q, issueID, userID, []int64{userID, req.AssignedTo}); err != nil {
+		return 0, err
 	}
-	
+
 	dao.Logger.WithFields(logrus.Fields{
 		"issue_id":     issueID,
 		"issue_number": issueNumber,
 		"project_id":   projectID,
 		"user_id":      userID,
 	}).Info("Successfully created issue")
-	
-	// Get the created issue with full details
-	return dao.GetIssueByID(ctx, issueID)
+
+	return issueID, nil
 }
 
 // GetIssueByID retrieves a specific issue by ID
 func (dao *IssueDao) GetIssueByID(ctx context.Context, issueID int64) (*models.IssueResponse, error) {
+	return dao.getIssueByID(ctx, dao.DB, issueID)
+}
+
+// getIssueByID reads an issue through q, so UpdateIssue can see its own uncommitted changes
+func (dao *IssueDao) getIssueByID(ctx context.Context, q dbtx, issueID int64) (*models.IssueResponse, error) {
+	defer util.TimeDB(ctx)()
+
 	var response models.IssueResponse
 	var distributionList pq.StringArray
 	
@@ -308,7 +420,8 @@ func (dao *IssueDao) GetIssueByID(ctx context.Context, issueID int64) (*models.I
 			CONCAT(u2.first_name, ' ', u2.last_name) as assigned_to_name,
 			o.name as assigned_company_name,
 			EXTRACT(DAY FROM (CURRENT_TIMESTAMP - i.created_at)) as days_open,
-			CASE WHEN i.due_date < CURRENT_TIMESTAMP AND i.status != 'closed' THEN true ELSE false END as is_overdue
+			CASE WHEN i.due_date < CURRENT_TIMESTAMP AND i.status NOT IN ('closed', 'rejected') THEN true ELSE false END as is_overdue,
+			` + agingColumnsSQL("i", "project.issue_comments", "issue_id") + `
 		FROM project.issues i
 		LEFT JOIN project.projects p ON i.project_id = p.id
 		LEFT JOIN iam.users u1 ON i.reported_by = u1.id
@@ -317,7 +430,7 @@ func (dao *IssueDao) GetIssueByID(ctx context.Context, issueID int64) (*models.I
 		WHERE i.id = $1 AND i.is_deleted = FALSE
 	`
 	
-	err := dao.DB.QueryRowContext(ctx, query, issueID).Scan(
+	err := q.QueryRowContext(ctx, query, issueID).Scan(
 		&response.ID, &response.ProjectID, &response.IssueNumber, &templateID,
 		&response.Title, &response.Description,
 		&category, &detailCategory, &response.IssueType,
@@ -341,11 +454,13 @@ func (dao *IssueDao) GetIssueByID(ctx context.Context, issueID int64) (*models.I
 		&assignedCompanyName,
 		&response.DaysOpen,
 		&response.IsOverdue,
+		&response.AgeDays,
+		&response.DaysSinceLastActivity,
 	)
 	
 	if err == sql.ErrNoRows {
 		dao.Logger.WithField("issue_id", issueID).Warn("Issue not found")
-		return nil, fmt.Errorf("issue not found")
+		return nil, notFoundError("issue not found")
 	}
 	
 	if err != nil {
@@ -447,7 +562,34 @@ func (dao *IssueDao) GetIssueByID(ctx context.Context, issueID int64) (*models.I
 }
 
 // GetIssuesByProject retrieves all issues for a specific project with optional filters
-func (dao *IssueDao) GetIssuesByProject(ctx context.Context, projectID int64, filters map[string]string) ([]models.IssueResponse, error) {
+func (dao *IssueDao) GetIssuesByProject(ctx context.Context, projectID int64, filters map[string]string, listQuery models.IssueListQuery) ([]models.IssueResponse, int, bool, error) {
+	defer util.TimeDB(ctx)()
+
+	where := `
+		WHERE i.project_id = $1 AND i.is_deleted = FALSE
+	`
+	return dao.listIssues(ctx, where, []interface{}{projectID}, filters, listQuery, logrus.Fields{"project_id": projectID})
+}
+
+// SearchIssues retrieves a page of issues across every live project in the organization, optionally
+// narrowed to one project with the project_id filter. It accepts the same filters as GetIssuesByProject.
+func (dao *IssueDao) SearchIssues(ctx context.Context, orgID int64, filters map[string]string, listQuery models.IssueListQuery) ([]models.IssueResponse, int, bool, error) {
+	defer util.TimeDB(ctx)()
+
+	where := `
+		WHERE p.org_id = $1 AND p.is_deleted = FALSE AND i.is_deleted = FALSE
+	`
+	args := []interface{}{orgID}
+	if projectID := filters["project_id"]; projectID != "" {
+		where += " AND i.project_id = $2"
+		args = append(args, projectID)
+	}
+	return dao.listIssues(ctx, where, args, filters, listQuery, logrus.Fields{"org_id": orgID})
+}
+
+// listIssues runs the issue list query for baseWhere (which must use args as $1..$n) plus the shared
+// list filters, returning the page, the filtered total and whether more issues exist past the page
+func (dao *IssueDao) listIssues(ctx context.Context, baseWhere string, baseArgs []interface{}, filters map[string]string, listQuery models.IssueListQuery, logFields logrus.Fields) ([]models.IssueResponse, int, bool, error) {
 	// Build query with filters
 	query := `
 		SELECT 
@@ -473,53 +615,104 @@ func (dao *IssueDao) GetIssuesByProject(ctx context.Context, projectID int64, fi
 			CONCAT(u2.first_name, ' ', u2.last_name) as assigned_to_name,
 			o.name as assigned_company_name,
 			EXTRACT(DAY FROM (CURRENT_TIMESTAMP - i.created_at)) as days_open,
-			CASE WHEN i.due_date < CURRENT_TIMESTAMP AND i.status != 'closed' THEN true ELSE false END as is_overdue
+			CASE WHEN i.due_date < CURRENT_TIMESTAMP AND i.status NOT IN ('closed', 'rejected') THEN true ELSE false END as is_overdue,
+			` + agingColumnsSQL("i", "project.issue_comments", "issue_id") + `
 		FROM project.issues i
 		LEFT JOIN project.projects p ON i.project_id = p.id
 		LEFT JOIN iam.users u1 ON i.reported_by = u1.id
 		LEFT JOIN iam.users u2 ON i.assigned_to = u2.id
 		LEFT JOIN iam.organizations o ON i.assigned_company_id = o.id
-		WHERE i.project_id = $1 AND i.is_deleted = FALSE
 	`
+	where := baseWhere
 	
 	// Add filters
-	args := []interface{}{projectID}
-	argIndex := 2
+	args := append([]interface{}{}, baseArgs...)
+	argIndex := len(args) + 1
 	
+	// status and priority accept comma-separated lists (validated by the handler)
 	if status, ok := filters["status"]; ok && status != "" {
-		query += fmt.Sprintf(" AND i.status = $%d", argIndex)
-		args = append(args, status)
+		where += fmt.Sprintf(" AND i.status = ANY($%d)", argIndex)
+		args = append(args, pq.Array(strings.Split(status, ",")))
 		argIndex++
 	}
 	
 	if priority, ok := filters["priority"]; ok && priority != "" {
-		query += fmt.Sprintf(" AND i.priority = $%d", argIndex)
-		args = append(args, priority)
+		where += fmt.Sprintf(" AND i.priority = ANY($%d)", argIndex)
+		args = append(args, pq.Array(strings.Split(priority, ",")))
 		argIndex++
 	}
 	
 	if category, ok := filters["category"]; ok && category != "" {
-		query += fmt.Sprintf(" AND i.category = $%d", argIndex)
+		where += fmt.Sprintf(" AND i.category = $%d", argIndex)
 		args = append(args, category)
 		argIndex++
 	}
 	
 	if assignedTo, ok := filters["assigned_to"]; ok && assignedTo != "" {
-		query += fmt.Sprintf(" AND i.assigned_to = $%d", argIndex)
+		where += fmt.Sprintf(" AND i.assigned_to = $%d", argIndex)
 		args = append(args, assignedTo)
 		argIndex++
 	}
+
+	// q matches title, description or issue number; LIKE wildcards in the input are matched literally
+	if q := strings.TrimSpace(filters["q"]); q != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q) + "%"
+		where += fmt.Sprintf(" AND (i.title ILIKE $%d OR i.description ILIKE $%d OR i.issue_number ILIKE $%d)", argIndex, argIndex, argIndex)
+		args = append(args, pattern)
+		argIndex++
+	}
+
+	// overdue and due_within_days only match open work with a due date; issues without one are never due
+	if filters["overdue"] == "true" {
+		where += fmt.Sprintf(" AND i.due_date IS NOT NULL AND i.due_date < CURRENT_TIMESTAMP AND NOT (i.status = ANY($%d))", argIndex)
+		args = append(args, pq.Array([]string{models.IssueStatusClosed, models.IssueStatusRejected}))
+		argIndex++
+	}
+
+	if days, ok := filters["due_within_days"]; ok && days != "" {
+		where += fmt.Sprintf(" AND i.due_date IS NOT NULL AND i.due_date >= CURRENT_TIMESTAMP AND i.due_date < CURRENT_TIMESTAMP + make_interval(days => $%d) AND NOT (i.status = ANY($%d))", argIndex, argIndex+1)
+		args = append(args, days, pq.Array([]string{models.IssueStatusClosed, models.IssueStatusRejected}))
+		argIndex += 2
+	}
+
+	// Total respects the filters but not the page or cursor
+	var total int
+	err := dao.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM project.issues i JOIN project.projects p ON i.project_id = p.id`+where, args...).Scan(&total)
+	if err != nil {
+		dao.Logger.WithFields(logFields).WithError(err).Error("Failed to count issues")
+		return nil, 0, false, fmt.Errorf("failed to count issues: %w", err)
+	}
+
+	// Cursor mode pages on (created_at, id) in the requested direction instead of using an offset
+	if listQuery.CursorID > 0 {
+		comparison := "<"
+		if filters["order"] == "asc" {
+			comparison = ">"
+		}
+		where += fmt.Sprintf(" AND (i.created_at, i.id) %s ($%d, $%d)", comparison, argIndex, argIndex+1)
+		args = append(args, listQuery.CursorCreatedAt, listQuery.CursorID)
+		argIndex += 2
+	}
+	query += where
 	
-	// Add ordering
-	query += " ORDER BY i.created_at DESC"
+	// Add ordering (sort=created_at|age_days|days_since_last_activity, order=asc|desc)
+	query += agingOrderBySQL(filters, "i")
+
+	// Fetch one extra row to learn whether another page exists
+	if listQuery.PageSize > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, listQuery.PageSize+1)
+		argIndex++
+		if listQuery.CursorID == 0 && listQuery.Page > 1 {
+			query += fmt.Sprintf(" OFFSET $%d", argIndex)
+			args = append(args, (listQuery.Page-1)*listQuery.PageSize)
+		}
+	}
 	
 	rows, err := dao.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		dao.Logger.WithFields(logrus.Fields{
-			"project_id": projectID,
-			"error":      err.Error(),
-		}).Error("Failed to query issues")
-		return nil, fmt.Errorf("failed to query issues: %w", err)
+		dao.Logger.WithFields(logFields).WithError(err).Error("Failed to query issues")
+		return nil, 0, false, fmt.Errorf("failed to query issues: %w", err)
 	}
 	defer rows.Close()
 	
@@ -564,11 +757,13 @@ func (dao *IssueDao) GetIssuesByProject(ctx context.Context, projectID int64, fi
 			&assignedCompanyName,
 			&issue.DaysOpen,
 			&issue.IsOverdue,
+			&issue.AgeDays,
+			&issue.DaysSinceLastActivity,
 		)
 		
 		if err != nil {
 			dao.Logger.WithError(err).Error("Failed to scan issue row")
-			return nil, fmt.Errorf("failed to scan issue: %w", err)
+			return nil, 0, false, fmt.Errorf("failed to scan issue: %w", err)
 		}
 		
 		issue.DistributionList = []string(distributionList)
@@ -663,36 +858,58 @@ func (dao *IssueDao) GetIssuesByProject(ctx context.Context, projectID int64, fi
 	
 	if err = rows.Err(); err != nil {
 		dao.Logger.WithError(err).Error("Error iterating issue rows")
-		return nil, fmt.Errorf("error iterating issues: %w", err)
+		return nil, 0, false, fmt.Errorf("error iterating issues: %w", err)
+	}
+
+	hasMore := false
+	if listQuery.PageSize > 0 && len(issues) > listQuery.PageSize {
+		issues = issues[:listQuery.PageSize]
+		hasMore = true
 	}
 	
-	dao.Logger.WithFields(logrus.Fields{
-		"project_id": projectID,
-		"count":      len(issues),
-	}).Debug("Successfully retrieved issues for project")
+	dao.Logger.WithFields(logFields).WithFields(logrus.Fields{
+		"count": len(issues),
+		"total": total,
+	}).Debug("Successfully retrieved issues")
 	
-	return issues, nil
+	return issues, total, hasMore, nil
 }
 
-// UpdateIssue updates an existing issue
+// UpdateIssue updates an existing issue and, in the same transaction, logs one activity entry per
+// field the update actually changed (see models.DiffIssues)
 func (dao *IssueDao) UpdateIssue(ctx context.Context, issueID, userID, orgID int64, req *models.UpdateIssueRequest) (*models.IssueResponse, error) {
+	defer util.TimeDB(ctx)()
+	// The issue row stays locked from the before-read to the activity entries, so the logged
+	// diff is exactly this update's change
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// First validate that issue exists and belongs to user's organization
 	var projectID, projectOrgID int64
-	err := dao.DB.QueryRowContext(ctx, `
+	err = tx.QueryRowContext(ctx, `
 		SELECT p.id, p.org_id
 		FROM project.issues i
 		JOIN project.projects p ON i.project_id = p.id
 		WHERE i.id = $1 AND i.is_deleted = FALSE AND p.is_deleted = FALSE
+		FOR UPDATE OF i
 	`, issueID).Scan(&projectID, &projectOrgID)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("issue not found")
+		return nil, notFoundError("issue not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate issue: %w", err)
 	}
 	if projectOrgID != orgID {
-		return nil, fmt.Errorf("issue does not belong to your organization")
+		return nil, orgMismatchError("issue does not belong to your organization")
+	}
+
+	oldIssue, err := dao.getIssueByID(ctx, tx, issueID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build dynamic update query using flatter structure
@@ -848,11 +1065,11 @@ func (dao *IssueDao) UpdateIssue(ctx context.Context, issueID, userID, orgID int
 	`, strings.Join(setParts, ", "), argIndex)
 	
 	var updatedAt time.Time
-	err = dao.DB.QueryRowContext(ctx, query, args...).Scan(&updatedAt)
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&updatedAt)
 	
 	if err == sql.ErrNoRows {
 		dao.Logger.WithField("issue_id", issueID).Warn("Issue not found for update")
-		return nil, fmt.Errorf("issue not found")
+		return nil, notFoundError("issue not found")
 	}
 	
 	if err != nil {
@@ -862,23 +1079,48 @@ func (dao *IssueDao) UpdateIssue(ctx context.Context, issueID, userID, orgID int
 		}).Error("Failed to update issue")
 		return nil, fmt.Errorf("failed to update issue: %w", err)
 	}
-	
+
+	updatedIssue, err := dao.getIssueByID(ctx, tx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log one activity entry per changed field; fields sent with their current value log nothing
+	changes := models.DiffIssues(oldIssue, updatedIssue)
+	for _, change := range changes {
+		if err := dao.CreateActivityLogTx(ctx, tx, issueID, userID, change.ActivityMessage(), change.OldValue, change.NewValue); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	dao.Logger.WithFields(logrus.Fields{
-		"issue_id": issueID,
-		"user_id":  userID,
+		"issue_id":       issueID,
+		"user_id":        userID,
+		"changed_fields": len(changes),
 	}).Info("Successfully updated issue")
-	
-	// Return updated issue
-	return dao.GetIssueByID(ctx, issueID)
+
+	return updatedIssue, nil
 }
 
 // DeleteIssue soft deletes an issue
-func (dao *IssueDao) DeleteIssue(ctx context.Context, issueID, userID int64) error {
-	result, err := dao.DB.ExecContext(ctx, `
+func (dao *IssueDao) DeleteIssue(ctx context.Context, issueID, userID int64, reason string) error {
+	defer util.TimeDB(ctx)()
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
 		UPDATE project.issues 
-		SET is_deleted = TRUE, updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		SET is_deleted = TRUE, delete_reason = $3, deleted_at = CURRENT_TIMESTAMP, deleted_by = $1,
+		    updated_by = $1, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $2 AND is_deleted = FALSE
-	`, userID, issueID)
+	`, userID, issueID, sql.NullString{String: reason, Valid: reason != ""})
 	
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
@@ -895,7 +1137,19 @@ func (dao *IssueDao) DeleteIssue(ctx context.Context, issueID, userID int64) err
 	
 	if rowsAffected == 0 {
 		dao.Logger.WithField("issue_id", issueID).Warn("Issue not found for deletion")
-		return fmt.Errorf("issue not found")
+		return notFoundError("issue not found")
+	}
+
+	activityMsg := "Issue deleted"
+	if reason != "" {
+		activityMsg = fmt.Sprintf("Issue deleted: %s", reason)
+	}
+	if err := dao.CreateActivityLogTx(ctx, tx, issueID, userID, activityMsg, "", ""); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	
 	dao.Logger.WithFields(logrus.Fields{
@@ -906,8 +1160,74 @@ func (dao *IssueDao) DeleteIssue(ctx context.Context, issueID, userID int64) err
 	return nil
 }
 
+// RestoreIssue undoes a soft delete. The issue must belong to orgID and its project must still exist;
+// an issue that is not deleted is reported as a conflict.
+func (dao *IssueDao) RestoreIssue(ctx context.Context, issueID, userID, orgID int64) error {
+	defer util.TimeDB(ctx)()
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var isDeleted, projectDeleted bool
+	var projectOrgID int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT i.is_deleted, p.is_deleted, p.org_id
+		FROM project.issues i
+		JOIN project.projects p ON p.id = i.project_id
+		WHERE i.id = $1
+		FOR UPDATE OF i
+	`, issueID).Scan(&isDeleted, &projectDeleted, &projectOrgID)
+	if err == sql.ErrNoRows {
+		return notFoundError("issue not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if projectOrgID != orgID {
+		return orgMismatchError("issue does not belong to your organization")
+	}
+	if !isDeleted {
+		return conflictError("issue is not deleted")
+	}
+	if projectDeleted {
+		return conflictError("issue cannot be restored because its project is deleted")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE project.issues
+		SET is_deleted = FALSE, delete_reason = NULL, deleted_at = NULL, deleted_by = NULL,
+		    updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, userID, issueID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"issue_id": issueID,
+			"error":    err.Error(),
+		}).Error("Failed to restore issue")
+		return fmt.Errorf("failed to restore issue: %w", err)
+	}
+
+	if err := dao.CreateActivityLogTx(ctx, tx, issueID, userID, "Issue restored", "", ""); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"issue_id": issueID,
+		"user_id":  userID,
+	}).Info("Successfully restored issue")
+
+	return nil
+}
+
 // UpdateIssueStatus updates only the status of an issue
 func (dao *IssueDao) UpdateIssueStatus(ctx context.Context, issueID, userID int64, status string) error {
+	defer util.TimeDB(ctx)()
 	query := `
 		UPDATE project.issues 
 		SET status = $1, updated_by = $2, updated_at = CURRENT_TIMESTAMP
@@ -939,7 +1259,7 @@ func (dao *IssueDao) UpdateIssueStatus(ctx context.Context, issueID, userID int6
 	
 	if rowsAffected == 0 {
 		dao.Logger.WithField("issue_id", issueID).Warn("Issue not found for status update")
-		return fmt.Errorf("issue not found")
+		return notFoundError("issue not found")
 	}
 	
 	dao.Logger.WithFields(logrus.Fields{
@@ -951,15 +1271,151 @@ func (dao *IssueDao) UpdateIssueStatus(ctx context.Context, issueID, userID int6
 	return nil
 }
 
+// BulkUpdateStatus validates every issue with a single locking query, then updates the valid ones and logs
+// a status change activity for each issue whose status actually changed
+func (dao *IssueDao) BulkUpdateStatus(ctx context.Context, issueIDs []int64, orgID, userID int64, status string, atomic bool) ([]IssueStatusBatchResult, error) {
+	defer util.TimeDB(ctx)()
+	results := make([]IssueStatusBatchResult, len(issueIDs))
+
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT i.id, i.project_id, i.status, i.assigned_to, p.org_id
+		FROM project.issues i
+		JOIN project.projects p ON p.id = i.project_id AND p.is_deleted = FALSE
+		WHERE i.id = ANY($1) AND i.is_deleted = FALSE
+		FOR UPDATE OF i
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	type issueState struct {
+		projectID  int64
+		status     string
+		assignedTo sql.NullInt64
+		orgID      int64
+	}
+	states := make(map[int64]issueState, len(issueIDs))
+	for rows.Next() {
+		var id int64
+		var state issueState
+		if err := rows.Scan(&id, &state.projectID, &state.status, &state.assignedTo, &state.orgID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		states[id] = state
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+
+	var changedIDs []int64
+	failed := 0
+	seen := make(map[int64]int, len(issueIDs))
+	for i, issueID := range issueIDs {
+		results[i].IssueID = issueID
+
+		if first, ok := seen[issueID]; ok {
+			results[i].Err = conflictError(fmt.Sprintf("duplicate of issue at index %d in this request", first))
+			failed++
+			continue
+		}
+		seen[issueID] = i
+
+		state, ok := states[issueID]
+		if !ok {
+			results[i].Err = notFoundError("issue not found")
+			failed++
+			continue
+		}
+		if state.orgID != orgID {
+			results[i].Err = orgMismatchError("issue does not belong to your organization")
+			failed++
+			continue
+		}
+		if err := models.ValidateIssueStatusTransition(state.status, status); err != nil {
+			results[i].Err = err
+			failed++
+			continue
+		}
+
+		results[i].ProjectID = state.projectID
+		results[i].OldStatus = state.status
+		if state.assignedTo.Valid {
+			results[i].AssignedTo = &state.assignedTo.Int64
+		}
+		if state.status != status {
+			changedIDs = append(changedIDs, issueID)
+		}
+	}
+
+	if atomic && failed > 0 {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = conflictError("not updated because other issues in the request failed")
+			}
+		}
+		return results, nil
+	}
+
+	if len(changedIDs) > 0 {
+		query := `
+			UPDATE project.issues
+			SET status = $1, updated_by = $2, updated_at = CURRENT_TIMESTAMP`
+		if status == models.IssueStatusClosed {
+			query += ", closed_date = CURRENT_TIMESTAMP"
+		}
+		query += " WHERE id = ANY($3) AND is_deleted = FALSE"
+
+		if _, err := tx.ExecContext(ctx, query, status, userID, pq.Array(changedIDs)); err != nil {
+			dao.Logger.WithFields(logrus.Fields{
+				"issue_count": len(changedIDs),
+				"status":      status,
+				"error":       err.Error(),
+			}).Error("Failed to bulk update issue status")
+			return nil, fmt.Errorf("failed to update issue status: %w", err)
+		}
+
+		for _, result := range results {
+			if result.Err != nil || result.OldStatus == status {
+				continue
+			}
+			activityMsg := fmt.Sprintf("Status changed from %s to %s", result.OldStatus, status)
+			if err := dao.CreateActivityLogTx(ctx, tx, result.IssueID, userID, activityMsg, result.OldStatus, status); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"org_id":    orgID,
+		"status":    status,
+		"requested": len(issueIDs),
+		"updated":   len(changedIDs),
+		"failed":    failed,
+	}).Info("Bulk issue status update completed")
+
+	return results, nil
+}
+
 // GetIssueAttachments retrieves all attachments for an issue
 func (dao *IssueDao) GetIssueAttachments(ctx context.Context, issueID int64) ([]models.IssueAttachment, error) {
 	query := `
 		SELECT
 			id, issue_id, file_name, file_path, file_size, file_type,
-			attachment_type, uploaded_by, created_at, created_by,
+			attachment_type, uploaded_by, scan_status, created_at, created_by,
 			updated_at, updated_by, is_deleted
 		FROM project.issue_attachments
-		WHERE issue_id = $1 AND is_deleted = FALSE
+		WHERE issue_id = $1 AND is_deleted = FALSE AND upload_status = 'confirmed'
 		ORDER BY created_at DESC`
 
 	rows, err := dao.DB.QueryContext(ctx, query, issueID)
@@ -978,7 +1434,7 @@ func (dao *IssueDao) GetIssueAttachments(ctx context.Context, issueID int64) ([]
 		err := rows.Scan(
 			&attachment.ID, &attachment.IssueID, &attachment.FileName,
 			&attachment.FilePath, &fileSize, &fileType,
-			&attachment.AttachmentType, &attachment.UploadedBy,
+			&attachment.AttachmentType, &attachment.UploadedBy, &attachment.ScanStatus,
 			&attachment.CreatedAt, &attachment.CreatedBy,
 			&attachment.UpdatedAt, &attachment.UpdatedBy, &attachment.IsDeleted,
 		)
@@ -1014,6 +1470,7 @@ func (dao *IssueDao) GetIssueAttachments(ctx context.Context, issueID int64) ([]
 
 // CreateComment creates a new comment on an issue
 func (dao *IssueDao) CreateComment(ctx context.Context, issueID, userID int64, req *models.CreateCommentRequest) (*models.IssueComment, error) {
+	defer util.TimeDB(ctx)()
 	var comment models.IssueComment
 
 	err := dao.DB.QueryRowContext(ctx, `
@@ -1083,6 +1540,16 @@ func (dao *IssueDao) CreateComment(ctx context.Context, issueID, userID int64, r
 	// Fetch attachments for this comment
 	comment.Attachments = dao.getCommentAttachments(ctx, comment.ID)
 
+	// Record the users mentioned in the comment; like attachment links, a failure here does not fail the comment
+	if tokens := util.ParseMentions(req.Comment); len(tokens) > 0 {
+		mentions, err := dao.recordIssueCommentMentions(ctx, issueID, comment.ID, tokens)
+		if err != nil {
+			dao.Logger.WithError(err).WithField("comment_id", comment.ID).Error("Failed to record comment mentions")
+		} else {
+			comment.Mentions = mentions
+		}
+	}
+
 	dao.Logger.WithFields(logrus.Fields{
 		"comment_id": comment.ID,
 		"issue_id":   issueID,
@@ -1092,84 +1559,392 @@ func (dao *IssueDao) CreateComment(ctx context.Context, issueID, userID int64, r
 	return &comment, nil
 }
 
-// GetIssueComments retrieves all comments for an issue
-func (dao *IssueDao) GetIssueComments(ctx context.Context, issueID int64) ([]models.IssueComment, error) {
-	query := `
-		SELECT
+// recordIssueCommentMentions resolves mention tokens against the organization that owns the issue and stores them
+func (dao *IssueDao) recordIssueCommentMentions(ctx context.Context, issueID, commentID int64, tokens []util.MentionToken) ([]models.CommentMention, error) {
+	var orgID int64
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT p.org_id
+		FROM project.issues i
+		JOIN project.projects p ON p.id = i.project_id
+		WHERE i.id = $1
+	`, issueID).Scan(&orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue organization: %w", err)
+	}
+
+	mentions, err := resolveCommentMentions(ctx, dao.DB, dao.Logger, orgID, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordCommentMentions(ctx, dao.DB, models.EntityTypeIssueComment, commentID, mentions); err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+// issueCommentColumns selects the columns scanned by scanIssueComment from project.issue_comments c
+// joined to its author u
+const issueCommentColumns = `
 			c.id, c.issue_id, c.comment, c.comment_type,
 			c.previous_value, c.new_value,
 			c.created_at, c.created_by,
 			COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as created_by_name,
-			c.updated_at, c.updated_by, c.is_deleted
+			c.updated_at, c.updated_by, c.edited_at, c.is_deleted, c.deleted_at`
+
+// scanIssueComment scans a row selected with issueCommentColumns. A comment deleted by its author
+// keeps its place in the thread, but its text is replaced with DeletedCommentPlaceholder.
+func scanIssueComment(scanner interface{ Scan(...interface{}) error }) (models.IssueComment, error) {
+	var comment models.IssueComment
+	var previousValue, newValue sql.NullString
+	var deletedAt *time.Time
+
+	err := scanner.Scan(
+		&comment.ID,
+		&comment.IssueID,
+		&comment.Comment,
+		&comment.CommentType,
+		&previousValue,
+		&newValue,
+		&comment.CreatedAt,
+		&comment.CreatedBy,
+		&comment.CreatedByName,
+		&comment.UpdatedAt,
+		&comment.UpdatedBy,
+		&comment.EditedAt,
+		&comment.IsDeleted,
+		&deletedAt,
+	)
+	if err != nil {
+		return models.IssueComment{}, err
+	}
+
+	// Handle nullable fields
+	if previousValue.Valid {
+		comment.PreviousValue = previousValue.String
+	}
+	if newValue.Valid {
+		comment.NewValue = newValue.String
+	}
+	if deletedAt != nil {
+		comment.Comment = models.DeletedCommentPlaceholder
+		comment.EditedAt = nil
+	}
+	return comment, nil
+}
+
+// lockEditableComment locks a live comment on the issue and checks that userID may change it.
+// It returns the comment's current text.
+func lockEditableComment(ctx context.Context, tx *sql.Tx, issueID, commentID, userID int64, isSuperAdmin bool) (string, error) {
+	var createdBy int64
+	var commentType, text string
+	err := tx.QueryRowContext(ctx, `
+		SELECT created_by, comment_type, comment
+		FROM project.issue_comments
+		WHERE id = $1 AND issue_id = $2 AND is_deleted = FALSE
+		FOR UPDATE
+	`, commentID, issueID).Scan(&createdBy, &commentType, &text)
+	if err == sql.ErrNoRows {
+		return "", notFoundError("comment not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if commentType != models.CommentTypeComment {
+		return "", conflictError("activity entries cannot be changed")
+	}
+	if createdBy != userID && !isSuperAdmin {
+		return "", forbiddenError("only the comment author can change this comment")
+	}
+	return text, nil
+}
+
+// UpdateComment replaces the text of a comment and records the previous text in project.issue_comment_edits
+func (dao *IssueDao) UpdateComment(ctx context.Context, issueID, commentID, userID int64, isSuperAdmin bool, req *models.UpdateCommentRequest) (*models.IssueComment, error) {
+	defer util.TimeDB(ctx)()
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	previousText, err := lockEditableComment(ctx, tx, issueID, commentID, userID, isSuperAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO project.issue_comment_edits (comment_id, previous_comment, edited_by)
+		VALUES ($1, $2, $3)
+	`, commentID, previousText, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record comment edit: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE project.issue_comments
+		SET comment = $1, edited_at = CURRENT_TIMESTAMP, updated_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, req.Comment, userID, commentID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("comment_id", commentID).Error("Failed to update comment")
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	comment, err := scanIssueComment(dao.DB.QueryRowContext(ctx, `
+		SELECT `+issueCommentColumns+`
 		FROM project.issue_comments c
 		LEFT JOIN iam.users u ON c.created_by = u.id
-		WHERE c.issue_id = $1 AND c.is_deleted = FALSE
-		ORDER BY c.created_at DESC
-	`
+		WHERE c.id = $1
+	`, commentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	comment.Attachments = dao.getCommentAttachments(ctx, commentID)
 
-	rows, err := dao.DB.QueryContext(ctx, query, issueID)
+	dao.Logger.WithFields(logrus.Fields{
+		"comment_id": commentID,
+		"issue_id":   issueID,
+		"user_id":    userID,
+	}).Info("Successfully updated comment")
+
+	return &comment, nil
+}
+
+// SoftDeleteComment marks a comment deleted; deleted_at tells the comment list to show a placeholder for it
+func (dao *IssueDao) SoftDeleteComment(ctx context.Context, issueID, commentID, userID int64, isSuperAdmin bool) error {
+	defer util.TimeDB(ctx)()
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockEditableComment(ctx, tx, issueID, commentID, userID, isSuperAdmin); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE project.issue_comments
+		SET is_deleted = TRUE, deleted_at = CURRENT_TIMESTAMP, updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, userID, commentID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("comment_id", commentID).Error("Failed to delete comment")
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"comment_id": commentID,
+		"issue_id":   issueID,
+		"user_id":    userID,
+	}).Info("Successfully deleted comment")
+
+	return nil
+}
+
+// GetIssueComments retrieves a page of comments for an issue in chronological order.
+// Comment ids increase with creation time, so the after_id/before_id cursors page on id.
+func (dao *IssueDao) GetIssueComments(ctx context.Context, issueID int64, query models.IssueCommentQuery) ([]models.IssueComment, int, bool, error) {
+	defer util.TimeDB(ctx)()
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultIssueCommentLimit
+	}
+	if limit > models.MaxIssueCommentLimit {
+		limit = models.MaxIssueCommentLimit
+	}
+
+	// Comments deleted by their author stay in the list as placeholders so replies keep their context
+	var totalCount int
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM project.issue_comments
+		WHERE issue_id = $1 AND (is_deleted = FALSE OR deleted_at IS NOT NULL)
+	`, issueID).Scan(&totalCount)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to count issue comments")
+		return []models.IssueComment{}, 0, false, fmt.Errorf("failed to count issue comments: %w", err)
+	}
+
+	sqlQuery := `
+		SELECT ` + issueCommentColumns + `
+		FROM project.issue_comments c
+		LEFT JOIN iam.users u ON c.created_by = u.id
+		WHERE c.issue_id = $1 AND (c.is_deleted = FALSE OR c.deleted_at IS NOT NULL)`
+	args := []interface{}{issueID}
+
+	// Loading older comments (before_id) or the newest page walks backwards and is reversed below
+	descending := query.Newest || query.BeforeID > 0
+	switch {
+	case query.AfterID > 0:
+		sqlQuery += " AND c.id > $2"
+		args = append(args, query.AfterID)
+	case query.BeforeID > 0:
+		sqlQuery += " AND c.id < $2"
+		args = append(args, query.BeforeID)
+	}
+	if descending {
+		sqlQuery += " ORDER BY c.id DESC"
+	} else {
+		sqlQuery += " ORDER BY c.id ASC"
+	}
+	// Fetch one extra row to know whether another page exists
+	if !query.All {
+		sqlQuery += fmt.Sprintf(" LIMIT %d", limit+1)
+	}
+	if query.AfterID <= 0 && query.BeforeID <= 0 && query.Offset > 0 {
+		sqlQuery += fmt.Sprintf(" OFFSET %d", query.Offset)
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to get issue comments")
-		return []models.IssueComment{}, fmt.Errorf("failed to get issue comments: %w", err)
+		return []models.IssueComment{}, 0, false, fmt.Errorf("failed to get issue comments: %w", err)
 	}
 	defer rows.Close()
 
 	// Initialize with empty slice to ensure JSON marshals as [] instead of null
 	comments := make([]models.IssueComment, 0)
 	for rows.Next() {
-		var comment models.IssueComment
-		var previousValue, newValue sql.NullString
-
-		err := rows.Scan(
-			&comment.ID,
-			&comment.IssueID,
-			&comment.Comment,
-			&comment.CommentType,
-			&previousValue,
-			&newValue,
-			&comment.CreatedAt,
-			&comment.CreatedBy,
-			&comment.CreatedByName,
-			&comment.UpdatedAt,
-			&comment.UpdatedBy,
-			&comment.IsDeleted,
-		)
-
+		comment, err := scanIssueComment(rows)
 		if err != nil {
 			dao.Logger.WithError(err).Error("Failed to scan comment row")
-			return nil, fmt.Errorf("failed to scan comment: %w", err)
-		}
-
-		// Handle nullable fields
-		if previousValue.Valid {
-			comment.PreviousValue = previousValue.String
+			return nil, 0, false, fmt.Errorf("failed to scan comment: %w", err)
 		}
-		if newValue.Valid {
-			comment.NewValue = newValue.String
-		}
-
-		// Fetch attachments for this comment
-		comment.Attachments = dao.getCommentAttachments(ctx, comment.ID)
-
 		comments = append(comments, comment)
 	}
 
 	if err = rows.Err(); err != nil {
 		dao.Logger.WithError(err).Error("Error iterating comment rows")
-		return nil, fmt.Errorf("error iterating comments: %w", err)
+		return nil, 0, false, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	hasMore := !query.All && len(comments) > limit
+	if hasMore {
+		comments = comments[:limit]
+	}
+	if descending {
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	}
+
+	// Fetch attachments and mentions for the comments on this page only; deleted comments show neither
+	commentIDs := make([]int64, 0, len(comments))
+	for i := range comments {
+		if comments[i].IsDeleted {
+			comments[i].Attachments = []models.IssueCommentAttachment{}
+			continue
+		}
+		comments[i].Attachments = dao.getCommentAttachments(ctx, comments[i].ID)
+		commentIDs = append(commentIDs, comments[i].ID)
+	}
+	mentions, err := getCommentMentions(ctx, dao.DB, models.EntityTypeIssueComment, commentIDs)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("issue_id", issueID).Warn("Failed to get comment mentions")
+	}
+	for i := range comments {
+		comments[i].Mentions = mentions[comments[i].ID]
 	}
 
 	dao.Logger.WithFields(logrus.Fields{
 		"issue_id":      issueID,
 		"comment_count": len(comments),
+		"total_count":   totalCount,
 	}).Debug("Retrieved comments for issue")
 
-	return comments, nil
+	return comments, totalCount, hasMore, nil
+}
+
+// GetActivityLog retrieves a page of the issue's activity log in chronological order, with each
+// actor's name. Activity entries are the issue_comments rows with comment_type 'activity'.
+func (dao *IssueDao) GetActivityLog(ctx context.Context, issueID int64, limit, offset int) ([]models.IssueActivity, int, bool, error) {
+	defer util.TimeDB(ctx)()
+	if limit <= 0 {
+		limit = models.DefaultIssueActivityLimit
+	}
+	if limit > models.MaxIssueActivityLimit {
+		limit = models.MaxIssueActivityLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var totalCount int
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM project.issue_comments
+		WHERE issue_id = $1 AND comment_type = $2 AND is_deleted = FALSE
+	`, issueID, models.CommentTypeActivity).Scan(&totalCount)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("issue_id", issueID).Error("Failed to count issue activity")
+		return nil, 0, false, fmt.Errorf("failed to count issue activity: %w", err)
+	}
+
+	activity := []models.IssueActivity{}
+	if totalCount == 0 {
+		return activity, 0, false, nil
+	}
+
+	// Fetch one extra row to know whether another page exists
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT c.id, c.issue_id, c.comment, c.previous_value, c.new_value, c.created_by,
+		       COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as actor_name,
+		       c.created_at
+		FROM project.issue_comments c
+		LEFT JOIN iam.users u ON c.created_by = u.id
+		WHERE c.issue_id = $1 AND c.comment_type = $2 AND c.is_deleted = FALSE
+		ORDER BY c.created_at ASC, c.id ASC
+		LIMIT $3 OFFSET $4
+	`, issueID, models.CommentTypeActivity, limit+1, offset)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("issue_id", issueID).Error("Failed to get issue activity")
+		return nil, 0, false, fmt.Errorf("failed to get issue activity: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.IssueActivity
+		var previousValue, newValue sql.NullString
+		err := rows.Scan(&entry.ID, &entry.IssueID, &entry.Message, &previousValue, &newValue,
+			&entry.ActorID, &entry.ActorName, &entry.CreatedAt)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan issue activity row")
+			return nil, 0, false, fmt.Errorf("failed to scan issue activity: %w", err)
+		}
+		entry.PreviousValue = previousValue.String
+		entry.NewValue = newValue.String
+		entry.ActorName = strings.TrimSpace(entry.ActorName)
+		activity = append(activity, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, false, fmt.Errorf("error iterating issue activity: %w", err)
+	}
+
+	hasMore := len(activity) > limit
+	if hasMore {
+		activity = activity[:limit]
+	}
+
+	return activity, totalCount, hasMore, nil
 }
 
 // CreateActivityLog creates an activity log entry for status changes and other system events
 func (dao *IssueDao) CreateActivityLog(ctx context.Context, issueID, userID int64, activityMsg, previousValue, newValue string) error {
-	_, err := dao.DB.ExecContext(ctx, `
+	return dao.CreateActivityLogTx(ctx, nil, issueID, userID, activityMsg, previousValue, newValue)
+}
+
+// CreateActivityLogTx creates an activity log entry, inside the caller's transaction when tx is non-nil
+func (dao *IssueDao) CreateActivityLogTx(ctx context.Context, tx *sql.Tx, issueID, userID int64, activityMsg, previousValue, newValue string) error {
+	_, err := txOrDB(dao.DB, tx).ExecContext(ctx, `
 		INSERT INTO project.issue_comments (
 			issue_id, comment, comment_type,
 			previous_value, new_value,
@@ -1209,10 +1984,10 @@ func (dao *IssueDao) CreateActivityLog(ctx context.Context, issueID, userID int6
 func (dao *IssueDao) getCommentAttachments(ctx context.Context, commentID int64) []models.IssueCommentAttachment {
 	query := `
 		SELECT id, comment_id, file_name, file_path, file_size, file_type,
-		       attachment_type, uploaded_by, created_at, created_by,
+		       attachment_type, uploaded_by, scan_status, created_at, created_by,
 		       updated_at, updated_by, is_deleted
 		FROM project.issue_comment_attachments
-		WHERE comment_id = $1 AND is_deleted = FALSE
+		WHERE comment_id = $1 AND is_deleted = FALSE AND upload_status = 'confirmed'
 		ORDER BY created_at ASC
 	`
 
@@ -1239,6 +2014,7 @@ func (dao *IssueDao) getCommentAttachments(ctx context.Context, commentID int64)
 			&fileType,
 			&att.AttachmentType,
 			&att.UploadedBy,
+			&att.ScanStatus,
 			&att.CreatedAt,
 			&att.CreatedBy,
 			&att.UpdatedAt,
@@ -1262,4 +2038,238 @@ func (dao *IssueDao) getCommentAttachments(ctx context.Context, commentID int64)
 	}
 
 	return attachments
-}
\ No newline at end of file
+}
+
+// CopyIssue duplicates an issue into another project of the same organization as a new open issue.
+// Location, assignee and distribution list are project-specific and are not carried over.
+func (dao *IssueDao) CopyIssue(ctx context.Context, issueID, targetProjectID, userID, orgID int64, copyAttachments bool) (*models.IssueResponse, error) {
+	defer util.TimeDB(ctx)()
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to start transaction for issue copy")
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := validateCopyProjects(ctx, tx, "project.issues", "issue", issueID, targetProjectID, orgID); err != nil {
+		return nil, err
+	}
+
+	var category sql.NullString
+	var issueType string
+	err = tx.QueryRowContext(ctx, `
+		SELECT category, issue_type FROM project.issues WHERE id = $1
+	`, issueID).Scan(&category, &issueType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source issue: %w", err)
+	}
+	numberCategory := category.String
+	if len(numberCategory) < 2 {
+		numberCategory = issueType
+	}
+
+	issueNumber, err := dao.generateIssueNumber(ctx, tx, targetProjectID, numberCategory)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to generate issue number for copy")
+		return nil, err
+	}
+
+	var newIssueID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO project.issues (
+			project_id, issue_number, template_id,
+			title, description,
+			issue_type, category, detail_category,
+			priority, severity,
+			root_cause,
+			discipline, trade_type,
+			reported_by,
+			drawing_reference, specification_reference,
+			due_date,
+			status,
+			created_by, updated_by
+		)
+		SELECT
+			$1, $2, template_id,
+			title, description,
+			issue_type, category, detail_category,
+			priority, severity,
+			root_cause,
+			discipline, trade_type,
+			$3,
+			drawing_reference, specification_reference,
+			due_date,
+			$4,
+			$3, $3
+		FROM project.issues
+		WHERE id = $5
+		RETURNING id
+	`, targetProjectID, issueNumber, userID, models.IssueStatusOpen, issueID).Scan(&newIssueID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"issue_id":          issueID,
+			"target_project_id": targetProjectID,
+			"error":             err.Error(),
+		}).Error("Failed to copy issue")
+		return nil, fmt.Errorf("failed to copy issue: %w", err)
+	}
+
+	if copyAttachments {
+		if err := copyEntityAttachments(ctx, tx, models.EntityTypeIssue, issueID, newIssueID, userID); err != nil {
+			dao.Logger.WithError(err).WithField("issue_id", issueID).Error("Failed to copy issue attachments")
+			return nil, err
+		}
+	}
+
+	// The copy is unassigned, so the user who made it is its only watcher
+	if err := dao.addIssueWatchers(ctx, tx, newIssueID, userID, []int64{userID}); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		dao.Logger.WithError(err).Error("Failed to commit issue copy transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"source_issue_id":   issueID,
+		"issue_id":          newIssueID,
+		"issue_number":      issueNumber,
+		"target_project_id": targetProjectID,
+		"user_id":           userID,
+	}).Info("Successfully copied issue")
+
+	return dao.GetIssueByID(ctx, newIssueID)
+}
+
+// RecountIssueCounts recomputes attachment_count and comment_count on issues from their source tables
+func (dao *IssueDao) RecountIssueCounts(ctx context.Context, orgID, projectID int64) (models.RecountResult, error) {
+	return recountEntityCounts(ctx, dao.DB, dao.Logger, models.EntityTypeIssue, "project.issues", orgID, projectID, []countSource{
+		{column: "attachment_count", childTable: "project.issue_attachments", childFK: "issue_id"},
+		{column: "comment_count", childTable: "project.issue_comments", childFK: "issue_id"},
+	})
+}
+
+// addIssueWatchers subscribes the users to an issue, skipping zero IDs and users already watching
+func (dao *IssueDao) addIssueWatchers(ctx context.Context, q dbtx, issueID, addedBy int64, userIDs []int64) error {
+	for _, userID := range userIDs {
+		if userID == 0 {
+			continue
+		}
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO project.issue_watchers (issue_id, user_id, added_by)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (issue_id, user_id) DO NOTHING
+		`, issueID, userID, addedBy)
+		if err != nil {
+			return fmt.Errorf("failed to add issue watcher: %w", err)
+		}
+	}
+	return nil
+}
+
+// AddIssueWatcher subscribes a user to an issue; it reports false when the user was already watching
+func (dao *IssueDao) AddIssueWatcher(ctx context.Context, issueID, userID, addedBy int64) (bool, error) {
+	result, err := dao.DB.ExecContext(ctx, `
+		INSERT INTO project.issue_watchers (issue_id, user_id, added_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (issue_id, user_id) DO NOTHING
+	`, issueID, userID, addedBy)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"issue_id": issueID,
+			"user_id":  userID,
+			"error":    err.Error(),
+		}).Error("Failed to add issue watcher")
+		return false, fmt.Errorf("failed to add issue watcher: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// RemoveIssueWatcher unsubscribes a user from an issue
+func (dao *IssueDao) RemoveIssueWatcher(ctx context.Context, issueID, userID int64) error {
+	result, err := dao.DB.ExecContext(ctx, `
+		DELETE FROM project.issue_watchers
+		WHERE issue_id = $1 AND user_id = $2
+	`, issueID, userID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"issue_id": issueID,
+			"user_id":  userID,
+			"error":    err.Error(),
+		}).Error("Failed to remove issue watcher")
+		return fmt.Errorf("failed to remove issue watcher: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return notFoundError("watcher not found")
+	}
+	return nil
+}
+
+// GetIssueWatchers lists the users watching an issue in the order they were added; deleted users are left out
+func (dao *IssueDao) GetIssueWatchers(ctx context.Context, issueID int64) ([]models.IssueWatcher, error) {
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT w.user_id, CONCAT(u.first_name, ' ', u.last_name), u.email, w.added_by, w.created_at
+		FROM project.issue_watchers w
+		JOIN iam.users u ON u.id = w.user_id
+		WHERE w.issue_id = $1 AND u.is_deleted = FALSE
+		ORDER BY w.created_at ASC, w.user_id ASC
+	`, issueID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("issue_id", issueID).Error("Failed to query issue watchers")
+		return nil, fmt.Errorf("failed to get issue watchers: %w", err)
+	}
+	defer rows.Close()
+
+	watchers := []models.IssueWatcher{}
+	for rows.Next() {
+		var watcher models.IssueWatcher
+		if err := rows.Scan(&watcher.UserID, &watcher.Name, &watcher.Email, &watcher.AddedBy, &watcher.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan issue watcher: %w", err)
+		}
+		watchers = append(watchers, watcher)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get issue watchers: %w", err)
+	}
+	return watchers, nil
+}
+
+// RecordWatcherNotification fills in the notification's recipients from the issue's watchers, leaving out the
+// actor, and records it in project.issue_notifications. Nothing is recorded when no one else is watching.
+func (dao *IssueDao) RecordWatcherNotification(ctx context.Context, notification *models.IssueWatcherNotification) error {
+	var recipientIDs pq.Int64Array
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(w.user_id ORDER BY w.user_id), '{}')
+		FROM project.issue_watchers w
+		JOIN iam.users u ON u.id = w.user_id
+		WHERE w.issue_id = $1 AND w.user_id <> $2 AND u.is_deleted = FALSE
+	`, notification.IssueID, notification.ActorUserID).Scan(&recipientIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get issue watchers: %w", err)
+	}
+
+	notification.RecipientIDs = []int64(recipientIDs)
+	if len(notification.RecipientIDs) == 0 {
+		return nil
+	}
+
+	_, err = dao.DB.ExecContext(ctx, `
+		INSERT INTO project.issue_notifications (
+			issue_id, org_id, event_type, recipient_ids, comment_id, old_status, new_status, actor_id, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, notification.IssueID, notification.OrgID, notification.Trigger, pq.Array(notification.RecipientIDs),
+		sql.NullInt64{Int64: notification.CommentID, Valid: notification.CommentID != 0},
+		sql.NullString{String: notification.OldStatus, Valid: notification.OldStatus != ""},
+		sql.NullString{String: notification.NewStatus, Valid: notification.NewStatus != ""},
+		notification.ActorUserID, notification.Timestamp)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("issue_id", notification.IssueID).Error("Failed to record issue watcher notification")
+		return fmt.Errorf("failed to record issue notification: %w", err)
+	}
+	return nil
+}
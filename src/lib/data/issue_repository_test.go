@@ -0,0 +1,172 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeActivityDB serves the two statements GetActivityLog issues from a fixed number of activity rows
+type fakeActivityDB struct {
+	count   int
+	queries []string
+}
+
+type fakeActivityConnector struct{ db *fakeActivityDB }
+
+type fakeActivityConn struct{ db *fakeActivityDB }
+
+type fakeActivityStmt struct {
+	db    *fakeActivityDB
+	query string
+}
+
+type fakeActivityRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (c *fakeActivityConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeActivityConn{db: c.db}, nil
+}
+
+func (c *fakeActivityConnector) Driver() driver.Driver { return nil }
+
+func (c *fakeActivityConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeActivityStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeActivityConn) Close() error              { return nil }
+func (c *fakeActivityConn) Begin() (driver.Tx, error) { return nil, errors.New("unexpected transaction") }
+
+func (s *fakeActivityStmt) Close() error  { return nil }
+func (s *fakeActivityStmt) NumInput() int { return -1 }
+
+func (s *fakeActivityStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("unexpected exec")
+}
+
+func (s *fakeActivityStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.queries = append(s.db.queries, s.query)
+	switch {
+	case strings.Contains(s.query, "SELECT COUNT(*)"):
+		return &fakeActivityRows{columns: []string{"count"}, values: [][]driver.Value{{int64(s.db.count)}}}, nil
+	case strings.Contains(s.query, "LIMIT $3 OFFSET $4"):
+		limit, offset := int(args[2].(int64)), int(args[3].(int64))
+		rows := &fakeActivityRows{columns: []string{
+			"id", "issue_id", "comment", "previous_value", "new_value", "created_by", "actor_name", "created_at",
+		}}
+		start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		for i := offset; i < s.db.count && i < offset+limit; i++ {
+			rows.values = append(rows.values, []driver.Value{
+				int64(i + 1), args[0], fmt.Sprintf("Activity %d", i+1), nil, "open", int64(7), " Sam Ortiz ", start.Add(time.Duration(i) * time.Minute),
+			})
+		}
+		return rows, nil
+	}
+	return nil, errors.New("unexpected query")
+}
+
+func (r *fakeActivityRows) Columns() []string { return r.columns }
+func (r *fakeActivityRows) Close() error      { return nil }
+
+func (r *fakeActivityRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
+
+func newFakeActivityDao(count int) (*IssueDao, *fakeActivityDB, func() error) {
+	fake := &fakeActivityDB{count: count}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	db := sql.OpenDB(&fakeActivityConnector{db: fake})
+	return &IssueDao{DB: db, Logger: logger}, fake, db.Close
+}
+
+func Test_GetActivityLog_Paging(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		limit       int
+		offset      int
+		wantFirstID int64
+		wantLen     int
+		wantHasMore bool
+	}{
+		{"first page with more", 25, 10, 0, 1, 10, true},
+		{"middle page", 25, 10, 10, 11, 10, true},
+		{"last partial page", 25, 10, 20, 21, 5, false},
+		{"page ends exactly at the last row", 20, 10, 10, 11, 10, false},
+		{"single page holds everything", 3, 10, 0, 1, 3, false},
+		{"offset past the end", 5, 10, 10, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			dao, _, closeDB := newFakeActivityDao(tt.total)
+			defer closeDB()
+
+			//Act
+			activity, totalCount, hasMore, err := dao.GetActivityLog(context.Background(), 42, tt.limit, tt.offset)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.total, totalCount)
+			assert.Equal(t, tt.wantHasMore, hasMore)
+			if assert.Len(t, activity, tt.wantLen) && tt.wantLen > 0 {
+				assert.Equal(t, tt.wantFirstID, activity[0].ID)
+				assert.Equal(t, int64(42), activity[0].IssueID)
+				assert.Equal(t, "Sam Ortiz", activity[0].ActorName)
+				assert.Equal(t, "", activity[0].PreviousValue)
+				assert.Equal(t, "open", activity[0].NewValue)
+			}
+		})
+	}
+}
+
+func Test_GetActivityLog_ClampsLimitAndOffset(t *testing.T) {
+	//Arrange
+	dao, _, closeDB := newFakeActivityDao(500)
+	defer closeDB()
+
+	//Act
+	activity, _, hasMore, err := dao.GetActivityLog(context.Background(), 42, 10000, -5)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Len(t, activity, 200)
+	assert.Equal(t, int64(1), activity[0].ID)
+	assert.True(t, hasMore)
+}
+
+func Test_GetActivityLog_NoActivitySkipsPageQuery(t *testing.T) {
+	//Arrange
+	dao, fake, closeDB := newFakeActivityDao(0)
+	defer closeDB()
+
+	//Act
+	activity, totalCount, hasMore, err := dao.GetActivityLog(context.Background(), 42, 10, 0)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Empty(t, activity)
+	assert.NotNil(t, activity)
+	assert.Equal(t, 0, totalCount)
+	assert.False(t, hasMore)
+	assert.Len(t, fake.queries, 1)
+}
@@ -3,10 +3,12 @@ package data
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"infrastructure/lib/models"
 	"strings"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,8 +17,9 @@ type LocationRepository interface {
 	// CreateLocation creates a new location in the organization and assigns it to the creator with SuperAdmin role
 	CreateLocation(ctx context.Context, userID, orgID int64, location *models.Location) (*models.Location, error)
 	
-	// GetLocationsByOrg retrieves all locations for a specific organization
-	GetLocationsByOrg(ctx context.Context, orgID int64) ([]models.Location, error)
+	// GetLocationsByOrg retrieves locations for a specific organization (active only unless filtered otherwise).
+	// A parent_id filter limits the result to that location's direct children.
+	GetLocationsByOrg(ctx context.Context, orgID int64, filters map[string]string) ([]models.Location, error)
 	
 	// GetLocationByID retrieves a specific location by ID (with org validation)
 	GetLocationByID(ctx context.Context, locationID, orgID int64) (*models.Location, error)
@@ -24,8 +27,10 @@ type LocationRepository interface {
 	// UpdateLocation updates an existing location
 	UpdateLocation(ctx context.Context, locationID, orgID int64, updateReq *models.UpdateLocationRequest, userID int64) (*models.Location, error)
 	
-	// DeleteLocation soft deletes a location (removes user assignments but keeps location record)
-	DeleteLocation(ctx context.Context, locationID, orgID int64, userID int64) error
+	// DeleteLocation soft deletes a location (removes user assignments but keeps location record).
+	// A location with children is only deleted when opts.Cascade is set, which also deletes every descendant;
+	// active projects block the delete unless opts.Force is set, which deletes them too.
+	DeleteLocation(ctx context.Context, locationID, orgID int64, userID int64, opts models.DeleteLocationOptions) (*models.LocationDeleteSummary, error)
 	
 	// VerifyLocationAccess verifies if a user has access to a specific location
 	VerifyLocationAccess(ctx context.Context, userID, locationID int64) (bool, error)
@@ -37,8 +42,149 @@ type LocationDao struct {
 	Logger *logrus.Logger
 }
 
+// locationNameExists reports whether another non-deleted location in the org already uses the name.
+// Names are compared trimmed and case-insensitively; excludeID skips the location being renamed.
+func (dao *LocationDao) locationNameExists(ctx context.Context, orgID int64, name string, excludeID int64) (bool, error) {
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM iam.locations
+			WHERE org_id = $1 AND LOWER(TRIM(name)) = LOWER($2) AND id != $3 AND is_deleted = FALSE
+		)
+	`, orgID, strings.TrimSpace(name), excludeID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check location name: %w", err)
+	}
+	return exists, nil
+}
+
+// locationColumns is the select list shared by location queries
+const locationColumns = `
+	id, org_id, name, location_type, address, city, state, zip_code, country,
+	status, parent_location_id, created_at, created_by, updated_at, updated_by`
+
+// scanLocation scans a row selected with locationColumns
+func scanLocation(scanner interface{ Scan(...interface{}) error }) (*models.Location, error) {
+	var location models.Location
+	var parentLocationID sql.NullInt64
+
+	err := scanner.Scan(
+		&location.ID,
+		&location.OrgID,
+		&location.Name,
+		&location.LocationType,
+		&location.Address,
+		&location.City,
+		&location.State,
+		&location.ZipCode,
+		&location.Country,
+		&location.Status,
+		&parentLocationID,
+		&location.CreatedAt,
+		&location.CreatedBy,
+		&location.UpdatedAt,
+		&location.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentLocationID.Valid {
+		location.ParentLocationID = &parentLocationID.Int64
+	}
+
+	return &location, nil
+}
+
+// ErrParentLocationNotFound is returned when a create or update names a parent outside the org.
+// It matches ErrInvalid, like the cycle errors, and is checked first to report it separately.
+var ErrParentLocationNotFound = invalidError("parent location not found")
+
+// validateLocationParent confirms parentLocationID is a live location in the same org and is neither
+// the location itself nor one of its descendants, which would create a cycle. locationID is 0 on create.
+func (dao *LocationDao) validateLocationParent(ctx context.Context, locationID, parentLocationID, orgID int64) error {
+	if locationID == parentLocationID {
+		return invalidError("location cannot be its own parent")
+	}
+
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM iam.locations
+			WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+		)
+	`, parentLocationID, orgID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to verify parent location: %w", err)
+	}
+	if !exists {
+		return ErrParentLocationNotFound
+	}
+
+	if locationID == 0 {
+		return nil
+	}
+
+	// Walk up from the proposed parent; finding locationID means the move would create a cycle
+	var createsCycle bool
+	err = dao.DB.QueryRowContext(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_location_id
+			FROM iam.locations
+			WHERE id = $1
+			UNION
+			SELECT l.id, l.parent_location_id
+			FROM iam.locations l
+			JOIN ancestors a ON l.id = a.parent_location_id
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors WHERE id = $2)
+	`, parentLocationID, locationID).Scan(&createsCycle)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"location_id":        locationID,
+			"parent_location_id": parentLocationID,
+			"error":              err.Error(),
+		}).Error("Failed to check location ancestry")
+		return fmt.Errorf("failed to check location ancestry: %w", err)
+	}
+	if createsCycle {
+		return invalidError("location cannot be moved under its own descendant")
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign key constraint violation
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
 // CreateLocation creates a new location and automatically assigns it to the creator with SuperAdmin role
 func (dao *LocationDao) CreateLocation(ctx context.Context, userID, orgID int64, location *models.Location) (*models.Location, error) {
+	location.Name = strings.TrimSpace(location.Name)
+	exists, err := dao.locationNameExists(ctx, orgID, location.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, conflictError("location name already exists")
+	}
+
+	var parentLocationID sql.NullInt64
+	if location.ParentLocationID != nil && *location.ParentLocationID != 0 {
+		if err := dao.validateLocationParent(ctx, 0, *location.ParentLocationID, orgID); err != nil {
+			return nil, err
+		}
+		parentLocationID = sql.NullInt64{Int64: *location.ParentLocationID, Valid: true}
+	}
+
 	// Start transaction for atomic operation
 	tx, err := dao.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -68,15 +214,18 @@ func (dao *LocationDao) CreateLocation(ctx context.Context, userID, orgID int64,
 	err = tx.QueryRowContext(ctx, `
 		INSERT INTO iam.locations (
 			org_id, name, location_type, address, city, state, zip_code, country, status,
-			created_by, updated_by
+			parent_location_id, created_by, updated_by
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, created_at, updated_at
 	`, orgID, location.Name, locationType, location.Address, location.City, location.State, 
-		location.ZipCode, country, status, userID, userID).Scan(
+		location.ZipCode, country, status, parentLocationID, userID, userID).Scan(
 		&locationID, &location.CreatedAt, &location.UpdatedAt)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, conflictError("location name already exists")
+		}
 		dao.Logger.WithFields(logrus.Fields{
 			"user_id": userID,
 			"org_id":  orgID,
@@ -98,6 +247,9 @@ func (dao *LocationDao) CreateLocation(ctx context.Context, userID, orgID int64,
 	location.OrgID = orgID
 	location.CreatedBy = userID
 	location.UpdatedBy = userID
+	if !parentLocationID.Valid {
+		location.ParentLocationID = nil
+	}
 
 
 	dao.Logger.WithFields(logrus.Fields{
@@ -110,17 +262,32 @@ func (dao *LocationDao) CreateLocation(ctx context.Context, userID, orgID int64,
 	return location, nil
 }
 
-// GetLocationsByOrg retrieves all locations for a specific organization
-func (dao *LocationDao) GetLocationsByOrg(ctx context.Context, orgID int64) ([]models.Location, error) {
-	query := `
-		SELECT id, org_id, name, location_type, address, city, state, zip_code, country, 
-		       status, created_at, created_by, updated_at, updated_by
+// GetLocationsByOrg retrieves locations for a specific organization.
+// Supported filters: status (exact match), include_inactive=true and parent_id (direct children only).
+// Without status or include_inactive, only active locations are returned.
+func (dao *LocationDao) GetLocationsByOrg(ctx context.Context, orgID int64, filters map[string]string) ([]models.Location, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM iam.locations
-		WHERE org_id = $1 AND is_deleted = FALSE
-		ORDER BY name ASC
-	`
+		WHERE org_id = $1 AND is_deleted = FALSE`, locationColumns)
+
+	args := []interface{}{orgID}
+
+	if status, ok := filters["status"]; ok && status != "" {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	} else if filters["include_inactive"] != "true" {
+		args = append(args, models.LocationStatusActive)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if parentID := filters["parent_id"]; parentID != "" {
+		args = append(args, parentID)
+		query += fmt.Sprintf(" AND parent_location_id = $%d", len(args))
+	}
 
-	rows, err := dao.DB.QueryContext(ctx, query, orgID)
+	query += " ORDER BY name ASC"
+
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"org_id": orgID,
@@ -132,28 +299,12 @@ func (dao *LocationDao) GetLocationsByOrg(ctx context.Context, orgID int64) ([]m
 
 	var locations []models.Location
 	for rows.Next() {
-		var location models.Location
-		err := rows.Scan(
-			&location.ID,
-			&location.OrgID,
-			&location.Name,
-			&location.LocationType,
-			&location.Address,
-			&location.City,
-			&location.State,
-			&location.ZipCode,
-			&location.Country,
-			&location.Status,
-			&location.CreatedAt,
-			&location.CreatedBy,
-			&location.UpdatedAt,
-			&location.UpdatedBy,
-		)
+		location, err := scanLocation(rows)
 		if err != nil {
 			dao.Logger.WithError(err).Error("Failed to scan location row")
 			return nil, fmt.Errorf("failed to scan location: %w", err)
 		}
-		locations = append(locations, location)
+		locations = append(locations, *location)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -171,37 +322,20 @@ func (dao *LocationDao) GetLocationsByOrg(ctx context.Context, orgID int64) ([]m
 
 // GetLocationByID retrieves a specific location by ID with organization validation
 func (dao *LocationDao) GetLocationByID(ctx context.Context, locationID, orgID int64) (*models.Location, error) {
-	var location models.Location
-	query := `
-		SELECT id, org_id, name, location_type, address, city, state, zip_code, country, 
-		       status, created_at, created_by, updated_at, updated_by
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM iam.locations
 		WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
-	`
+	`, locationColumns)
 
-	err := dao.DB.QueryRowContext(ctx, query, locationID, orgID).Scan(
-		&location.ID,
-		&location.OrgID,
-		&location.Name,
-		&location.LocationType,
-		&location.Address,
-		&location.City,
-		&location.State,
-		&location.ZipCode,
-		&location.Country,
-		&location.Status,
-		&location.CreatedAt,
-		&location.CreatedBy,
-		&location.UpdatedAt,
-		&location.UpdatedBy,
-	)
+	location, err := scanLocation(dao.DB.QueryRowContext(ctx, query, locationID, orgID))
 
 	if err == sql.ErrNoRows {
 		dao.Logger.WithFields(logrus.Fields{
 			"location_id": locationID,
 			"org_id":      orgID,
 		}).Warn("Location not found")
-		return nil, fmt.Errorf("location not found")
+		return nil, notFoundError("location not found")
 	}
 
 	if err != nil {
@@ -213,7 +347,7 @@ func (dao *LocationDao) GetLocationByID(ctx context.Context, locationID, orgID i
 		return nil, fmt.Errorf("failed to get location: %w", err)
 	}
 
-	return &location, nil
+	return location, nil
 }
 
 // UpdateLocation updates an existing location with the provided fields
@@ -223,9 +357,16 @@ func (dao *LocationDao) UpdateLocation(ctx context.Context, locationID, orgID in
 	args := []interface{}{userID}
 	argIndex := 2
 	
-	if updateReq.Name != "" {
+	if name := strings.TrimSpace(updateReq.Name); name != "" {
+		exists, err := dao.locationNameExists(ctx, orgID, name, locationID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, conflictError("location name already exists")
+		}
 		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
-		args = append(args, updateReq.Name)
+		args = append(args, name)
 		argIndex++
 	}
 	if updateReq.LocationType != "" {
@@ -263,6 +404,18 @@ func (dao *LocationDao) UpdateLocation(ctx context.Context, locationID, orgID in
 		args = append(args, updateReq.Status)
 		argIndex++
 	}
+	if updateReq.ParentLocationID != nil {
+		parentLocationID := sql.NullInt64{}
+		if *updateReq.ParentLocationID != 0 {
+			if err := dao.validateLocationParent(ctx, locationID, *updateReq.ParentLocationID, orgID); err != nil {
+				return nil, err
+			}
+			parentLocationID = sql.NullInt64{Int64: *updateReq.ParentLocationID, Valid: true}
+		}
+		setParts = append(setParts, fmt.Sprintf("parent_location_id = $%d", argIndex))
+		args = append(args, parentLocationID)
+		argIndex++
+	}
 	
 	// Add WHERE conditions
 	args = append(args, locationID, orgID)
@@ -271,37 +424,23 @@ func (dao *LocationDao) UpdateLocation(ctx context.Context, locationID, orgID in
 		UPDATE iam.locations 
 		SET %s
 		WHERE id = $%d AND org_id = $%d AND is_deleted = FALSE
-		RETURNING id, org_id, name, location_type, address, city, state, zip_code, country, 
-		          status, created_at, created_by, updated_at, updated_by
-	`, strings.Join(setParts, ", "), argIndex, argIndex+1)
-
-	var updatedLocation models.Location
-	err := dao.DB.QueryRowContext(ctx, query, args...).Scan(
-		&updatedLocation.ID,
-		&updatedLocation.OrgID,
-		&updatedLocation.Name,
-		&updatedLocation.LocationType,
-		&updatedLocation.Address,
-		&updatedLocation.City,
-		&updatedLocation.State,
-		&updatedLocation.ZipCode,
-		&updatedLocation.Country,
-		&updatedLocation.Status,
-		&updatedLocation.CreatedAt,
-		&updatedLocation.CreatedBy,
-		&updatedLocation.UpdatedAt,
-		&updatedLocation.UpdatedBy,
-	)
+		RETURNING %s
+	`, strings.Join(setParts, ", "), argIndex, argIndex+1, locationColumns)
+
+	updatedLocation, err := scanLocation(dao.DB.QueryRowContext(ctx, query, args...))
 
 	if err == sql.ErrNoRows {
 		dao.Logger.WithFields(logrus.Fields{
 			"location_id": locationID,
 			"org_id":      orgID,
 		}).Warn("Location not found for update")
-		return nil, fmt.Errorf("location not found")
+		return nil, notFoundError("location not found")
 	}
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, conflictError("location name already exists")
+		}
 		dao.Logger.WithFields(logrus.Fields{
 			"location_id": locationID,
 			"org_id":      orgID,
@@ -316,48 +455,144 @@ func (dao *LocationDao) UpdateLocation(ctx context.Context, locationID, orgID in
 		"location_name": updatedLocation.Name,
 	}).Info("Successfully updated location")
 
-	return &updatedLocation, nil
+	return updatedLocation, nil
 }
 
-// DeleteLocation soft deletes a location (sets is_deleted = TRUE)
-func (dao *LocationDao) DeleteLocation(ctx context.Context, locationID, orgID int64, userID int64) error {
-	// Soft delete the location
-	result, err := dao.DB.ExecContext(ctx, `
-		UPDATE iam.locations 
-		SET is_deleted = TRUE, updated_by = $1, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $2 AND org_id = $3 AND is_deleted = FALSE
-	`, userID, locationID, orgID)
+// DeleteLocation soft deletes a location (sets is_deleted = TRUE) in one transaction and returns a summary
+// of everything it removed. Without opts.Cascade a location that still has child locations is rejected with
+// a conflict; with it the location and all of its descendants are deleted together. Active projects at any
+// of those locations block the delete with a *models.LocationHasProjectsError unless opts.Force is set, in
+// which case the projects are soft deleted too. Every deleted location and project gets an audit entry.
+func (dao *LocationDao) DeleteLocation(ctx context.Context, locationID, orgID int64, userID int64, opts models.DeleteLocationOptions) (*models.LocationDeleteSummary, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
 
+	// Lock the location and, when cascading, every live descendant
+	var locationIDs []int64
+	err = tx.QueryRowContext(ctx, `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM iam.locations
+			WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+			UNION
+			SELECT l.id
+			FROM iam.locations l
+			JOIN subtree s ON l.parent_location_id = s.id
+			WHERE $3 AND l.is_deleted = FALSE
+		),
+		locked AS (
+			SELECT id FROM iam.locations WHERE id IN (SELECT id FROM subtree) FOR UPDATE
+		)
+		SELECT COALESCE(array_agg(id ORDER BY id), '{}') FROM locked
+	`, locationID, orgID, opts.Cascade).Scan(pq.Array(&locationIDs))
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"location_id": locationID,
 			"org_id":      orgID,
 			"error":       err.Error(),
-		}).Error("Failed to delete location")
-		return fmt.Errorf("failed to delete location: %w", err)
+		}).Error("Failed to load locations for deletion")
+		return nil, fmt.Errorf("failed to load locations for deletion: %w", err)
+	}
+	if len(locationIDs) == 0 {
+		dao.Logger.WithFields(logrus.Fields{
+			"location_id": locationID,
+			"org_id":      orgID,
+		}).Warn("Location not found for deletion")
+		return nil, notFoundError("location not found")
+	}
+
+	if !opts.Cascade {
+		var hasChildren bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS(
+				SELECT 1 FROM iam.locations
+				WHERE parent_location_id = $1 AND org_id = $2 AND is_deleted = FALSE
+			)
+		`, locationID, orgID).Scan(&hasChildren)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check child locations: %w", err)
+		}
+		if hasChildren {
+			return nil, conflictError("location has child locations")
+		}
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	var projectIDs []int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(id ORDER BY id), '{}')
+		FROM project.projects
+		WHERE location_id = ANY($1) AND org_id = $2 AND is_deleted = FALSE
+	`, pq.Array(locationIDs), orgID).Scan(pq.Array(&projectIDs))
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to check location projects: %w", err)
+	}
+	if len(projectIDs) > 0 && !opts.Force {
+		return nil, &models.LocationHasProjectsError{ProjectIDs: projectIDs}
+	}
+
+	if len(projectIDs) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE project.projects
+			SET is_deleted = TRUE, updated_by = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ANY($2)
+		`, userID, pq.Array(projectIDs))
+		if err != nil {
+			dao.Logger.WithFields(logrus.Fields{
+				"location_id": locationID,
+				"project_ids": projectIDs,
+				"error":       err.Error(),
+			}).Error("Failed to delete location projects")
+			return nil, fmt.Errorf("failed to delete location projects: %w", err)
+		}
 	}
 
-	if rowsAffected == 0 {
+	_, err = tx.ExecContext(ctx, `
+		UPDATE iam.locations 
+		SET is_deleted = TRUE, updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ANY($2)
+	`, userID, pq.Array(locationIDs))
+	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"location_id": locationID,
 			"org_id":      orgID,
-		}).Warn("Location not found for deletion")
-		return fmt.Errorf("location not found")
+			"error":       err.Error(),
+		}).Error("Failed to delete location")
+		return nil, fmt.Errorf("failed to delete location: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO iam.location_delete_audit (org_id, location_id, entity_type, entity_id, forced, actor_user_id)
+		SELECT $1::bigint, $2::bigint, 'location', id, $4::boolean, $5::bigint FROM unnest($3::bigint[]) AS id
+		UNION ALL
+		SELECT $1::bigint, $2::bigint, 'project', id, $4::boolean, $5::bigint FROM unnest($6::bigint[]) AS id
+	`, orgID, locationID, pq.Array(locationIDs), opts.Force, userID, pq.Array(projectIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record location delete audit: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	dao.Logger.WithFields(logrus.Fields{
-		"location_id": locationID,
-		"org_id":      orgID,
-		"user_id":     userID,
+		"location_id":       locationID,
+		"org_id":            orgID,
+		"user_id":           userID,
+		"cascade":           opts.Cascade,
+		"force":             opts.Force,
+		"deleted_locations": len(locationIDs),
+		"deleted_projects":  len(projectIDs),
 	}).Info("Successfully soft deleted location")
 
-
-	return nil
+	return &models.LocationDeleteSummary{
+		LocationID:         locationID,
+		DeletedLocationIDs: locationIDs,
+		DeletedProjectIDs:  projectIDs,
+		Cascade:            opts.Cascade,
+		Force:              opts.Force,
+	}, nil
 }
 
 // VerifyLocationAccess checks if a user has access to a specific location
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"infrastructure/lib/models"
+	"infrastructure/lib/util"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// resolveCommentMentions resolves mention tokens parsed with util.ParseMentions to active users of the organization,
+// in the order they are first mentioned. Mentions of anyone else are dropped and logged; they never fail the comment.
+func resolveCommentMentions(ctx context.Context, q dbtx, logger *logrus.Logger, orgID int64, tokens []util.MentionToken) ([]models.CommentMention, error) {
+	userIDs := []int64{}
+	emails := []string{}
+	for _, token := range tokens {
+		if token.UserID != 0 {
+			userIDs = append(userIDs, token.UserID)
+		} else {
+			emails = append(emails, token.Email)
+		}
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, CONCAT(first_name, ' ', last_name), LOWER(email)
+		FROM iam.users
+		WHERE org_id = $1 AND is_deleted = FALSE
+		  AND (id = ANY($2) OR LOWER(email) = ANY($3))
+	`, orgID, pq.Array(userIDs), pq.Array(emails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+	defer rows.Close()
+
+	byID := map[int64]models.CommentMention{}
+	idByEmail := map[string]int64{}
+	for rows.Next() {
+		var mention models.CommentMention
+		var email string
+		if err := rows.Scan(&mention.UserID, &mention.Name, &email); err != nil {
+			return nil, fmt.Errorf("failed to scan mentioned user: %w", err)
+		}
+		byID[mention.UserID] = mention
+		idByEmail[email] = mention.UserID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+
+	var mentions []models.CommentMention
+	added := map[int64]bool{}
+	for _, token := range tokens {
+		userID := token.UserID
+		if userID == 0 {
+			userID = idByEmail[token.Email]
+		}
+		mention, ok := byID[userID]
+		if !ok {
+			logger.WithFields(logrus.Fields{
+				"org_id": orgID,
+				"token":  token.Text,
+			}).Info("Ignoring mention of a user outside the organization")
+			continue
+		}
+		if added[userID] {
+			continue
+		}
+		added[userID] = true
+		mention.Token = token.Text
+		mentions = append(mentions, mention)
+	}
+	return mentions, nil
+}
+
+// recordCommentMentions stores the mentions of a comment; entityType is EntityTypeIssueComment or EntityTypeRFIComment
+func recordCommentMentions(ctx context.Context, q dbtx, entityType string, commentID int64, mentions []models.CommentMention) error {
+	for _, mention := range mentions {
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO project.comment_mentions (entity_type, comment_id, mentioned_user_id, token)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (entity_type, comment_id, mentioned_user_id) DO NOTHING
+		`, entityType, commentID, mention.UserID, mention.Token)
+		if err != nil {
+			return fmt.Errorf("failed to record comment mention: %w", err)
+		}
+	}
+	return nil
+}
+
+// getCommentMentions loads the stored mentions of the comments, keyed by comment ID
+func getCommentMentions(ctx context.Context, q dbtx, entityType string, commentIDs []int64) (map[int64][]models.CommentMention, error) {
+	mentions := map[int64][]models.CommentMention{}
+	if len(commentIDs) == 0 {
+		return mentions, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT m.comment_id, m.mentioned_user_id, CONCAT(u.first_name, ' ', u.last_name), m.token
+		FROM project.comment_mentions m
+		JOIN iam.users u ON u.id = m.mentioned_user_id
+		WHERE m.entity_type = $1 AND m.comment_id = ANY($2)
+		ORDER BY m.comment_id, m.id
+	`, entityType, pq.Array(commentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment mentions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int64
+		var mention models.CommentMention
+		if err := rows.Scan(&commentID, &mention.UserID, &mention.Name, &mention.Token); err != nil {
+			return nil, fmt.Errorf("failed to scan comment mention: %w", err)
+		}
+		mentions[commentID] = append(mentions[commentID], mention)
+	}
+	return mentions, rows.Err()
+}
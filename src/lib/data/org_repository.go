@@ -7,6 +7,7 @@ import (
 	"infrastructure/lib/models"
 	"strings"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,6 +18,9 @@ type OrgRepository interface {
 	GetOrganizationByUserID(ctx context.Context, userID int64) (*models.Organization, error)
 	GetOrganizationByID(ctx context.Context, orgID int64) (*models.Organization, error)
 	DeleteOrganization(ctx context.Context, orgID int64, userID int64) error
+	RequiresProjectMembership(ctx context.Context, orgID int64) (bool, error)
+	GetAllowedEmailDomains(ctx context.Context, orgID int64) ([]string, error)
+	GetPrioritySLADays(ctx context.Context, orgID int64) (models.PrioritySLADays, error)
 }
 
 // OrgDao implements the OrgRepository interface for PostgreSQL
@@ -46,10 +50,10 @@ func (dao *OrgDao) CreateOrganization(ctx context.Context, userID int64, org *mo
 			created_by, updated_by
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, created_at, updated_at
+		RETURNING id, rfi_auto_close_days, priority_sla_days, created_at, updated_at
 	`, org.Name, orgType, org.LicenseNumber, org.Address, org.Phone, org.Email, 
 		org.Website, status, userID, userID).Scan(
-		&orgID, &org.CreatedAt, &org.UpdatedAt)
+		&orgID, &org.RFIAutoCloseDays, &org.PrioritySLADays, &org.CreatedAt, &org.UpdatedAt)
 
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
@@ -121,6 +125,26 @@ func (dao *OrgDao) UpdateOrganization(ctx context.Context, userID int64, orgID i
 		args = append(args, updateReq.Status)
 		argIndex++
 	}
+	if updateReq.RequireProjectMembership != nil {
+		setParts = append(setParts, fmt.Sprintf("require_project_membership = $%d", argIndex))
+		args = append(args, *updateReq.RequireProjectMembership)
+		argIndex++
+	}
+	if updateReq.AllowedEmailDomains != nil {
+		setParts = append(setParts, fmt.Sprintf("allowed_email_domains = $%d", argIndex))
+		args = append(args, pq.Array(updateReq.AllowedEmailDomains))
+		argIndex++
+	}
+	if updateReq.RFIAutoCloseDays != nil {
+		setParts = append(setParts, fmt.Sprintf("rfi_auto_close_days = $%d", argIndex))
+		args = append(args, *updateReq.RFIAutoCloseDays)
+		argIndex++
+	}
+	if updateReq.PrioritySLADays != nil {
+		setParts = append(setParts, fmt.Sprintf("priority_sla_days = $%d", argIndex))
+		args = append(args, updateReq.PrioritySLADays)
+		argIndex++
+	}
 	
 	// Add WHERE conditions
 	args = append(args, orgID)
@@ -130,7 +154,7 @@ func (dao *OrgDao) UpdateOrganization(ctx context.Context, userID int64, orgID i
 		SET %s
 		WHERE id = $%d AND is_deleted = FALSE
 		RETURNING id, name, org_type, license_number, address, phone, email, website, 
-		          status, created_at, created_by, updated_at, updated_by
+		          status, require_project_membership, allowed_email_domains, rfi_auto_close_days, priority_sla_days, created_at, created_by, updated_at, updated_by
 	`, strings.Join(setParts, ", "), argIndex)
 
 	var updatedOrg models.Organization
@@ -144,6 +168,10 @@ func (dao *OrgDao) UpdateOrganization(ctx context.Context, userID int64, orgID i
 		&updatedOrg.Email,
 		&updatedOrg.Website,
 		&updatedOrg.Status,
+		&updatedOrg.RequireProjectMembership,
+		pq.Array(&updatedOrg.AllowedEmailDomains),
+		&updatedOrg.RFIAutoCloseDays,
+		&updatedOrg.PrioritySLADays,
 		&updatedOrg.CreatedAt,
 		&updatedOrg.CreatedBy,
 		&updatedOrg.UpdatedAt,
@@ -192,7 +220,7 @@ func (dao *OrgDao) UpdateOrganization(ctx context.Context, userID int64, orgID i
 func (dao *OrgDao) GetOrganizationByUserID(ctx context.Context, userID int64) (*models.Organization, error) {
 	query := `
 		SELECT o.id, o.name, o.org_type, o.license_number, o.address, o.phone, o.email, o.website,
-		       o.status, o.created_at, o.created_by, o.updated_at, o.updated_by
+		       o.status, o.require_project_membership, o.allowed_email_domains, o.rfi_auto_close_days, o.priority_sla_days, o.created_at, o.created_by, o.updated_at, o.updated_by
 		FROM iam.organizations o
 		INNER JOIN iam.users u ON u.org_id = o.id
 		WHERE u.id = $1 AND o.is_deleted = FALSE
@@ -209,6 +237,10 @@ func (dao *OrgDao) GetOrganizationByUserID(ctx context.Context, userID int64) (*
 		&org.Email,
 		&org.Website,
 		&org.Status,
+		&org.RequireProjectMembership,
+		pq.Array(&org.AllowedEmailDomains),
+		&org.RFIAutoCloseDays,
+		&org.PrioritySLADays,
 		&org.CreatedAt,
 		&org.CreatedBy,
 		&org.UpdatedAt,
@@ -239,7 +271,7 @@ func (dao *OrgDao) GetOrganizationByID(ctx context.Context, orgID int64) (*model
 	var org models.Organization
 	query := `
 		SELECT id, name, org_type, license_number, address, phone, email, website,
-		       status, created_at, created_by, updated_at, updated_by
+		       status, require_project_membership, allowed_email_domains, rfi_auto_close_days, priority_sla_days, created_at, created_by, updated_at, updated_by
 		FROM iam.organizations
 		WHERE id = $1 AND is_deleted = FALSE
 	`
@@ -254,6 +286,10 @@ func (dao *OrgDao) GetOrganizationByID(ctx context.Context, orgID int64) (*model
 		&org.Email,
 		&org.Website,
 		&org.Status,
+		&org.RequireProjectMembership,
+		pq.Array(&org.AllowedEmailDomains),
+		&org.RFIAutoCloseDays,
+		&org.PrioritySLADays,
 		&org.CreatedAt,
 		&org.CreatedBy,
 		&org.UpdatedAt,
@@ -378,4 +414,72 @@ func (dao *OrgDao) checkAndUpdateUserStatus(ctx context.Context, userID, orgID i
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+// RequiresProjectMembership reports whether the organization restricts issue/RFI creation to project members
+func (dao *OrgDao) RequiresProjectMembership(ctx context.Context, orgID int64) (bool, error) {
+	var required bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT require_project_membership
+		FROM iam.organizations
+		WHERE id = $1 AND is_deleted = FALSE
+	`, orgID).Scan(&required)
+
+	if err == sql.ErrNoRows {
+		return false, fmt.Errorf("organization not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id": orgID,
+			"error":  err.Error(),
+		}).Error("Failed to get organization membership setting")
+		return false, fmt.Errorf("failed to get organization membership setting: %w", err)
+	}
+
+	return required, nil
+}
+
+// GetAllowedEmailDomains returns the organization's invite email domain allow-list (empty means any domain)
+func (dao *OrgDao) GetAllowedEmailDomains(ctx context.Context, orgID int64) ([]string, error) {
+	var domains []string
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT allowed_email_domains
+		FROM iam.organizations
+		WHERE id = $1 AND is_deleted = FALSE
+	`, orgID).Scan(pq.Array(&domains))
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("organization not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id": orgID,
+			"error":  err.Error(),
+		}).Error("Failed to get organization allowed email domains")
+		return nil, fmt.Errorf("failed to get organization allowed email domains: %w", err)
+	}
+
+	return domains, nil
+}
+
+// GetPrioritySLADays returns the organization's per-priority SLA offsets (empty when none are configured)
+func (dao *OrgDao) GetPrioritySLADays(ctx context.Context, orgID int64) (models.PrioritySLADays, error) {
+	var slaDays models.PrioritySLADays
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT priority_sla_days
+		FROM iam.organizations
+		WHERE id = $1 AND is_deleted = FALSE
+	`, orgID).Scan(&slaDays)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("organization not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id": orgID,
+			"error":  err.Error(),
+		}).Error("Failed to get organization priority SLA days")
+		return nil, fmt.Errorf("failed to get organization priority SLA days: %w", err)
+	}
+
+	return slaDays, nil
+}
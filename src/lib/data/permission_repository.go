@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"infrastructure/lib/models"
+	"sort"
 
 	"github.com/sirupsen/logrus"
 )
@@ -25,6 +26,12 @@ type PermissionRepository interface {
 	
 	// DeletePermission deletes a permission (removes role-permission assignments but keeps permission record)
 	DeletePermission(ctx context.Context, permissionID, orgID int64) error
+
+	// GetEffectivePermissions resolves a user's role and permission names for an org, or a project when projectID > 0
+	GetEffectivePermissions(ctx context.Context, userID, orgID, projectID int64) (roles []string, permissions []string, err error)
+
+	// GetProjectRoles lists the roles a user holds that apply to a project, with where each was granted
+	GetProjectRoles(ctx context.Context, userID, orgID, projectID int64) ([]models.MyProjectRole, error)
 }
 
 // PermissionDao implements PermissionRepository interface using PostgreSQL
@@ -261,4 +268,137 @@ func (dao *PermissionDao) DeletePermission(ctx context.Context, permissionID, or
 	}).Info("Successfully deleted permission and all assignments")
 
 	return nil
-}
\ No newline at end of file
+}
+
+// GetEffectivePermissions collects the roles a user holds in the given context and the permissions
+// granted by those roles. Roles come from org-wide assignments and, when projectID > 0, from project
+// roles, project assignments and assignments on the project's location. Expired assignments are ignored.
+func (dao *PermissionDao) GetEffectivePermissions(ctx context.Context, userID, orgID, projectID int64) ([]string, []string, error) {
+	query := `
+		WITH user_roles AS (
+			SELECT pur.role_id
+			FROM project.project_user_roles pur
+			WHERE $3 > 0 AND pur.project_id = $3 AND pur.user_id = $1 AND pur.is_deleted = FALSE
+			UNION
+			SELECT ua.role_id
+			FROM iam.user_assignments ua
+			WHERE ua.user_id = $1 AND ua.is_deleted = FALSE AND ua.role_id IS NOT NULL
+			  AND (ua.end_date IS NULL OR ua.end_date >= CURRENT_DATE)
+			  AND (
+				(ua.context_type = 'organization' AND ua.context_id = $2)
+				OR ($3 > 0 AND ua.context_type = 'project' AND ua.context_id = $3)
+				OR ($3 > 0 AND ua.context_type = 'location' AND ua.context_id = (
+					SELECT location_id FROM project.projects WHERE id = $3
+				))
+			  )
+		)
+		SELECT r.name, p.permission_name
+		FROM user_roles ur
+		JOIN iam.roles r ON r.id = ur.role_id AND r.org_id = $2 AND r.is_deleted = FALSE
+		LEFT JOIN iam.role_permission rp ON rp.role_id = r.id
+		LEFT JOIN iam.permission p ON p.permission_id = rp.permission_id AND p.org_id = $2
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, orgID, projectID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id":    userID,
+			"org_id":     orgID,
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to query effective permissions")
+		return nil, nil, fmt.Errorf("failed to query effective permissions: %w", err)
+	}
+	defer rows.Close()
+
+	roleSet := make(map[string]bool)
+	permissionSet := make(map[string]bool)
+	for rows.Next() {
+		var roleName string
+		var permissionName sql.NullString
+		if err := rows.Scan(&roleName, &permissionName); err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan effective permission row")
+			return nil, nil, fmt.Errorf("failed to scan effective permission: %w", err)
+		}
+		roleSet[roleName] = true
+		if permissionName.Valid {
+			permissionSet[permissionName.String] = true
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating effective permissions: %w", err)
+	}
+
+	return sortedKeys(roleSet), sortedKeys(permissionSet), nil
+}
+
+// GetProjectRoles lists the roles that apply to the user on a project: direct project roles from
+// project_user_roles and project assignments, plus roles inherited from the project's location and
+// the organization. It draws on the same grants as GetEffectivePermissions so the two always agree.
+func (dao *PermissionDao) GetProjectRoles(ctx context.Context, userID, orgID, projectID int64) ([]models.MyProjectRole, error) {
+	query := `
+		WITH user_roles AS (
+			SELECT pur.role_id, 'project' AS source
+			FROM project.project_user_roles pur
+			WHERE pur.project_id = $3 AND pur.user_id = $1 AND pur.is_deleted = FALSE
+			UNION
+			SELECT ua.role_id, ua.context_type AS source
+			FROM iam.user_assignments ua
+			WHERE ua.user_id = $1 AND ua.is_deleted = FALSE AND ua.role_id IS NOT NULL
+			  AND (ua.end_date IS NULL OR ua.end_date >= CURRENT_DATE)
+			  AND (
+				(ua.context_type = 'organization' AND ua.context_id = $2)
+				OR (ua.context_type = 'project' AND ua.context_id = $3)
+				OR (ua.context_type = 'location' AND ua.context_id = (
+					SELECT location_id FROM project.projects WHERE id = $3
+				))
+			  )
+		)
+		SELECT r.id, r.name, ur.source
+		FROM user_roles ur
+		JOIN iam.roles r ON r.id = ur.role_id AND r.org_id = $2 AND r.is_deleted = FALSE
+		ORDER BY CASE ur.source WHEN 'project' THEN 0 WHEN 'location' THEN 1 ELSE 2 END, r.name
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, orgID, projectID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id":    userID,
+			"org_id":     orgID,
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to query project roles")
+		return nil, fmt.Errorf("failed to query project roles: %w", err)
+	}
+	defer rows.Close()
+
+	// A role granted both directly and through an assignment is reported once, under its closest source
+	roles := []models.MyProjectRole{}
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var role models.MyProjectRole
+		if err := rows.Scan(&role.RoleID, &role.RoleName, &role.Source); err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan project role row")
+			return nil, fmt.Errorf("failed to scan project role: %w", err)
+		}
+		if seen[role.RoleID] {
+			continue
+		}
+		seen[role.RoleID] = true
+		roles = append(roles, role)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating project roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,11 +3,15 @@ package data
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"infrastructure/lib/clients"
 	"infrastructure/lib/models"
+	"infrastructure/lib/util"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,22 +23,53 @@ type ProjectRepository interface {
 	GetProjectsByOrg(ctx context.Context, orgID int64) ([]models.Project, error)
 	GetProjectsByLocationID(ctx context.Context, locationID, orgID int64) ([]models.Project, error)
 	GetProjectsByIDs(ctx context.Context, projectIDs []int64, orgID int64) ([]models.Project, error)
+	GetAccessibleProjects(ctx context.Context, userID, orgID int64, isSuperAdmin bool, limit, offset int) ([]models.Project, int, error)
+	GetProjectsByWarrantyExpiry(ctx context.Context, userID, orgID int64, isSuperAdmin bool, withinDays int) ([]models.Project, error)
 	GetProjectByID(ctx context.Context, projectID, orgID int64) (*models.Project, error)
+	GetProjectOrgID(ctx context.Context, projectID int64) (int64, error)
+	IsUserMember(ctx context.Context, projectID, userID int64) (bool, error)
+	HasManagementRole(ctx context.Context, projectID, userID int64) (bool, error)
 	UpdateProject(ctx context.Context, projectID, orgID int64, project *models.UpdateProjectRequest, userID int64) (*models.Project, error)
+	RestoreProject(ctx context.Context, projectID, orgID, userID int64) (*models.Project, error)
+
+	// Project search operations
+	SearchProject(ctx context.Context, projectID, orgID int64, query string, limit, offset int) ([]models.ProjectSearchResult, int, error)
+
+	// Project statistics
+	GetProjectStats(ctx context.Context, projectID, orgID int64) (*models.ProjectStats, error)
+
+	// Project milestone operations
+	CreateProjectMilestone(ctx context.Context, projectID, orgID int64, request *models.CreateProjectMilestoneRequest, userID int64) (*models.ProjectMilestone, error)
+	GetProjectMilestones(ctx context.Context, projectID, orgID int64) ([]models.ProjectMilestone, error)
+	GetUpcomingProjectMilestones(ctx context.Context, projectID, orgID int64, limit int) ([]models.ProjectMilestone, error)
+	UpdateProjectMilestone(ctx context.Context, milestoneID, projectID, orgID int64, request *models.UpdateProjectMilestoneRequest, userID int64) (*models.ProjectMilestone, error)
+	CompleteProjectMilestone(ctx context.Context, milestoneID, projectID, orgID int64, actualDate string, userID int64) (*models.ProjectMilestone, error)
+	DeleteProjectMilestone(ctx context.Context, milestoneID, projectID, orgID int64, userID int64) error
+
+	// Project settings operations
+	GetProjectDefaultAssignee(ctx context.Context, projectID, orgID int64) (int64, error)
+	SetProjectDefaultAssignee(ctx context.Context, projectID, orgID, assigneeID, userID int64) error
 	
 	// Project Manager operations
 	
 	// Project Attachment operations
 	CreateProjectAttachment(ctx context.Context, projectID int64, attachment *models.CreateProjectAttachmentRequest, userID int64) (*models.ProjectAttachment, error)
-	GetProjectAttachmentsByProject(ctx context.Context, projectID int64) ([]models.ProjectAttachment, error)
+	GetProjectAttachmentsByProject(ctx context.Context, projectID int64, folderID *int64) ([]models.ProjectAttachment, error)
 	GetProjectAttachmentByID(ctx context.Context, attachmentID, projectID int64) (*models.ProjectAttachment, error)
 	DeleteProjectAttachment(ctx context.Context, attachmentID, projectID int64, userID int64) error
+	MoveProjectAttachment(ctx context.Context, attachmentID, projectID, orgID int64, folderID *int64, userID int64) (*models.ProjectAttachment, error)
+
+	// Project document folder operations
+	CreateProjectFolder(ctx context.Context, projectID, orgID int64, request *models.CreateProjectFolderRequest, userID int64) (*models.ProjectFolder, error)
+	GetProjectFolders(ctx context.Context, projectID, orgID int64) ([]models.ProjectFolder, error)
+	UpdateProjectFolder(ctx context.Context, folderID, projectID, orgID int64, request *models.UpdateProjectFolderRequest, userID int64) (*models.ProjectFolder, error)
 	
 	// Project User Role operations
 	AssignUserToProject(ctx context.Context, projectID int64, assignment *models.CreateProjectUserRoleRequest, userID int64) (*models.ProjectUserRole, error)
 	GetProjectUserRoles(ctx context.Context, projectID int64) ([]models.ProjectUserRole, error)
 	UpdateProjectUserRole(ctx context.Context, assignmentID, projectID int64, assignment *models.UpdateProjectUserRoleRequest, userID int64) (*models.ProjectUserRole, error)
 	RemoveUserFromProject(ctx context.Context, assignmentID, projectID int64, userID int64) error
+	GetProjectUserRoleHistory(ctx context.Context, projectID, orgID int64) ([]models.ProjectUserRoleHistory, error)
 }
 
 // ProjectDao implements ProjectRepository interface using PostgreSQL
@@ -121,6 +156,9 @@ func (dao *ProjectDao) CreateProjectLegacy(ctx context.Context, orgID int64, req
 	).Scan(&projectID, &createdAt, &updatedAt)
 
 	if err != nil {
+		if isProjectNumberViolation(err) {
+			return nil, fmt.Errorf("project number already exists")
+		}
 		dao.Logger.WithFields(logrus.Fields{
 			"org_id": orgID,
 			"name":   request.Name,
@@ -139,8 +177,64 @@ func (dao *ProjectDao) CreateProjectLegacy(ctx context.Context, orgID int64, req
 	return dao.GetProjectByID(ctx, projectID, orgID)
 }
 
-// CreateProject creates a new project following the API contract structure
+// projectNumberIndex is the unique index on (org_id, project_number) for active projects
+const projectNumberIndex = "idx_projects_org_project_number_unique"
+
+// maxProjectNumberAttempts bounds how often CreateProject retries after losing a project number to another insert
+const maxProjectNumberAttempts = 3
+
+// Errors returned by the project, milestone and folder DAOs. Each one also matches a sentinel from errors.go;
+// handlers check the specific error first where several share a sentinel (e.g. a missing folder vs. project).
+var (
+	ErrProjectNotDeleted         = conflictError("project is not deleted")
+	ErrNoFieldsToUpdate          = invalidError("no fields to update")
+	ErrMilestoneNotFound         = notFoundError("milestone not found")
+	ErrInvalidMilestoneDate      = invalidError("invalid milestone date format")
+	ErrMilestoneOutsideTimeline  = invalidError("milestone date must fall within the project timeline")
+	ErrFolderNotFound            = notFoundError("folder not found")
+	ErrParentFolderNotFound      = invalidError("parent folder not found")
+	ErrProjectAttachmentNotFound = notFoundError("project attachment not found")
+)
+
+// errProjectNumberTaken is returned by createProject when the generated number violates projectNumberIndex
+var errProjectNumberTaken = errors.New("project number already taken")
+
+// isProjectNumberViolation reports whether err is a violation of projectNumberIndex
+func isProjectNumberViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == projectNumberIndex
+}
+
+// CreateProject creates a new project following the API contract structure. The project number
+// comes from a per-org sequence; if it still collides with an existing project the whole
+// transaction is retried, and the next attempt skips past the taken number.
 func (dao *ProjectDao) CreateProject(ctx context.Context, orgID int64, request *models.CreateProjectRequest, userID int64) (*models.CreateProjectResponse, error) {
+	var err error
+	for attempt := 1; attempt <= maxProjectNumberAttempts; attempt++ {
+		var response *models.CreateProjectResponse
+		response, err = dao.createProject(ctx, orgID, request, userID)
+		if !errors.Is(err, errProjectNumberTaken) {
+			return response, err
+		}
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id":  orgID,
+			"attempt": attempt,
+		}).Warn("Project number already taken, retrying project creation")
+	}
+	return nil, fmt.Errorf("failed to create project: %w", err)
+}
+
+// createProject makes a single attempt at CreateProject inside its own transaction
+func (dao *ProjectDao) createProject(ctx context.Context, orgID int64, request *models.CreateProjectRequest, userID int64) (*models.CreateProjectResponse, error) {
+	loc, err := util.LoadTimezone(request.Timeline.Timezone)
+	if err != nil {
+		return &models.CreateProjectResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  map[string][]string{"timezone": {"Must be an IANA time zone such as America/Los_Angeles"}},
+		}, nil
+	}
+
 	// Start transaction for atomic project and manager creation
 	tx, err := dao.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -148,8 +242,8 @@ func (dao *ProjectDao) CreateProject(ctx context.Context, orgID int64, request *
 	}
 	defer tx.Rollback()
 
-	// Generate project number (PROJ-YYYY-NNNN format)
-	projectNumber, err := dao.generateProjectNumber(ctx, orgID)
+	// Generate project number (PROJ-YYYY-NNNN format), using the year it is in the project's time zone
+	projectNumber, err := generateProjectNumber(ctx, tx, orgID, util.Today(time.Now(), loc).Year())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate project number: %w", err)
 	}
@@ -256,6 +350,30 @@ func (dao *ProjectDao) CreateProject(ctx context.Context, orgID int64, request *
 
 	// Use location_id from request
 	locationID := request.LocationID
+
+	// New projects can only be created at active locations
+	var locationStatus string
+	err = tx.QueryRowContext(ctx, `
+		SELECT status FROM iam.locations
+		WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+	`, locationID, orgID).Scan(&locationStatus)
+	if err == sql.ErrNoRows {
+		return &models.CreateProjectResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  map[string][]string{"location_id": {"Invalid location ID - location does not exist or does not belong to your organization"}},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate location: %w", err)
+	}
+	if locationStatus != models.LocationStatusActive {
+		return &models.CreateProjectResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  map[string][]string{"location_id": {fmt.Sprintf("Location is %s - projects can only be created at active locations", locationStatus)}},
+		}, nil
+	}
 	
 	// Use project_sector as project_type (they have the same valid values)
 	projectType := request.ProjectDetails.ProjectSector
@@ -288,6 +406,9 @@ func (dao *ProjectDao) CreateProject(ctx context.Context, orgID int64, request *
 		}).Error("Failed to create project")
 		
 		// Check for specific constraint violations
+		if isProjectNumberViolation(err) {
+			return nil, errProjectNumberTaken
+		}
 		if strings.Contains(err.Error(), "fk_projects_location") {
 			return &models.CreateProjectResponse{
 				Success: false,
@@ -328,25 +449,32 @@ func (dao *ProjectDao) CreateProject(ctx context.Context, orgID int64, request *
 	}, nil
 }
 
-// generateProjectNumber generates a unique project number in PROJ-YYYY-NNNN format
-func (dao *ProjectDao) generateProjectNumber(ctx context.Context, orgID int64) (string, error) {
-	currentYear := time.Now().Year()
-	
-	// Find the next available number for this year
-	var nextNum int
-	query := `
-		SELECT COALESCE(MAX(CAST(SUBSTRING(project_number, 11) AS INTEGER)), 0) + 1
-		FROM project.projects 
-		WHERE org_id = $1 AND project_number LIKE $2
-	`
-	
-	yearPrefix := fmt.Sprintf("PROJ-%d-%%", currentYear)
-	err := dao.DB.QueryRowContext(ctx, query, orgID, yearPrefix).Scan(&nextNum)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate project number: %w", err)
+// generateProjectNumber generates the next project number in PROJ-YYYY-NNNN format for the org.
+// Numbers come from a per-org, per-year sequence, so concurrent creates never share one; numbers
+// already used by another project (including deleted ones, which may be restored) are skipped.
+func generateProjectNumber(ctx context.Context, tx *sql.Tx, orgID int64, year int) (string, error) {
+	key := SequenceKey{OrgID: orgID, Name: fmt.Sprintf("%s_%d", SequenceProjectNumber, year)}
+	for {
+		next, err := NextSequence(ctx, tx, key)
+		if err != nil {
+			return "", err
+		}
+		projectNumber := fmt.Sprintf("PROJ-%d-%04d", year, next)
+
+		var taken bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM project.projects
+				WHERE org_id = $1 AND project_number = $2
+			)
+		`, orgID, projectNumber).Scan(&taken)
+		if err != nil {
+			return "", fmt.Errorf("failed to check project number: %w", err)
+		}
+		if !taken {
+			return projectNumber, nil
+		}
 	}
-	
-	return fmt.Sprintf("PROJ-%d-%04d", currentYear, nextNum), nil
 }
 
 // GetProjectsByOrg retrieves all projects for a specific organization
@@ -407,6 +535,162 @@ func (dao *ProjectDao) GetProjectsByOrg(ctx context.Context, orgID int64) ([]mod
 	return projects, nil
 }
 
+// accessibleProjectsFilter matches the org's projects that user $2 reaches through an active
+// organization, location or project assignment, or a project role; $3 (super admin) matches all
+const accessibleProjectsFilter = `
+		p.org_id = $1 AND p.is_deleted = FALSE
+		AND (
+			$3
+			OR EXISTS (
+				SELECT 1 FROM iam.user_assignments ua
+				WHERE ua.user_id = $2
+				  AND ua.is_deleted = FALSE
+				  AND (ua.start_date IS NULL OR ua.start_date <= NOW())
+				  AND (ua.end_date IS NULL OR ua.end_date >= NOW())
+				  AND (
+					(ua.context_type = 'organization' AND ua.context_id = p.org_id)
+					OR (ua.context_type = 'location' AND ua.context_id = p.location_id)
+					OR (ua.context_type = 'project' AND ua.context_id = p.id)
+				  )
+			)
+			OR EXISTS (
+				SELECT 1 FROM project.project_user_roles pur
+				WHERE pur.project_id = p.id AND pur.user_id = $2 AND pur.is_deleted = FALSE
+			)
+		)`
+
+// GetAccessibleProjects retrieves a page of the projects the user can access (see accessibleProjectsFilter).
+// Returns the page and the total number of accessible projects.
+func (dao *ProjectDao) GetAccessibleProjects(ctx context.Context, userID, orgID int64, isSuperAdmin bool, limit, offset int) ([]models.Project, int, error) {
+	var totalCount int
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM project.projects p
+		WHERE `+accessibleProjectsFilter,
+		orgID, userID, isSuperAdmin,
+	).Scan(&totalCount)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id":  orgID,
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to count accessible projects")
+		return nil, 0, fmt.Errorf("failed to count accessible projects: %w", err)
+	}
+
+	projects := []models.Project{}
+	if totalCount == 0 {
+		return projects, 0, nil
+	}
+
+	query := `
+		SELECT p.id, p.org_id, p.location_id, p.project_number, p.name, p.description, p.project_type,
+		       p.project_stage, p.work_scope, p.project_sector, p.delivery_method, p.project_phase,
+		       p.start_date, p.planned_end_date, p.actual_start_date, p.actual_end_date,
+		       p.substantial_completion_date, p.project_finish_date, p.warranty_start_date, p.warranty_end_date,
+		       p.budget, p.contract_value, p.square_footage, p.address, p.city, p.state, p.zip_code,
+		       p.country, p.language, p.latitude, p.longitude, p.status, p.created_at, p.created_by, p.updated_at, p.updated_by
+		FROM project.projects p
+		WHERE ` + accessibleProjectsFilter + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, orgID, userID, isSuperAdmin, limit, offset)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id":  orgID,
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to query accessible projects")
+		return nil, 0, fmt.Errorf("failed to query accessible projects: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var project models.Project
+		err := rows.Scan(
+			&project.ProjectID, &project.OrgID, &project.LocationID, &project.ProjectNumber,
+			&project.Name, &project.Description, &project.ProjectType, &project.ProjectStage,
+			&project.WorkScope, &project.ProjectSector, &project.DeliveryMethod, &project.ProjectPhase,
+			&project.StartDate, &project.PlannedEndDate, &project.ActualStartDate, &project.ActualEndDate,
+			&project.SubstantialCompletionDate, &project.ProjectFinishDate, &project.WarrantyStartDate, &project.WarrantyEndDate,
+			&project.Budget, &project.ContractValue, &project.SquareFootage, &project.Address,
+			&project.City, &project.State, &project.ZipCode, &project.Country, &project.Language,
+			&project.Latitude, &project.Longitude, &project.Status, &project.CreatedAt,
+			&project.CreatedBy, &project.UpdatedAt, &project.UpdatedBy,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan accessible project row")
+			return nil, 0, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating accessible projects: %w", err)
+	}
+
+	return projects, totalCount, nil
+}
+
+// GetProjectsByWarrantyExpiry retrieves the projects the user can access whose warranty_end_date falls between
+// today and withinDays from today, inclusive, ordered by warranty_end_date. Projects without a warranty end
+// date are excluded.
+func (dao *ProjectDao) GetProjectsByWarrantyExpiry(ctx context.Context, userID, orgID int64, isSuperAdmin bool, withinDays int) ([]models.Project, error) {
+	query := `
+		SELECT p.id, p.org_id, p.location_id, p.project_number, p.name, p.description, p.project_type,
+		       p.project_stage, p.work_scope, p.project_sector, p.delivery_method, p.project_phase,
+		       p.start_date, p.planned_end_date, p.actual_start_date, p.actual_end_date,
+		       p.substantial_completion_date, p.project_finish_date, p.warranty_start_date, p.warranty_end_date,
+		       p.budget, p.contract_value, p.square_footage, p.address, p.city, p.state, p.zip_code,
+		       p.country, p.language, p.latitude, p.longitude, p.status, p.created_at, p.created_by, p.updated_at, p.updated_by
+		FROM project.projects p
+		WHERE ` + accessibleProjectsFilter + `
+		  AND p.warranty_end_date IS NOT NULL
+		  AND p.warranty_end_date BETWEEN CURRENT_DATE AND CURRENT_DATE + $4::int
+		ORDER BY p.warranty_end_date ASC, p.id ASC
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, orgID, userID, isSuperAdmin, withinDays)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id":      orgID,
+			"user_id":     userID,
+			"within_days": withinDays,
+			"error":       err.Error(),
+		}).Error("Failed to query projects by warranty expiry")
+		return nil, fmt.Errorf("failed to query projects by warranty expiry: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var project models.Project
+		err := rows.Scan(
+			&project.ProjectID, &project.OrgID, &project.LocationID, &project.ProjectNumber,
+			&project.Name, &project.Description, &project.ProjectType, &project.ProjectStage,
+			&project.WorkScope, &project.ProjectSector, &project.DeliveryMethod, &project.ProjectPhase,
+			&project.StartDate, &project.PlannedEndDate, &project.ActualStartDate, &project.ActualEndDate,
+			&project.SubstantialCompletionDate, &project.ProjectFinishDate, &project.WarrantyStartDate, &project.WarrantyEndDate,
+			&project.Budget, &project.ContractValue, &project.SquareFootage, &project.Address,
+			&project.City, &project.State, &project.ZipCode, &project.Country, &project.Language,
+			&project.Latitude, &project.Longitude, &project.Status, &project.CreatedAt,
+			&project.CreatedBy, &project.UpdatedAt, &project.UpdatedBy,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan warranty expiring project row")
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating warranty expiring projects: %w", err)
+	}
+
+	return projects, nil
+}
+
 // GetProjectsByLocationID retrieves all projects for a specific location within an organization
 func (dao *ProjectDao) GetProjectsByLocationID(ctx context.Context, locationID, orgID int64) ([]models.Project, error) {
 	query := `
@@ -554,7 +838,7 @@ func (dao *ProjectDao) GetProjectByID(ctx context.Context, projectID, orgID int6
 		WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
 	`
 
-	err := dao.DB.QueryRowContext(ctx, query, projectID, orgID).Scan(
+	err := clients.QueryRowContextRetry(ctx, dao.DB, query, []interface{}{projectID, orgID},
 		&project.ProjectID, &project.OrgID, &project.LocationID, &project.ProjectNumber,
 		&project.Name, &project.Description, &project.ProjectType, &project.ProjectStage,
 		&project.WorkScope, &project.ProjectSector, &project.DeliveryMethod, &project.ProjectPhase,
@@ -571,7 +855,7 @@ func (dao *ProjectDao) GetProjectByID(ctx context.Context, projectID, orgID int6
 			"project_id": projectID,
 			"org_id":     orgID,
 		}).Warn("Project not found")
-		return nil, fmt.Errorf("project not found")
+		return nil, notFoundError("project not found")
 	}
 
 	if err != nil {
@@ -586,6 +870,156 @@ func (dao *ProjectDao) GetProjectByID(ctx context.Context, projectID, orgID int6
 	return &project, nil
 }
 
+// RestoreProject undoes a soft delete. It refuses when another active project in the org has
+// since taken the same project_number.
+func (dao *ProjectDao) RestoreProject(ctx context.Context, projectID, orgID, userID int64) (*models.Project, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var projectOrgID int64
+	var projectNumber sql.NullString
+	var isDeleted bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT org_id, project_number, is_deleted FROM project.projects
+		WHERE id = $1
+		FOR UPDATE
+	`, projectID).Scan(&projectOrgID, &projectNumber, &isDeleted)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("project not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if projectOrgID != orgID {
+		return nil, orgMismatchError("project does not belong to your organization")
+	}
+	if !isDeleted {
+		return nil, ErrProjectNotDeleted
+	}
+
+	if projectNumber.Valid && projectNumber.String != "" {
+		var inUse bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM project.projects
+				WHERE org_id = $1 AND project_number = $2 AND id <> $3 AND is_deleted = FALSE
+			)
+		`, orgID, projectNumber.String, projectID).Scan(&inUse)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check project number: %w", err)
+		}
+		if inUse {
+			return nil, conflictError("project number is in use by an active project")
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE project.projects
+		SET is_deleted = FALSE, updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, userID, projectID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to restore project")
+		return nil, fmt.Errorf("failed to restore project: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"org_id":     orgID,
+		"user_id":    userID,
+	}).Info("Project restored")
+
+	return dao.GetProjectByID(ctx, projectID, orgID)
+}
+
+// GetProjectOrgID returns the organization that owns a non-deleted project
+func (dao *ProjectDao) GetProjectOrgID(ctx context.Context, projectID int64) (int64, error) {
+	var orgID int64
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT org_id FROM project.projects
+		WHERE id = $1 AND is_deleted = FALSE
+	`, projectID).Scan(&orgID)
+
+	if err == sql.ErrNoRows {
+		return 0, notFoundError("project not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to get project organization")
+		return 0, fmt.Errorf("failed to get project organization: %w", err)
+	}
+
+	return orgID, nil
+}
+
+// IsUserMember reports whether the user holds an active role on the project, either through
+// project.project_user_roles or a project-context assignment in iam.user_assignments
+func (dao *ProjectDao) IsUserMember(ctx context.Context, projectID, userID int64) (bool, error) {
+	var isMember bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM project.project_user_roles
+			WHERE project_id = $1 AND user_id = $2 AND is_deleted = FALSE
+		) OR EXISTS (
+			SELECT 1 FROM iam.user_assignments
+			WHERE context_type = 'project' AND context_id = $1 AND user_id = $2 AND is_deleted = FALSE
+		)
+	`, projectID, userID).Scan(&isMember)
+
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"user_id":    userID,
+			"error":      err.Error(),
+		}).Error("Failed to check project membership")
+		return false, fmt.Errorf("failed to check project membership: %w", err)
+	}
+
+	return isMember, nil
+}
+
+// HasManagementRole reports whether the user holds a management or admin category role on the project,
+// either through project.project_user_roles or a project-context assignment in iam.user_assignments
+func (dao *ProjectDao) HasManagementRole(ctx context.Context, projectID, userID int64) (bool, error) {
+	var isManager bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM project.project_user_roles pur
+			JOIN iam.roles r ON r.id = pur.role_id
+			WHERE pur.project_id = $1 AND pur.user_id = $2 AND pur.is_deleted = FALSE
+			AND r.category IN ('management', 'admin')
+		) OR EXISTS (
+			SELECT 1 FROM iam.user_assignments ua
+			JOIN iam.roles r ON r.id = ua.role_id
+			WHERE ua.context_type = 'project' AND ua.context_id = $1 AND ua.user_id = $2 AND ua.is_deleted = FALSE
+			AND r.category IN ('management', 'admin')
+		)
+	`, projectID, userID).Scan(&isManager)
+
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"user_id":    userID,
+			"error":      err.Error(),
+		}).Error("Failed to check project management role")
+		return false, fmt.Errorf("failed to check project management role: %w", err)
+	}
+
+	return isManager, nil
+}
+
 // UpdateProject updates an existing project using same structure as CreateProjectRequest
 func (dao *ProjectDao) UpdateProject(ctx context.Context, projectID, orgID int64, request *models.UpdateProjectRequest, userID int64) (*models.Project, error) {
 	// Build dynamic update query based on provided fields
@@ -764,7 +1198,7 @@ func (dao *ProjectDao) UpdateProject(ctx context.Context, projectID, orgID int64
 	whereClause := fmt.Sprintf("WHERE id = $%d AND org_id = $%d AND is_deleted = FALSE", argIndex, argIndex+1)
 
 	if len(setParts) == 1 { // Only updated_by was set
-		return nil, fmt.Errorf("no fields to update")
+		return nil, ErrNoFieldsToUpdate
 	}
 
 	query := fmt.Sprintf(`
@@ -800,7 +1234,7 @@ func (dao *ProjectDao) UpdateProject(ctx context.Context, projectID, orgID int64
 			"project_id": projectID,
 			"org_id":     orgID,
 		}).Warn("Project not found for update")
-		return nil, fmt.Errorf("project not found")
+		return nil, notFoundError("project not found")
 	}
 
 	if err != nil {
@@ -866,17 +1300,30 @@ func (dao *ProjectDao) CreateProjectAttachment(ctx context.Context, projectID in
 	}, nil
 }
 
-// GetProjectAttachmentsByProject retrieves all attachments for a project
-func (dao *ProjectDao) GetProjectAttachmentsByProject(ctx context.Context, projectID int64) ([]models.ProjectAttachment, error) {
+// GetProjectAttachmentsByProject retrieves attachments for a project.
+// A nil folderID returns every attachment, 0 returns attachments at the project root,
+// and any other value returns the attachments filed directly in that folder.
+func (dao *ProjectDao) GetProjectAttachmentsByProject(ctx context.Context, projectID int64, folderID *int64) ([]models.ProjectAttachment, error) {
+	folderFilter := ""
+	args := []interface{}{projectID}
+	if folderID != nil {
+		if *folderID == 0 {
+			folderFilter = " AND folder_id IS NULL"
+		} else {
+			folderFilter = " AND folder_id = $2"
+			args = append(args, *folderID)
+		}
+	}
+
 	query := `
-		SELECT id, project_id, file_name, file_path, file_size, file_type, attachment_type, 
-		       uploaded_by, created_at, created_by, updated_at, updated_by
+		SELECT id, project_id, file_name, file_path, file_size, file_type, attachment_type, folder_id,
+		       uploaded_by, scan_status, created_at, created_by, updated_at, updated_by
 		FROM project.project_attachments
-		WHERE project_id = $1 AND is_deleted = FALSE
+		WHERE project_id = $1 AND is_deleted = FALSE AND upload_status = 'confirmed'` + folderFilter + `
 		ORDER BY created_at DESC
 	`
 
-	rows, err := dao.DB.QueryContext(ctx, query, projectID)
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"project_id": projectID,
@@ -889,15 +1336,19 @@ func (dao *ProjectDao) GetProjectAttachmentsByProject(ctx context.Context, proje
 	var attachments []models.ProjectAttachment
 	for rows.Next() {
 		var attachment models.ProjectAttachment
+		var attachmentFolderID sql.NullInt64
 		err := rows.Scan(
 			&attachment.ID, &attachment.ProjectID, &attachment.FileName, &attachment.FilePath,
-			&attachment.FileSize, &attachment.FileType, &attachment.AttachmentType, &attachment.UploadedBy,
-			&attachment.CreatedAt, &attachment.CreatedBy, &attachment.UpdatedAt, &attachment.UpdatedBy,
+			&attachment.FileSize, &attachment.FileType, &attachment.AttachmentType, &attachmentFolderID, &attachment.UploadedBy,
+			&attachment.ScanStatus, &attachment.CreatedAt, &attachment.CreatedBy, &attachment.UpdatedAt, &attachment.UpdatedBy,
 		)
 		if err != nil {
 			dao.Logger.WithError(err).Error("Failed to scan project attachment row")
 			return nil, fmt.Errorf("failed to scan project attachment: %w", err)
 		}
+		if attachmentFolderID.Valid {
+			attachment.FolderID = &attachmentFolderID.Int64
+		}
 		attachments = append(attachments, attachment)
 	}
 
@@ -907,21 +1358,22 @@ func (dao *ProjectDao) GetProjectAttachmentsByProject(ctx context.Context, proje
 // GetProjectAttachmentByID retrieves a specific project attachment by ID
 func (dao *ProjectDao) GetProjectAttachmentByID(ctx context.Context, attachmentID, projectID int64) (*models.ProjectAttachment, error) {
 	var attachment models.ProjectAttachment
+	var folderID sql.NullInt64
 	query := `
-		SELECT id, project_id, file_name, file_path, file_size, file_type, attachment_type, 
-		       uploaded_by, created_at, created_by, updated_at, updated_by
+		SELECT id, project_id, file_name, file_path, file_size, file_type, attachment_type, folder_id,
+		       uploaded_by, scan_status, created_at, created_by, updated_at, updated_by
 		FROM project.project_attachments
 		WHERE id = $1 AND project_id = $2 AND is_deleted = FALSE
 	`
 
 	err := dao.DB.QueryRowContext(ctx, query, attachmentID, projectID).Scan(
 		&attachment.ID, &attachment.ProjectID, &attachment.FileName, &attachment.FilePath,
-		&attachment.FileSize, &attachment.FileType, &attachment.AttachmentType, &attachment.UploadedBy,
-		&attachment.CreatedAt, &attachment.CreatedBy, &attachment.UpdatedAt, &attachment.UpdatedBy,
+		&attachment.FileSize, &attachment.FileType, &attachment.AttachmentType, &folderID, &attachment.UploadedBy,
+		&attachment.ScanStatus, &attachment.CreatedAt, &attachment.CreatedBy, &attachment.UpdatedAt, &attachment.UpdatedBy,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("project attachment not found")
+		return nil, ErrProjectAttachmentNotFound
 	}
 
 	if err != nil {
@@ -933,6 +1385,10 @@ func (dao *ProjectDao) GetProjectAttachmentByID(ctx context.Context, attachmentI
 		return nil, fmt.Errorf("failed to get project attachment: %w", err)
 	}
 
+	if folderID.Valid {
+		attachment.FolderID = &folderID.Int64
+	}
+
 	return &attachment, nil
 }
 
@@ -955,7 +1411,7 @@ func (dao *ProjectDao) DeleteProjectAttachment(ctx context.Context, attachmentID
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("project attachment not found")
+		return ErrProjectAttachmentNotFound
 	}
 
 	return nil
@@ -982,6 +1438,12 @@ func (dao *ProjectDao) AssignUserToProject(ctx context.Context, projectID int64,
 		}
 	}
 
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO project.project_user_roles (
 			project_id, user_id, role_id, trade_type, is_primary, start_date, end_date, created_by, updated_by
@@ -990,7 +1452,7 @@ func (dao *ProjectDao) AssignUserToProject(ctx context.Context, projectID int64,
 		RETURNING id, created_at, updated_at
 	`
 
-	err := dao.DB.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		projectID, request.UserID, request.RoleID, tradeType, request.IsPrimary,
 		startDate, endDate, userID, userID,
 	).Scan(&assignmentID, &createdAt, &updatedAt)
@@ -1005,6 +1467,14 @@ func (dao *ProjectDao) AssignUserToProject(ctx context.Context, projectID int64,
 		return nil, fmt.Errorf("failed to assign user to project: %w", err)
 	}
 
+	if err := recordProjectUserRoleHistory(ctx, tx, projectID, request.UserID, request.RoleID, sql.NullInt64{}, models.ProjectUserRoleActionAssigned, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return &models.ProjectUserRole{
 		ID:        assignmentID,
 		ProjectID: projectID,
@@ -1082,7 +1552,27 @@ func (dao *ProjectDao) UpdateProjectUserRole(ctx context.Context, assignmentID,
 	whereClause := fmt.Sprintf("WHERE id = $%d AND project_id = $%d AND is_deleted = FALSE", argIndex, argIndex+1)
 
 	if len(setParts) == 1 { // Only updated_by was set
-		return nil, fmt.Errorf("no fields to update")
+		return nil, ErrNoFieldsToUpdate
+	}
+
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the live row so the recorded previous role matches what the update replaced
+	var previousRoleID int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT role_id FROM project.project_user_roles
+		WHERE id = $1 AND project_id = $2 AND is_deleted = FALSE
+		FOR UPDATE
+	`, assignmentID, projectID).Scan(&previousRoleID)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("project user role assignment not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project user role: %w", err)
 	}
 
 	query := fmt.Sprintf(`
@@ -1097,14 +1587,14 @@ func (dao *ProjectDao) UpdateProjectUserRole(ctx context.Context, assignmentID,
 	)
 
 	var assignment models.ProjectUserRole
-	err := dao.DB.QueryRowContext(ctx, query, args...).Scan(
+	err = tx.QueryRowContext(ctx, query, args...).Scan(
 		&assignment.ID, &assignment.ProjectID, &assignment.UserID, &assignment.RoleID,
 		&assignment.TradeType, &assignment.IsPrimary, &assignment.StartDate, &assignment.EndDate,
 		&assignment.CreatedAt, &assignment.CreatedBy, &assignment.UpdatedAt, &assignment.UpdatedBy,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("project user role assignment not found")
+		return nil, notFoundError("project user role assignment not found")
 	}
 
 	if err != nil {
@@ -1116,16 +1606,40 @@ func (dao *ProjectDao) UpdateProjectUserRole(ctx context.Context, assignmentID,
 		return nil, fmt.Errorf("failed to update project user role: %w", err)
 	}
 
+	if assignment.RoleID != previousRoleID {
+		if err := recordProjectUserRoleHistory(ctx, tx, projectID, assignment.UserID, assignment.RoleID,
+			sql.NullInt64{Int64: previousRoleID, Valid: true}, models.ProjectUserRoleActionRoleChanged, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return &assignment, nil
 }
 
 // RemoveUserFromProject removes a user from a project (soft delete)
 func (dao *ProjectDao) RemoveUserFromProject(ctx context.Context, assignmentID, projectID int64, userID int64) error {
-	result, err := dao.DB.ExecContext(ctx, `
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The live row is only soft-deleted, so the removal is recorded in the history explicitly
+	var removedUserID, removedRoleID int64
+	err = tx.QueryRowContext(ctx, `
 		UPDATE project.project_user_roles 
 		SET is_deleted = TRUE, updated_by = $1
 		WHERE id = $2 AND project_id = $3 AND is_deleted = FALSE
-	`, userID, assignmentID, projectID)
+		RETURNING user_id, role_id
+	`, userID, assignmentID, projectID).Scan(&removedUserID, &removedRoleID)
+
+	if err == sql.ErrNoRows {
+		return notFoundError("project user role assignment not found")
+	}
 
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
@@ -1136,10 +1650,900 @@ func (dao *ProjectDao) RemoveUserFromProject(ctx context.Context, assignmentID,
 		return fmt.Errorf("failed to remove user from project: %w", err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("project user role assignment not found")
+	if err := recordProjectUserRoleHistory(ctx, tx, projectID, removedUserID, removedRoleID, sql.NullInt64{}, models.ProjectUserRoleActionRemoved, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// GetProjectUserRoleHistory returns a project's user role audit trail, oldest entry first
+func (dao *ProjectDao) GetProjectUserRoleHistory(ctx context.Context, projectID, orgID int64) ([]models.ProjectUserRoleHistory, error) {
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM project.projects WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE)
+	`, projectID, orgID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project: %w", err)
+	}
+	if !exists {
+		return nil, notFoundError("project not found")
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT id, project_id, user_id, role_id, previous_role_id, action, actor_user_id, created_at
+		FROM project.project_user_role_history
+		WHERE project_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, projectID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to query project user role history")
+		return nil, fmt.Errorf("failed to query project user role history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.ProjectUserRoleHistory{}
+	for rows.Next() {
+		var entry models.ProjectUserRoleHistory
+		var previousRoleID sql.NullInt64
+		if err := rows.Scan(&entry.ID, &entry.ProjectID, &entry.UserID, &entry.RoleID, &previousRoleID,
+			&entry.Action, &entry.ActorUserID, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan project user role history: %w", err)
+		}
+		if previousRoleID.Valid {
+			entry.PreviousRoleID = &previousRoleID.Int64
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project user role history: %w", err)
+	}
+
+	return history, nil
+}
+
+// recordProjectUserRoleHistory appends an entry to the project user role audit trail. It runs on the
+// caller's transaction so the history row commits or rolls back with the mutation it describes.
+func recordProjectUserRoleHistory(ctx context.Context, q dbtx, projectID, userID, roleID int64, previousRoleID sql.NullInt64, action string, actorUserID int64) error {
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO project.project_user_role_history (
+			project_id, user_id, role_id, previous_role_id, action, actor_user_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, projectID, userID, roleID, previousRoleID, action, actorUserID)
+	if err != nil {
+		return fmt.Errorf("failed to record project user role history: %w", err)
+	}
+	return nil
+}
+
+// GetProjectDefaultAssignee returns the project's default assignee, or 0 when none is configured.
+// Returns an error if the configured user is no longer an active member of the organization.
+func (dao *ProjectDao) GetProjectDefaultAssignee(ctx context.Context, projectID, orgID int64) (int64, error) {
+	var defaultAssigneeID, assigneeOrgID sql.NullInt64
+	query := `
+		SELECT p.default_assignee_id, u.org_id
+		FROM project.projects p
+		LEFT JOIN iam.users u ON u.id = p.default_assignee_id AND u.is_deleted = FALSE
+		WHERE p.id = $1 AND p.org_id = $2 AND p.is_deleted = FALSE
+	`
+
+	err := dao.DB.QueryRowContext(ctx, query, projectID, orgID).Scan(&defaultAssigneeID, &assigneeOrgID)
+	if err == sql.ErrNoRows {
+		return 0, notFoundError("project not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"org_id":     orgID,
+			"error":      err.Error(),
+		}).Error("Failed to get project default assignee")
+		return 0, fmt.Errorf("failed to get project default assignee: %w", err)
+	}
+
+	if !defaultAssigneeID.Valid {
+		return 0, nil
+	}
+
+	if !assigneeOrgID.Valid || assigneeOrgID.Int64 != orgID {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id":          projectID,
+			"org_id":              orgID,
+			"default_assignee_id": defaultAssigneeID.Int64,
+		}).Warn("Project default assignee is no longer a member of the organization")
+		return 0, conflictError("default assignee is no longer a member of the organization")
+	}
+
+	return defaultAssigneeID.Int64, nil
+}
+
+// SetProjectDefaultAssignee sets or clears (assigneeID = 0) the project's default assignee
+func (dao *ProjectDao) SetProjectDefaultAssignee(ctx context.Context, projectID, orgID, assigneeID, userID int64) error {
+	defaultAssigneeID := sql.NullInt64{Int64: assigneeID, Valid: assigneeID != 0}
+
+	if defaultAssigneeID.Valid {
+		var assigneeOrgID int64
+		err := dao.DB.QueryRowContext(ctx, `
+			SELECT org_id FROM iam.users
+			WHERE id = $1 AND is_deleted = FALSE
+		`, assigneeID).Scan(&assigneeOrgID)
+		if err == sql.ErrNoRows || (err == nil && assigneeOrgID != orgID) {
+			return orgMismatchError("default assignee does not belong to organization")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to validate default assignee: %w", err)
+		}
+	}
+
+	result, err := dao.DB.ExecContext(ctx, `
+		UPDATE project.projects
+		SET default_assignee_id = $1, updated_by = $2, updated_at = NOW()
+		WHERE id = $3 AND org_id = $4 AND is_deleted = FALSE
+	`, defaultAssigneeID, userID, projectID, orgID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id":  projectID,
+			"org_id":      orgID,
+			"assignee_id": assigneeID,
+			"error":       err.Error(),
+		}).Error("Failed to set project default assignee")
+		return fmt.Errorf("failed to set project default assignee: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return notFoundError("project not found")
+	}
+
+	return nil
+}
+
+// SearchProject searches issues, RFIs and submittals of a project by title/subject and number.
+// Results are ranked by relevance (exact number match, then prefix match, then substring match)
+// and recency, and paginated. Returns the page of results and the total match count.
+func (dao *ProjectDao) SearchProject(ctx context.Context, projectID, orgID int64, query string, limit, offset int) ([]models.ProjectSearchResult, int, error) {
+	// Escape LIKE wildcards so user input is matched literally
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+	containsPattern := "%" + escaped + "%"
+	prefixPattern := escaped + "%"
+
+	matchesCTE := `
+		WITH matches AS (
+			SELECT 'issue' AS entity_type, i.id, COALESCE(i.issue_number, '') AS number,
+			       i.title AS title, i.status AS status, i.created_at, i.updated_at
+			FROM project.issues i
+			JOIN project.projects p ON p.id = i.project_id
+			WHERE i.project_id = $1 AND p.org_id = $2 AND i.is_deleted = FALSE
+			  AND (i.title ILIKE $3 OR i.issue_number ILIKE $3)
+			UNION ALL
+			SELECT 'rfi' AS entity_type, r.id, COALESCE(r.rfi_number, '') AS number,
+			       r.subject AS title, r.status AS status, r.created_at, r.updated_at
+			FROM project.rfis r
+			WHERE r.project_id = $1 AND r.org_id = $2 AND r.is_deleted = FALSE
+			  AND (r.subject ILIKE $3 OR r.rfi_number ILIKE $3)
+			UNION ALL
+			SELECT 'submittal' AS entity_type, s.id, COALESCE(s.submittal_number, '') AS number,
+			       s.title AS title, s.workflow_status AS status, s.created_at, s.updated_at
+			FROM project.submittals s
+			WHERE s.project_id = $1 AND s.org_id = $2 AND s.is_deleted = FALSE
+			  AND (s.title ILIKE $3 OR s.submittal_number ILIKE $3)
+		)`
+
+	var totalCount int
+	err := dao.DB.QueryRowContext(ctx, matchesCTE+`
+		SELECT COUNT(*) FROM matches
+	`, projectID, orgID, containsPattern).Scan(&totalCount)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"org_id":     orgID,
+			"error":      err.Error(),
+		}).Error("Failed to count project search results")
+		return nil, 0, fmt.Errorf("failed to count project search results: %w", err)
+	}
+
+	results := []models.ProjectSearchResult{}
+	if totalCount == 0 {
+		return results, 0, nil
+	}
+
+	searchQuery := matchesCTE + `
+		SELECT entity_type, id, number, title, status, created_at, updated_at,
+		       CASE
+		           WHEN LOWER(number) = LOWER($4) THEN 3
+		           WHEN title ILIKE $5 OR number ILIKE $5 THEN 2
+		           ELSE 1
+		       END AS relevance
+		FROM matches
+		ORDER BY relevance DESC, updated_at DESC
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, searchQuery, projectID, orgID, containsPattern, query, prefixPattern, limit, offset)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"org_id":     orgID,
+			"error":      err.Error(),
+		}).Error("Failed to search project")
+		return nil, 0, fmt.Errorf("failed to search project: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result models.ProjectSearchResult
+		err := rows.Scan(
+			&result.EntityType, &result.ID, &result.Number, &result.Title, &result.Status,
+			&result.CreatedAt, &result.UpdatedAt, &result.Relevance,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan project search row")
+			return nil, 0, fmt.Errorf("failed to scan project search result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating project search results: %w", err)
+	}
+
+	return results, totalCount, nil
+}
+
+// Each stats query returns one row per status: the status, its count, whether it is final and how many
+// of its rows are overdue. Overdue matches the is_overdue rules used when the items are read.
+const (
+	issueStatsQuery = `
+		SELECT i.status, COUNT(*), i.status IN ('closed', 'rejected'),
+		       COUNT(*) FILTER (WHERE i.due_date < CURRENT_TIMESTAMP AND i.status NOT IN ('closed', 'rejected'))
+		FROM project.issues i
+		JOIN project.projects p ON p.id = i.project_id
+		WHERE i.project_id = $1 AND p.org_id = $2 AND i.is_deleted = FALSE
+		GROUP BY i.status`
+
+	rfiStatsQuery = `
+		SELECT r.status, COUNT(*), r.status = 'CLOSE',
+		       COUNT(*) FILTER (WHERE r.status = 'OPEN' AND r.for_information = FALSE AND r.due_date < CURRENT_DATE)
+		FROM project.rfis r
+		WHERE r.project_id = $1 AND r.org_id = $2 AND r.is_deleted = FALSE
+		GROUP BY r.status`
+
+	submittalStatsQuery = `
+		SELECT s.workflow_status, COUNT(*), s.workflow_status NOT IN ('draft', 'pending_submission', 'under_review', 'revise_resubmit'),
+		       COUNT(*) FILTER (WHERE s.required_approval_date < CURRENT_TIMESTAMP AND s.workflow_status IN ('pending_submission', 'under_review'))
+		FROM project.submittals s
+		WHERE s.project_id = $1 AND s.org_id = $2 AND s.is_deleted = FALSE
+		GROUP BY s.workflow_status`
+)
+
+// GetProjectStats counts the project's issues, RFIs and submittals by status with one grouped query
+// per kind. The caller is expected to have checked the project belongs to orgID.
+func (dao *ProjectDao) GetProjectStats(ctx context.Context, projectID, orgID int64) (*models.ProjectStats, error) {
+	stats := &models.ProjectStats{ProjectID: projectID}
+
+	for _, kind := range []struct {
+		name  string
+		query string
+		into  *models.ProjectEntityStats
+	}{
+		{"issue", issueStatsQuery, &stats.Issues},
+		{"rfi", rfiStatsQuery, &stats.RFIs},
+		{"submittal", submittalStatsQuery, &stats.Submittals},
+	} {
+		if err := dao.countProjectEntityStats(ctx, kind.query, projectID, orgID, kind.into); err != nil {
+			dao.Logger.WithFields(logrus.Fields{
+				"project_id":  projectID,
+				"entity_type": kind.name,
+				"error":       err.Error(),
+			}).Error("Failed to get project stats")
+			return nil, fmt.Errorf("failed to get %s stats: %w", kind.name, err)
+		}
+	}
+
+	return stats, nil
+}
+
+// countProjectEntityStats runs one of the grouped stats queries and totals its rows into stats
+func (dao *ProjectDao) countProjectEntityStats(ctx context.Context, query string, projectID, orgID int64, stats *models.ProjectEntityStats) error {
+	rows, err := dao.DB.QueryContext(ctx, query, projectID, orgID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	stats.ByStatus = make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count, overdue int
+		var closed bool
+		if err := rows.Scan(&status, &count, &closed, &overdue); err != nil {
+			return err
+		}
+		stats.ByStatus[status] = count
+		stats.Total += count
+		stats.Overdue += overdue
+		if closed {
+			stats.Closed += count
+		} else {
+			stats.Open += count
+		}
+	}
+	return rows.Err()
+}
+
+// projectMilestoneColumns is the select list shared by milestone queries
+const projectMilestoneColumns = `
+	m.id, m.project_id, m.name, m.description,
+	TO_CHAR(m.target_date, 'YYYY-MM-DD'), TO_CHAR(m.actual_date, 'YYYY-MM-DD'),
+	m.status, m.created_at, m.created_by, m.updated_at, m.updated_by`
+
+// scanProjectMilestone scans a row selected with projectMilestoneColumns
+func scanProjectMilestone(scanner interface{ Scan(...interface{}) error }) (*models.ProjectMilestone, error) {
+	var milestone models.ProjectMilestone
+	var description, actualDate sql.NullString
+
+	err := scanner.Scan(
+		&milestone.ID, &milestone.ProjectID, &milestone.Name, &description,
+		&milestone.TargetDate, &actualDate,
+		&milestone.Status, &milestone.CreatedAt, &milestone.CreatedBy, &milestone.UpdatedAt, &milestone.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		milestone.Description = &description.String
+	}
+	if actualDate.Valid {
+		milestone.ActualDate = &actualDate.String
+	}
+
+	return &milestone, nil
+}
+
+// validateMilestoneDates confirms the project belongs to the org and that the target date
+// (YYYY-MM-DD) falls within the project timeline. The actual date only has to fall on or after the
+// project start, since milestones are often completed after the planned finish. Empty dates are skipped.
+func (dao *ProjectDao) validateMilestoneDates(ctx context.Context, projectID, orgID int64, targetDate, actualDate string) error {
+	var startDate, finishDate sql.NullTime
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT start_date, COALESCE(project_finish_date, planned_end_date)
+		FROM project.projects
+		WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+	`, projectID, orgID).Scan(&startDate, &finishDate)
+	if err == sql.ErrNoRows {
+		return notFoundError("project not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"org_id":     orgID,
+			"error":      err.Error(),
+		}).Error("Failed to get project timeline")
+		return fmt.Errorf("failed to get project timeline: %w", err)
+	}
+
+	projectStart := startDate.Time.Truncate(24 * time.Hour)
+	projectFinish := finishDate.Time.Truncate(24 * time.Hour)
+
+	if targetDate != "" {
+		parsed, err := time.Parse("2006-01-02", targetDate)
+		if err != nil {
+			return ErrInvalidMilestoneDate
+		}
+		if (startDate.Valid && parsed.Before(projectStart)) || (finishDate.Valid && parsed.After(projectFinish)) {
+			return ErrMilestoneOutsideTimeline
+		}
+	}
+
+	if actualDate != "" {
+		parsed, err := time.Parse("2006-01-02", actualDate)
+		if err != nil {
+			return ErrInvalidMilestoneDate
+		}
+		if startDate.Valid && parsed.Before(projectStart) {
+			return ErrMilestoneOutsideTimeline
+		}
+	}
+
+	return nil
+}
+
+// CreateProjectMilestone creates a milestone on a project
+func (dao *ProjectDao) CreateProjectMilestone(ctx context.Context, projectID, orgID int64, request *models.CreateProjectMilestoneRequest, userID int64) (*models.ProjectMilestone, error) {
+	if err := dao.validateMilestoneDates(ctx, projectID, orgID, request.TargetDate, ""); err != nil {
+		return nil, err
+	}
+
+	status := request.Status
+	if status == "" {
+		status = models.MilestoneStatusPending
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO project.project_milestones AS m (
+			project_id, name, description, target_date, status, created_by, updated_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING %s
+	`, projectMilestoneColumns)
+
+	milestone, err := scanProjectMilestone(dao.DB.QueryRowContext(ctx, query,
+		projectID, request.Name, request.Description, request.TargetDate, status, userID,
+	))
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to create project milestone")
+		return nil, fmt.Errorf("failed to create project milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// GetProjectMilestones retrieves all milestones for a project ordered by target date
+func (dao *ProjectDao) GetProjectMilestones(ctx context.Context, projectID, orgID int64) ([]models.ProjectMilestone, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM project.project_milestones m
+		JOIN project.projects p ON p.id = m.project_id
+		WHERE m.project_id = $1 AND p.org_id = $2 AND m.is_deleted = FALSE
+		ORDER BY m.target_date ASC, m.id ASC
+	`, projectMilestoneColumns)
+
+	return dao.queryProjectMilestones(ctx, query, projectID, orgID)
+}
+
+// GetUpcomingProjectMilestones retrieves the next open milestones on or after today
+func (dao *ProjectDao) GetUpcomingProjectMilestones(ctx context.Context, projectID, orgID int64, limit int) ([]models.ProjectMilestone, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM project.project_milestones m
+		JOIN project.projects p ON p.id = m.project_id
+		WHERE m.project_id = $1 AND p.org_id = $2 AND m.is_deleted = FALSE
+		  AND m.status <> 'completed' AND m.target_date >= CURRENT_DATE
+		ORDER BY m.target_date ASC, m.id ASC
+		LIMIT $3
+	`, projectMilestoneColumns)
+
+	return dao.queryProjectMilestones(ctx, query, projectID, orgID, limit)
+}
+
+// queryProjectMilestones runs a milestone select and scans all rows
+func (dao *ProjectDao) queryProjectMilestones(ctx context.Context, query string, args ...interface{}) ([]models.ProjectMilestone, error) {
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to query project milestones")
+		return nil, fmt.Errorf("failed to query project milestones: %w", err)
+	}
+	defer rows.Close()
+
+	milestones := []models.ProjectMilestone{}
+	for rows.Next() {
+		milestone, err := scanProjectMilestone(rows)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan project milestone row")
+			return nil, fmt.Errorf("failed to scan project milestone: %w", err)
+		}
+		milestones = append(milestones, *milestone)
+	}
+
+	if err = rows.Err(); err != nil {
+		dao.Logger.WithError(err).Error("Error iterating project milestone rows")
+		return nil, fmt.Errorf("failed to iterate project milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+// UpdateProjectMilestone updates the provided fields of a project milestone.
+// Setting status to completed without an actual date records today as the actual date.
+func (dao *ProjectDao) UpdateProjectMilestone(ctx context.Context, milestoneID, projectID, orgID int64, request *models.UpdateProjectMilestoneRequest, userID int64) (*models.ProjectMilestone, error) {
+	var targetDate, actualDate string
+	if request.TargetDate != nil {
+		targetDate = *request.TargetDate
+	}
+	if request.ActualDate != nil {
+		actualDate = *request.ActualDate
+	}
+	if err := dao.validateMilestoneDates(ctx, projectID, orgID, targetDate, actualDate); err != nil {
+		return nil, err
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if request.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *request.Name)
+		argIndex++
+	}
+	if request.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, *request.Description)
+		argIndex++
+	}
+	if request.TargetDate != nil {
+		setParts = append(setParts, fmt.Sprintf("target_date = $%d", argIndex))
+		args = append(args, *request.TargetDate)
+		argIndex++
+	}
+	if request.ActualDate != nil {
+		setParts = append(setParts, fmt.Sprintf("actual_date = $%d", argIndex))
+		args = append(args, sql.NullString{String: *request.ActualDate, Valid: *request.ActualDate != ""})
+		argIndex++
+	}
+	if request.Status != nil {
+		setParts = append(setParts, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *request.Status)
+		argIndex++
+		if *request.Status == models.MilestoneStatusCompleted && request.ActualDate == nil {
+			setParts = append(setParts, "actual_date = COALESCE(actual_date, CURRENT_DATE)")
+		}
+	}
+
+	if len(setParts) == 0 {
+		return nil, ErrNoFieldsToUpdate
+	}
+
+	setParts = append(setParts, fmt.Sprintf("updated_by = $%d", argIndex), "updated_at = CURRENT_TIMESTAMP")
+	args = append(args, userID)
+	argIndex++
+
+	args = append(args, milestoneID, projectID)
+	query := fmt.Sprintf(`
+		UPDATE project.project_milestones AS m
+		SET %s
+		WHERE m.id = $%d AND m.project_id = $%d AND m.is_deleted = FALSE
+		RETURNING %s
+	`, strings.Join(setParts, ", "), argIndex, argIndex+1, projectMilestoneColumns)
+
+	milestone, err := scanProjectMilestone(dao.DB.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrMilestoneNotFound
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"milestone_id": milestoneID,
+			"project_id":   projectID,
+			"error":        err.Error(),
+		}).Error("Failed to update project milestone")
+		return nil, fmt.Errorf("failed to update project milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// CompleteProjectMilestone marks a milestone completed with the given actual date (today when empty)
+func (dao *ProjectDao) CompleteProjectMilestone(ctx context.Context, milestoneID, projectID, orgID int64, actualDate string, userID int64) (*models.ProjectMilestone, error) {
+	if actualDate == "" {
+		actualDate = time.Now().Format("2006-01-02")
+	}
+
+	status := models.MilestoneStatusCompleted
+	return dao.UpdateProjectMilestone(ctx, milestoneID, projectID, orgID, &models.UpdateProjectMilestoneRequest{
+		ActualDate: &actualDate,
+		Status:     &status,
+	}, userID)
+}
+
+// DeleteProjectMilestone soft deletes a project milestone
+func (dao *ProjectDao) DeleteProjectMilestone(ctx context.Context, milestoneID, projectID, orgID int64, userID int64) error {
+	result, err := dao.DB.ExecContext(ctx, `
+		UPDATE project.project_milestones m
+		SET is_deleted = TRUE, updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		FROM project.projects p
+		WHERE m.id = $2 AND m.project_id = $3 AND p.id = m.project_id AND p.org_id = $4 AND m.is_deleted = FALSE
+	`, userID, milestoneID, projectID, orgID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"milestone_id": milestoneID,
+			"project_id":   projectID,
+			"error":        err.Error(),
+		}).Error("Failed to delete project milestone")
+		return fmt.Errorf("failed to delete project milestone: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrMilestoneNotFound
+	}
+
+	return nil
+}
+
+// projectFolderColumns is the select list shared by folder queries
+const projectFolderColumns = `
+	f.id, f.project_id, f.parent_folder_id, f.name,
+	f.created_at, f.created_by, f.updated_at, f.updated_by`
+
+// scanProjectFolder scans a row selected with projectFolderColumns
+func scanProjectFolder(scanner interface{ Scan(...interface{}) error }) (*models.ProjectFolder, error) {
+	var folder models.ProjectFolder
+	var parentFolderID sql.NullInt64
+
+	err := scanner.Scan(
+		&folder.ID, &folder.ProjectID, &parentFolderID, &folder.Name,
+		&folder.CreatedAt, &folder.CreatedBy, &folder.UpdatedAt, &folder.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentFolderID.Valid {
+		folder.ParentFolderID = &parentFolderID.Int64
+	}
+
+	return &folder, nil
+}
+
+// ensureProjectInOrg confirms the project exists and belongs to the org
+func (dao *ProjectDao) ensureProjectInOrg(ctx context.Context, projectID, orgID int64) error {
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM project.projects
+			WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+		)
+	`, projectID, orgID).Scan(&exists)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"org_id":     orgID,
+			"error":      err.Error(),
+		}).Error("Failed to verify project organization")
+		return fmt.Errorf("failed to verify project organization: %w", err)
+	}
+	if !exists {
+		return notFoundError("project not found")
+	}
+	return nil
+}
+
+// ensureFolderInProject confirms the folder exists on the project
+func (dao *ProjectDao) ensureFolderInProject(ctx context.Context, folderID, projectID int64) error {
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM project.project_folders
+			WHERE id = $1 AND project_id = $2 AND is_deleted = FALSE
+		)
+	`, folderID, projectID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to verify project folder: %w", err)
+	}
+	if !exists {
+		return ErrFolderNotFound
+	}
+	return nil
+}
+
+// CreateProjectFolder creates a document folder on a project, optionally nested under a parent folder
+func (dao *ProjectDao) CreateProjectFolder(ctx context.Context, projectID, orgID int64, request *models.CreateProjectFolderRequest, userID int64) (*models.ProjectFolder, error) {
+	if err := dao.ensureProjectInOrg(ctx, projectID, orgID); err != nil {
+		return nil, err
+	}
+
+	var parentFolderID sql.NullInt64
+	if request.ParentFolderID != nil {
+		if err := dao.ensureFolderInProject(ctx, *request.ParentFolderID, projectID); err != nil {
+			if errors.Is(err, ErrFolderNotFound) {
+				return nil, ErrParentFolderNotFound
+			}
+			return nil, err
+		}
+		parentFolderID = sql.NullInt64{Int64: *request.ParentFolderID, Valid: true}
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO project.project_folders AS f (
+			project_id, parent_folder_id, name, created_by, updated_by
+		)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING %s
+	`, projectFolderColumns)
+
+	folder, err := scanProjectFolder(dao.DB.QueryRowContext(ctx, query,
+		projectID, parentFolderID, request.Name, userID,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, conflictError("folder name already exists")
+		}
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to create project folder")
+		return nil, fmt.Errorf("failed to create project folder: %w", err)
+	}
+
+	return folder, nil
+}
+
+// GetProjectFolders retrieves every folder on a project as a flat list ordered by name
+func (dao *ProjectDao) GetProjectFolders(ctx context.Context, projectID, orgID int64) ([]models.ProjectFolder, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM project.project_folders f
+		JOIN project.projects p ON p.id = f.project_id
+		WHERE f.project_id = $1 AND p.org_id = $2 AND f.is_deleted = FALSE
+		ORDER BY LOWER(f.name) ASC, f.id ASC
+	`, projectFolderColumns)
+
+	rows, err := dao.DB.QueryContext(ctx, query, projectID, orgID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to query project folders")
+		return nil, fmt.Errorf("failed to query project folders: %w", err)
+	}
+	defer rows.Close()
+
+	folders := []models.ProjectFolder{}
+	for rows.Next() {
+		folder, err := scanProjectFolder(rows)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan project folder row")
+			return nil, fmt.Errorf("failed to scan project folder: %w", err)
+		}
+		folders = append(folders, *folder)
+	}
+
+	return folders, nil
+}
+
+// UpdateProjectFolder renames a folder and/or moves it under a new parent.
+// Moving a folder beneath itself or one of its descendants is rejected.
+func (dao *ProjectDao) UpdateProjectFolder(ctx context.Context, folderID, projectID, orgID int64, request *models.UpdateProjectFolderRequest, userID int64) (*models.ProjectFolder, error) {
+	if err := dao.ensureProjectInOrg(ctx, projectID, orgID); err != nil {
+		return nil, err
+	}
+	if err := dao.ensureFolderInProject(ctx, folderID, projectID); err != nil {
+		return nil, err
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if request.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, strings.TrimSpace(*request.Name))
+		argIndex++
+	}
+	if request.ParentFolderID != nil {
+		parentFolderID := sql.NullInt64{}
+		if *request.ParentFolderID != 0 {
+			if err := dao.validateFolderParent(ctx, folderID, *request.ParentFolderID, projectID); err != nil {
+				return nil, err
+			}
+			parentFolderID = sql.NullInt64{Int64: *request.ParentFolderID, Valid: true}
+		}
+		setParts = append(setParts, fmt.Sprintf("parent_folder_id = $%d", argIndex))
+		args = append(args, parentFolderID)
+		argIndex++
+	}
+
+	if len(setParts) == 0 {
+		return nil, ErrNoFieldsToUpdate
+	}
+
+	setParts = append(setParts, fmt.Sprintf("updated_by = $%d", argIndex), "updated_at = CURRENT_TIMESTAMP")
+	args = append(args, userID)
+	argIndex++
+
+	args = append(args, folderID, projectID)
+	query := fmt.Sprintf(`
+		UPDATE project.project_folders AS f
+		SET %s
+		WHERE f.id = $%d AND f.project_id = $%d AND f.is_deleted = FALSE
+		RETURNING %s
+	`, strings.Join(setParts, ", "), argIndex, argIndex+1, projectFolderColumns)
+
+	folder, err := scanProjectFolder(dao.DB.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrFolderNotFound
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, conflictError("folder name already exists")
+		}
+		dao.Logger.WithFields(logrus.Fields{
+			"folder_id":  folderID,
+			"project_id": projectID,
+			"error":      err.Error(),
+		}).Error("Failed to update project folder")
+		return nil, fmt.Errorf("failed to update project folder: %w", err)
+	}
+
+	return folder, nil
+}
+
+// validateFolderParent confirms parentFolderID is on the same project and is neither the
+// folder itself nor one of its descendants, which would create a cycle
+func (dao *ProjectDao) validateFolderParent(ctx context.Context, folderID, parentFolderID, projectID int64) error {
+	if folderID == parentFolderID {
+		return invalidError("folder cannot be its own parent")
+	}
+	if err := dao.ensureFolderInProject(ctx, parentFolderID, projectID); err != nil {
+		if errors.Is(err, ErrFolderNotFound) {
+			return ErrParentFolderNotFound
+		}
+		return err
+	}
+
+	// Walk up from the proposed parent; finding folderID means the move would create a cycle
+	var createsCycle bool
+	err := dao.DB.QueryRowContext(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_folder_id
+			FROM project.project_folders
+			WHERE id = $1
+			UNION
+			SELECT f.id, f.parent_folder_id
+			FROM project.project_folders f
+			JOIN ancestors a ON f.id = a.parent_folder_id
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors WHERE id = $2)
+	`, parentFolderID, folderID).Scan(&createsCycle)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"folder_id":        folderID,
+			"parent_folder_id": parentFolderID,
+			"error":            err.Error(),
+		}).Error("Failed to check folder ancestry")
+		return fmt.Errorf("failed to check folder ancestry: %w", err)
+	}
+	if createsCycle {
+		return invalidError("folder cannot be moved into its own subfolder")
+	}
+
+	return nil
+}
+
+// MoveProjectAttachment files an attachment into a folder, or back to the project root when folderID is nil
+func (dao *ProjectDao) MoveProjectAttachment(ctx context.Context, attachmentID, projectID, orgID int64, folderID *int64, userID int64) (*models.ProjectAttachment, error) {
+	if err := dao.ensureProjectInOrg(ctx, projectID, orgID); err != nil {
+		return nil, err
+	}
+
+	targetFolderID := sql.NullInt64{}
+	if folderID != nil {
+		if err := dao.ensureFolderInProject(ctx, *folderID, projectID); err != nil {
+			return nil, err
+		}
+		targetFolderID = sql.NullInt64{Int64: *folderID, Valid: true}
+	}
+
+	result, err := dao.DB.ExecContext(ctx, `
+		UPDATE project.project_attachments
+		SET folder_id = $1, updated_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND project_id = $4 AND is_deleted = FALSE
+	`, targetFolderID, userID, attachmentID, projectID)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"attachment_id": attachmentID,
+			"project_id":    projectID,
+			"error":         err.Error(),
+		}).Error("Failed to move project attachment")
+		return nil, fmt.Errorf("failed to move project attachment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, ErrProjectAttachmentNotFound
+	}
+
+	return dao.GetProjectAttachmentByID(ctx, attachmentID, projectID)
+}
@@ -0,0 +1,189 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"infrastructure/lib/models"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProjectDB emulates the statements CreateProject issues. Sequence statements go to
+// fakeSequenceDB, whose racy counter only stays unique under the advisory lock.
+type fakeProjectDB struct {
+	sequences *fakeSequenceDB
+	mu        sync.Mutex
+	numbers   map[string]bool
+	nextID    int64
+}
+
+type fakeProjectConnector struct{ db *fakeProjectDB }
+
+type fakeProjectConn struct {
+	*fakeSequenceConn
+	db *fakeProjectDB
+}
+
+type fakeProjectStmt struct {
+	conn  *fakeProjectConn
+	query string
+}
+
+type fakeProjectRows struct {
+	columns []string
+	values  []driver.Value
+	done    bool
+}
+
+func (c *fakeProjectConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeProjectConn{fakeSequenceConn: &fakeSequenceConn{db: c.db.sequences}, db: c.db}, nil
+}
+
+func (c *fakeProjectConnector) Driver() driver.Driver { return &fakeSequenceDriver{db: c.db.sequences} }
+
+func (c *fakeProjectConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeProjectStmt{conn: c, query: query}, nil
+}
+
+func (s *fakeProjectStmt) Close() error  { return nil }
+func (s *fakeProjectStmt) NumInput() int { return -1 }
+
+func (s *fakeProjectStmt) sequenceStmt() *fakeSequenceStmt {
+	return &fakeSequenceStmt{conn: s.conn.fakeSequenceConn, query: s.query}
+}
+
+func (s *fakeProjectStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.sequenceStmt().Exec(args)
+}
+
+func (s *fakeProjectStmt) Query(args []driver.Value) (driver.Rows, error) {
+	db := s.conn.db
+	switch {
+	case strings.Contains(s.query, "INSERT INTO project.sequences"):
+		return s.sequenceStmt().Query(args)
+	case strings.Contains(s.query, "SELECT EXISTS"):
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		return &fakeProjectRows{columns: []string{"exists"}, values: []driver.Value{db.numbers[args[1].(string)]}}, nil
+	case strings.Contains(s.query, "FROM iam.locations"):
+		return &fakeProjectRows{columns: []string{"status"}, values: []driver.Value{models.LocationStatusActive}}, nil
+	case strings.Contains(s.query, "INSERT INTO project.projects"):
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		projectNumber := args[2].(string)
+		if db.numbers[projectNumber] {
+			return nil, &pq.Error{Code: "23505", Constraint: projectNumberIndex}
+		}
+		db.numbers[projectNumber] = true
+		db.nextID++
+		now := time.Now()
+		return &fakeProjectRows{columns: []string{"id", "created_at", "updated_at"}, values: []driver.Value{db.nextID, now, now}}, nil
+	}
+	return nil, errors.New("unexpected query")
+}
+
+func (r *fakeProjectRows) Columns() []string { return r.columns }
+func (r *fakeProjectRows) Close() error      { return nil }
+
+func (r *fakeProjectRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.done = true
+	return nil
+}
+
+func newFakeProjectDao(existingNumbers ...string) (*ProjectDao, func() error) {
+	fake := &fakeProjectDB{
+		sequences: &fakeSequenceDB{locks: map[int64]*sync.Mutex{}, counters: map[string]int64{}},
+		numbers:   map[string]bool{},
+	}
+	for _, number := range existingNumbers {
+		fake.numbers[number] = true
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	db := sql.OpenDB(&fakeProjectConnector{db: fake})
+	return &ProjectDao{DB: db, Logger: logger}, db.Close
+}
+
+func testCreateProjectRequest(name string) *models.CreateProjectRequest {
+	request := &models.CreateProjectRequest{LocationID: 1}
+	request.BasicInfo.Name = name
+	request.Timeline.StartDate = "2026-03-02"
+	return request
+}
+
+func Test_CreateProject_ConcurrentCreatesGetUniqueNumbers(t *testing.T) {
+	//Arrange
+	dao, closeDB := newFakeProjectDao()
+	defer closeDB()
+
+	const callers = 20
+	numbers := make(chan string, callers)
+	var wg sync.WaitGroup
+
+	//Act
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, err := dao.CreateProject(context.Background(), 1, testCreateProjectRequest(fmt.Sprintf("Tower %d", i)), 7)
+			if assert.NoError(t, err) && assert.True(t, response.Success) {
+				numbers <- response.Data.ProjectNumber
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(numbers)
+
+	//Assert
+	seen := map[string]bool{}
+	for number := range numbers {
+		assert.False(t, seen[number], "duplicate project number %s", number)
+		seen[number] = true
+	}
+	assert.Len(t, seen, callers)
+}
+
+func Test_CreateProject_SkipsNumbersAlreadyInUse(t *testing.T) {
+	//Arrange
+	year := time.Now().Year()
+	dao, closeDB := newFakeProjectDao(fmt.Sprintf("PROJ-%d-0001", year), fmt.Sprintf("PROJ-%d-0002", year))
+	defer closeDB()
+
+	//Act
+	response, err := dao.CreateProject(context.Background(), 1, testCreateProjectRequest("Tower"), 7)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("PROJ-%d-0003", year), response.Data.ProjectNumber)
+}
+
+func Test_CreateProject_RejectsUnknownTimezone(t *testing.T) {
+	//Arrange
+	dao, closeDB := newFakeProjectDao()
+	defer closeDB()
+	request := testCreateProjectRequest("Tower")
+	request.Timeline.Timezone = "Pacific Time"
+
+	//Act
+	response, err := dao.CreateProject(context.Background(), 1, request, 7)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Errors, "timezone")
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"infrastructure/lib/models"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// recountBatchSize is the number of parent rows recomputed per transaction
+	recountBatchSize = 500
+	// recountStatementTimeout bounds each batch so a recount cannot hold locks for long
+	recountStatementTimeout = "30s"
+)
+
+// countSource describes one denormalized count column and the child table it counts
+type countSource struct {
+	column     string
+	childTable string
+	childFK    string
+}
+
+// recountEntityCounts recomputes the count columns of parentTable from their child tables in id-ordered
+// batches, one bounded transaction per batch, and only writes rows whose stored counts have drifted.
+// Only projects of orgID are touched; projectID of 0 recounts every project in the organization.
+// Table and column names must be trusted constants.
+func recountEntityCounts(ctx context.Context, db *sql.DB, logger *logrus.Logger, entityType, parentTable string, orgID, projectID int64, sources []countSource) (models.RecountResult, error) {
+	result := models.RecountResult{EntityType: entityType}
+
+	var computed, setClauses, drift []string
+	for _, source := range sources {
+		result.Columns = append(result.Columns, source.column)
+		computed = append(computed, fmt.Sprintf(
+			"(SELECT COUNT(*) FROM %s c WHERE c.%s = s.id AND c.is_deleted = FALSE) AS %s",
+			source.childTable, source.childFK, source.column))
+		setClauses = append(setClauses, fmt.Sprintf("%[1]s = counted.%[1]s", source.column))
+		drift = append(drift, fmt.Sprintf("p.%[1]s <> counted.%[1]s", source.column))
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE %s p
+		SET %s
+		FROM (
+			SELECT s.id, %s
+			FROM %s s
+			WHERE s.id > $1 AND s.id <= $2 AND s.is_deleted = FALSE AND ($3 = 0 OR s.project_id = $3)
+				AND s.project_id IN (SELECT id FROM project.projects WHERE org_id = $4)
+		) counted
+		WHERE p.id = counted.id AND (%s)
+	`, parentTable, strings.Join(setClauses, ", "), strings.Join(computed, ", "), parentTable, strings.Join(drift, " OR "))
+
+	batchQuery := fmt.Sprintf(`
+		SELECT COUNT(*), COALESCE(MAX(id), 0)
+		FROM (
+			SELECT id FROM %s
+			WHERE id > $1 AND is_deleted = FALSE AND ($2 = 0 OR project_id = $2)
+				AND project_id IN (SELECT id FROM project.projects WHERE org_id = $4)
+			ORDER BY id
+			LIMIT $3
+		) batch
+	`, parentTable)
+
+	var lastID int64
+	for {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return result, fmt.Errorf("failed to start transaction: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = '%s'", recountStatementTimeout)); err != nil {
+			tx.Rollback()
+			return result, fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+
+		var scanned int
+		var upperID int64
+		if err = tx.QueryRowContext(ctx, batchQuery, lastID, projectID, recountBatchSize, orgID).Scan(&scanned, &upperID); err != nil {
+			tx.Rollback()
+			return result, fmt.Errorf("failed to select %s recount batch: %w", entityType, err)
+		}
+		if scanned == 0 {
+			tx.Rollback()
+			break
+		}
+
+		res, err := tx.ExecContext(ctx, updateQuery, lastID, upperID, projectID, orgID)
+		if err != nil {
+			tx.Rollback()
+			return result, fmt.Errorf("failed to recount %s batch: %w", entityType, err)
+		}
+		corrected, _ := res.RowsAffected()
+
+		if err = tx.Commit(); err != nil {
+			return result, fmt.Errorf("failed to commit %s recount batch: %w", entityType, err)
+		}
+
+		result.RowsScanned += scanned
+		result.RowsCorrected += int(corrected)
+		lastID = upperID
+
+		if scanned < recountBatchSize {
+			break
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"entity_type":    entityType,
+		"org_id":         orgID,
+		"project_id":     projectID,
+		"rows_scanned":   result.RowsScanned,
+		"rows_corrected": result.RowsCorrected,
+	}).Info("Recounted denormalized counts")
+
+	return result, nil
+}
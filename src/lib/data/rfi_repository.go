@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"infrastructure/lib/models"
+	"infrastructure/lib/util"
 	"strings"
 	"time"
 
@@ -15,8 +16,14 @@ import (
 // RFIRepository defines the interface for RFI data operations
 type RFIRepository interface {
 	CreateRFI(ctx context.Context, projectID, userID, orgID int64, req *models.CreateRFIRequest) (*models.RFIResponse, error)
+	// CreateRFITx inserts a new RFI using the caller's transaction (nil runs without one) and returns its ID
+	CreateRFITx(ctx context.Context, tx *sql.Tx, projectID, userID, orgID int64, req *models.CreateRFIRequest) (int64, error)
 	GetRFI(ctx context.Context, rfiID int64) (*models.RFIResponse, error)
+	GetRFIByNumber(ctx context.Context, projectID, orgID int64, rfiNumber string) (*models.RFIResponse, error)
 	GetRFIsByProject(ctx context.Context, projectID int64, filters map[string]string) ([]models.RFIResponse, error)
+	GetRFIsAssignedToUser(ctx context.Context, userID, orgID int64, filters map[string]string) ([]models.RFIResponse, error)
+	GetSLABreachedRFIs(ctx context.Context, projectID, orgID int64) ([]models.RFIResponse, error)
+	GetRFIExport(ctx context.Context, projectID, orgID int64) ([]models.RFIExportItem, error)
 	UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, req *models.UpdateRFIRequest) (*models.RFIResponse, error)
 	DeleteRFI(ctx context.Context, rfiID int64, deletedBy int64) error
 	AddRFIComment(ctx context.Context, rfiID, userID int64, req *models.CreateRFICommentRequest) (*models.RFIComment, error)
@@ -24,6 +31,10 @@ type RFIRepository interface {
 	AddRFIAttachment(ctx context.Context, attachment *models.RFIAttachment) (*models.RFIAttachment, error)
 	GetRFIAttachments(ctx context.Context, rfiID int64) ([]models.RFIAttachment, error)
 	GenerateRFINumber(ctx context.Context, projectID int64) (string, error)
+	CopyRFI(ctx context.Context, rfiID, targetProjectID, userID, orgID int64, copyAttachments bool) (*models.RFIResponse, error)
+	RecountRFICounts(ctx context.Context, orgID, projectID int64) (models.RecountResult, error)
+	GetStaleAnsweredRFIs(ctx context.Context, limit int) ([]models.StaleRFI, error)
+	AutoCloseRFI(ctx context.Context, rfi models.StaleRFI) (bool, error)
 }
 
 // RFIDao implements RFIRepository interface
@@ -87,6 +98,22 @@ func (dao *RFIDao) getUsersDetails(ctx context.Context, userIDs []int64) ([]mode
 
 // CreateRFI creates a new RFI
 func (dao *RFIDao) CreateRFI(ctx context.Context, projectID, userID, orgID int64, req *models.CreateRFIRequest) (*models.RFIResponse, error) {
+	rfiID, err := dao.CreateRFITx(ctx, nil, projectID, userID, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	dao.Logger.WithField("rfi_id", rfiID).Info("RFI created successfully, fetching complete RFI data")
+
+	return dao.GetRFI(ctx, rfiID)
+}
+
+// CreateRFITx inserts a new RFI using the caller's transaction and returns its ID.
+// The caller commits and loads the full RFI afterwards.
+func (dao *RFIDao) CreateRFITx(ctx context.Context, tx *sql.Tx, projectID, userID, orgID int64, req *models.CreateRFIRequest) (int64, error) {
+	defer util.TimeDB(ctx)()
+	q := txOrDB(dao.DB, tx)
+
 	dao.Logger.WithFields(logrus.Fields{
 		"project_id": projectID,
 		"user_id":    userID,
@@ -95,43 +122,45 @@ func (dao *RFIDao) CreateRFI(ctx context.Context, projectID, userID, orgID int64
 
 	// Validate project belongs to organization
 	var projectOrgID int64
-	err := dao.DB.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		SELECT org_id FROM project.projects
 		WHERE id = $1 AND is_deleted = FALSE
 	`, projectID).Scan(&projectOrgID)
 
 	if err == sql.ErrNoRows {
 		dao.Logger.WithField("project_id", projectID).Warn("Project not found")
-		return nil, fmt.Errorf("project not found")
+		return 0, notFoundError("project not found")
 	}
 	if err != nil {
 		dao.Logger.WithError(err).WithField("project_id", projectID).Error("Failed to validate project")
-		return nil, fmt.Errorf("failed to validate project: %w", err)
+		return 0, fmt.Errorf("failed to validate project: %w", err)
 	}
 	if projectOrgID != orgID {
 		dao.Logger.WithFields(logrus.Fields{
 			"project_org_id": projectOrgID,
 			"user_org_id":    orgID,
 		}).Warn("Project does not belong to user's organization")
-		return nil, fmt.Errorf("project does not belong to your organization")
+		return 0, orgMismatchError("project does not belong to your organization")
 	}
 
 	dao.Logger.Info("Project validation successful")
 
+	forInformation := req.ForInformation != nil && *req.ForInformation
+
 	// Determine status - default is DRAFT
 	status := models.RFIStatusDraft
 	if req.Status != "" {
-		status = req.Status
+		status = models.ResolveRFIStatus(req.Status, forInformation)
 	}
 
-	// Generate RFI number only if status is OPEN
+	// Generate RFI number only if status is OPEN (or DISTRIBUTED for for-information RFIs)
 	// DRAFT RFIs don't get a number until they're moved to OPEN
 	var rfiNumber *string
-	if status == models.RFIStatusOpen {
+	if status == models.RFIStatusOpen || status == models.RFIStatusDistributed {
 		generatedNumber, err := dao.GenerateRFINumber(ctx, projectID)
 		if err != nil {
 			dao.Logger.WithError(err).Error("Failed to generate RFI number")
-			return nil, fmt.Errorf("failed to generate RFI number: %w", err)
+			return 0, fmt.Errorf("failed to generate RFI number: %w", err)
 		}
 		rfiNumber = &generatedNumber
 		dao.Logger.WithField("rfi_number", generatedNumber).Info("Generated RFI number for OPEN status")
@@ -139,14 +168,21 @@ func (dao *RFIDao) CreateRFI(ctx context.Context, projectID, userID, orgID int64
 		dao.Logger.Info("DRAFT status - no RFI number generated")
 	}
 
-	// Parse due date
+	// Parse due date; for-information RFIs expect no answer so they carry no due date
 	var dueDate *time.Time
-	if req.DueDate != "" {
+	if req.DueDate != "" && !forInformation {
 		if parsedDate, err := time.Parse("2006-01-02", req.DueDate); err == nil {
 			dueDate = &parsedDate
 		}
 	}
 
+	var responseDueDate *time.Time
+	if req.ResponseDueDate != "" && !forInformation {
+		if parsedDate, err := time.Parse("2006-01-02", req.ResponseDueDate); err == nil {
+			responseDueDate = &parsedDate
+		}
+	}
+
 	// Handle assigned_to array
 	assignedTo := req.AssignedTo
 	if assignedTo == nil {
@@ -176,11 +212,11 @@ func (dao *RFIDao) CreateRFI(ctx context.Context, projectID, userID, orgID int64
 			distribution_list, due_date, cost_impact, schedule_impact,
 			cost_impact_amount, schedule_impact_days, location_description,
 			drawing_numbers, specification_sections, related_rfis,
-			created_by, updated_by
+			created_by, updated_by, for_information, response_due_date
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
 			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-			$21, $22, $23, $24, $25, $26
+			$21, $22, $23, $24, $25, $26, $27, $28
 		) RETURNING id, created_at, updated_at`
 
 	var rfiID int64
@@ -197,14 +233,14 @@ func (dao *RFIDao) CreateRFI(ctx context.Context, projectID, userID, orgID int64
 		"priority":    priority,
 	}).Info("Executing INSERT query")
 
-	err = dao.DB.QueryRowContext(ctx, query,
+	err = q.QueryRowContext(ctx, query,
 		projectID, orgID, req.LocationID, rfiNumber, req.Subject,
 		req.Description, req.Category, req.Discipline, req.ProjectPhase, priority,
 		status, receivedFrom, pq.Array(assignedTo), ballInCourt,
 		pq.Array(req.DistributionList), dueDate, req.CostImpact, req.ScheduleImpact,
 		req.CostImpactAmount, req.ScheduleImpactDays, req.LocationDescription,
 		pq.Array(req.DrawingNumbers), pq.Array(req.SpecificationSections), pq.Array(req.RelatedRFIs),
-		userID, userID,
+		userID, userID, forInformation, responseDueDate,
 	).Scan(&rfiID, &createdAt, &updatedAt)
 
 	if err != nil {
@@ -213,29 +249,54 @@ func (dao *RFIDao) CreateRFI(ctx context.Context, projectID, userID, orgID int64
 			"location_id": req.LocationID,
 			"sql_error":   err.Error(),
 		}).Error("Failed to execute INSERT query for RFI")
-		return nil, fmt.Errorf("failed to create RFI: %w", err)
+		return 0, fmt.Errorf("failed to create RFI: %w", err)
 	}
 
-	dao.Logger.WithField("rfi_id", rfiID).Info("RFI created successfully, fetching complete RFI data")
+	return rfiID, nil
+}
+
+// GetRFIByNumber retrieves an RFI by its human-readable number (e.g. RFI-2026-0007) within
+// a project in the organization. Matching is case-insensitive.
+func (dao *RFIDao) GetRFIByNumber(ctx context.Context, projectID, orgID int64, rfiNumber string) (*models.RFIResponse, error) {
+	var rfiID int64
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT id FROM project.rfis
+		WHERE project_id = $1 AND org_id = $2 AND rfi_number = $3 AND is_deleted = FALSE
+	`, projectID, orgID, strings.ToUpper(strings.TrimSpace(rfiNumber))).Scan(&rfiID)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("RFI not found")
+	}
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"rfi_number": rfiNumber,
+			"error":      err.Error(),
+		}).Error("Failed to look up RFI by number")
+		return nil, fmt.Errorf("failed to look up RFI by number: %w", err)
+	}
 
 	return dao.GetRFI(ctx, rfiID)
 }
 
 // GetRFI retrieves a single RFI by ID
 func (dao *RFIDao) GetRFI(ctx context.Context, rfiID int64) (*models.RFIResponse, error) {
+	defer util.TimeDB(ctx)()
+
 	query := `
 		SELECT
 			r.id, r.project_id, r.org_id, r.location_id, r.rfi_number,
 			r.subject, r.description, r.category, r.discipline,
-			r.project_phase, r.priority, r.status,
+			r.project_phase, r.priority, r.status, r.for_information,
 			r.received_from, r.assigned_to, r.ball_in_court,
 			r.distribution_list, r.due_date, r.closed_date,
+			r.response_due_date, r.responded_at,
 			r.cost_impact, r.schedule_impact, r.cost_impact_amount,
 			r.schedule_impact_days, r.location_description,
 			r.drawing_numbers, r.specification_sections, r.related_rfis,
-			r.created_at, r.created_by, r.updated_at, r.updated_by,
+			r.created_at, r.created_by, r.updated_at, r.updated_by, r.version,
 			p.name as project_name,
-			l.name as location_name
+			l.name as location_name,
+			` + agingColumnsSQL("r", "project.rfi_comments", "rfi_id") + `
 		FROM project.rfis r
 		LEFT JOIN project.projects p ON r.project_id = p.id
 		LEFT JOIN iam.locations l ON r.location_id = l.id
@@ -248,7 +309,7 @@ func (dao *RFIDao) GetRFI(ctx context.Context, rfiID int64) (*models.RFIResponse
 	var discipline, projectPhase, locationDesc sql.NullString
 	var costImpactAmount sql.NullFloat64
 	var scheduleImpactDays sql.NullInt32
-	var dueDate, closedDate *time.Time
+	var dueDate, closedDate, responseDueDate, respondedAt *time.Time
 	var receivedFromID, ballInCourtID sql.NullInt64
 	var assignedToIDs pq.Int64Array
 	var distributionList, drawingNumbers, specSections, relatedRFIs pq.StringArray
@@ -257,18 +318,20 @@ func (dao *RFIDao) GetRFI(ctx context.Context, rfiID int64) (*models.RFIResponse
 	err := dao.DB.QueryRowContext(ctx, query, rfiID).Scan(
 		&rfi.ID, &rfi.ProjectID, &rfi.OrgID, &locationID, &rfiNumber,
 		&rfi.Subject, &rfi.Description, &rfi.Category, &discipline,
-		&projectPhase, &rfi.Priority, &rfi.Status,
+		&projectPhase, &rfi.Priority, &rfi.Status, &rfi.ForInformation,
 		&receivedFromID, &assignedToIDs, &ballInCourtID,
 		&distributionList, &dueDate, &closedDate,
+		&responseDueDate, &respondedAt,
 		&rfi.CostImpact, &rfi.ScheduleImpact, &costImpactAmount,
 		&scheduleImpactDays, &locationDesc,
 		&drawingNumbers, &specSections, &relatedRFIs,
-		&rfi.CreatedAt, &createdByID, &rfi.UpdatedAt, &updatedByID,
+		&rfi.CreatedAt, &createdByID, &rfi.UpdatedAt, &updatedByID, &rfi.Version,
 		&rfi.ProjectName, &locationName,
+		&rfi.AgeDays, &rfi.DaysSinceLastActivity,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("RFI not found")
+		return nil, notFoundError("RFI not found")
 	}
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to get RFI")
@@ -304,6 +367,10 @@ func (dao *RFIDao) GetRFI(ctx context.Context, rfiID int64) (*models.RFIResponse
 
 	rfi.DueDate = dueDate
 	rfi.ClosedDate = closedDate
+	rfi.IsOverdue = models.IsRFIOverdue(rfi.Status, rfi.ForInformation, dueDate, time.Now().UTC())
+	rfi.ResponseDueDate = responseDueDate
+	rfi.RespondedAt = respondedAt
+	rfi.SLABreached = models.IsRFISLABreached(respondedAt, responseDueDate, time.Now().UTC())
 	rfi.DistributionList = []string(distributionList)
 	rfi.DrawingNumbers = []string(drawingNumbers)
 	rfi.SpecificationSections = []string(specSections)
@@ -359,39 +426,53 @@ func (dao *RFIDao) GetRFI(ctx context.Context, rfiID int64) (*models.RFIResponse
 	return &rfi, nil
 }
 
-// GetRFIsByProject retrieves all RFIs for a specific project with optional filters
-func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filters map[string]string) ([]models.RFIResponse, error) {
-	query := `
+// rfiSearchVectorSQL is the document searched by q=; it must match the expression of idx_rfis_search
+const rfiSearchVectorSQL = `to_tsvector('english', coalesce(r.subject, '') || ' ' || coalesce(r.description, '') || ' ' || coalesce(r.rfi_number, ''))`
+
+// rfiMinFullTextTokenLength is the shortest search word the full-text match handles well; shorter words
+// (drawing refs like "A1", stop words) fall back to substring matching
+const rfiMinFullTextTokenLength = 3
+
+// rfiListSelectSQL selects the columns scanned by scanRFIListRows; callers append the WHERE clause
+var rfiListSelectSQL = `
 		SELECT
 			r.id, r.project_id, r.org_id, r.location_id, r.rfi_number,
 			r.subject, r.description, r.category, r.discipline,
-			r.project_phase, r.priority, r.status,
+			r.project_phase, r.priority, r.status, r.for_information,
 			r.received_from, r.assigned_to, r.ball_in_court,
 			r.distribution_list, r.due_date, r.closed_date,
+			r.response_due_date, r.responded_at,
 			r.cost_impact, r.schedule_impact, r.cost_impact_amount,
 			r.schedule_impact_days, r.location_description,
 			r.drawing_numbers, r.specification_sections, r.related_rfis,
-			r.created_at, r.created_by, r.updated_at, r.updated_by,
+			r.created_at, r.created_by, r.updated_at, r.updated_by, r.version,
 			p.name as project_name,
-			l.name as location_name
+			l.name as location_name,
+			` + agingColumnsSQL("r", "project.rfi_comments", "rfi_id") + `
 		FROM project.rfis r
 		LEFT JOIN project.projects p ON r.project_id = p.id
-		LEFT JOIN iam.locations l ON r.location_id = l.id
+		LEFT JOIN iam.locations l ON r.location_id = l.id`
+
+// GetRFIsByProject retrieves all RFIs for a specific project with optional filters
+func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filters map[string]string) ([]models.RFIResponse, error) {
+	defer util.TimeDB(ctx)()
+
+	query := rfiListSelectSQL + `
 		WHERE r.project_id = $1 AND r.is_deleted = FALSE`
 
 	args := []interface{}{projectID}
 	argIndex := 2
 
-	// Add filters
+	// Add filters (status and priority accept comma-separated lists)
 	if status, ok := filters["status"]; ok && status != "" {
-		query += fmt.Sprintf(" AND r.status = $%d", argIndex)
-		args = append(args, status)
+		query += fmt.Sprintf(" AND r.status = ANY($%d)", argIndex)
+		args = append(args, pq.Array(strings.Split(status, ",")))
 		argIndex++
 	}
 
 	if priority, ok := filters["priority"]; ok && priority != "" {
-		query += fmt.Sprintf(" AND r.priority = $%d", argIndex)
-		args = append(args, priority)
+		query += fmt.Sprintf(" AND r.priority = ANY($%d)", argIndex)
+		args = append(args, pq.Array(strings.Split(priority, ",")))
 		argIndex++
 	}
 
@@ -407,7 +488,36 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 		argIndex++
 	}
 
-	query += " ORDER BY r.created_at DESC"
+	// q= searches subject, description and rfi_number; rfi_number is always substring-matched
+	// because numbers like RFI-0012 tokenize poorly
+	search := strings.TrimSpace(filters["q"])
+	if search != "" {
+		fullText := true
+		for _, word := range strings.Fields(search) {
+			if len([]rune(word)) < rfiMinFullTextTokenLength {
+				fullText = false
+				break
+			}
+		}
+
+		// Escape LIKE wildcards so user input is matched literally
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(search)
+		if fullText {
+			query += fmt.Sprintf(" AND (%s @@ plainto_tsquery('english', $%d) OR r.rfi_number ILIKE $%d)", rfiSearchVectorSQL, argIndex, argIndex+1)
+		} else {
+			query += fmt.Sprintf(" AND (r.subject ILIKE $%d OR r.description ILIKE $%d OR r.rfi_number ILIKE $%d)", argIndex+1, argIndex+1, argIndex+1)
+		}
+		args = append(args, search, "%"+escaped+"%")
+		argIndex += 2
+	}
+
+	// sort=created_at|age_days|days_since_last_activity, order=asc|desc; searches rank by relevance first
+	orderBy := agingOrderBySQL(filters, "r")
+	if search != "" {
+		orderBy = fmt.Sprintf(" ORDER BY ts_rank(%s, plainto_tsquery('english', $%d)) DESC, %s",
+			rfiSearchVectorSQL, argIndex-2, strings.TrimPrefix(orderBy, " ORDER BY "))
+	}
+	query += orderBy
 
 	rows, err := dao.DB.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -416,6 +526,73 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 	}
 	defer rows.Close()
 
+	return dao.scanRFIListRows(ctx, rows)
+}
+
+// GetRFIsAssignedToUser lists the RFIs in the org that have the user among their assignees, across
+// all projects. Filters: status (comma-separated), due_before and due_after (YYYY-MM-DD, inclusive).
+// Results are ordered by due date, soonest first, with undated RFIs last.
+func (dao *RFIDao) GetRFIsAssignedToUser(ctx context.Context, userID, orgID int64, filters map[string]string) ([]models.RFIResponse, error) {
+	defer util.TimeDB(ctx)()
+	query := rfiListSelectSQL + `
+		WHERE r.org_id = $1 AND $2 = ANY(r.assigned_to) AND r.is_deleted = FALSE
+		  AND p.is_deleted = FALSE`
+
+	args := []interface{}{orgID, userID}
+	argIndex := 3
+
+	if status, ok := filters["status"]; ok && status != "" {
+		query += fmt.Sprintf(" AND r.status = ANY($%d)", argIndex)
+		args = append(args, pq.Array(strings.Split(status, ",")))
+		argIndex++
+	}
+
+	if dueBefore, ok := filters["due_before"]; ok && dueBefore != "" {
+		query += fmt.Sprintf(" AND r.due_date::date <= $%d", argIndex)
+		args = append(args, dueBefore)
+		argIndex++
+	}
+
+	if dueAfter, ok := filters["due_after"]; ok && dueAfter != "" {
+		query += fmt.Sprintf(" AND r.due_date::date >= $%d", argIndex)
+		args = append(args, dueAfter)
+		argIndex++
+	}
+
+	query += " ORDER BY r.due_date ASC NULLS LAST, r.created_at DESC, r.id DESC"
+
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to query assigned RFIs")
+		return nil, fmt.Errorf("failed to query RFIs: %w", err)
+	}
+	defer rows.Close()
+
+	return dao.scanRFIListRows(ctx, rows)
+}
+
+// GetSLABreachedRFIs lists the project's RFIs that have no response and whose response due date
+// has passed, most overdue first.
+func (dao *RFIDao) GetSLABreachedRFIs(ctx context.Context, projectID, orgID int64) ([]models.RFIResponse, error) {
+	defer util.TimeDB(ctx)()
+	query := rfiListSelectSQL + `
+		WHERE r.project_id = $1 AND r.org_id = $2 AND r.is_deleted = FALSE
+		  AND r.responded_at IS NULL
+		  AND r.response_due_date < (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')::date
+		ORDER BY r.response_due_date ASC, r.id ASC`
+
+	rows, err := dao.DB.QueryContext(ctx, query, projectID, orgID)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to query SLA-breached RFIs")
+		return nil, fmt.Errorf("failed to query RFIs: %w", err)
+	}
+	defer rows.Close()
+
+	return dao.scanRFIListRows(ctx, rows)
+}
+
+// scanRFIListRows scans rows selected with rfiListSelectSQL and fills in users, attachments and comments
+func (dao *RFIDao) scanRFIListRows(ctx context.Context, rows *sql.Rows) ([]models.RFIResponse, error) {
 	var rfis []models.RFIResponse
 	for rows.Next() {
 		var rfi models.RFIResponse
@@ -425,7 +602,7 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 		var discipline, projectPhase, locationDesc sql.NullString
 		var costImpactAmount sql.NullFloat64
 		var scheduleImpactDays sql.NullInt32
-		var dueDate, closedDate *time.Time
+		var dueDate, closedDate, responseDueDate, respondedAt *time.Time
 		var receivedFromID, ballInCourtID sql.NullInt64
 		var assignedToIDs pq.Int64Array
 		var distributionList, drawingNumbers, specSections, relatedRFIs pq.StringArray
@@ -434,14 +611,16 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 		err := rows.Scan(
 			&rfi.ID, &rfi.ProjectID, &rfi.OrgID, &locationID, &rfiNumber,
 			&rfi.Subject, &rfi.Description, &rfi.Category, &discipline,
-			&projectPhase, &rfi.Priority, &rfi.Status,
+			&projectPhase, &rfi.Priority, &rfi.Status, &rfi.ForInformation,
 			&receivedFromID, &assignedToIDs, &ballInCourtID,
 			&distributionList, &dueDate, &closedDate,
+			&responseDueDate, &respondedAt,
 			&rfi.CostImpact, &rfi.ScheduleImpact, &costImpactAmount,
 			&scheduleImpactDays, &locationDesc,
 			&drawingNumbers, &specSections, &relatedRFIs,
-			&rfi.CreatedAt, &createdByID, &rfi.UpdatedAt, &updatedByID,
+			&rfi.CreatedAt, &createdByID, &rfi.UpdatedAt, &updatedByID, &rfi.Version,
 			&rfi.ProjectName, &locationName,
+			&rfi.AgeDays, &rfi.DaysSinceLastActivity,
 		)
 
 		if err != nil {
@@ -478,6 +657,10 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 
 		rfi.DueDate = dueDate
 		rfi.ClosedDate = closedDate
+		rfi.IsOverdue = models.IsRFIOverdue(rfi.Status, rfi.ForInformation, dueDate, time.Now().UTC())
+		rfi.ResponseDueDate = responseDueDate
+		rfi.RespondedAt = respondedAt
+		rfi.SLABreached = models.IsRFISLABreached(respondedAt, responseDueDate, time.Now().UTC())
 		rfi.DistributionList = []string(distributionList)
 		rfi.DrawingNumbers = []string(drawingNumbers)
 		rfi.SpecificationSections = []string(specSections)
@@ -527,7 +710,7 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 		rfis = append(rfis, rfi)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		dao.Logger.WithError(err).Error("Error iterating RFI rows")
 		return nil, fmt.Errorf("error iterating RFIs: %w", err)
 	}
@@ -537,13 +720,17 @@ func (dao *RFIDao) GetRFIsByProject(ctx context.Context, projectID int64, filter
 
 // UpdateRFI updates an existing RFI
 func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, req *models.UpdateRFIRequest) (*models.RFIResponse, error) {
+	defer util.TimeDB(ctx)()
 	// First check if RFI exists and belongs to org
 	rfi, err := dao.GetRFI(ctx, rfiID)
 	if err != nil {
 		return nil, err
 	}
 	if rfi.OrgID != orgID {
-		return nil, fmt.Errorf("RFI does not belong to your organization")
+		return nil, orgMismatchError("RFI does not belong to your organization")
+	}
+	if req.Version != nil && *req.Version != rfi.Version {
+		return nil, &models.RFIVersionConflictError{CurrentVersion: rfi.Version}
 	}
 
 	var setClauses []string
@@ -575,13 +762,31 @@ func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, re
 		argIndex++
 	}
 
-	if req.Status != "" {
+	// Switching an RFI to or from for-information moves an issued RFI between OPEN and DISTRIBUTED
+	forInformation := rfi.ForInformation
+	status := req.Status
+	if req.ForInformation != nil && *req.ForInformation != rfi.ForInformation {
+		forInformation = *req.ForInformation
+		setClauses = append(setClauses, fmt.Sprintf("for_information = $%d", argIndex))
+		args = append(args, forInformation)
+		argIndex++
+
+		if forInformation {
+			setClauses = append(setClauses, "due_date = NULL", "response_due_date = NULL")
+		}
+		if status == "" && (rfi.Status == models.RFIStatusOpen || rfi.Status == models.RFIStatusDistributed) {
+			status = rfi.Status
+		}
+	}
+
+	if status != "" {
+		status = models.ResolveRFIStatus(status, forInformation)
 		setClauses = append(setClauses, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, req.Status)
+		args = append(args, status)
 		argIndex++
 
-		// Generate RFI number when transitioning from DRAFT to OPEN
-		if req.Status == models.RFIStatusOpen && (rfi.RFINumber == nil || *rfi.RFINumber == "") {
+		// Generate RFI number when transitioning from DRAFT to OPEN (or DISTRIBUTED)
+		if (status == models.RFIStatusOpen || status == models.RFIStatusDistributed) && (rfi.RFINumber == nil || *rfi.RFINumber == "") {
 			generatedNumber, err := dao.GenerateRFINumber(ctx, rfi.ProjectID)
 			if err != nil {
 				dao.Logger.WithError(err).Error("Failed to generate RFI number during status change")
@@ -590,14 +795,16 @@ func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, re
 			setClauses = append(setClauses, fmt.Sprintf("rfi_number = $%d", argIndex))
 			args = append(args, generatedNumber)
 			argIndex++
+			rfi.RFINumber = &generatedNumber
 			dao.Logger.WithField("rfi_number", generatedNumber).Info("Generated RFI number when changing status from DRAFT to OPEN")
 		}
 
-		// Set closed_date when status changes to CLOSE
-		if req.Status == models.RFIStatusClose {
+		// Set closed_date when status changes to CLOSE; responded_at keeps the first close across reopenings
+		if status == models.RFIStatusClose {
 			setClauses = append(setClauses, fmt.Sprintf("closed_date = $%d", argIndex))
 			args = append(args, time.Now())
 			argIndex++
+			setClauses = append(setClauses, "responded_at = COALESCE(responded_at, CURRENT_TIMESTAMP)")
 		}
 	}
 
@@ -631,7 +838,7 @@ func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, re
 		argIndex++
 	}
 
-	if req.DueDate != "" {
+	if req.DueDate != "" && !forInformation {
 		if parsedDate, err := time.Parse("2006-01-02", req.DueDate); err == nil {
 			setClauses = append(setClauses, fmt.Sprintf("due_date = $%d", argIndex))
 			args = append(args, parsedDate)
@@ -639,6 +846,14 @@ func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, re
 		}
 	}
 
+	if req.ResponseDueDate != "" && !forInformation {
+		if parsedDate, err := time.Parse("2006-01-02", req.ResponseDueDate); err == nil {
+			setClauses = append(setClauses, fmt.Sprintf("response_due_date = $%d", argIndex))
+			args = append(args, parsedDate)
+			argIndex++
+		}
+	}
+
 	if req.DistributionList != nil {
 		setClauses = append(setClauses, fmt.Sprintf("distribution_list = $%d", argIndex))
 		args = append(args, pq.Array(req.DistributionList))
@@ -702,16 +917,48 @@ func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, re
 	args = append(args, time.Now())
 	argIndex++
 
+	setClauses = append(setClauses, "version = version + 1")
+
 	// Add WHERE clause parameters
 	args = append(args, rfiID)
+	where := fmt.Sprintf("id = $%d AND is_deleted = FALSE", argIndex)
+	argIndex++
+
+	// Guard against a concurrent update landing between the read above and this write
+	if req.Version != nil {
+		where += fmt.Sprintf(" AND version = $%d", argIndex)
+		args = append(args, *req.Version)
+	}
 
 	query := fmt.Sprintf(`
 		UPDATE project.rfis
 		SET %s
-		WHERE id = $%d AND is_deleted = FALSE
-	`, strings.Join(setClauses, ", "), argIndex)
+		WHERE %s
+	`, strings.Join(setClauses, ", "), where)
+
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Read the stored assignees under lock so the notification reflects the row this update replaces
+	var previousAssignees pq.Int64Array
+	if req.AssignedTo != nil {
+		err = tx.QueryRowContext(ctx, `
+			SELECT assigned_to FROM project.rfis
+			WHERE id = $1 AND is_deleted = FALSE
+			FOR UPDATE
+		`, rfiID).Scan(&previousAssignees)
+		if err == sql.ErrNoRows {
+			return nil, notFoundError("RFI not found or no changes made")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RFI assignees: %w", err)
+		}
+	}
 
-	result, err := dao.DB.ExecContext(ctx, query, args...)
+	result, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		dao.Logger.WithError(err).Error("Failed to update RFI")
 		return nil, fmt.Errorf("failed to update RFI: %w", err)
@@ -719,14 +966,78 @@ func (dao *RFIDao) UpdateRFI(ctx context.Context, rfiID, userID, orgID int64, re
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("RFI not found or no changes made")
+		tx.Rollback()
+		if req.Version != nil {
+			if current, err := dao.GetRFI(ctx, rfiID); err == nil {
+				return nil, &models.RFIVersionConflictError{CurrentVersion: current.Version}
+			}
+		}
+		return nil, notFoundError("RFI not found or no changes made")
+	}
+
+	// Reassigning to the same set of users is not a change and records no notification
+	var assigneeChange *models.RFIAssigneeChangedEvent
+	if req.AssignedTo != nil && !sameAssignees(previousAssignees, req.AssignedTo) {
+		assigneeChange = &models.RFIAssigneeChangedEvent{
+			EventType:        models.RFIEventAssigneeChanged,
+			RFIID:            rfiID,
+			ProjectID:        rfi.ProjectID,
+			OrgID:            rfi.OrgID,
+			PreviousAssignee: []int64(previousAssignees),
+			NewAssignee:      req.AssignedTo,
+			ChangedBy:        userID,
+			Timestamp:        time.Now().UTC(),
+		}
+		if rfi.RFINumber != nil {
+			assigneeChange.RFINumber = *rfi.RFINumber
+		}
+		if assigneeChange.PreviousAssignee == nil {
+			assigneeChange.PreviousAssignee = []int64{}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO project.rfi_notifications (
+				rfi_id, org_id, event_type, rfi_number, previous_assignees, new_assignees, changed_by, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, rfiID, rfi.OrgID, assigneeChange.EventType, rfi.RFINumber,
+			pq.Array(assigneeChange.PreviousAssignee), pq.Array(assigneeChange.NewAssignee), userID, assigneeChange.Timestamp)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to record RFI assignee notification")
+			return nil, fmt.Errorf("failed to record RFI notification: %w", err)
+		}
 	}
 
-	return dao.GetRFI(ctx, rfiID)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	updated, err := dao.GetRFI(ctx, rfiID)
+	if err != nil {
+		return nil, err
+	}
+	updated.AssigneeChange = assigneeChange
+	return updated, nil
+}
+
+// sameAssignees reports whether both lists name the same users, ignoring order and duplicates
+func sameAssignees(previous, next []int64) bool {
+	seen := make(map[int64]bool, len(previous))
+	for _, id := range previous {
+		seen[id] = true
+	}
+	nextSet := make(map[int64]bool, len(next))
+	for _, id := range next {
+		if !seen[id] {
+			return false
+		}
+		nextSet[id] = true
+	}
+	return len(nextSet) == len(seen)
 }
 
 // DeleteRFI soft deletes an RFI
 func (dao *RFIDao) DeleteRFI(ctx context.Context, rfiID int64, deletedBy int64) error {
+	defer util.TimeDB(ctx)()
 	query := `
 		UPDATE project.rfis
 		SET is_deleted = TRUE, updated_by = $1, updated_at = $2
@@ -740,7 +1051,7 @@ func (dao *RFIDao) DeleteRFI(ctx context.Context, rfiID int64, deletedBy int64)
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("RFI not found")
+		return notFoundError("RFI not found")
 	}
 
 	return nil
@@ -748,15 +1059,45 @@ func (dao *RFIDao) DeleteRFI(ctx context.Context, rfiID int64, deletedBy int64)
 
 // AddRFIComment adds a comment to an RFI with optional attachments
 func (dao *RFIDao) AddRFIComment(ctx context.Context, rfiID, userID int64, req *models.CreateRFICommentRequest) (*models.RFIComment, error) {
+	defer util.TimeDB(ctx)()
 	var comment models.RFIComment
 
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Re-check RFI state inside the transaction. FOR SHARE blocks a concurrent delete until
+	// this comment commits. Comments on closed RFIs are allowed for the record; deleted RFIs are not.
+	var isDeleted bool
+	var orgID int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT is_deleted, org_id FROM project.rfis
+		WHERE id = $1
+		FOR SHARE
+	`, rfiID).Scan(&isDeleted, &orgID)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("RFI not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check RFI state: %w", err)
+	}
+	if isDeleted {
+		dao.Logger.WithFields(logrus.Fields{
+			"rfi_id":  rfiID,
+			"user_id": userID,
+		}).Warn("Rejected comment on deleted RFI")
+		return nil, conflictError("RFI has been deleted")
+	}
+
 	query := `
 		INSERT INTO project.rfi_comments (
 			rfi_id, comment, comment_type, created_by, updated_by
 		) VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, rfi_id, comment, comment_type, created_at, created_by, updated_at, updated_by, is_deleted`
 
-	err := dao.DB.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		rfiID, req.Comment, models.RFICommentTypeComment,
 		userID, userID,
 	).Scan(
@@ -772,7 +1113,7 @@ func (dao *RFIDao) AddRFIComment(ctx context.Context, rfiID, userID int64, req *
 
 	// Link attachments if provided
 	if len(req.AttachmentIDs) > 0 {
-		_, err = dao.DB.ExecContext(ctx, `
+		_, err = tx.ExecContext(ctx, `
 			UPDATE project.rfi_comment_attachments
 			SET comment_id = $1, updated_by = $2, updated_at = NOW()
 			WHERE id = ANY($3)
@@ -781,8 +1122,26 @@ func (dao *RFIDao) AddRFIComment(ctx context.Context, rfiID, userID int64, req *
 			AND is_deleted = FALSE
 		`, comment.ID, userID, pq.Array(req.AttachmentIDs))
 
+		// A failed statement aborts the transaction, so a link failure fails the whole comment
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to link attachments to RFI comment")
+			return nil, fmt.Errorf("failed to link attachments to RFI comment: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		dao.Logger.WithError(err).Error("Failed to commit RFI comment transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Record the users mentioned in the comment after it is saved, so a failure here does not fail the
+	// comment; mentions of users outside the org are dropped
+	if tokens := util.ParseMentions(req.Comment); len(tokens) > 0 {
+		mentions, err := dao.recordRFICommentMentions(ctx, orgID, comment.ID, tokens)
 		if err != nil {
-			dao.Logger.WithError(err).Warn("Failed to link attachments to RFI comment")
+			dao.Logger.WithError(err).WithField("comment_id", comment.ID).Error("Failed to record comment mentions")
+		} else {
+			comment.Mentions = mentions
 		}
 	}
 
@@ -804,8 +1163,21 @@ func (dao *RFIDao) AddRFIComment(ctx context.Context, rfiID, userID int64, req *
 	return &comment, nil
 }
 
+// recordRFICommentMentions resolves mention tokens against the RFI's organization and stores them
+func (dao *RFIDao) recordRFICommentMentions(ctx context.Context, orgID, commentID int64, tokens []util.MentionToken) ([]models.CommentMention, error) {
+	mentions, err := resolveCommentMentions(ctx, dao.DB, dao.Logger, orgID, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordCommentMentions(ctx, dao.DB, models.EntityTypeRFIComment, commentID, mentions); err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
 // GetRFIComments retrieves all comments for an RFI with attachments
 func (dao *RFIDao) GetRFIComments(ctx context.Context, rfiID int64) ([]models.RFIComment, error) {
+	defer util.TimeDB(ctx)()
 	query := `
 		SELECT
 			c.id, c.rfi_id, c.comment, c.comment_type,
@@ -843,11 +1215,24 @@ func (dao *RFIDao) GetRFIComments(ctx context.Context, rfiID int64) ([]models.RF
 		comments = append(comments, comment)
 	}
 
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	mentions, err := getCommentMentions(ctx, dao.DB, models.EntityTypeRFIComment, commentIDs)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("rfi_id", rfiID).Warn("Failed to get comment mentions")
+	}
+	for i := range comments {
+		comments[i].Mentions = mentions[comments[i].ID]
+	}
+
 	return comments, nil
 }
 
 // AddRFIAttachment adds an attachment to an RFI
 func (dao *RFIDao) AddRFIAttachment(ctx context.Context, attachment *models.RFIAttachment) (*models.RFIAttachment, error) {
+	defer util.TimeDB(ctx)()
 	query := `
 		INSERT INTO project.rfi_attachments (
 			rfi_id, file_name, file_path, file_type, file_size,
@@ -878,10 +1263,10 @@ func (dao *RFIDao) GetRFIAttachments(ctx context.Context, rfiID int64) ([]models
 		SELECT
 			id, rfi_id, file_name, file_path, file_type, file_size,
 			description, s3_bucket, s3_key, s3_url, attachment_type,
-			uploaded_by, upload_date, created_at, created_by,
+			uploaded_by, scan_status, upload_date, created_at, created_by,
 			updated_at, updated_by
 		FROM project.rfi_attachments
-		WHERE rfi_id = $1 AND is_deleted = FALSE
+		WHERE rfi_id = $1 AND is_deleted = FALSE AND upload_status = 'confirmed'
 		ORDER BY created_at DESC`
 
 	rows, err := dao.DB.QueryContext(ctx, query, rfiID)
@@ -897,7 +1282,7 @@ func (dao *RFIDao) GetRFIAttachments(ctx context.Context, rfiID int64) ([]models
 			&att.ID, &att.RFIID, &att.FileName, &att.FilePath,
 			&att.FileType, &att.FileSize, &att.Description,
 			&att.S3Bucket, &att.S3Key, &att.S3URL, &att.AttachmentType,
-			&att.UploadedBy, &att.UploadDate, &att.CreatedAt,
+			&att.UploadedBy, &att.ScanStatus, &att.UploadDate, &att.CreatedAt,
 			&att.CreatedBy, &att.UpdatedAt, &att.UpdatedBy,
 		)
 		if err != nil {
@@ -938,14 +1323,111 @@ func (dao *RFIDao) GenerateRFINumber(ctx context.Context, projectID int64) (stri
 	return fmt.Sprintf("RFI-%d-%04d", year, nextNumber), nil
 }
 
+// GetRFIExport retrieves the full RFI log for a project with nested comments.
+// RFIs and their comments are loaded in a single joined query to avoid a per-RFI comment lookup.
+func (dao *RFIDao) GetRFIExport(ctx context.Context, projectID, orgID int64) ([]models.RFIExportItem, error) {
+	query := `
+		SELECT
+			r.id, COALESCE(r.rfi_number, ''), r.subject, r.description,
+			r.category, r.priority, r.status,
+			COALESCE(l.name, ''),
+			COALESCE((
+				SELECT string_agg(CONCAT(au.first_name, ' ', au.last_name), '; ')
+				FROM iam.users au
+				WHERE au.id = ANY(r.assigned_to)
+			), ''),
+			r.due_date, r.closed_date, r.created_at,
+			CONCAT(cu.first_name, ' ', cu.last_name),
+			c.id, c.comment, c.comment_type, c.previous_value, c.new_value,
+			c.created_at, c.created_by,
+			CONCAT(u.first_name, ' ', u.last_name),
+			c.updated_at, c.updated_by
+		FROM project.rfis r
+		LEFT JOIN iam.locations l ON r.location_id = l.id
+		LEFT JOIN iam.users cu ON r.created_by = cu.id
+		LEFT JOIN project.rfi_comments c ON c.rfi_id = r.id AND c.is_deleted = FALSE
+		LEFT JOIN iam.users u ON c.created_by = u.id
+		WHERE r.project_id = $1 AND r.org_id = $2 AND r.is_deleted = FALSE
+		ORDER BY r.created_at ASC, r.id ASC, c.created_at ASC`
+
+	rows, err := dao.DB.QueryContext(ctx, query, projectID, orgID)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to query RFI export")
+		return nil, fmt.Errorf("failed to query RFI export: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.RFIExportItem{}
+	for rows.Next() {
+		var item models.RFIExportItem
+		var dueDate, closedDate *time.Time
+		var commentID, commentCreatedBy, commentUpdatedBy sql.NullInt64
+		var commentText, commentType, previousValue, newValue, commentCreatedByName sql.NullString
+		var commentCreatedAt, commentUpdatedAt sql.NullTime
+
+		err := rows.Scan(
+			&item.ID, &item.RFINumber, &item.Subject, &item.Description,
+			&item.Category, &item.Priority, &item.Status,
+			&item.LocationName, &item.AssignedTo,
+			&dueDate, &closedDate, &item.CreatedAt, &item.CreatedByName,
+			&commentID, &commentText, &commentType, &previousValue, &newValue,
+			&commentCreatedAt, &commentCreatedBy, &commentCreatedByName,
+			&commentUpdatedAt, &commentUpdatedBy,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan RFI export row")
+			return nil, fmt.Errorf("failed to scan RFI export row: %w", err)
+		}
+
+		// Rows arrive grouped by RFI; start a new item when the RFI changes
+		if len(items) == 0 || items[len(items)-1].ID != item.ID {
+			item.DueDate = dueDate
+			item.ClosedDate = closedDate
+			item.Comments = []models.RFIComment{}
+			items = append(items, item)
+		}
+
+		if !commentID.Valid {
+			continue
+		}
+
+		current := &items[len(items)-1]
+		current.Comments = append(current.Comments, models.RFIComment{
+			ID:            commentID.Int64,
+			RFIID:         current.ID,
+			Comment:       commentText.String,
+			CommentType:   commentType.String,
+			PreviousValue: previousValue.String,
+			NewValue:      newValue.String,
+			Attachments:   []models.RFICommentAttachment{},
+			CreatedAt:     commentCreatedAt.Time,
+			CreatedBy:     commentCreatedBy.Int64,
+			CreatedByName: commentCreatedByName.String,
+			UpdatedAt:     commentUpdatedAt.Time,
+			UpdatedBy:     commentUpdatedBy.Int64,
+		})
+		current.CommentCount++
+		if commentType.String == models.RFICommentTypeComment {
+			current.OfficialResponse = commentText.String
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		dao.Logger.WithError(err).Error("Error iterating RFI export rows")
+		return nil, fmt.Errorf("error iterating RFI export rows: %w", err)
+	}
+
+	return items, nil
+}
+
 // getRFICommentAttachments retrieves all attachments for a specific comment
 func (dao *RFIDao) getRFICommentAttachments(ctx context.Context, commentID int64) []models.RFICommentAttachment {
 	query := `
 		SELECT id, comment_id, file_name, file_path, file_size, file_type,
-		       attachment_type, uploaded_by, created_at, created_by,
+		       attachment_type, uploaded_by, scan_status, created_at, created_by,
 		       updated_at, updated_by, is_deleted
 		FROM project.rfi_comment_attachments
-		WHERE comment_id = $1 AND is_deleted = FALSE
+		WHERE comment_id = $1 AND is_deleted = FALSE AND upload_status = 'confirmed'
 		ORDER BY created_at ASC`
 
 	rows, err := dao.DB.QueryContext(ctx, query, commentID)
@@ -964,7 +1446,7 @@ func (dao *RFIDao) getRFICommentAttachments(ctx context.Context, commentID int64
 		err := rows.Scan(
 			&att.ID, &att.CommentID, &att.FileName, &att.FilePath,
 			&fileSize, &fileType, &att.AttachmentType,
-			&att.UploadedBy, &att.CreatedAt, &att.CreatedBy,
+			&att.UploadedBy, &att.ScanStatus, &att.CreatedAt, &att.CreatedBy,
 			&att.UpdatedAt, &att.UpdatedBy, &att.IsDeleted,
 		)
 
@@ -989,3 +1471,186 @@ func (dao *RFIDao) getRFICommentAttachments(ctx context.Context, commentID int64
 
 	return attachments
 }
+
+// CopyRFI duplicates an RFI into another project of the same organization as a new DRAFT.
+// Like any draft it gets an RFI number when it is opened. Assignees, distribution list,
+// location and related RFIs are project-specific and are not carried over.
+func (dao *RFIDao) CopyRFI(ctx context.Context, rfiID, targetProjectID, userID, orgID int64, copyAttachments bool) (*models.RFIResponse, error) {
+	defer util.TimeDB(ctx)()
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to start transaction for RFI copy")
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	targetLocationID, err := validateCopyProjects(ctx, tx, "project.rfis", "RFI", rfiID, targetProjectID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var newRFIID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO project.rfis (
+			project_id, org_id, location_id, subject,
+			description, category, discipline, project_phase, priority,
+			status, due_date, cost_impact, schedule_impact,
+			cost_impact_amount, schedule_impact_days,
+			drawing_numbers, specification_sections,
+			created_by, updated_by, for_information
+		)
+		SELECT
+			$1, org_id, $2, subject,
+			description, category, discipline, project_phase, priority,
+			$3, due_date, cost_impact, schedule_impact,
+			cost_impact_amount, schedule_impact_days,
+			drawing_numbers, specification_sections,
+			$4, $4, for_information
+		FROM project.rfis
+		WHERE id = $5
+		RETURNING id
+	`, targetProjectID, targetLocationID, models.RFIStatusDraft, userID, rfiID).Scan(&newRFIID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"rfi_id":            rfiID,
+			"target_project_id": targetProjectID,
+		}).Error("Failed to copy RFI")
+		return nil, fmt.Errorf("failed to copy RFI: %w", err)
+	}
+
+	if copyAttachments {
+		if err := copyEntityAttachments(ctx, tx, models.EntityTypeRFI, rfiID, newRFIID, userID); err != nil {
+			dao.Logger.WithError(err).WithField("rfi_id", rfiID).Error("Failed to copy RFI attachments")
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		dao.Logger.WithError(err).Error("Failed to commit RFI copy transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"source_rfi_id":     rfiID,
+		"rfi_id":            newRFIID,
+		"target_project_id": targetProjectID,
+		"user_id":           userID,
+	}).Info("RFI copied successfully")
+
+	return dao.GetRFI(ctx, newRFIID)
+}
+
+// RecountRFICounts recomputes attachment_count and comment_count on RFIs from their source tables
+func (dao *RFIDao) RecountRFICounts(ctx context.Context, orgID, projectID int64) (models.RecountResult, error) {
+	return recountEntityCounts(ctx, dao.DB, dao.Logger, models.EntityTypeRFI, "project.rfis", orgID, projectID, []countSource{
+		{column: "attachment_count", childTable: "project.rfi_attachments", childFK: "rfi_id"},
+		{column: "comment_count", childTable: "project.rfi_comments", childFK: "rfi_id"},
+	})
+}
+
+// GetStaleAnsweredRFIs returns open RFIs that have been answered and have had no new comments for
+// longer than their organization's rfi_auto_close_days (0 disables auto-close for the org).
+// An RFI counts as answered once it has a response comment or a comment from one of its assignees.
+// For-information RFIs expect no answer and are never returned. Oldest activity comes first.
+func (dao *RFIDao) GetStaleAnsweredRFIs(ctx context.Context, limit int) ([]models.StaleRFI, error) {
+	query := `
+		SELECT r.id, r.project_id, r.org_id, r.rfi_number, r.created_by,
+		       o.rfi_auto_close_days, activity.last_activity_at
+		FROM project.rfis r
+		JOIN iam.organizations o ON o.id = r.org_id AND o.is_deleted = FALSE
+		JOIN LATERAL (
+			SELECT MAX(c.created_at) AS last_activity_at,
+			       BOOL_OR(c.comment_type = 'response'
+			               OR (c.comment_type = $1 AND c.created_by = ANY(r.assigned_to))) AS answered
+			FROM project.rfi_comments c
+			WHERE c.rfi_id = r.id AND c.is_deleted = FALSE
+		) activity ON TRUE
+		WHERE r.status = $2
+		  AND r.for_information = FALSE
+		  AND r.is_deleted = FALSE
+		  AND o.rfi_auto_close_days > 0
+		  AND activity.answered
+		  AND activity.last_activity_at < CURRENT_TIMESTAMP - make_interval(days => o.rfi_auto_close_days)
+		ORDER BY activity.last_activity_at ASC
+		LIMIT $3`
+
+	rows, err := dao.DB.QueryContext(ctx, query, models.RFICommentTypeComment, models.RFIStatusOpen, limit)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to query stale answered RFIs")
+		return nil, fmt.Errorf("failed to query stale answered RFIs: %w", err)
+	}
+	defer rows.Close()
+
+	var stale []models.StaleRFI
+	for rows.Next() {
+		var rfi models.StaleRFI
+		var rfiNumber sql.NullString
+		if err := rows.Scan(
+			&rfi.ID, &rfi.ProjectID, &rfi.OrgID, &rfiNumber, &rfi.CreatedBy,
+			&rfi.AutoCloseDays, &rfi.LastActivityAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan stale RFI: %w", err)
+		}
+		if rfiNumber.Valid {
+			rfi.RFINumber = &rfiNumber.String
+		}
+		stale = append(stale, rfi)
+	}
+
+	return stale, rows.Err()
+}
+
+// AutoCloseRFI closes a stale answered RFI and records the change as a status_change comment.
+// The close only applies if the RFI is still open and no comment has been added since
+// rfi.LastActivityAt; otherwise it returns false without changing anything.
+// The history entry is attributed to the RFI's creator, on whose behalf it is closed.
+func (dao *RFIDao) AutoCloseRFI(ctx context.Context, rfi models.StaleRFI) (bool, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE project.rfis r
+		SET status = $1, closed_date = CURRENT_TIMESTAMP, responded_at = COALESCE(responded_at, CURRENT_TIMESTAMP),
+			updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE r.id = $2 AND r.status = $3 AND r.is_deleted = FALSE
+		  AND NOT EXISTS (
+			SELECT 1 FROM project.rfi_comments c
+			WHERE c.rfi_id = r.id AND c.is_deleted = FALSE AND c.created_at > $4
+		  )
+	`, models.RFIStatusClose, rfi.ID, models.RFIStatusOpen, rfi.LastActivityAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to auto-close RFI: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO project.rfi_comments (
+			rfi_id, comment, comment_type, previous_value, new_value, created_by, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $6)
+	`, rfi.ID,
+		fmt.Sprintf("RFI automatically closed after %d days with no activity since it was answered", rfi.AutoCloseDays),
+		models.RFICommentTypeStatusChange, models.RFIStatusOpen, models.RFIStatusClose, rfi.CreatedBy,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record RFI auto-close: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit RFI auto-close: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"rfi_id":          rfi.ID,
+		"org_id":          rfi.OrgID,
+		"auto_close_days": rfi.AutoCloseDays,
+	}).Info("Auto-closed stale answered RFI")
+
+	return true, nil
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sameAssignees_ReorderedIsSame(t *testing.T) {
+	//Arrange
+	previous := []int64{4, 7, 9}
+	next := []int64{9, 4, 7, 7}
+
+	//Act
+	actual := sameAssignees(previous, next)
+
+	//Assert
+	assert.True(t, actual)
+}
+
+func Test_sameAssignees_Reassigned(t *testing.T) {
+	//Arrange
+	previous := []int64{4, 7}
+	next := []int64{4, 8}
+
+	//Act
+	actual := sameAssignees(previous, next)
+
+	//Assert
+	assert.False(t, actual)
+}
+
+func Test_sameAssignees_Unassigned(t *testing.T) {
+	//Arrange
+	previous := []int64{4}
+	next := []int64{}
+
+	//Act
+	actual := sameAssignees(previous, next)
+
+	//Assert
+	assert.False(t, actual)
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// Sequence names used with NextSequence
+const (
+	SequenceRFINumber     = "rfi_number"
+	SequenceIssueNumber   = "issue_number"
+	SequenceProjectNumber = "project_number"
+)
+
+// SequenceKey identifies a counter. ProjectID is 0 for org-wide sequences.
+type SequenceKey struct {
+	OrgID     int64
+	ProjectID int64
+	Name      string
+}
+
+// lockID derives the advisory lock key for the sequence. Collisions only cause unrelated
+// sequences to briefly serialize, never incorrect values.
+func (k SequenceKey) lockID() int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d:%d:%s", k.OrgID, k.ProjectID, k.Name)
+	return int64(h.Sum64())
+}
+
+// NextSequence returns the next value of a per-project sequence within the caller's transaction.
+//
+// It takes a transaction-scoped advisory lock for the key, then increments (or creates) the counter
+// row in project.sequences. The lock is released when the caller commits or rolls back, so a
+// rolled-back transaction gives its number back and concurrent callers never see the same value.
+// Use this instead of MAX()+1 queries when generating numbers.
+func NextSequence(ctx context.Context, tx *sql.Tx, key SequenceKey) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("sequence requires a transaction")
+	}
+	if key.Name == "" {
+		return 0, errors.New("sequence name is required")
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, key.lockID()); err != nil {
+		return 0, fmt.Errorf("failed to acquire sequence lock: %w", err)
+	}
+
+	var value int64
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO project.sequences (org_id, project_id, sequence_name, current_value, updated_at)
+		VALUES ($1, $2, $3, 1, CURRENT_TIMESTAMP)
+		ON CONFLICT (org_id, project_id, sequence_name)
+		DO UPDATE SET current_value = project.sequences.current_value + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING current_value
+	`, key.OrgID, key.ProjectID, key.Name).Scan(&value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment sequence %s: %w", key.Name, err)
+	}
+
+	return value, nil
+}
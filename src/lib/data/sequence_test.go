@@ -0,0 +1,182 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSequenceDB emulates the two statements NextSequence issues. The counter update is a
+// deliberately racy read-sleep-write, so only the advisory lock keeps values unique.
+type fakeSequenceDB struct {
+	mu       sync.Mutex
+	locks    map[int64]*sync.Mutex
+	counters map[string]int64
+}
+
+type fakeSequenceDriver struct{ db *fakeSequenceDB }
+
+type fakeSequenceConn struct {
+	db   *fakeSequenceDB
+	held []*sync.Mutex
+}
+
+type fakeSequenceStmt struct {
+	conn  *fakeSequenceConn
+	query string
+}
+
+type fakeSequenceRows struct {
+	value int64
+	done  bool
+}
+
+type fakeSequenceConnector struct{ db *fakeSequenceDB }
+
+func (d *fakeSequenceDriver) Open(name string) (driver.Conn, error) {
+	return &fakeSequenceConn{db: d.db}, nil
+}
+
+func (c *fakeSequenceConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeSequenceConn{db: c.db}, nil
+}
+
+func (c *fakeSequenceConnector) Driver() driver.Driver { return &fakeSequenceDriver{db: c.db} }
+
+func (c *fakeSequenceConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSequenceStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeSequenceConn) Close() error { return nil }
+
+func (c *fakeSequenceConn) Begin() (driver.Tx, error) { return c, nil }
+
+// Commit and Rollback release transaction-scoped advisory locks, like Postgres does
+func (c *fakeSequenceConn) Commit() error {
+	for _, lock := range c.held {
+		lock.Unlock()
+	}
+	c.held = nil
+	return nil
+}
+
+func (c *fakeSequenceConn) Rollback() error { return c.Commit() }
+
+func (s *fakeSequenceStmt) Close() error  { return nil }
+func (s *fakeSequenceStmt) NumInput() int { return -1 }
+
+func (s *fakeSequenceStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if !strings.Contains(s.query, "pg_advisory_xact_lock") {
+		return nil, errors.New("unexpected exec")
+	}
+	lockID := args[0].(int64)
+
+	s.conn.db.mu.Lock()
+	lock, ok := s.conn.db.locks[lockID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.conn.db.locks[lockID] = lock
+	}
+	s.conn.db.mu.Unlock()
+
+	// Postgres advisory locks are reentrant within a session
+	for _, held := range s.conn.held {
+		if held == lock {
+			return driver.RowsAffected(0), nil
+		}
+	}
+	lock.Lock()
+	s.conn.held = append(s.conn.held, lock)
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeSequenceStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.Contains(s.query, "INSERT INTO project.sequences") {
+		return nil, errors.New("unexpected query")
+	}
+	key := args[2].(string)
+
+	s.conn.db.mu.Lock()
+	current := s.conn.db.counters[key]
+	s.conn.db.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	s.conn.db.mu.Lock()
+	s.conn.db.counters[key] = current + 1
+	s.conn.db.mu.Unlock()
+
+	return &fakeSequenceRows{value: current + 1}, nil
+}
+
+func (r *fakeSequenceRows) Columns() []string { return []string{"current_value"} }
+func (r *fakeSequenceRows) Close() error      { return nil }
+
+func (r *fakeSequenceRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.value
+	r.done = true
+	return nil
+}
+
+func Test_NextSequence_ConcurrentCallersGetUniqueValues(t *testing.T) {
+	//Arrange
+	fake := &fakeSequenceDB{locks: map[int64]*sync.Mutex{}, counters: map[string]int64{}}
+	db := sql.OpenDB(&fakeSequenceConnector{db: fake})
+	defer db.Close()
+
+	key := SequenceKey{OrgID: 1, ProjectID: 42, Name: SequenceRFINumber}
+	const callers = 25
+	values := make(chan int64, callers)
+	var wg sync.WaitGroup
+
+	//Act
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := db.BeginTx(context.Background(), nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			value, err := NextSequence(context.Background(), tx, key)
+			assert.NoError(t, err)
+			assert.NoError(t, tx.Commit())
+			values <- value
+		}()
+	}
+	wg.Wait()
+	close(values)
+
+	//Assert
+	seen := map[int64]bool{}
+	for value := range values {
+		assert.False(t, seen[value], "duplicate sequence value %d", value)
+		seen[value] = true
+	}
+	assert.Len(t, seen, callers)
+	for i := int64(1); i <= callers; i++ {
+		assert.True(t, seen[i], "missing sequence value %d", i)
+	}
+}
+
+func Test_NextSequence_RequiresName(t *testing.T) {
+	//Arrange
+	key := SequenceKey{OrgID: 1, ProjectID: 42}
+
+	//Act
+	_, err := NextSequence(context.Background(), &sql.Tx{}, key)
+
+	//Assert
+	assert.EqualError(t, err, "sequence name is required")
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,10 +21,16 @@ type SubmittalRepository interface {
 	GetSubmittalsByProject(ctx context.Context, projectID int64, filters map[string]string) ([]models.SubmittalResponse, error)
 	UpdateSubmittal(ctx context.Context, submittalID, userID, orgID int64, req *models.UpdateSubmittalRequest) (*models.SubmittalResponse, error)
 	ExecuteWorkflowAction(ctx context.Context, submittalID, userID int64, action *models.SubmittalWorkflowAction) (*models.SubmittalResponse, error)
+	GetSubmittalRevisions(ctx context.Context, submittalID, orgID int64) ([]models.SubmittalRevision, error)
 	GetSubmittalStats(ctx context.Context, projectID int64) (*models.SubmittalStats, error)
 	AddSubmittalAttachment(ctx context.Context, attachment *models.SubmittalAttachment) (*models.SubmittalAttachment, error)
 	GetSubmittalAttachments(ctx context.Context, submittalID int64) ([]models.SubmittalAttachment, error)
 	AddSubmittalHistory(ctx context.Context, history *models.SubmittalHistory) error
+	CopySubmittal(ctx context.Context, submittalID, targetProjectID, userID, orgID int64, copyAttachments bool) (*models.SubmittalResponse, error)
+	GetSubmittalDistribution(ctx context.Context, submittalID int64) (*models.SubmittalDistributionResponse, error)
+	ReorderSubmittalDistribution(ctx context.Context, submittalID, userID, orgID int64, reviewerIDs []int64) (*models.SubmittalDistributionResponse, error)
+	SkipSubmittalReviewer(ctx context.Context, submittalID, reviewerID, userID int64, reason *string) (*models.SubmittalDistributionResponse, error)
+	RecountSubmittalCounts(ctx context.Context, orgID, projectID int64) (models.RecountResult, error)
 }
 
 // SubmittalDao implements the SubmittalRepository interface
@@ -202,7 +209,7 @@ func (dao *SubmittalDao) GetSubmittal(ctx context.Context, submittalID int64) (*
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("submittal not found")
+			return nil, notFoundError("submittal not found")
 		}
 		dao.Logger.WithError(err).Error("Failed to get submittal")
 		return nil, fmt.Errorf("failed to get submittal: %w", err)
@@ -291,15 +298,16 @@ func (dao *SubmittalDao) GetSubmittalsByProject(ctx context.Context, projectID i
 	// Build WHERE conditions based on filters
 	conditions := []string{}
 
+	// status and priority accept comma-separated lists
 	if status := filters["status"]; status != "" {
-		conditions = append(conditions, fmt.Sprintf("s.workflow_status = $%d", argIndex))
-		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("s.workflow_status = ANY($%d)", argIndex))
+		args = append(args, pq.Array(strings.Split(status, ",")))
 		argIndex++
 	}
 
 	if priority := filters["priority"]; priority != "" {
-		conditions = append(conditions, fmt.Sprintf("s.priority = $%d", argIndex))
-		args = append(args, priority)
+		conditions = append(conditions, fmt.Sprintf("s.priority = ANY($%d)", argIndex))
+		args = append(args, pq.Array(strings.Split(priority, ",")))
 		argIndex++
 	}
 
@@ -647,62 +655,116 @@ func (dao *SubmittalDao) UpdateSubmittal(ctx context.Context, submittalID, userI
 	return dao.GetSubmittal(ctx, submittalID)
 }
 
-// ExecuteWorkflowAction executes a workflow action on a submittal
+// ExecuteWorkflowAction executes a workflow action on a submittal. On a submittal with review routing,
+// an approval completes the current reviewer's step and, while reviewers remain, passes the submittal
+// to the next pending reviewer instead of closing the review.
 func (dao *SubmittalDao) ExecuteWorkflowAction(ctx context.Context, submittalID, userID int64, action *models.SubmittalWorkflowAction) (*models.SubmittalResponse, error) {
-	var newStatus, newPhase, newBallInCourt string
-	var actionDescription string
+	// Rejections and revise-and-resubmit must explain why
+	if models.WorkflowActionRequiresComment(action.Action) && (action.Comments == nil || strings.TrimSpace(*action.Comments) == "") {
+		return nil, invalidError("comments are required for this workflow action")
+	}
+
+	if _, ok := models.NextSubmittalWorkflowState(action.Action, false); !ok {
+		return nil, fmt.Errorf("invalid workflow action: %s", action.Action)
+	}
 
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the submittal so the status the transition is checked against can't change underneath it
+	var currentStatus string
+	var revisionNumber int
+	var currentReviewer sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT workflow_status, revision_number, reviewer FROM project.submittals
+		WHERE id = $1 AND is_deleted = false
+		FOR UPDATE
+	`, submittalID).Scan(&currentStatus, &revisionNumber, &currentReviewer)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("submittal not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submittal: %w", err)
+	}
+
+	if err := models.ValidateWorkflowTransition(currentStatus, action.Action); err != nil {
+		return nil, err
+	}
+
+	// The reviewer the submittal goes to: the one the action names, otherwise the routing decides
+	var nextReviewer sql.NullInt64
+	if action.NextReviewer != nil {
+		nextReviewer = sql.NullInt64{Int64: *action.NextReviewer, Valid: true}
+	}
+	reviewersPending := false
 	switch action.Action {
 	case models.WorkflowActionSubmitForReview:
-		newStatus = models.SubmittalStatusUnderReview
-		newPhase = models.SubmittalPhaseReview
-		newBallInCourt = models.BallInCourtArchitect
-		actionDescription = "submitted for review"
-	case models.WorkflowActionApprove:
-		newStatus = models.SubmittalStatusApproved
-		newPhase = models.SubmittalPhaseFabrication
-		newBallInCourt = models.BallInCourtContractor
-		actionDescription = "approved"
-	case models.WorkflowActionApproveAsNoted:
-		newStatus = models.SubmittalStatusApprovedAsNoted
-		newPhase = models.SubmittalPhaseFabrication
-		newBallInCourt = models.BallInCourtContractor
-		actionDescription = "approved as noted"
-	case models.WorkflowActionReviseResubmit:
-		newStatus = models.SubmittalStatusReviseResubmit
-		newPhase = models.SubmittalPhasePreparation
-		newBallInCourt = models.BallInCourtContractor
-		actionDescription = "requires revision and resubmission"
-	case models.WorkflowActionReject:
-		newStatus = models.SubmittalStatusRejected
-		newPhase = models.SubmittalPhasePreparation
-		newBallInCourt = models.BallInCourtContractor
-		actionDescription = "rejected"
-	case models.WorkflowActionMarkForInformation:
-		newStatus = models.SubmittalStatusForInformationOnly
-		newPhase = models.SubmittalPhaseCompleted
-		newBallInCourt = models.BallInCourtContractor
-		actionDescription = "marked for information only"
-	default:
-		return nil, fmt.Errorf("invalid workflow action: %s", action.Action)
+		if !nextReviewer.Valid {
+			if nextReviewer, err = dao.nextPendingSubmittalReviewer(ctx, tx, submittalID); err != nil {
+				return nil, err
+			}
+		}
+	case models.WorkflowActionApprove, models.WorkflowActionApproveAsNoted:
+		if currentReviewer.Valid {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE project.submittal_reviewers
+				SET status = $1, updated_by = $2, updated_at = NOW()
+				WHERE submittal_id = $3 AND reviewer_id = $4 AND status = $5
+			`, models.SubmittalReviewerCompleted, userID, submittalID, currentReviewer.Int64, models.SubmittalReviewerPending); err != nil {
+				return nil, fmt.Errorf("failed to complete reviewer: %w", err)
+			}
+		}
+		pending, err := dao.nextPendingSubmittalReviewer(ctx, tx, submittalID)
+		if err != nil {
+			return nil, err
+		}
+		if pending.Valid {
+			reviewersPending = true
+			if !nextReviewer.Valid {
+				nextReviewer = pending
+			}
+		}
 	}
 
+	state, _ := models.NextSubmittalWorkflowState(action.Action, reviewersPending)
+	actionDescription := state.Description
+
 	// Override ball in court if specified
 	if action.BallInCourtTransfer != nil {
-		newBallInCourt = *action.BallInCourtTransfer
+		state.BallInCourt = *action.BallInCourtTransfer
 	}
 
-	query := `
-		UPDATE project.submittals
-		SET workflow_status = $1, current_phase = $2, ball_in_court = $3,
-			reviewer = $4, updated_by = $5, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $6 AND is_deleted = false`
+	// Going back out after revise and resubmit is the next revision, not the first submission;
+	// the revision number was already bumped when the revision was requested
+	if action.Action == models.WorkflowActionSubmitForReview && currentStatus == models.SubmittalStatusReviseResubmit {
+		actionDescription = fmt.Sprintf("resubmitted for review as revision %d", revisionNumber)
+	}
 
-	_, err := dao.DB.ExecContext(ctx, query,
-		newStatus, newPhase, newBallInCourt, action.NextReviewer, userID, submittalID)
-	if err != nil {
-		dao.Logger.WithError(err).Error("Failed to execute workflow action")
-		return nil, fmt.Errorf("failed to execute workflow action: %w", err)
+	// Only revise_resubmit starts a new revision; every other action keeps the revision number
+	if action.Action == models.WorkflowActionReviseResubmit {
+		if err := dao.startSubmittalRevision(ctx, tx, submittalID, userID, state.Status, state.Phase, state.BallInCourt, action); err != nil {
+			return nil, err
+		}
+	} else {
+		query := `
+			UPDATE project.submittals
+			SET workflow_status = $1, current_phase = $2, ball_in_court = $3,
+				reviewer = $4, updated_by = $5, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $6 AND is_deleted = false`
+
+		_, err := tx.ExecContext(ctx, query,
+			state.Status, state.Phase, state.BallInCourt, nextReviewer, userID, submittalID)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to execute workflow action")
+			return nil, fmt.Errorf("failed to execute workflow action: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	// Add history entry
@@ -722,6 +784,119 @@ func (dao *SubmittalDao) ExecuteWorkflowAction(ctx context.Context, submittalID,
 	return dao.GetSubmittal(ctx, submittalID)
 }
 
+// nextPendingSubmittalReviewer returns the first reviewer in the submittal's routing who is still pending
+func (dao *SubmittalDao) nextPendingSubmittalReviewer(ctx context.Context, tx *sql.Tx, submittalID int64) (sql.NullInt64, error) {
+	var reviewerID sql.NullInt64
+	err := tx.QueryRowContext(ctx, `
+		SELECT reviewer_id FROM project.submittal_reviewers
+		WHERE submittal_id = $1 AND status = $2
+		ORDER BY sequence
+		LIMIT 1
+	`, submittalID, models.SubmittalReviewerPending).Scan(&reviewerID)
+	if err != nil && err != sql.ErrNoRows {
+		return sql.NullInt64{}, fmt.Errorf("failed to get next reviewer: %w", err)
+	}
+	return reviewerID, nil
+}
+
+// startSubmittalRevision snapshots the submittal's current review state into submittal_revisions,
+// bumps revision_number and clears the review so the next revision is routed from the first reviewer again
+func (dao *SubmittalDao) startSubmittalRevision(ctx context.Context, tx *sql.Tx, submittalID, userID int64, newStatus, newPhase, newBallInCourt string, action *models.SubmittalWorkflowAction) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO project.submittal_revisions (
+			submittal_id, revision_number, workflow_status, current_phase, ball_in_court,
+			reviewer, reviewed_by, reviewed_date, review_comments, revision_notes, created_by
+		)
+		SELECT id, revision_number, workflow_status, current_phase, ball_in_court,
+			reviewer, reviewed_by, reviewed_date, review_comments, $2, $3
+		FROM project.submittals
+		WHERE id = $1 AND is_deleted = false
+	`, submittalID, action.RevisionNotes, userID)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to snapshot submittal revision")
+		return fmt.Errorf("failed to snapshot submittal revision: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return notFoundError("submittal not found")
+	}
+
+	// Put every routed reviewer back to pending; the first one in sequence becomes the current
+	// reviewer unless the action names one
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE project.submittal_reviewers
+		SET status = 'pending', skipped_by = NULL, skipped_at = NULL, skip_reason = NULL,
+			updated_by = $2, updated_at = NOW()
+		WHERE submittal_id = $1
+	`, submittalID, userID); err != nil {
+		return fmt.Errorf("failed to reset submittal reviewers: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE project.submittals
+		SET workflow_status = $1, current_phase = $2, ball_in_court = $3,
+			reviewer = COALESCE($4, (
+				SELECT reviewer_id FROM project.submittal_reviewers
+				WHERE submittal_id = $6 ORDER BY sequence LIMIT 1
+			)),
+			revision_number = revision_number + 1,
+			reviewed_by = NULL, reviewed_date = NULL, approval_date = NULL, review_comments = NULL,
+			updated_by = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6 AND is_deleted = false
+	`, newStatus, newPhase, newBallInCourt, action.NextReviewer, userID, submittalID)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to start submittal revision")
+		return fmt.Errorf("failed to execute workflow action: %w", err)
+	}
+	return nil
+}
+
+// GetSubmittalRevisions returns the review state snapshots of earlier revisions, oldest first
+func (dao *SubmittalDao) GetSubmittalRevisions(ctx context.Context, submittalID, orgID int64) ([]models.SubmittalRevision, error) {
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM project.submittals s
+			JOIN project.projects p ON p.id = s.project_id
+			WHERE s.id = $1 AND s.is_deleted = false AND p.org_id = $2
+		)
+	`, submittalID, orgID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify submittal: %w", err)
+	}
+	if !exists {
+		return nil, notFoundError("submittal not found")
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT id, submittal_id, revision_number, workflow_status, current_phase, ball_in_court,
+			reviewer, reviewed_by, reviewed_date, review_comments, revision_notes, created_at, created_by
+		FROM project.submittal_revisions
+		WHERE submittal_id = $1
+		ORDER BY revision_number ASC, id ASC
+	`, submittalID)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to get submittal revisions")
+		return nil, fmt.Errorf("failed to get submittal revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []models.SubmittalRevision{}
+	for rows.Next() {
+		var revision models.SubmittalRevision
+		if err := rows.Scan(
+			&revision.ID, &revision.SubmittalID, &revision.RevisionNumber, &revision.WorkflowStatus,
+			&revision.CurrentPhase, &revision.BallInCourt, &revision.Reviewer, &revision.ReviewedBy,
+			&revision.ReviewedDate, &revision.ReviewComments, &revision.RevisionNotes,
+			&revision.CreatedAt, &revision.CreatedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan submittal revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
 // DeleteSubmittal soft deletes a submittal
 
 // GetSubmittalStats returns statistics for submittals in a project
@@ -839,9 +1014,9 @@ func (dao *SubmittalDao) AddSubmittalAttachment(ctx context.Context, attachment
 func (dao *SubmittalDao) GetSubmittalAttachments(ctx context.Context, submittalID int64) ([]models.SubmittalAttachment, error) {
 	query := `
 		SELECT id, submittal_id, file_name, file_path, file_size, file_type, attachment_type,
-			   uploaded_by, created_at, created_by, updated_at, updated_by, is_deleted
+			   uploaded_by, scan_status, created_at, created_by, updated_at, updated_by, is_deleted
 		FROM project.submittal_attachments
-		WHERE submittal_id = $1 AND is_deleted = false
+		WHERE submittal_id = $1 AND is_deleted = false AND upload_status = 'confirmed'
 		ORDER BY created_at`
 
 	rows, err := dao.DB.QueryContext(ctx, query, submittalID)
@@ -857,7 +1032,7 @@ func (dao *SubmittalDao) GetSubmittalAttachments(ctx context.Context, submittalI
 		err := rows.Scan(
 			&attachment.ID, &attachment.SubmittalID, &attachment.FileName, &attachment.FilePath,
 			&attachment.FileSize, &attachment.FileType, &attachment.AttachmentType,
-			&attachment.UploadedBy, &attachment.CreatedAt, &attachment.CreatedBy,
+			&attachment.UploadedBy, &attachment.ScanStatus, &attachment.CreatedAt, &attachment.CreatedBy,
 			&attachment.UpdatedAt, &attachment.UpdatedBy, &attachment.IsDeleted,
 		)
 		if err != nil {
@@ -943,4 +1118,365 @@ func isOverdue(requiredApprovalDate *time.Time, workflowStatus string) bool {
 	}
 
 	return time.Now().After(*requiredApprovalDate)
-}
\ No newline at end of file
+}
+
+// CopySubmittal duplicates a submittal into another project of the same organization as a new draft
+// with a fresh submittal number and reset workflow. Reviewers, dates and logs are not carried over.
+func (dao *SubmittalDao) CopySubmittal(ctx context.Context, submittalID, targetProjectID, userID, orgID int64, copyAttachments bool) (*models.SubmittalResponse, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to start transaction for submittal copy")
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	targetLocationID, err := validateCopyProjects(ctx, tx, "project.submittals", "submittal", submittalID, targetProjectID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	submittalNumber, err := dao.generateSubmittalNumber(ctx, targetProjectID)
+	if err != nil {
+		dao.Logger.WithError(err).Error("Failed to generate submittal number for copy")
+		return nil, fmt.Errorf("failed to generate submittal number: %w", err)
+	}
+
+	var newSubmittalID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO project.submittals (
+			project_id, org_id, location_id, submittal_number, package_name, csi_division, csi_section,
+			title, description, submittal_type, specification_section, priority,
+			current_phase, ball_in_court, workflow_status, submitted_by,
+			submittal_references, tags, custom_fields, created_by, updated_by
+		)
+		SELECT
+			$1, org_id, $2, $3, package_name, csi_division, csi_section,
+			title, description, submittal_type, specification_section, priority,
+			$4, $5, $6, $7,
+			submittal_references, tags, custom_fields, $7, $7
+		FROM project.submittals
+		WHERE id = $8
+		RETURNING id
+	`, targetProjectID, targetLocationID, submittalNumber,
+		models.SubmittalPhasePreparation, models.BallInCourtContractor, models.SubmittalStatusDraft, userID,
+		submittalID).Scan(&newSubmittalID)
+	if err != nil {
+		dao.Logger.WithError(err).WithFields(logrus.Fields{
+			"submittal_id":      submittalID,
+			"target_project_id": targetProjectID,
+		}).Error("Failed to copy submittal")
+		return nil, fmt.Errorf("failed to copy submittal: %w", err)
+	}
+
+	if copyAttachments {
+		if err := copyEntityAttachments(ctx, tx, models.EntityTypeSubmittal, submittalID, newSubmittalID, userID); err != nil {
+			dao.Logger.WithError(err).WithField("submittal_id", submittalID).Error("Failed to copy submittal attachments")
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		dao.Logger.WithError(err).Error("Failed to commit submittal copy transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Add history entry
+	comment := fmt.Sprintf("Submittal copied from submittal %d", submittalID)
+	history := &models.SubmittalHistory{
+		SubmittalID: newSubmittalID,
+		Action:      "created",
+		Comment:     &comment,
+		CreatedBy:   userID,
+	}
+	dao.AddSubmittalHistory(ctx, history)
+
+	return dao.GetSubmittal(ctx, newSubmittalID)
+}
+
+// GetSubmittalDistribution returns the submittal's review routing in sequence order
+func (dao *SubmittalDao) GetSubmittalDistribution(ctx context.Context, submittalID int64) (*models.SubmittalDistributionResponse, error) {
+	response := &models.SubmittalDistributionResponse{
+		SubmittalID: submittalID,
+		Reviewers:   []models.SubmittalReviewer{},
+	}
+
+	var currentReviewer sql.NullInt64
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT reviewer FROM project.submittals WHERE id = $1 AND is_deleted = FALSE
+	`, submittalID).Scan(&currentReviewer)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("submittal not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submittal: %w", err)
+	}
+	if currentReviewer.Valid {
+		response.CurrentReviewer = &currentReviewer.Int64
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT sr.id, sr.submittal_id, sr.reviewer_id, CONCAT(u.first_name, ' ', u.last_name),
+			sr.sequence, sr.status, sr.skipped_by, sr.skipped_at, sr.skip_reason
+		FROM project.submittal_reviewers sr
+		LEFT JOIN iam.users u ON u.id = sr.reviewer_id
+		WHERE sr.submittal_id = $1
+		ORDER BY sr.sequence
+	`, submittalID)
+	if err != nil {
+		dao.Logger.WithError(err).WithField("submittal_id", submittalID).Error("Failed to get submittal distribution")
+		return nil, fmt.Errorf("failed to get submittal distribution: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewer models.SubmittalReviewer
+		var skippedBy sql.NullInt64
+		var skipReason sql.NullString
+		if err := rows.Scan(&reviewer.ID, &reviewer.SubmittalID, &reviewer.ReviewerID, &reviewer.ReviewerName,
+			&reviewer.Sequence, &reviewer.Status, &skippedBy, &reviewer.SkippedAt, &skipReason); err != nil {
+			return nil, fmt.Errorf("failed to scan submittal reviewer: %w", err)
+		}
+		if skippedBy.Valid {
+			reviewer.SkippedBy = &skippedBy.Int64
+		}
+		if skipReason.Valid {
+			reviewer.SkipReason = &skipReason.String
+		}
+		response.Reviewers = append(response.Reviewers, reviewer)
+	}
+
+	return response, rows.Err()
+}
+
+// ReorderSubmittalDistribution replaces the pending part of the review routing with reviewerIDs, in order.
+// Reviewers who already completed or were skipped stay in place; the first pending reviewer gets the ball in court.
+func (dao *SubmittalDao) ReorderSubmittalDistribution(ctx context.Context, submittalID, userID, orgID int64, reviewerIDs []int64) (*models.SubmittalDistributionResponse, error) {
+	seen := make(map[int64]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		if id <= 0 {
+			return nil, invalidError("invalid reviewer id")
+		}
+		if seen[id] {
+			return nil, invalidError("duplicate reviewer id")
+		}
+		seen[id] = true
+	}
+
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previousReviewer sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT reviewer FROM project.submittals WHERE id = $1 AND is_deleted = FALSE FOR UPDATE
+	`, submittalID).Scan(&previousReviewer)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("submittal not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock submittal: %w", err)
+	}
+
+	if len(reviewerIDs) > 0 {
+		var orgMembers int
+		err = tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM iam.users WHERE id = ANY($1) AND org_id = $2
+		`, pq.Array(reviewerIDs), orgID).Scan(&orgMembers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate reviewers: %w", err)
+		}
+		if orgMembers != len(reviewerIDs) {
+			return nil, orgMismatchError("reviewer does not belong to your organization")
+		}
+
+		var finished int
+		err = tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM project.submittal_reviewers
+			WHERE submittal_id = $1 AND reviewer_id = ANY($2) AND status != $3
+		`, submittalID, pq.Array(reviewerIDs), models.SubmittalReviewerPending).Scan(&finished)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate reviewers: %w", err)
+		}
+		if finished > 0 {
+			return nil, conflictError("reviewer has already reviewed or been skipped")
+		}
+	}
+
+	var nextSequence int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(sequence), 0) + 1 FROM project.submittal_reviewers
+		WHERE submittal_id = $1 AND status != $2
+	`, submittalID, models.SubmittalReviewerPending).Scan(&nextSequence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer sequence: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `
+		DELETE FROM project.submittal_reviewers WHERE submittal_id = $1 AND status = $2
+	`, submittalID, models.SubmittalReviewerPending); err != nil {
+		return nil, fmt.Errorf("failed to clear pending reviewers: %w", err)
+	}
+
+	for i, reviewerID := range reviewerIDs {
+		if _, err = tx.ExecContext(ctx, `
+			INSERT INTO project.submittal_reviewers (submittal_id, reviewer_id, sequence, status, created_by, updated_by)
+			VALUES ($1, $2, $3, $4, $5, $5)
+		`, submittalID, reviewerID, nextSequence+i, models.SubmittalReviewerPending, userID); err != nil {
+			dao.Logger.WithError(err).WithField("submittal_id", submittalID).Error("Failed to insert submittal reviewer")
+			return nil, fmt.Errorf("failed to save reviewer order: %w", err)
+		}
+	}
+
+	var currentReviewer sql.NullInt64
+	if len(reviewerIDs) > 0 {
+		currentReviewer = sql.NullInt64{Int64: reviewerIDs[0], Valid: true}
+	}
+	if err = dao.advanceSubmittalReviewer(ctx, tx, submittalID, userID, previousReviewer, currentReviewer,
+		"distribution_reordered", "Review routing reordered"); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return dao.GetSubmittalDistribution(ctx, submittalID)
+}
+
+// SkipSubmittalReviewer marks a pending reviewer as skipped and passes the ball in court to the next
+// pending reviewer. Skipping the last pending reviewer of a submittal under review closes the review the way
+// the last approval would; it is refused when no reviewer has completed a review yet, since the submittal
+// would otherwise be approved unreviewed. The skip is recorded in the submittal history under the user who
+// authorized it.
+func (dao *SubmittalDao) SkipSubmittalReviewer(ctx context.Context, submittalID, reviewerID, userID int64, reason *string) (*models.SubmittalDistributionResponse, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previousReviewer sql.NullInt64
+	var workflowStatus string
+	err = tx.QueryRowContext(ctx, `
+		SELECT reviewer, workflow_status FROM project.submittals WHERE id = $1 AND is_deleted = FALSE FOR UPDATE
+	`, submittalID).Scan(&previousReviewer, &workflowStatus)
+	if err == sql.ErrNoRows {
+		return nil, notFoundError("submittal not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock submittal: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE project.submittal_reviewers
+		SET status = $1, skipped_by = $2, skipped_at = NOW(), skip_reason = $3, updated_by = $2, updated_at = NOW()
+		WHERE submittal_id = $4 AND reviewer_id = $5 AND status = $6
+	`, models.SubmittalReviewerSkipped, userID, reason, submittalID, reviewerID, models.SubmittalReviewerPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to skip reviewer: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, conflictError("reviewer is not pending on this submittal")
+	}
+
+	nextReviewer, err := dao.nextPendingSubmittalReviewer(ctx, tx, submittalID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := fmt.Sprintf("Reviewer %d skipped", reviewerID)
+	if reason != nil && strings.TrimSpace(*reason) != "" {
+		comment = fmt.Sprintf("%s: %s", comment, strings.TrimSpace(*reason))
+	}
+
+	// With nobody left in the routing, the review ends here instead of waiting on a reviewer that doesn't exist
+	if !nextReviewer.Valid && workflowStatus == models.SubmittalStatusUnderReview {
+		state, err := dao.closeSkippedSubmittalReview(ctx, tx, submittalID, userID)
+		if err != nil {
+			return nil, err
+		}
+		comment = fmt.Sprintf("%s; no reviewers remain, submittal %s", comment, state.Description)
+	}
+
+	if err = dao.advanceSubmittalReviewer(ctx, tx, submittalID, userID, previousReviewer, nextReviewer,
+		"reviewer_skipped", comment); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	dao.Logger.WithFields(logrus.Fields{
+		"submittal_id": submittalID,
+		"reviewer_id":  reviewerID,
+		"skipped_by":   userID,
+	}).Info("Submittal reviewer skipped")
+
+	return dao.GetSubmittalDistribution(ctx, submittalID)
+}
+
+// closeSkippedSubmittalReview moves a submittal whose remaining reviewers were all skipped to the state the
+// last reviewer's approval would have. It needs at least one completed review to stand on.
+func (dao *SubmittalDao) closeSkippedSubmittalReview(ctx context.Context, tx *sql.Tx, submittalID, userID int64) (models.SubmittalWorkflowState, error) {
+	var completed int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM project.submittal_reviewers WHERE submittal_id = $1 AND status = $2
+	`, submittalID, models.SubmittalReviewerCompleted).Scan(&completed)
+	if err != nil {
+		return models.SubmittalWorkflowState{}, fmt.Errorf("failed to count completed reviewers: %w", err)
+	}
+	if completed == 0 {
+		return models.SubmittalWorkflowState{}, conflictError("cannot skip the last pending reviewer before any reviewer has approved the submittal")
+	}
+
+	state, _ := models.NextSubmittalWorkflowState(models.WorkflowActionApprove, false)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE project.submittals
+		SET workflow_status = $1, current_phase = $2, ball_in_court = $3, updated_by = $4, updated_at = NOW()
+		WHERE id = $5
+	`, state.Status, state.Phase, state.BallInCourt, userID, submittalID); err != nil {
+		dao.Logger.WithError(err).WithField("submittal_id", submittalID).Error("Failed to close submittal review")
+		return models.SubmittalWorkflowState{}, fmt.Errorf("failed to close submittal review: %w", err)
+	}
+
+	return state, nil
+}
+
+// advanceSubmittalReviewer sets the submittal's current reviewer and records the change in its history
+func (dao *SubmittalDao) advanceSubmittalReviewer(ctx context.Context, tx *sql.Tx, submittalID, userID int64, previous, next sql.NullInt64, action, comment string) error {
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE project.submittals SET reviewer = $1, updated_by = $2, updated_at = NOW() WHERE id = $3
+	`, next, userID, submittalID); err != nil {
+		return fmt.Errorf("failed to update current reviewer: %w", err)
+	}
+
+	fieldName := "reviewer"
+	var oldValue, newValue *string
+	if previous.Valid {
+		value := strconv.FormatInt(previous.Int64, 10)
+		oldValue = &value
+	}
+	if next.Valid {
+		value := strconv.FormatInt(next.Int64, 10)
+		newValue = &value
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO project.submittal_history
+		(submittal_id, action, field_name, old_value, new_value, comment, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, submittalID, action, fieldName, oldValue, newValue, comment, userID); err != nil {
+		return fmt.Errorf("failed to add history: %w", err)
+	}
+	return nil
+}
+
+// RecountSubmittalCounts recomputes attachment_count on submittals from submittal_attachments
+func (dao *SubmittalDao) RecountSubmittalCounts(ctx context.Context, orgID, projectID int64) (models.RecountResult, error) {
+	return recountEntityCounts(ctx, dao.DB, dao.Logger, models.EntityTypeSubmittal, "project.submittals", orgID, projectID, []countSource{
+		{column: "attachment_count", childTable: "project.submittal_attachments", childFK: "submittal_id"},
+	})
+}
@@ -3,7 +3,9 @@ package data
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"infrastructure/lib/clients"
 	"infrastructure/lib/models"
 	"math/rand"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -42,8 +45,32 @@ type UserManagementRepository interface {
 	// GetUserLocationRoleAssignments retrieves user's location-role assignments
 	GetUserLocationRoleAssignments(ctx context.Context, userID int64) ([]models.UserLocationRoleAssignment, error)
 
+	// GetUserProjects retrieves the projects a user holds a role on within the organization (paginated)
+	GetUserProjects(ctx context.Context, userID, orgID int64, limit, offset int) ([]models.UserProjectRole, int, error)
+
 	// SendPasswordResetEmail sends a password reset email to a user
 	SendPasswordResetEmail(ctx context.Context, userEmail string) error
+
+	// UserBelongsToOrg reports whether an active (non-deleted) user exists in the organization
+	UserBelongsToOrg(ctx context.Context, userID, orgID int64) (bool, error)
+
+	// EmailExists reports whether a non-deleted user in the organization has the email, ignoring case
+	EmailExists(ctx context.Context, orgID int64, email string) (bool, error)
+
+	// RecordLastLogin sets the user's last_login_at unless a later login is already recorded
+	RecordLastLogin(ctx context.Context, cognitoID string, loginAt time.Time) error
+
+	// GetEmailRecipients returns the active users among userIDs who have email on for the event type
+	GetEmailRecipients(ctx context.Context, orgID int64, userIDs []int64, eventType string) ([]models.NotificationRecipient, error)
+
+	// GetSNSRecipientIDs returns the active users among userIDs who have SNS on for the event type
+	GetSNSRecipientIDs(ctx context.Context, orgID int64, userIDs []int64, eventType string) ([]int64, error)
+
+	// GetNotificationPrefs returns the user's settings for every event type, filling in defaults
+	GetNotificationPrefs(ctx context.Context, userID int64) ([]models.NotificationPref, error)
+
+	// UpdateNotificationPrefs applies the updates and returns the resulting settings for every event type
+	UpdateNotificationPrefs(ctx context.Context, userID int64, updates []models.NotificationPrefUpdate, updatedBy int64) ([]models.NotificationPref, error)
 }
 
 // UserManagementDao implements UserManagementRepository interface using PostgreSQL
@@ -53,11 +80,13 @@ type UserManagementDao struct {
 	CognitoClient *cognitoidentityprovider.Client
 	UserPoolID    string
 	ClientID      string
+	RetryConfig   clients.CognitoRetryConfig // Retry/timeout settings for Cognito admin calls
 }
 
 // CreateUser creates a new user in the organization
 func (dao *UserManagementDao) CreateUser(ctx context.Context, orgID int64, user *models.User) (*models.User, error) {
 	var userID int64
+	user.Email = models.NormalizeEmail(user.Email)
 
 	// Convert sql.NullString fields for insertion
 	phone := sql.NullString{String: "", Valid: false}
@@ -115,11 +144,45 @@ func (dao *UserManagementDao) CreateUser(ctx context.Context, orgID int64, user
 	return user, nil
 }
 
+// Errors CreateNormalUser wraps to say which step failed; handlers branch on them with errors.Is.
+// ErrCognitoUserNotRemoved and ErrCognitoUserDisabled mean a Cognito account was left behind.
+var (
+	ErrEmailDomainNotAllowed = invalidError("email domain is not allowed for this organization")
+	ErrDatabaseUnavailable   = errors.New("database is unavailable")
+	ErrCognitoCreateFailed   = errors.New("failed to create user in Cognito")
+	ErrCognitoUserExists     = errors.New("a Cognito user with this email already exists")
+	ErrUserRecordUnconfirmed = errors.New("failed to confirm user record")
+	ErrUserRecordFailed      = errors.New("failed to create user in database")
+	ErrCognitoUserDisabled   = errors.New("cognito user disabled, not deleted")
+	ErrCognitoUserNotRemoved = errors.New("failed to remove Cognito user")
+)
+
 // CreateNormalUser creates a normal user (non-super admin) with Cognito integration
 func (dao *UserManagementDao) CreateNormalUser(ctx context.Context, orgID int64, request *models.CreateUserRequest, createdBy int64) (*models.CreateUserResponse, error) {
+	// Store emails normalized so case variants of the same address cannot become separate users
+	request.Email = models.NormalizeEmail(request.Email)
+
+	// Enforce the org's email domain allow-list before anything is created in Cognito
+	if !request.BypassDomainCheck {
+		orgDao := &OrgDao{DB: dao.DB, Logger: dao.Logger}
+		allowedDomains, err := orgDao.GetAllowedEmailDomains(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if !models.IsEmailDomainAllowed(request.Email, allowedDomains) {
+			return nil, ErrEmailDomainNotAllowed
+		}
+	}
+
 	// Generate temporary password
 	tempPassword := generateTemporaryPassword()
 
+	// Check the database before touching Cognito so one that is unreachable fails the request
+	// without leaving a Cognito account behind. No transaction is held across the Cognito calls.
+	if err := dao.DB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDatabaseUnavailable, err)
+	}
+
 	// Create user in Cognito first - default behavior sends welcome email
 	cognitoInput := &cognitoidentityprovider.AdminCreateUserInput{
 		UserPoolId: aws.String(dao.UserPoolID),
@@ -142,17 +205,19 @@ func (dao *UserManagementDao) CreateNormalUser(ctx context.Context, orgID int64,
 		// No MessageAction specified - uses default behavior to send invite email
 	}
 
-	cognitoResult, err := dao.CognitoClient.AdminCreateUser(ctx, cognitoInput)
+	cognitoUserID, err := dao.createCognitoUser(ctx, cognitoInput)
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"email": request.Email,
 			"error": err.Error(),
 		}).Error("Failed to create user in Cognito")
-		return nil, fmt.Errorf("failed to create user in Cognito: %w", err)
+		var exists *types.UsernameExistsException
+		if errors.As(err, &exists) {
+			return nil, fmt.Errorf("%w: %w: %w", ErrCognitoCreateFailed, ErrCognitoUserExists, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrCognitoCreateFailed, err)
 	}
 
-	cognitoUserID := *cognitoResult.User.Username
-
 	// Create user record in database
 	var userID int64
 	var createdAt, updatedAt time.Time
@@ -170,7 +235,6 @@ func (dao *UserManagementDao) CreateNormalUser(ctx context.Context, orgID int64,
 		RETURNING id, created_at, updated_at
 	`, cognitoUserID, request.Email, request.FirstName, request.LastName, phone, mobile, jobTitle, employeeID, avatarURL, lastSelectedLocationID, false, "pending", orgID, createdBy, createdBy).Scan(
 		&userID, &createdAt, &updatedAt)
-
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"org_id":     orgID,
@@ -179,16 +243,35 @@ func (dao *UserManagementDao) CreateNormalUser(ctx context.Context, orgID int64,
 			"error":      err.Error(),
 		}).Error("Failed to create user in database")
 
-		// If database creation fails, clean up Cognito user
-		_, deleteErr := dao.CognitoClient.AdminDeleteUser(ctx, &cognitoidentityprovider.AdminDeleteUserInput{
-			UserPoolId: aws.String(dao.UserPoolID),
-			Username:   aws.String(cognitoUserID),
-		})
-		if deleteErr != nil {
-			dao.Logger.WithError(deleteErr).Error("Failed to cleanup Cognito user after database error")
+		// Only an error reported by Postgres proves the insert did not happen. Anything else (a dropped
+		// connection, a cancelled context) may have committed, so check for the row before cleaning up.
+		var pqErr *pq.Error
+		if !errors.As(err, &pqErr) {
+			lookupErr := dao.DB.QueryRowContext(ctx, `
+				SELECT id, created_at, updated_at FROM iam.users WHERE cognito_id = $1
+			`, cognitoUserID).Scan(&userID, &createdAt, &updatedAt)
+			if lookupErr != nil {
+				// Leave the Cognito user in place: deleting it could orphan a committed user record
+				dao.Logger.WithFields(logrus.Fields{
+					"cognito_id": cognitoUserID,
+					"error":      lookupErr.Error(),
+				}).Error("Could not tell whether the user record was saved; Cognito user kept for reconciliation")
+				return nil, fmt.Errorf("%w for Cognito user %s: %w", ErrUserRecordUnconfirmed, cognitoUserID, err)
+			}
+			err = nil
 		}
+	}
 
-		return nil, fmt.Errorf("failed to create user in database: %w", err)
+	if err != nil {
+		// The insert definitely failed; undo the Cognito side so no half-created user remains
+		deleted, cleanupErr := dao.removeCognitoUser(ctx, cognitoUserID)
+		if cleanupErr != nil {
+			return nil, fmt.Errorf("%w and %w %s: %w", ErrUserRecordFailed, ErrCognitoUserNotRemoved, cognitoUserID, err)
+		}
+		if !deleted {
+			return nil, fmt.Errorf("%w (%s): %w: %w", ErrCognitoUserDisabled, cognitoUserID, ErrUserRecordFailed, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUserRecordFailed, err)
 	}
 
 	// Email with temporary password is automatically sent via MessageAction: RESEND
@@ -214,6 +297,86 @@ func (dao *UserManagementDao) CreateNormalUser(ctx context.Context, orgID int64,
 	}, nil
 }
 
+// createCognitoUser runs AdminCreateUser with retries and returns the new account's username.
+// AdminCreateUser is not idempotent: an attempt that times out may still have created the account, so
+// the retry fails with UsernameExistsException. When that happens after a failed attempt, and the
+// existing account was created since the first attempt started, it is adopted as this call's account.
+func (dao *UserManagementDao) createCognitoUser(ctx context.Context, input *cognitoidentityprovider.AdminCreateUserInput) (string, error) {
+	started := time.Now()
+	attempts := 0
+	var username string
+	err := clients.WithCognitoRetry(ctx, dao.RetryConfig, "AdminCreateUser", dao.Logger, func(ctx context.Context) error {
+		attempts++
+		result, callErr := dao.CognitoClient.AdminCreateUser(ctx, input)
+		if callErr == nil {
+			username = *result.User.Username
+			return nil
+		}
+
+		var exists *types.UsernameExistsException
+		if attempts == 1 || !errors.As(callErr, &exists) {
+			return callErr
+		}
+		existing, getErr := dao.CognitoClient.AdminGetUser(ctx, &cognitoidentityprovider.AdminGetUserInput{
+			UserPoolId: input.UserPoolId,
+			Username:   input.Username,
+		})
+		if getErr != nil {
+			return getErr
+		}
+		// Allow for clock skew between Lambda and Cognito; an older account belongs to someone else
+		if existing.UserCreateDate == nil || existing.UserCreateDate.Before(started.Add(-time.Minute)) {
+			return callErr
+		}
+		dao.Logger.WithFields(logrus.Fields{
+			"email":   aws.ToString(input.Username),
+			"attempt": attempts,
+		}).Warn("AdminCreateUser retry found the account created by an earlier attempt; using it")
+		username = *existing.Username
+		return nil
+	})
+	return username, err
+}
+
+// removeCognitoUser compensates for a failed DB insert by deleting the just-created Cognito user.
+// If the delete fails the user is disabled instead so the orphaned account cannot sign in; deleted
+// reports which of the two happened. Returns an error only when both attempts fail, leaving an
+// account that needs manual cleanup.
+func (dao *UserManagementDao) removeCognitoUser(ctx context.Context, cognitoUserID string) (deleted bool, err error) {
+	deleteErr := clients.WithCognitoRetry(ctx, dao.RetryConfig, "AdminDeleteUser", dao.Logger, func(ctx context.Context) error {
+		_, callErr := dao.CognitoClient.AdminDeleteUser(ctx, &cognitoidentityprovider.AdminDeleteUserInput{
+			UserPoolId: aws.String(dao.UserPoolID),
+			Username:   aws.String(cognitoUserID),
+		})
+		return callErr
+	})
+	if deleteErr == nil {
+		return true, nil
+	}
+	dao.Logger.WithFields(logrus.Fields{
+		"cognito_id": cognitoUserID,
+		"error":      deleteErr.Error(),
+	}).Warn("Failed to delete Cognito user after database error, disabling instead")
+
+	disableErr := clients.WithCognitoRetry(ctx, dao.RetryConfig, "AdminDisableUser", dao.Logger, func(ctx context.Context) error {
+		_, callErr := dao.CognitoClient.AdminDisableUser(ctx, &cognitoidentityprovider.AdminDisableUserInput{
+			UserPoolId: aws.String(dao.UserPoolID),
+			Username:   aws.String(cognitoUserID),
+		})
+		return callErr
+	})
+	if disableErr != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"cognito_id": cognitoUserID,
+			"error":      disableErr.Error(),
+		}).Error("Failed to delete or disable orphaned Cognito user; manual cleanup required")
+		return false, fmt.Errorf("failed to remove Cognito user: %w", disableErr)
+	}
+	dao.Logger.WithField("cognito_id", cognitoUserID).Warn("Orphaned Cognito user disabled but not deleted; manual cleanup required")
+
+	return false, nil
+}
+
 // generateTemporaryPassword generates a secure temporary password
 func generateTemporaryPassword() string {
 	// Generate a random 12-character password with mixed case, numbers, and symbols
@@ -289,15 +452,17 @@ func (dao *UserManagementDao) GetUserByID(ctx context.Context, userID, orgID int
 	var user models.User
 	query := `
 		SELECT id, cognito_id, email, first_name, last_name, phone, mobile, job_title, employee_id, 
-		       avatar_url, last_selected_location_id, is_super_admin, status, org_id, created_at, updated_at
+		       avatar_url, last_selected_location_id, is_super_admin, status, org_id, created_at, updated_at,
+		       last_login_at
 		FROM iam.users
 		WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
 	`
 
+	var lastLoginAt sql.NullTime
 	err := dao.DB.QueryRowContext(ctx, query, userID, orgID).Scan(
 		&user.UserID, &user.CognitoID, &user.Email, &user.FirstName, &user.LastName,
 		&user.Phone, &user.Mobile, &user.JobTitle, &user.EmployeeID, &user.AvatarURL, &user.LastSelectedLocationID, &user.IsSuperAdmin, &user.Status, &user.OrgID,
-		&user.CreatedAt, &user.UpdatedAt,
+		&user.CreatedAt, &user.UpdatedAt, &lastLoginAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -305,7 +470,7 @@ func (dao *UserManagementDao) GetUserByID(ctx context.Context, userID, orgID int
 			"user_id": userID,
 			"org_id":  orgID,
 		}).Warn("User not found")
-		return nil, fmt.Errorf("user not found")
+		return nil, notFoundError("user not found")
 	}
 
 	if err != nil {
@@ -316,6 +481,9 @@ func (dao *UserManagementDao) GetUserByID(ctx context.Context, userID, orgID int
 		}).Error("Failed to get user")
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	if lastLoginAt.Valid {
+		user.LastLoginAt = &lastLoginAt.Time
+	}
 
 	// Get location-role assignments
 	assignments, err := dao.GetUserLocationRoleAssignments(ctx, user.UserID)
@@ -403,15 +571,19 @@ func (dao *UserManagementDao) UpdateUser(ctx context.Context, userID, orgID int6
 	paramIndex := 1
 
 	// Only update email if provided and different
+	user.Email = models.NormalizeEmail(user.Email)
 	if user.Email != "" && currentUser.Email != user.Email {
 		// Update Cognito email first
-		_, err = dao.CognitoClient.AdminUpdateUserAttributes(ctx, &cognitoidentityprovider.AdminUpdateUserAttributesInput{
-			UserPoolId: aws.String(dao.UserPoolID),
-			Username:   aws.String(currentUser.CognitoID),
-			UserAttributes: []types.AttributeType{
-				{Name: aws.String("email"), Value: aws.String(user.Email)},
-				{Name: aws.String("email_verified"), Value: aws.String("true")},
-			},
+		err = clients.WithCognitoRetry(ctx, dao.RetryConfig, "AdminUpdateUserAttributes", dao.Logger, func(ctx context.Context) error {
+			_, callErr := dao.CognitoClient.AdminUpdateUserAttributes(ctx, &cognitoidentityprovider.AdminUpdateUserAttributesInput{
+				UserPoolId: aws.String(dao.UserPoolID),
+				Username:   aws.String(currentUser.CognitoID),
+				UserAttributes: []types.AttributeType{
+					{Name: aws.String("email"), Value: aws.String(user.Email)},
+					{Name: aws.String("email_verified"), Value: aws.String("true")},
+				},
+			})
+			return callErr
 		})
 		if err != nil {
 			dao.Logger.WithFields(logrus.Fields{
@@ -575,6 +747,295 @@ func (dao *UserManagementDao) DeleteUser(ctx context.Context, userID, orgID int6
 	return nil
 }
 
+// UserBelongsToOrg reports whether the user exists, is not deleted and belongs to the organization.
+// Used to validate assignees before they are attached to issues, RFIs and assignments.
+func (dao *UserManagementDao) UserBelongsToOrg(ctx context.Context, userID, orgID int64) (bool, error) {
+	var belongs bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM iam.users
+			WHERE id = $1 AND org_id = $2 AND is_deleted = FALSE
+		)
+	`, userID, orgID).Scan(&belongs)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"org_id":  orgID,
+			"error":   err.Error(),
+		}).Error("Failed to check user organization")
+		return false, fmt.Errorf("failed to check user organization: %w", err)
+	}
+	return belongs, nil
+}
+
+// EmailExists reports whether a non-deleted user in the organization already has the email. The
+// comparison ignores case so rows stored before emails were normalized are still matched.
+func (dao *UserManagementDao) EmailExists(ctx context.Context, orgID int64, email string) (bool, error) {
+	var exists bool
+	err := dao.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM iam.users
+			WHERE org_id = $1 AND LOWER(email) = $2 AND is_deleted = FALSE
+		)
+	`, orgID, models.NormalizeEmail(email)).Scan(&exists)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id": orgID,
+			"error":  err.Error(),
+		}).Error("Failed to check email")
+		return false, fmt.Errorf("failed to check email: %w", err)
+	}
+	return exists, nil
+}
+
+// RecordLastLogin sets last_login_at for the user with the given Cognito ID. loginAt is captured by the
+// caller when the sign-in happened, and an older value never replaces a newer one, so a delayed write
+// cannot move the timestamp backwards.
+func (dao *UserManagementDao) RecordLastLogin(ctx context.Context, cognitoID string, loginAt time.Time) error {
+	_, err := dao.DB.ExecContext(ctx, `
+		UPDATE iam.users
+		SET last_login_at = $2
+		WHERE cognito_id = $1 AND is_deleted = FALSE
+		  AND (last_login_at IS NULL OR last_login_at < $2)
+	`, cognitoID, loginAt.UTC())
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"cognito_id": cognitoID,
+			"error":      err.Error(),
+		}).Error("Failed to record last login")
+		return fmt.Errorf("failed to record last login: %w", err)
+	}
+	return nil
+}
+
+// GetEmailRecipients returns the active, non-deleted users in the organization from userIDs who have
+// email enabled for eventType (on unless turned off). Users from other organizations are silently dropped.
+func (dao *UserManagementDao) GetEmailRecipients(ctx context.Context, orgID int64, userIDs []int64, eventType string) ([]models.NotificationRecipient, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT u.id, u.email, TRIM(CONCAT(u.first_name, ' ', u.last_name))
+		FROM iam.users u
+		LEFT JOIN iam.user_notification_prefs p ON p.user_id = u.id AND p.event_type = $3
+		WHERE u.org_id = $1 AND u.id = ANY($2) AND u.is_deleted = FALSE
+		  AND u.status = 'active' AND COALESCE(p.email_enabled, TRUE)
+		ORDER BY u.id
+	`, orgID, pq.Array(userIDs), eventType)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id": orgID,
+			"error":  err.Error(),
+		}).Error("Failed to get email recipients")
+		return nil, fmt.Errorf("failed to get email recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []models.NotificationRecipient
+	for rows.Next() {
+		var recipient models.NotificationRecipient
+		if err := rows.Scan(&recipient.UserID, &recipient.Email, &recipient.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan email recipient: %w", err)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+// GetSNSRecipientIDs returns the IDs of the active, non-deleted users in the organization from userIDs who
+// have SNS enabled for eventType (on unless turned off), in ID order. Senders include only these users as
+// recipients of an SNS notification.
+func (dao *UserManagementDao) GetSNSRecipientIDs(ctx context.Context, orgID int64, userIDs []int64, eventType string) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, `
+		SELECT u.id
+		FROM iam.users u
+		LEFT JOIN iam.user_notification_prefs p ON p.user_id = u.id AND p.event_type = $3
+		WHERE u.org_id = $1 AND u.id = ANY($2) AND u.is_deleted = FALSE
+		  AND u.status = 'active' AND COALESCE(p.sns_enabled, TRUE)
+		ORDER BY u.id
+	`, orgID, pq.Array(userIDs), eventType)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"org_id": orgID,
+			"error":  err.Error(),
+		}).Error("Failed to get SNS recipients")
+		return nil, fmt.Errorf("failed to get SNS recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipientIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan SNS recipient: %w", err)
+		}
+		recipientIDs = append(recipientIDs, userID)
+	}
+	return recipientIDs, rows.Err()
+}
+
+// GetNotificationPrefs returns the user's preferences for every known event type, using the defaults
+// for event types the user has never changed
+func (dao *UserManagementDao) GetNotificationPrefs(ctx context.Context, userID int64) ([]models.NotificationPref, error) {
+	return getNotificationPrefs(ctx, dao.DB, userID)
+}
+
+func getNotificationPrefs(ctx context.Context, db dbtx, userID int64) ([]models.NotificationPref, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT event_type, email_enabled, sns_enabled
+		FROM iam.user_notification_prefs
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	stored := make(map[string]models.NotificationPref)
+	for rows.Next() {
+		var pref models.NotificationPref
+		if err := rows.Scan(&pref.EventType, &pref.Email, &pref.SNS); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		stored[pref.EventType] = pref
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	prefs := make([]models.NotificationPref, 0, len(models.NotificationEventTypes))
+	for _, eventType := range models.NotificationEventTypes {
+		pref, ok := stored[eventType]
+		if !ok {
+			pref = models.DefaultNotificationPref(eventType)
+		}
+		prefs = append(prefs, pref)
+	}
+	return prefs, nil
+}
+
+// UpdateNotificationPrefs upserts one row per update in a single transaction. A channel left nil keeps
+// its stored value, or the default when the event type has no row yet.
+func (dao *UserManagementDao) UpdateNotificationPrefs(ctx context.Context, userID int64, updates []models.NotificationPrefUpdate, updatedBy int64) ([]models.NotificationPref, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, update := range updates {
+		defaults := models.DefaultNotificationPref(update.EventType)
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO iam.user_notification_prefs (user_id, event_type, email_enabled, sns_enabled, updated_by)
+			VALUES ($1, $2, COALESCE($3::boolean, $5::boolean), COALESCE($4::boolean, $6::boolean), $7)
+			ON CONFLICT (user_id, event_type) DO UPDATE SET
+				email_enabled = COALESCE($3::boolean, iam.user_notification_prefs.email_enabled),
+				sns_enabled = COALESCE($4::boolean, iam.user_notification_prefs.sns_enabled),
+				updated_at = CURRENT_TIMESTAMP,
+				updated_by = $7
+		`, userID, update.EventType, update.Email, update.SNS, defaults.Email, defaults.SNS, updatedBy)
+		if err != nil {
+			dao.Logger.WithFields(logrus.Fields{
+				"user_id":    userID,
+				"event_type": update.EventType,
+				"error":      err.Error(),
+			}).Error("Failed to update notification preference")
+			return nil, fmt.Errorf("failed to update notification preference: %w", err)
+		}
+	}
+
+	prefs, err := getNotificationPrefs(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return prefs, nil
+}
+
+// GetUserProjects retrieves the projects a user holds a role on within the organization.
+// Returns the page of project roles and the total number of matching rows.
+func (dao *UserManagementDao) GetUserProjects(ctx context.Context, userID, orgID int64, limit, offset int) ([]models.UserProjectRole, int, error) {
+	const fromClause = `
+		FROM project.project_user_roles pur
+		JOIN project.projects p ON p.id = pur.project_id AND p.is_deleted = FALSE
+		JOIN iam.roles r ON r.id = pur.role_id
+		WHERE pur.user_id = $1 AND p.org_id = $2 AND pur.is_deleted = FALSE`
+
+	var totalCount int
+	err := dao.DB.QueryRowContext(ctx, `SELECT COUNT(*)`+fromClause, userID, orgID).Scan(&totalCount)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"org_id":  orgID,
+			"error":   err.Error(),
+		}).Error("Failed to count user projects")
+		return nil, 0, fmt.Errorf("failed to count user projects: %w", err)
+	}
+
+	projects := []models.UserProjectRole{}
+	if totalCount == 0 {
+		return projects, 0, nil
+	}
+
+	query := `
+		SELECT p.id, COALESCE(p.project_number, ''), p.name, p.status, p.location_id,
+		       pur.role_id, r.name, pur.trade_type, pur.is_primary,
+		       TO_CHAR(pur.start_date, 'YYYY-MM-DD'), TO_CHAR(pur.end_date, 'YYYY-MM-DD')` + fromClause + `
+		ORDER BY p.name ASC, pur.is_primary DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, orgID, limit, offset)
+	if err != nil {
+		dao.Logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"org_id":  orgID,
+			"error":   err.Error(),
+		}).Error("Failed to query user projects")
+		return nil, 0, fmt.Errorf("failed to query user projects: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var project models.UserProjectRole
+		var tradeType, startDate, endDate sql.NullString
+		err := rows.Scan(
+			&project.ProjectID, &project.ProjectNumber, &project.ProjectName, &project.ProjectStatus, &project.LocationID,
+			&project.RoleID, &project.RoleName, &tradeType, &project.IsPrimary,
+			&startDate, &endDate,
+		)
+		if err != nil {
+			dao.Logger.WithError(err).Error("Failed to scan user project row")
+			return nil, 0, fmt.Errorf("failed to scan user project: %w", err)
+		}
+		if tradeType.Valid {
+			project.TradeType = &tradeType.String
+		}
+		if startDate.Valid {
+			project.StartDate = &startDate.String
+		}
+		if endDate.Valid {
+			project.EndDate = &endDate.String
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		dao.Logger.WithError(err).Error("Error iterating user project rows")
+		return nil, 0, fmt.Errorf("failed to iterate user projects: %w", err)
+	}
+
+	return projects, totalCount, nil
+}
+
 // GetUserLocationRoleAssignments retrieves user's location-role assignments
 // Based on new schema: user_location_access + org_user_roles + location_user_roles
 func (dao *UserManagementDao) GetUserLocationRoleAssignments(ctx context.Context, userID int64) ([]models.UserLocationRoleAssignment, error) {
@@ -592,7 +1053,10 @@ func (dao *UserManagementDao) SendPasswordResetEmail(ctx context.Context, userEm
 		Username:   aws.String(userEmail),
 	}
 
-	_, err := dao.CognitoClient.AdminResetUserPassword(ctx, input)
+	err := clients.WithCognitoRetry(ctx, dao.RetryConfig, "AdminResetUserPassword", dao.Logger, func(ctx context.Context) error {
+		_, callErr := dao.CognitoClient.AdminResetUserPassword(ctx, input)
+		return callErr
+	})
 	if err != nil {
 		dao.Logger.WithFields(logrus.Fields{
 			"email": userEmail,
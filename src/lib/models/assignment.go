@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 )
 
@@ -45,6 +46,16 @@ type UpdateAssignmentRequest struct {
 	EndDate     string `json:"end_date,omitempty"`
 }
 
+// AssignmentConflictError reports a create that duplicates a live assignment of the same user, role
+// and context whose dates overlap
+type AssignmentConflictError struct {
+	AssignmentID int64
+}
+
+func (e *AssignmentConflictError) Error() string {
+	return fmt.Sprintf("conflicts with existing assignment %d", e.AssignmentID)
+}
+
 // BulkAssignmentRequest represents the request to create multiple assignments at once
 type BulkAssignmentRequest struct {
 	UserIDs     []int64 `json:"user_ids" binding:"required,min=1"`
@@ -57,6 +68,14 @@ type BulkAssignmentRequest struct {
 	EndDate     string  `json:"end_date,omitempty"`
 }
 
+// BulkCreateAssignmentsRequest represents POST /assignments/bulk: independent assignments created in one transaction
+type BulkCreateAssignmentsRequest struct {
+	Assignments []CreateAssignmentRequest `json:"assignments" binding:"required,min=1"`
+}
+
+// MaxBulkAssignments caps the number of assignments accepted by POST /assignments/bulk
+const MaxBulkAssignments = 100
+
 // AssignmentResponse represents the clean assignment response without sql.Null* types
 type AssignmentResponse struct {
 	ID          int64     `json:"id"`
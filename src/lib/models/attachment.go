@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -22,7 +23,9 @@ type Attachment struct {
 	MimeType       *string   `json:"mime_type,omitempty"`
 	AttachmentType string    `json:"attachment_type"` // Category of attachment
 	UploadedBy     int64     `json:"uploaded_by"`
-	UploadStatus   string    `json:"upload_status"`   // "pending", "uploaded", "failed"
+	UploadStatus   string    `json:"upload_status"`   // "pending", "confirmed", "failed"
+	ScanStatus     string    `json:"scan_status"`     // "pending", "clean", "infected"
+	ThumbnailPath  *string   `json:"thumbnail_path,omitempty"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int64     `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -35,7 +38,7 @@ type AttachmentUploadRequest struct {
 	EntityType     string `json:"entity_type" binding:"required,oneof=project issue rfi submittal issue_comment rfi_comment"`
 	EntityID       int64  `json:"entity_id"` // Required for most types, can be 0 for issue_comment/rfi_comment (updated after comment creation)
 	ProjectID      int64  `json:"project_id" binding:"required"`
-	LocationID     int64  `json:"location_id" binding:"required"`
+	LocationID     int64  `json:"location_id,omitempty"` // Optional; defaults to the project's current location
 	OrgID          int64  `json:"org_id,omitempty"` // Set from JWT claims
 	FileName       string `json:"file_name" binding:"required,max=255"`
 	FileSize       int64  `json:"file_size" binding:"required,max=104857600"` // 100MB max
@@ -50,17 +53,89 @@ type AttachmentUploadResponse struct {
 	ExpiresAt    string `json:"expires_at"`
 }
 
+// AttachmentBatchUploadFile describes one file in a batch upload URL request
+type AttachmentBatchUploadFile struct {
+	FileName       string `json:"file_name" binding:"required,max=255"`
+	FileSize       int64  `json:"file_size" binding:"required"`
+	AttachmentType string `json:"attachment_type" binding:"required"`
+}
+
+// AttachmentBatchUploadRequest represents a request for upload URLs for several files on the same entity
+type AttachmentBatchUploadRequest struct {
+	EntityType string                      `json:"entity_type" binding:"required,oneof=project issue rfi submittal issue_comment rfi_comment"`
+	EntityID   int64                       `json:"entity_id"`
+	ProjectID  int64                       `json:"project_id" binding:"required"`
+	LocationID int64                       `json:"location_id,omitempty"`
+	OrgID      int64                       `json:"org_id,omitempty"` // Set from JWT claims
+	Files      []AttachmentBatchUploadFile `json:"files" binding:"required,min=1"`
+}
+
 // AttachmentConfirmRequest represents a request to confirm upload completion
 type AttachmentConfirmRequest struct {
-	AttachmentID int64 `json:"attachment_id" binding:"required"`
+	AttachmentID int64  `json:"attachment_id" binding:"required"`
+	EntityType   string `json:"entity_type,omitempty"` // Optional; looked up from the attachment when omitted
+}
+
+// AttachmentConfirmResponse reports the size S3 actually stored next to the size claimed at upload-url time
+type AttachmentConfirmResponse struct {
+	AttachmentID    int64   `json:"attachment_id"`
+	Status          string  `json:"status"`
+	FileSize        int64   `json:"file_size"`
+	ClaimedFileSize *int64  `json:"claimed_file_size,omitempty"`
+	SizeMismatch    bool    `json:"size_mismatch"`
+	ThumbnailPath   *string `json:"thumbnail_path,omitempty"`
+}
+
+// AttachmentThumbnailResponse represents the response with a thumbnail URL
+type AttachmentThumbnailResponse struct {
+	ThumbnailURL string `json:"thumbnail_url"`
+	ExpiresAt    string `json:"expires_at"`
 }
 
 // AttachmentDownloadResponse represents the response with download URL
+// FileName is sanitized for display; ContentDisposition is a ready-to-use header value that
+// carries the original name RFC 5987-encoded and is also applied by S3 when the URL is fetched
 type AttachmentDownloadResponse struct {
-	DownloadURL string `json:"download_url"`
-	FileName    string `json:"file_name"`
-	FileSize    *int64 `json:"file_size,omitempty"`
-	ExpiresAt   string `json:"expires_at"`
+	DownloadURL        string `json:"download_url"`
+	FileName           string `json:"file_name"`
+	ContentDisposition string `json:"content_disposition"`
+	FileSize           *int64 `json:"file_size,omitempty"`
+	ExpiresAt          string `json:"expires_at"`
+}
+
+// AttachmentAccessLog represents a single download of an attachment, based on project.attachment_access_log table
+type AttachmentAccessLog struct {
+	ID           int64     `json:"id"`
+	AttachmentID int64     `json:"attachment_id"`
+	EntityType   string    `json:"entity_type"`
+	OrgID        int64     `json:"org_id"`
+	UserID       int64     `json:"user_id"`
+	UserName     string    `json:"user_name,omitempty"`
+	UserEmail    string    `json:"user_email,omitempty"`
+	IPAddress    string    `json:"ip_address,omitempty"`
+	UserAgent    string    `json:"user_agent,omitempty"`
+	AccessedAt   time.Time `json:"accessed_at"`
+}
+
+// AttachmentAccessLogResponse represents the access history of an attachment
+type AttachmentAccessLogResponse struct {
+	AttachmentID int64                 `json:"attachment_id"`
+	EntityType   string                `json:"entity_type"`
+	Entries      []AttachmentAccessLog `json:"entries"`
+	TotalCount   int                   `json:"total_count"`
+}
+
+// AttachmentRelinkRequest represents the request to link an orphaned comment attachment to a comment
+type AttachmentRelinkRequest struct {
+	EntityType string `json:"entity_type" binding:"required,oneof=issue_comment rfi_comment"`
+	CommentID  int64  `json:"comment_id" binding:"required"`
+}
+
+// AttachmentEntityUpdateRequest represents the request to link a comment attachment uploaded with
+// entity_id 0 to the comment created afterwards
+type AttachmentEntityUpdateRequest struct {
+	EntityType string `json:"entity_type" binding:"required,oneof=issue_comment rfi_comment"`
+	EntityID   int64  `json:"entity_id" binding:"required"`
 }
 
 // AttachmentListResponse represents a paginated list of attachments
@@ -105,11 +180,38 @@ const (
 
 // Upload Status constants
 const (
-	UploadStatusPending  = "pending"
-	UploadStatusUploaded = "uploaded"
-	UploadStatusFailed   = "failed"
+	UploadStatusPending   = "pending"
+	UploadStatusConfirmed = "confirmed"
+	UploadStatusFailed    = "failed"
 )
 
+// Scan Status constants. Only clean attachments can be downloaded.
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+)
+
+// AttachmentScanResultRequest is sent by the virus scanner to POST /attachments/{id}/scan-result
+type AttachmentScanResultRequest struct {
+	EntityType string `json:"entity_type" binding:"required"`
+	ScanStatus string `json:"scan_status" binding:"required,oneof=clean infected"`
+}
+
+// ThumbnailMaxDimension is the longest side, in pixels, of generated image thumbnails
+const ThumbnailMaxDimension = 256
+
+// SupportsThumbnail reports whether thumbnails are generated for the MIME type
+func SupportsThumbnail(mimeType string) bool {
+	return mimeType == "image/png" || mimeType == "image/jpeg"
+}
+
+// ThumbnailKey returns the S3 key of the thumbnail for an attachment, in a thumbnails/ folder next to the original
+func ThumbnailKey(filePath string) string {
+	dir, file := filepath.Split(filePath)
+	return dir + "thumbnails/" + file
+}
+
 // Entity Type constants
 const (
 	EntityTypeProject      = "project"
@@ -120,6 +222,61 @@ const (
 	EntityTypeRFIComment   = "rfi_comment"
 )
 
+// AttachmentEntityTypes lists every entity type that has its own attachment table
+var AttachmentEntityTypes = []string{
+	EntityTypeProject,
+	EntityTypeIssue,
+	EntityTypeRFI,
+	EntityTypeSubmittal,
+	EntityTypeIssueComment,
+	EntityTypeRFIComment,
+}
+
+// DefaultMaxAttachmentsPerEntity caps non-deleted attachments on a single entity
+// when no per-entity-type override is configured
+const DefaultMaxAttachmentsPerEntity = 200
+
+// MaxAttachmentFileSize is the hard cap on a single upload, whatever the entity type
+const MaxAttachmentFileSize int64 = 100 * 1024 * 1024
+
+// MaxAttachmentBatchFiles caps the number of files in one batch upload URL request
+const MaxAttachmentBatchFiles = 25
+
+// MaxAttachmentCountEntities caps the number of entity ids in one attachment counts request
+const MaxAttachmentCountEntities = 500
+
+// AttachmentCountsRequest asks for the attachment counts of several entities of one type
+type AttachmentCountsRequest struct {
+	EntityIDs []int64 `json:"entity_ids"`
+}
+
+// AttachmentCountsResponse maps each requested entity id to its number of non-deleted attachments.
+// Entities with no attachments, or outside the caller's organization, count 0.
+type AttachmentCountsResponse struct {
+	EntityType string        `json:"entity_type"`
+	Counts     map[int64]int `json:"counts"`
+}
+
+// MaxFileSizeByEntityType limits upload size per entity type. Comment attachments are
+// photos and markups, so they get a much smaller limit than submittal packages.
+var MaxFileSizeByEntityType = map[string]int64{
+	EntityTypeProject:      100 * 1024 * 1024,
+	EntityTypeIssue:        50 * 1024 * 1024,
+	EntityTypeRFI:          50 * 1024 * 1024,
+	EntityTypeSubmittal:    100 * 1024 * 1024,
+	EntityTypeIssueComment: 25 * 1024 * 1024,
+	EntityTypeRFIComment:   25 * 1024 * 1024,
+}
+
+// MaxFileSizeForEntityType returns the upload size limit in bytes for an entity type,
+// never more than MaxAttachmentFileSize
+func MaxFileSizeForEntityType(entityType string) int64 {
+	if limit, ok := MaxFileSizeByEntityType[entityType]; ok && limit < MaxAttachmentFileSize {
+		return limit
+	}
+	return MaxAttachmentFileSize
+}
+
 // GenerateS3Key creates the S3 key based on the hierarchical path structure
 func (req *AttachmentUploadRequest) GenerateS3Key() string {
 	timestamp := time.Now().Format("20060102150405")
@@ -256,4 +413,30 @@ func GetMimeType(fileName string) string {
 		return mimeType
 	}
 	return "application/octet-stream"
-}
\ No newline at end of file
+}
+
+// UnmarshalJSON accepts entity_id, project_id and location_id as numbers or numeric strings
+func (req *AttachmentUploadRequest) UnmarshalJSON(data []byte) error {
+	type plain AttachmentUploadRequest
+	aux := struct {
+		*plain
+		EntityID   json.RawMessage `json:"entity_id"`
+		ProjectID  json.RawMessage `json:"project_id"`
+		LocationID json.RawMessage `json:"location_id"`
+	}{plain: (*plain)(req)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if req.EntityID, err = ParseFlexibleInt64("entity_id", aux.EntityID); err != nil {
+		return err
+	}
+	if req.ProjectID, err = ParseFlexibleInt64("project_id", aux.ProjectID); err != nil {
+		return err
+	}
+	if req.LocationID, err = ParseFlexibleInt64("location_id", aux.LocationID); err != nil {
+		return err
+	}
+	return nil
+}
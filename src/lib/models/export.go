@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// ExportJobLease is how long a claimed job stays with its worker. It is longer than the export worker's
+// 15 minute timeout, so a processing job older than this belongs to a worker that crashed or timed out.
+const ExportJobLease = 20 * time.Minute
+
+// MaxExportJobAttempts caps how many times a job is claimed before it is marked failed
+const MaxExportJobAttempts = 3
+
+// ExportJob represents an asynchronous project export package job
+type ExportJob struct {
+	ID           int64      `json:"id"`
+	OrgID        int64      `json:"org_id"`
+	ProjectID    int64      `json:"project_id"`
+	Status       string     `json:"status"`
+	FilePath     *string    `json:"-"`
+	FileSize     *int64     `json:"file_size,omitempty"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+	Attempts     int        `json:"attempts"`
+	RequestedBy  int64      `json:"requested_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// ExportJobResponse is returned when polling an export job; DownloadURL is set once the job completes
+type ExportJobResponse struct {
+	ExportJob
+	DownloadURL string `json:"download_url,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+}
+
+// Export job status constants
+const (
+	ExportJobStatusPending    = "pending"
+	ExportJobStatusProcessing = "processing"
+	ExportJobStatusCompleted  = "completed"
+	ExportJobStatusFailed     = "failed"
+)
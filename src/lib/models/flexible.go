@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InvalidNumberError reports a request field that must hold a whole number but doesn't.
+// Handlers surface Error() directly so clients see which field was wrong.
+type InvalidNumberError struct {
+	Field string
+	Value string
+}
+
+func (e *InvalidNumberError) Error() string {
+	return fmt.Sprintf("%s must be a number, got %s", e.Field, e.Value)
+}
+
+// ParseFlexibleInt64 decodes an ID field that clients may send either as a number (123) or as a
+// numeric string ("123"), as form libraries often do. Missing, null and empty-string values decode to 0.
+func ParseFlexibleInt64(field string, raw json.RawMessage) (int64, error) {
+	value := strings.TrimSpace(string(raw))
+	if value == "" || value == "null" || value == `""` {
+		return 0, nil
+	}
+
+	if strings.HasPrefix(value, `"`) {
+		var unquoted string
+		if err := json.Unmarshal(raw, &unquoted); err != nil {
+			return 0, &InvalidNumberError{Field: field, Value: value}
+		}
+		value = strings.TrimSpace(unquoted)
+		if value == "" {
+			return 0, nil
+		}
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, &InvalidNumberError{Field: field, Value: string(raw)}
+	}
+	return parsed, nil
+}
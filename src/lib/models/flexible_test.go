@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlexibleInt64(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want int64
+	}{
+		{"number", `123`, 123},
+		{"numeric string", `"123"`, 123},
+		{"numeric string with spaces", `" 42 "`, 42},
+		{"negative number", `-7`, -7},
+		{"null", `null`, 0},
+		{"missing", ``, 0},
+		{"empty string", `""`, 0},
+		{"blank string", `"  "`, 0},
+		{"max int64", `9223372036854775807`, 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			value, err := ParseFlexibleInt64("project_id", json.RawMessage(tt.raw))
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestParseFlexibleInt64_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"overflow number", `9223372036854775808`},
+		{"overflow string", `"99999999999999999999"`},
+		{"decimal", `1.5`},
+		{"non-numeric string", `"abc"`},
+		{"boolean", `true`},
+		{"unterminated string", `"12`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			value, err := ParseFlexibleInt64("project_id", json.RawMessage(tt.raw))
+
+			//Assert
+			var numberErr *InvalidNumberError
+			assert.True(t, errors.As(err, &numberErr))
+			assert.Equal(t, "project_id", numberErr.Field)
+			assert.Equal(t, int64(0), value)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// IdempotencyClaimLease is how long a key reserved by a request that has not finished blocks
+// retries. It is longer than any Lambda timeout, so a claim older than this belongs to a request
+// that died and can be taken over.
+const IdempotencyClaimLease = 2 * time.Minute
+
+// Idempotency scopes keep keys for different create endpoints apart
+const (
+	IdempotencyScopeCreateIssue = "create_issue"
+	IdempotencyScopeCreateRFI   = "create_rfi"
+)
+
+// IdempotencyKey represents a client-supplied Idempotency-Key, based on project.idempotency_keys table.
+// ResourceID is nil while the first request with the key is still running.
+type IdempotencyKey struct {
+	OrgID       int64     `json:"org_id"`
+	Scope       string    `json:"scope"`
+	Key         string    `json:"idempotency_key"`
+	UserID      int64     `json:"user_id"`
+	RequestHash string    `json:"request_hash"`
+	ResourceID  *int64    `json:"resource_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
@@ -2,6 +2,10 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -237,19 +241,142 @@ type IssueResponse struct {
 	DaysOpen            int    `json:"days_open,omitempty"`
 	IsOverdue           bool   `json:"is_overdue"`
 
+	// AgeDays is whole days since creation; DaysSinceLastActivity is whole days since the last update or comment
+	AgeDays               int `json:"age_days"`
+	DaysSinceLastActivity int `json:"days_since_last_activity"`
+
+	// DefaultAssigneeApplied is set on create when assigned_to came from the project's default assignee
+	DefaultAssigneeApplied bool `json:"default_assignee_applied,omitempty"`
+
+	// SLADueDateApplied is set on create when due_date was derived from the org's SLA for the priority
+	SLADueDateApplied bool `json:"sla_due_date_applied,omitempty"`
+
 	// Attachments
 	Attachments []IssueAttachment `json:"attachments"`
 
 	// Comments and Activity Log
-	Comments []IssueComment `json:"comments,omitempty"`
+	// On the full issue GET only the most recent comments are bundled; CommentCount is the total
+	Comments     []IssueComment `json:"comments,omitempty"`
+	CommentCount int            `json:"comment_count,omitempty"`
 }
 
-// IssueListResponse represents the response for listing issues
+// IssueListResponse represents the response for listing issues.
+// Total counts every issue matching the filters, not just the returned page.
+// NextCursor is only set in cursor mode when another page exists.
 type IssueListResponse struct {
-	Issues   []IssueResponse `json:"issues"`
-	Total    int             `json:"total"`
-	Page     int             `json:"page"`
-	PageSize int             `json:"page_size"`
+	Issues     []IssueResponse `json:"issues"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	HasNext    bool            `json:"has_next"`
+	HasPrev    bool            `json:"has_prev"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// IssueEventStatusChanged is the event_type of IssueStatusChangedEvent
+const IssueEventStatusChanged = "issue.status_changed"
+
+// IssueStatusChangedEvent is published to the issue events topic whenever an issue's status changes.
+// RecipientIDs holds the assignee, unless they made the change or turned SNS off for issue_status_changed;
+// notification consumers deliver only to them.
+type IssueStatusChangedEvent struct {
+	EventType    string    `json:"event_type"`
+	IssueID      int64     `json:"issue_id"`
+	ProjectID    int64     `json:"project_id"`
+	OrgID        int64     `json:"org_id"`
+	OldStatus    string    `json:"old_status"`
+	NewStatus    string    `json:"new_status"`
+	RecipientIDs []int64   `json:"recipient_ids"`
+	ActorUserID  int64     `json:"actor_user_id"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// IssueEventAssigned is the event_type of IssueAssignedEvent
+const IssueEventAssigned = "issue.assigned"
+
+// IssueAssignedEvent is published to the issue events topic when an issue is created with, or changed to,
+// an assignee other than the acting user, and that assignee has SNS on for issue_assigned
+type IssueAssignedEvent struct {
+	EventType   string    `json:"event_type"`
+	IssueID     int64     `json:"issue_id"`
+	ProjectID   int64     `json:"project_id"`
+	OrgID       int64     `json:"org_id"`
+	AssigneeID  int64     `json:"assignee_id"`
+	ActorUserID int64     `json:"actor_user_id"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// IssueEventWatcherNotification is the event_type of IssueWatcherNotification
+const IssueEventWatcherNotification = "issue.watcher_notification"
+
+// IssueEventCommentAdded is the Trigger of a watcher notification sent for a new comment
+const IssueEventCommentAdded = "issue.comment_added"
+
+// IssueWatcherNotification is recorded in project.issue_notifications and published to the issue events
+// topic when a comment is added or the status changes. Trigger is IssueEventCommentAdded or IssueEventStatusChanged.
+// RecipientIDs are the issue's watchers, excluding the user who made the change; the published copy keeps only
+// the watchers with SNS on for issue_watcher_update.
+type IssueWatcherNotification struct {
+	EventType    string    `json:"event_type"`
+	Trigger      string    `json:"trigger"`
+	IssueID      int64     `json:"issue_id"`
+	ProjectID    int64     `json:"project_id"`
+	OrgID        int64     `json:"org_id"`
+	RecipientIDs []int64   `json:"recipient_ids"`
+	ActorUserID  int64     `json:"actor_user_id"`
+	CommentID    int64     `json:"comment_id,omitempty"`
+	OldStatus    string    `json:"old_status,omitempty"`
+	NewStatus    string    `json:"new_status,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// IssueWatcher is a user subscribed to updates on an issue
+type IssueWatcher struct {
+	UserID    int64     `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	AddedBy   int64     `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddIssueWatcherRequest is the body of POST /issues/{issueId}/watchers; without user_id the caller is added
+type AddIssueWatcherRequest struct {
+	UserID int64 `json:"user_id,omitempty"`
+}
+
+// IssueWatchersResponse lists an issue's watchers
+type IssueWatchersResponse struct {
+	IssueID  int64          `json:"issue_id"`
+	Watchers []IssueWatcher `json:"watchers"`
+}
+
+// BulkIssueStatusRequest represents POST /issues/bulk-status.
+// By default each issue is updated independently; Atomic applies all updates or none.
+type BulkIssueStatusRequest struct {
+	IssueIDs []int64 `json:"issue_ids" binding:"required,min=1"`
+	Status   string  `json:"status" binding:"required"`
+	Atomic   bool    `json:"atomic,omitempty"`
+}
+
+// MaxBulkIssueStatusUpdates caps the number of issues accepted by POST /issues/bulk-status
+const MaxBulkIssueStatusUpdates = 100
+
+// DeleteIssueRequest is the optional body of DELETE /issues/{issueId}
+type DeleteIssueRequest struct {
+	DeleteReason string `json:"delete_reason,omitempty"`
+}
+
+// MaxIssueDeleteReasonLength caps the delete_reason accepted by DELETE /issues/{issueId}
+const MaxIssueDeleteReasonLength = 1000
+
+// IssueListQuery controls which page of a project's issues is returned.
+// When CursorID is set the page starts after the (CursorCreatedAt, CursorID) position and Page is ignored.
+// A PageSize of 0 returns every matching issue.
+type IssueListQuery struct {
+	Page            int
+	PageSize        int
+	CursorCreatedAt time.Time
+	CursorID        int64
 }
 
 // IssueTemplate represents a reusable template for creating issues
@@ -279,6 +406,137 @@ const (
 	IssueStatusOnHold         = "on_hold"
 )
 
+// IssueStatuses lists every valid issue status
+var IssueStatuses = []string{
+	IssueStatusOpen, IssueStatusInProgress, IssueStatusReadyForReview,
+	IssueStatusClosed, IssueStatusRejected, IssueStatusOnHold,
+}
+
+// issueStatusTransitions lists the statuses each status may move to. Closed and rejected are
+// terminal: the only way out is an explicit reopen back to open.
+var issueStatusTransitions = map[string][]string{
+	IssueStatusOpen:           {IssueStatusInProgress, IssueStatusReadyForReview, IssueStatusClosed, IssueStatusRejected, IssueStatusOnHold},
+	IssueStatusInProgress:     {IssueStatusOpen, IssueStatusReadyForReview, IssueStatusClosed, IssueStatusRejected, IssueStatusOnHold},
+	IssueStatusReadyForReview: {IssueStatusOpen, IssueStatusInProgress, IssueStatusClosed, IssueStatusRejected, IssueStatusOnHold},
+	IssueStatusOnHold:         {IssueStatusOpen, IssueStatusInProgress, IssueStatusReadyForReview, IssueStatusClosed, IssueStatusRejected},
+	IssueStatusClosed:         {IssueStatusOpen},
+	IssueStatusRejected:       {IssueStatusOpen},
+}
+
+// IsValidIssueStatus checks if the status is a known issue status
+func IsValidIssueStatus(status string) bool {
+	_, ok := issueStatusTransitions[status]
+	return ok
+}
+
+// MaxIssueDueWithinDays caps the due_within_days issue list filter
+const MaxIssueDueWithinDays = 365
+
+// IsTerminalIssueStatus reports whether the status requires a reopen before any other change
+func IsTerminalIssueStatus(status string) bool {
+	return status == IssueStatusClosed || status == IssueStatusRejected
+}
+
+// ValidateIssueStatusTransition checks a status change against the transition matrix.
+// Setting the current status again is a no-op and always allowed.
+func ValidateIssueStatusTransition(from, to string) error {
+	if !IsValidIssueStatus(to) {
+		return fmt.Errorf("invalid status value")
+	}
+	if from == to {
+		return nil
+	}
+	for _, allowed := range issueStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	if IsTerminalIssueStatus(from) {
+		return fmt.Errorf("cannot change status from %s to %s: reopen the issue (set status to %s) first", from, to, IssueStatusOpen)
+	}
+	return fmt.Errorf("cannot change status from %s to %s", from, to)
+}
+
+// IssueFieldChange is one field edited by an issue update, recorded to the activity log
+type IssueFieldChange struct {
+	Field    string `json:"field"`
+	Label    string `json:"label"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	// OldDisplay/NewDisplay are human-readable forms for the activity message (e.g. assignee names)
+	OldDisplay string `json:"-"`
+	NewDisplay string `json:"-"`
+}
+
+// ActivityMessage renders the change for the issue activity log. Long text fields are not
+// echoed into the message; their old and new values are still stored on the entry.
+func (c IssueFieldChange) ActivityMessage() string {
+	if c.Field == "description" {
+		return fmt.Sprintf("%s updated", c.Label)
+	}
+	oldDisplay, newDisplay := c.OldDisplay, c.NewDisplay
+	if oldDisplay == "" {
+		oldDisplay = "(none)"
+	}
+	if newDisplay == "" {
+		newDisplay = "(none)"
+	}
+	return fmt.Sprintf("%s changed from %s to %s", c.Label, oldDisplay, newDisplay)
+}
+
+// DiffIssues compares an issue before and after an update and returns one change per edited
+// field (title, description, priority, severity, status, assignee, due date).
+func DiffIssues(oldIssue, newIssue *IssueResponse) []IssueFieldChange {
+	var changes []IssueFieldChange
+	add := func(field, label, oldValue, newValue, oldDisplay, newDisplay string) {
+		if oldValue != newValue {
+			changes = append(changes, IssueFieldChange{
+				Field: field, Label: label,
+				OldValue: oldValue, NewValue: newValue,
+				OldDisplay: oldDisplay, NewDisplay: newDisplay,
+			})
+		}
+	}
+
+	add("title", "Title", oldIssue.Title, newIssue.Title, oldIssue.Title, newIssue.Title)
+	add("description", "Description", oldIssue.Description, newIssue.Description, "", "")
+	add("priority", "Priority", oldIssue.Priority, newIssue.Priority, oldIssue.Priority, newIssue.Priority)
+	add("severity", "Severity", oldIssue.Severity, newIssue.Severity, oldIssue.Severity, newIssue.Severity)
+	add("status", "Status", oldIssue.Status, newIssue.Status, oldIssue.Status, newIssue.Status)
+
+	oldAssignee, newAssignee := formatOptionalID(oldIssue.AssignedTo), formatOptionalID(newIssue.AssignedTo)
+	add("assigned_to", "Assignee", oldAssignee, newAssignee,
+		firstNonEmpty(oldIssue.AssignedToName, oldAssignee), firstNonEmpty(newIssue.AssignedToName, newAssignee))
+
+	oldDue, newDue := formatOptionalDate(oldIssue.DueDate), formatOptionalDate(newIssue.DueDate)
+	add("due_date", "Due date", oldDue, newDue, oldDue, newDue)
+
+	return changes
+}
+
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+func formatOptionalDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
 // Issue Priority Constants
 const (
 	IssuePriorityCritical = "critical"
@@ -288,6 +546,21 @@ const (
 	IssuePriorityPlanned  = "planned"
 )
 
+// IssuePriorities lists every valid issue priority
+var IssuePriorities = []string{
+	IssuePriorityCritical, IssuePriorityHigh, IssuePriorityMedium, IssuePriorityLow, IssuePriorityPlanned,
+}
+
+// List sort fields shared by the issue and RFI lists
+const (
+	SortCreatedAt             = "created_at"
+	SortAgeDays               = "age_days"
+	SortDaysSinceLastActivity = "days_since_last_activity"
+)
+
+// IssueSortFields lists the accepted sort values for issue lists
+var IssueSortFields = []string{SortCreatedAt, SortAgeDays, SortDaysSinceLastActivity}
+
 // Issue Severity Constants
 const (
 	IssueSeverityBlocking = "blocking"
@@ -306,6 +579,7 @@ type IssueAttachment struct {
 	FileType       *string   `json:"file_type,omitempty"`
 	AttachmentType string    `json:"attachment_type"`
 	UploadedBy     int64     `json:"uploaded_by"`
+	ScanStatus     string    `json:"scan_status"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int64     `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -323,6 +597,7 @@ type IssueCommentAttachment struct {
 	FileType       *string   `json:"file_type,omitempty"`
 	AttachmentType string    `json:"attachment_type"`
 	UploadedBy     int64     `json:"uploaded_by"`
+	ScanStatus     string    `json:"scan_status"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int64     `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -344,17 +619,102 @@ type IssueComment struct {
 	CreatedByName string                   `json:"created_by_name,omitempty"`
 	UpdatedAt     time.Time                `json:"updated_at"`
 	UpdatedBy     int64                    `json:"updated_by"`
+	EditedAt      *time.Time               `json:"edited_at,omitempty"`
 	IsDeleted     bool                     `json:"is_deleted"`
+	Mentions      []CommentMention         `json:"mentions,omitempty"`
+}
+
+// DeletedCommentPlaceholder replaces the text of a deleted comment in comment lists
+const DeletedCommentPlaceholder = "[deleted]"
+
+// IssueCommentQuery controls which page of an issue's comments is returned.
+// AfterID/BeforeID are comment id cursors; when either is set Offset is ignored.
+// Newest selects the most recent Limit comments instead of the oldest.
+// All returns the whole thread and ignores Limit, for callers that do not page.
+// Comments are always returned in chronological order.
+type IssueCommentQuery struct {
+	Limit    int
+	Offset   int
+	AfterID  int64
+	BeforeID int64
+	Newest   bool
+	All      bool
 }
 
+// Issue comment pagination limits
+const (
+	DefaultIssueCommentLimit = 50
+	MaxIssueCommentLimit     = 200
+	IssueDetailCommentLimit  = 20 // comments bundled into the full issue GET
+)
+
+// IssueActivity is one entry of an issue's activity log: a status change, field edit or other system event
+type IssueActivity struct {
+	ID            int64     `json:"id"`
+	IssueID       int64     `json:"issue_id"`
+	Message       string    `json:"message"`
+	PreviousValue string    `json:"previous_value,omitempty"`
+	NewValue      string    `json:"new_value,omitempty"`
+	ActorID       int64     `json:"actor_id"`
+	ActorName     string    `json:"actor_name,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// IssueActivityListResponse represents a page of an issue's activity log, oldest first
+type IssueActivityListResponse struct {
+	IssueID    int64           `json:"issue_id"`
+	Activity   []IssueActivity `json:"activity"`
+	TotalCount int             `json:"total_count"`
+	Limit      int             `json:"limit"`
+	Offset     int             `json:"offset"`
+	HasMore    bool            `json:"has_more"`
+}
+
+// Issue activity log pagination limits
+const (
+	DefaultIssueActivityLimit = 50
+	MaxIssueActivityLimit     = 200
+)
+
 // CreateCommentRequest for adding a comment to an issue
 type CreateCommentRequest struct {
 	Comment       string  `json:"comment" binding:"required"`
 	AttachmentIDs []int64 `json:"attachment_ids,omitempty"`
 }
 
+// UpdateCommentRequest for editing the text of an issue comment
+type UpdateCommentRequest struct {
+	Comment string `json:"comment" binding:"required"`
+}
+
 // Comment Type Constants
 const (
 	CommentTypeComment  = "comment"
 	CommentTypeActivity = "activity"
 )
+
+// UnmarshalJSON accepts project_id, location_id and assigned_to as numbers or numeric strings
+func (r *CreateIssueRequest) UnmarshalJSON(data []byte) error {
+	type plain CreateIssueRequest
+	aux := struct {
+		*plain
+		ProjectID  json.RawMessage `json:"project_id"`
+		LocationID json.RawMessage `json:"location_id"`
+		AssignedTo json.RawMessage `json:"assigned_to"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if r.ProjectID, err = ParseFlexibleInt64("project_id", aux.ProjectID); err != nil {
+		return err
+	}
+	if r.LocationID, err = ParseFlexibleInt64("location_id", aux.LocationID); err != nil {
+		return err
+	}
+	if r.AssignedTo, err = ParseFlexibleInt64("assigned_to", aux.AssignedTo); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func baseDiffIssue() *IssueResponse {
+	return &IssueResponse{
+		ID:          1,
+		Title:       "Cracked slab",
+		Description: "Hairline crack near grid B4",
+		Priority:    IssuePriorityMedium,
+		Severity:    IssueSeverityMinor,
+		Status:      IssueStatusOpen,
+	}
+}
+
+func TestDiffIssues_NoChanges(t *testing.T) {
+	//Arrange
+	assignee := int64(7)
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	oldIssue := baseDiffIssue()
+	oldIssue.AssignedTo = &assignee
+	oldIssue.DueDate = &due
+	newIssue := baseDiffIssue()
+	sameAssignee := int64(7)
+	sameDue := due.Add(5 * time.Hour)
+	newIssue.AssignedTo = &sameAssignee
+	newIssue.DueDate = &sameDue
+
+	//Act
+	changes := DiffIssues(oldIssue, newIssue)
+
+	//Assert
+	assert.Empty(t, changes)
+}
+
+func TestDiffIssues_TrackedFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(issue *IssueResponse)
+		field   string
+		old     string
+		new     string
+		message string
+	}{
+		{"title", func(i *IssueResponse) { i.Title = "Cracked slab at B4" }, "title", "Cracked slab", "Cracked slab at B4", "Title changed from Cracked slab to Cracked slab at B4"},
+		{"description", func(i *IssueResponse) { i.Description = "Crack widened" }, "description", "Hairline crack near grid B4", "Crack widened", "Description updated"},
+		{"priority", func(i *IssueResponse) { i.Priority = IssuePriorityHigh }, "priority", IssuePriorityMedium, IssuePriorityHigh, "Priority changed from medium to high"},
+		{"severity", func(i *IssueResponse) { i.Severity = IssueSeverityMajor }, "severity", IssueSeverityMinor, IssueSeverityMajor, "Severity changed from minor to major"},
+		{"status", func(i *IssueResponse) { i.Status = IssueStatusClosed }, "status", IssueStatusOpen, IssueStatusClosed, "Status changed from open to closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			newIssue := baseDiffIssue()
+			tt.edit(newIssue)
+
+			//Act
+			changes := DiffIssues(baseDiffIssue(), newIssue)
+
+			//Assert
+			if assert.Len(t, changes, 1) {
+				assert.Equal(t, tt.field, changes[0].Field)
+				assert.Equal(t, tt.old, changes[0].OldValue)
+				assert.Equal(t, tt.new, changes[0].NewValue)
+				assert.Equal(t, tt.message, changes[0].ActivityMessage())
+			}
+		})
+	}
+}
+
+func TestDiffIssues_AssignedTo(t *testing.T) {
+	first, second := int64(7), int64(9)
+	tests := []struct {
+		name    string
+		old     *int64
+		oldName string
+		new     *int64
+		newName string
+		wantOld string
+		wantNew string
+		message string
+	}{
+		{"nil to value", nil, "", &second, "Dana Reyes", "", "9", "Assignee changed from (none) to Dana Reyes"},
+		{"value to nil", &first, "Sam Ortiz", nil, "", "7", "", "Assignee changed from Sam Ortiz to (none)"},
+		{"value to value", &first, "Sam Ortiz", &second, "Dana Reyes", "7", "9", "Assignee changed from Sam Ortiz to Dana Reyes"},
+		{"falls back to id without a name", &first, "", &second, "", "7", "9", "Assignee changed from 7 to 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			oldIssue, newIssue := baseDiffIssue(), baseDiffIssue()
+			oldIssue.AssignedTo, oldIssue.AssignedToName = tt.old, tt.oldName
+			newIssue.AssignedTo, newIssue.AssignedToName = tt.new, tt.newName
+
+			//Act
+			changes := DiffIssues(oldIssue, newIssue)
+
+			//Assert
+			if assert.Len(t, changes, 1) {
+				assert.Equal(t, "assigned_to", changes[0].Field)
+				assert.Equal(t, tt.wantOld, changes[0].OldValue)
+				assert.Equal(t, tt.wantNew, changes[0].NewValue)
+				assert.Equal(t, tt.message, changes[0].ActivityMessage())
+			}
+		})
+	}
+}
+
+func TestDiffIssues_DueDate(t *testing.T) {
+	first := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		old     *time.Time
+		new     *time.Time
+		wantOld string
+		wantNew string
+	}{
+		{"nil to value", nil, &second, "", "2026-11-15"},
+		{"value to nil", &first, nil, "2026-11-01", ""},
+		{"value to value", &first, &second, "2026-11-01", "2026-11-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			oldIssue, newIssue := baseDiffIssue(), baseDiffIssue()
+			oldIssue.DueDate = tt.old
+			newIssue.DueDate = tt.new
+
+			//Act
+			changes := DiffIssues(oldIssue, newIssue)
+
+			//Assert
+			if assert.Len(t, changes, 1) {
+				assert.Equal(t, "due_date", changes[0].Field)
+				assert.Equal(t, tt.wantOld, changes[0].OldValue)
+				assert.Equal(t, tt.wantNew, changes[0].NewValue)
+			}
+		})
+	}
+}
+
+func TestDiffIssues_MultipleFieldsInOrder(t *testing.T) {
+	//Arrange
+	assignee := int64(7)
+	newIssue := baseDiffIssue()
+	newIssue.Priority = IssuePriorityCritical
+	newIssue.Status = IssueStatusInProgress
+	newIssue.AssignedTo = &assignee
+
+	//Act
+	changes := DiffIssues(baseDiffIssue(), newIssue)
+
+	//Assert
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+	assert.Equal(t, []string{"priority", "status", "assigned_to"}, fields)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -17,6 +18,8 @@ type Location struct {
 	ZipCode      string    `json:"zip_code,omitempty"`
 	Country      string    `json:"country,omitempty"`
 	Status       string    `json:"status"`         // 'active', 'inactive', 'under_construction', 'closed'
+	ParentLocationID *int64 `json:"parent_location_id,omitempty"` // Parent in the region/site/building hierarchy; nil for top-level locations
+	Children     []Location `json:"children,omitempty"` // Populated only by GET /locations/tree
 	CreatedAt    time.Time `json:"created_at"`     // Creation timestamp
 	CreatedBy    int64     `json:"created_by"`     // User who created this location
 	UpdatedAt    time.Time `json:"updated_at"`     // Last update timestamp
@@ -33,9 +36,11 @@ type CreateLocationRequest struct {
 	ZipCode      string `json:"zip_code,omitempty" binding:"omitempty,max=20"`
 	Country      string `json:"country,omitempty" binding:"omitempty,max=100"`
 	Status       string `json:"status,omitempty" binding:"omitempty,oneof=active inactive under_construction closed"`
+	ParentLocationID *int64 `json:"parent_location_id,omitempty"`
 }
 
 // UpdateLocationRequest represents the request payload for updating an existing location
+// A ParentLocationID of 0 moves the location to the top level
 type UpdateLocationRequest struct {
 	Name         string `json:"name,omitempty" binding:"omitempty,min=2,max=255"`
 	LocationType string `json:"location_type,omitempty" binding:"omitempty,oneof=office warehouse job_site yard"`
@@ -45,10 +50,78 @@ type UpdateLocationRequest struct {
 	ZipCode      string `json:"zip_code,omitempty" binding:"omitempty,max=20"`
 	Country      string `json:"country,omitempty" binding:"omitempty,max=100"`
 	Status       string `json:"status,omitempty" binding:"omitempty,oneof=active inactive under_construction closed"`
+	ParentLocationID *int64 `json:"parent_location_id,omitempty"`
 }
 
 // LocationListResponse represents the response for listing locations
 type LocationListResponse struct {
 	Locations []Location `json:"locations"`
 	Total     int        `json:"total"`
-}
\ No newline at end of file
+}
+
+// DeleteLocationOptions controls how far a location delete reaches.
+// Cascade also deletes child locations; Force also deletes the projects at the deleted locations.
+type DeleteLocationOptions struct {
+	Cascade bool
+	Force   bool
+}
+
+// LocationDeleteSummary describes everything a location delete removed, so callers can see its blast radius
+type LocationDeleteSummary struct {
+	LocationID         int64   `json:"location_id"`
+	DeletedLocationIDs []int64 `json:"deleted_location_ids"`
+	DeletedProjectIDs  []int64 `json:"deleted_project_ids"`
+	Cascade            bool    `json:"cascade"`
+	Force              bool    `json:"force"`
+}
+
+// LocationHasProjectsError reports a location delete blocked by active projects at the location
+// (or, when cascading, at one of its descendants)
+type LocationHasProjectsError struct {
+	ProjectIDs []int64
+}
+
+func (e *LocationHasProjectsError) Error() string {
+	return fmt.Sprintf("location has %d active project(s)", len(e.ProjectIDs))
+}
+
+// BuildLocationTree nests locations under their parents. Locations whose parent is not in the
+// list (for example an inactive parent filtered out of the query) are returned as roots.
+func BuildLocationTree(locations []Location) []Location {
+	children := make(map[int64][]Location)
+	known := make(map[int64]bool, len(locations))
+	for _, location := range locations {
+		known[location.ID] = true
+	}
+
+	roots := []Location{}
+	for _, location := range locations {
+		if location.ParentLocationID != nil && known[*location.ParentLocationID] {
+			children[*location.ParentLocationID] = append(children[*location.ParentLocationID], location)
+		} else {
+			roots = append(roots, location)
+		}
+	}
+
+	var attach func(nodes []Location, depth int) []Location
+	attach = func(nodes []Location, depth int) []Location {
+		// depth guard keeps a corrupted parent chain from recursing forever
+		if depth > len(locations) {
+			return nodes
+		}
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID], depth+1)
+		}
+		return nodes
+	}
+
+	return attach(roots, 0)
+}
+
+// Location status constants
+const (
+	LocationStatusActive            = "active"
+	LocationStatusInactive          = "inactive"
+	LocationStatusUnderConstruction = "under_construction"
+	LocationStatusClosed            = "closed"
+)
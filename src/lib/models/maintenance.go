@@ -0,0 +1,19 @@
+package models
+
+// RecountResult reports one entity type's pass of a denormalized count rebuild
+type RecountResult struct {
+	EntityType    string   `json:"entity_type"`
+	Columns       []string `json:"columns"`
+	RowsScanned   int      `json:"rows_scanned"`
+	RowsCorrected int      `json:"rows_corrected"`
+}
+
+// RecountResponse represents the response of POST /admin/recount
+type RecountResponse struct {
+	ProjectID      *int64          `json:"project_id,omitempty"`
+	Results        []RecountResult `json:"results"`
+	TotalCorrected int             `json:"total_corrected"`
+}
+
+// RecountEntityTypes lists the entity types that carry denormalized counts
+var RecountEntityTypes = []string{EntityTypeIssue, EntityTypeRFI, EntityTypeSubmittal}
@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+// Notification event types a user can set preferences for
+const (
+	NotificationEventRFIAssigned              = "rfi_assigned"
+	NotificationEventIssueAssigned            = "issue_assigned"
+	NotificationEventIssueStatusChanged       = "issue_status_changed"
+	NotificationEventIssueWatcherUpdate       = "issue_watcher_update"
+	NotificationEventSubmittalReviewRequested = "submittal_review_requested"
+)
+
+// NotificationEventTypes lists every event type in the order preferences are returned
+var NotificationEventTypes = []string{
+	NotificationEventRFIAssigned,
+	NotificationEventIssueAssigned,
+	NotificationEventIssueStatusChanged,
+	NotificationEventIssueWatcherUpdate,
+	NotificationEventSubmittalReviewRequested,
+}
+
+// NotificationPref is a user's channel settings for one event type. Event types the user has never
+// changed report the defaults: both channels on.
+type NotificationPref struct {
+	EventType string `json:"event_type"`
+	Email     bool   `json:"email"`
+	SNS       bool   `json:"sns"`
+}
+
+// DefaultNotificationPref returns the settings used for an event type with no stored preference
+func DefaultNotificationPref(eventType string) NotificationPref {
+	return NotificationPref{EventType: eventType, Email: true, SNS: true}
+}
+
+// NotificationPrefsResponse is returned by GET and PUT /users/{userId}/notification-prefs
+type NotificationPrefsResponse struct {
+	UserID      int64              `json:"user_id"`
+	Preferences []NotificationPref `json:"preferences"`
+}
+
+// NotificationPrefUpdate changes one event type's channels; omitted channels keep their current setting
+type NotificationPrefUpdate struct {
+	EventType string `json:"event_type"`
+	Email     *bool  `json:"email,omitempty"`
+	SNS       *bool  `json:"sns,omitempty"`
+}
+
+// UpdateNotificationPrefsRequest is the body of PUT /users/{userId}/notification-prefs
+type UpdateNotificationPrefsRequest struct {
+	Preferences []NotificationPrefUpdate `json:"preferences"`
+}
+
+// NotificationRecipient is a user an email notification can be delivered to
+type NotificationRecipient struct {
+	UserID int64
+	Email  string
+	Name   string
+}
+
+// CommentMention is a user @mentioned in a comment. Token is the text that mentioned them, e.g. "@[42]",
+// so the UI can highlight it.
+type CommentMention struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Token  string `json:"token"`
+}
+
+// CommentEventMention is the event_type of CommentMentionEvent
+const CommentEventMention = "comment.mention"
+
+// CommentMentionEvent is published to the events topic when a new issue or RFI comment mentions users.
+// EntityType is EntityTypeIssue or EntityTypeRFI; the comment's author is never among MentionedUserIDs.
+type CommentMentionEvent struct {
+	EventType        string    `json:"event_type"`
+	EntityType       string    `json:"entity_type"`
+	EntityID         int64     `json:"entity_id"`
+	CommentID        int64     `json:"comment_id"`
+	OrgID            int64     `json:"org_id"`
+	MentionedUserIDs []int64   `json:"mentioned_user_ids"`
+	ActorUserID      int64     `json:"actor_user_id"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// MentionedUserIDs returns the IDs of the mentioned users other than actorID
+func MentionedUserIDs(mentions []CommentMention, actorID int64) []int64 {
+	userIDs := []int64{}
+	for _, mention := range mentions {
+		if mention.UserID != actorID {
+			userIDs = append(userIDs, mention.UserID)
+		}
+	}
+	return userIDs
+}
@@ -2,6 +2,11 @@ package models
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -20,6 +25,18 @@ type Organization struct {
 	CreatedBy     int64          `json:"created_by"` // User who created this organization
 	UpdatedAt     time.Time      `json:"updated_at"`
 	UpdatedBy     int64          `json:"updated_by"` // User who last updated this organization
+
+	// RequireProjectMembership restricts issue/RFI creation to users with a role on the project
+	RequireProjectMembership bool `json:"require_project_membership"`
+
+	// AllowedEmailDomains limits user invites to these email domains; empty allows any domain
+	AllowedEmailDomains []string `json:"allowed_email_domains"`
+
+	// RFIAutoCloseDays closes answered RFIs after this many days without new comments; 0 disables auto-close
+	RFIAutoCloseDays int `json:"rfi_auto_close_days"`
+
+	// PrioritySLADays maps a lowercase issue/RFI priority to the days allowed before it is due
+	PrioritySLADays PrioritySLADays `json:"priority_sla_days"`
 }
 
 // CreateOrganizationRequest represents the request payload for creating a new organization
@@ -44,4 +61,116 @@ type UpdateOrganizationRequest struct {
 	Email         string `json:"email,omitempty" binding:"omitempty,email,max=255"`
 	Website       string `json:"website,omitempty" binding:"omitempty,url,max=255"`
 	Status        string `json:"status,omitempty" binding:"omitempty,oneof=active inactive pending_setup suspended"`
+
+	// RequireProjectMembership toggles project-member-only creation of issues and RFIs
+	RequireProjectMembership *bool `json:"require_project_membership,omitempty"`
+
+	// AllowedEmailDomains replaces the invite domain allow-list; send an empty list to allow any domain
+	AllowedEmailDomains []string `json:"allowed_email_domains,omitempty"`
+
+	// RFIAutoCloseDays sets the answered-RFI auto-close threshold in days; send 0 to disable auto-close
+	RFIAutoCloseDays *int `json:"rfi_auto_close_days,omitempty"`
+
+	// PrioritySLADays replaces the per-priority SLA offsets; send an empty object to clear them
+	PrioritySLADays PrioritySLADays `json:"priority_sla_days,omitempty"`
+}
+
+// NormalizeEmailDomains lowercases and trims the domains, strips a leading "@" and drops duplicates
+func NormalizeEmailDomains(domains []string) ([]string, error) {
+	normalized := make([]string, 0, len(domains))
+	seen := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		d := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+		if d == "" || !strings.Contains(d, ".") || strings.ContainsAny(d, "@ ") {
+			return nil, fmt.Errorf("invalid email domain '%s'", domain)
+		}
+		if !seen[d] {
+			seen[d] = true
+			normalized = append(normalized, d)
+		}
+	}
+	return normalized, nil
+}
+
+// IsEmailDomainAllowed reports whether the email's domain is in the allow-list (case-insensitive).
+// An empty allow-list permits every domain.
+func IsEmailDomainAllowed(email string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	for _, allowed := range allowedDomains {
+		if strings.EqualFold(domain, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxPrioritySLADays caps a single priority's SLA offset
+const MaxPrioritySLADays = 365
+
+// PrioritySLADays maps a lowercase priority (critical, high, urgent, ...) to the number of calendar
+// days after creation an issue or RFI of that priority is due. Stored as JSONB on iam.organizations.
+type PrioritySLADays map[string]int
+
+// Scan implements sql.Scanner for the JSONB column
+func (s *PrioritySLADays) Scan(value interface{}) error {
+	if value == nil {
+		*s = PrioritySLADays{}
+		return nil
+	}
+	raw, ok := value.([]byte)
+	if !ok {
+		str, isString := value.(string)
+		if !isString {
+			return fmt.Errorf("unsupported type for priority SLA days: %T", value)
+		}
+		raw = []byte(str)
+	}
+	parsed := PrioritySLADays{}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return fmt.Errorf("failed to parse priority SLA days: %w", err)
+	}
+	*s = parsed
+	return nil
+}
+
+// Value implements driver.Valuer for the JSONB column
+func (s PrioritySLADays) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]int(s))
+}
+
+// DueDate returns the SLA due date (YYYY-MM-DD) for an item of the given priority created at from.
+// ok is false when the organization has no SLA for the priority.
+func (s PrioritySLADays) DueDate(priority string, from time.Time) (dueDate string, ok bool) {
+	days, ok := s[strings.ToLower(strings.TrimSpace(priority))]
+	if !ok {
+		return "", false
+	}
+	return from.AddDate(0, 0, days).Format("2006-01-02"), true
+}
+
+// NormalizePrioritySLADays lowercases the priority keys and checks that each is a known issue or
+// RFI priority with an offset between 0 and MaxPrioritySLADays days
+func NormalizePrioritySLADays(slaDays PrioritySLADays) (PrioritySLADays, error) {
+	normalized := make(PrioritySLADays, len(slaDays))
+	for priority, days := range slaDays {
+		key := strings.ToLower(strings.TrimSpace(priority))
+		if !slices.Contains(IssuePriorities, key) && !slices.Contains(RFIPriorities, strings.ToUpper(key)) {
+			return nil, fmt.Errorf("invalid priority '%s' in priority_sla_days", priority)
+		}
+		if days < 0 || days > MaxPrioritySLADays {
+			return nil, fmt.Errorf("priority_sla_days for '%s' must be between 0 and %d", priority, MaxPrioritySLADays)
+		}
+		normalized[key] = days
+	}
+	return normalized, nil
 }
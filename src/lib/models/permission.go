@@ -40,4 +40,14 @@ type AssignPermissionRequest struct {
 // UnassignPermissionRequest represents the request payload for unassigning permission from role
 type UnassignPermissionRequest struct {
 	PermissionID int64 `json:"permission_id" binding:"required"`
-}
\ No newline at end of file
+}
+
+// EffectivePermissionsResponse is the caller's resolved roles and permissions for an org or project context
+type EffectivePermissionsResponse struct {
+	UserID       int64    `json:"user_id"`
+	OrgID        int64    `json:"org_id"`
+	ProjectID    *int64   `json:"project_id,omitempty"`
+	IsSuperAdmin bool     `json:"is_super_admin"`
+	Roles        []string `json:"roles"`
+	Permissions  []string `json:"permissions"`
+}
@@ -44,6 +44,9 @@ type Project struct {
 	CreatedBy                 int64           `json:"created_by"`
 	UpdatedAt                 time.Time       `json:"updated_at"`
 	UpdatedBy                 int64           `json:"updated_by"`
+
+	// Populated on single-project reads only
+	UpcomingMilestones []ProjectMilestone `json:"upcoming_milestones,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler to properly handle SQL null types
@@ -176,6 +179,9 @@ type Timeline struct {
 	ProjectFinishDate         string `json:"project_finish_date,omitempty"`
 	WarrantyStartDate         string `json:"warranty_start_date,omitempty"`
 	WarrantyEndDate           string `json:"warranty_end_date,omitempty"`
+	// Timezone is the IANA zone the project works in (UTC when omitted). It decides which day is
+	// "today" when a project is created; the dates above are calendar dates and are never shifted.
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // Financial represents financial information
@@ -292,6 +298,29 @@ type ProjectListResponse struct {
 	Total    int       `json:"total"`
 }
 
+// AccessibleProjectsResponse represents a page of the projects the caller can access
+type AccessibleProjectsResponse struct {
+	Projects   []Project `json:"projects"`
+	TotalCount int       `json:"total_count"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	HasNext    bool      `json:"has_next"`
+}
+
+// Bounds for the within_days window of GET /projects/warranty-expiring
+const (
+	DefaultWarrantyExpiryWindowDays = 30
+	MaxWarrantyExpiryWindowDays     = 365
+)
+
+// WarrantyExpiringProjectsResponse lists projects whose warranty ends within the next WithinDays days,
+// soonest first
+type WarrantyExpiringProjectsResponse struct {
+	Projects   []Project `json:"projects"`
+	WithinDays int       `json:"within_days"`
+	Total      int       `json:"total"`
+}
+
 // ProjectAttachment represents a project attachment based on project.project_attachments table
 type ProjectAttachment struct {
 	ID             int64     `json:"id"`
@@ -301,7 +330,9 @@ type ProjectAttachment struct {
 	FileSize       int64     `json:"file_size,omitempty"`
 	FileType       string    `json:"file_type,omitempty"`
 	AttachmentType string    `json:"attachment_type"`
+	FolderID       *int64    `json:"folder_id,omitempty"`
 	UploadedBy     int64     `json:"uploaded_by"`
+	ScanStatus     string    `json:"scan_status"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int64     `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -317,6 +348,86 @@ type CreateProjectAttachmentRequest struct {
 	AttachmentType string `json:"attachment_type" binding:"required"`
 }
 
+// ProjectFolder represents a document folder based on project.project_folders table
+type ProjectFolder struct {
+	ID             int64           `json:"id"`
+	ProjectID      int64           `json:"project_id"`
+	ParentFolderID *int64          `json:"parent_folder_id,omitempty"`
+	Name           string          `json:"name"`
+	Children       []ProjectFolder `json:"children,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	CreatedBy      int64           `json:"created_by"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	UpdatedBy      int64           `json:"updated_by"`
+}
+
+// CreateProjectFolderRequest represents the request payload for creating a project folder
+// ParentFolderID is omitted for top-level folders
+type CreateProjectFolderRequest struct {
+	Name           string `json:"name" binding:"required,max=255"`
+	ParentFolderID *int64 `json:"parent_folder_id,omitempty"`
+}
+
+// UpdateProjectFolderRequest represents the request payload for renaming or moving a project folder
+// A ParentFolderID of 0 moves the folder to the project root
+type UpdateProjectFolderRequest struct {
+	Name           *string `json:"name,omitempty"`
+	ParentFolderID *int64  `json:"parent_folder_id,omitempty"`
+}
+
+// MoveProjectAttachmentRequest represents the request payload for moving an attachment between folders
+// A nil FolderID moves the attachment to the project root
+type MoveProjectAttachmentRequest struct {
+	FolderID *int64 `json:"folder_id"`
+}
+
+// ProjectFolderListResponse represents the response for listing project folders as a tree
+type ProjectFolderListResponse struct {
+	Folders []ProjectFolder `json:"folders"`
+	Total   int             `json:"total"`
+}
+
+// ProjectDocumentsResponse represents the folder tree and the attachments matching a folder filter
+type ProjectDocumentsResponse struct {
+	FolderID    *int64              `json:"folder_id,omitempty"`
+	Folders     []ProjectFolder     `json:"folders"`
+	Attachments []ProjectAttachment `json:"attachments"`
+	Total       int                 `json:"total"`
+}
+
+// BuildProjectFolderTree nests a flat folder list under each folder's parent.
+// Folders whose parent is missing from the list are returned at the top level.
+func BuildProjectFolderTree(folders []ProjectFolder) []ProjectFolder {
+	children := make(map[int64][]ProjectFolder)
+	known := make(map[int64]bool, len(folders))
+	for _, folder := range folders {
+		known[folder.ID] = true
+	}
+
+	var roots []ProjectFolder
+	for _, folder := range folders {
+		if folder.ParentFolderID != nil && known[*folder.ParentFolderID] {
+			children[*folder.ParentFolderID] = append(children[*folder.ParentFolderID], folder)
+		} else {
+			roots = append(roots, folder)
+		}
+	}
+
+	var attach func(nodes []ProjectFolder, depth int) []ProjectFolder
+	attach = func(nodes []ProjectFolder, depth int) []ProjectFolder {
+		// depth guard keeps a corrupted parent chain from recursing forever
+		if depth > len(folders) {
+			return nodes
+		}
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID], depth+1)
+		}
+		return nodes
+	}
+
+	return attach(roots, 0)
+}
+
 // ProjectUserRole represents a user's role assignment to a project based on project.project_user_roles table
 type ProjectUserRole struct {
 	ID        int64          `json:"id"`
@@ -351,3 +462,164 @@ type UpdateProjectUserRoleRequest struct {
 	StartDate string `json:"start_date,omitempty"`
 	EndDate   string `json:"end_date,omitempty"`
 }
+
+// Project user role history actions
+const (
+	ProjectUserRoleActionAssigned    = "assigned"
+	ProjectUserRoleActionRoleChanged = "role_changed"
+	ProjectUserRoleActionRemoved     = "removed"
+)
+
+// ProjectUserRoleHistory is one append-only entry in a project's user role audit trail
+type ProjectUserRoleHistory struct {
+	ID             int64     `json:"id"`
+	ProjectID      int64     `json:"project_id"`
+	UserID         int64     `json:"user_id"`
+	RoleID         int64     `json:"role_id"`
+	PreviousRoleID *int64    `json:"previous_role_id,omitempty"`
+	Action         string    `json:"action"`
+	ActorUserID    int64     `json:"actor_user_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Sources of a role that applies to a project
+const (
+	ProjectRoleSourceProject      = "project"
+	ProjectRoleSourceLocation     = "location"
+	ProjectRoleSourceOrganization = "organization"
+)
+
+// MyProjectRole is one role the caller holds that applies to a project, and where it was granted
+type MyProjectRole struct {
+	RoleID   int64  `json:"role_id"`
+	RoleName string `json:"role_name"`
+	Source   string `json:"source"`
+}
+
+// MyProjectRolesResponse is the caller's roles and effective permissions on a project
+type MyProjectRolesResponse struct {
+	ProjectID    int64           `json:"project_id"`
+	UserID       int64           `json:"user_id"`
+	IsSuperAdmin bool            `json:"is_super_admin"`
+	Roles        []MyProjectRole `json:"roles"`
+	Permissions  []string        `json:"permissions"`
+}
+
+// ProjectDefaultAssigneeRequest represents the request payload for setting a project's default assignee
+// A default_assignee_id of 0 clears the default
+type ProjectDefaultAssigneeRequest struct {
+	DefaultAssigneeID int64 `json:"default_assignee_id"`
+}
+
+// ProjectDefaultAssigneeResponse represents the default assignee configured for a project
+type ProjectDefaultAssigneeResponse struct {
+	ProjectID         int64  `json:"project_id"`
+	DefaultAssigneeID *int64 `json:"default_assignee_id"`
+}
+
+// ProjectSearchResult represents a single issue, RFI or submittal matched by project search
+type ProjectSearchResult struct {
+	EntityType string    `json:"entity_type"` // "issue", "rfi", "submittal"
+	ID         int64     `json:"id"`
+	Number     string    `json:"number,omitempty"`
+	Title      string    `json:"title"`
+	Status     string    `json:"status"`
+	Relevance  int       `json:"relevance"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ProjectSearchResponse represents a paginated list of project search results
+type ProjectSearchResponse struct {
+	Query      string                `json:"query"`
+	Results    []ProjectSearchResult `json:"results"`
+	TotalCount int                   `json:"total_count"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	HasNext    bool                  `json:"has_next"`
+}
+
+// ProjectMilestone represents a schedule milestone based on project.project_milestones table
+type ProjectMilestone struct {
+	ID          int64     `json:"id"`
+	ProjectID   int64     `json:"project_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	TargetDate  string    `json:"target_date"`
+	ActualDate  *string   `json:"actual_date,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   int64     `json:"created_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UpdatedBy   int64     `json:"updated_by"`
+}
+
+// CreateProjectMilestoneRequest represents the request payload for creating a project milestone
+type CreateProjectMilestoneRequest struct {
+	Name        string  `json:"name" binding:"required,max=255"`
+	Description *string `json:"description,omitempty"`
+	TargetDate  string  `json:"target_date" binding:"required"` // YYYY-MM-DD
+	Status      string  `json:"status,omitempty"`
+}
+
+// UpdateProjectMilestoneRequest represents the request payload for updating a project milestone
+type UpdateProjectMilestoneRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	TargetDate  *string `json:"target_date,omitempty"` // YYYY-MM-DD
+	ActualDate  *string `json:"actual_date,omitempty"` // YYYY-MM-DD
+	Status      *string `json:"status,omitempty"`
+}
+
+// CompleteProjectMilestoneRequest represents the request payload for marking a milestone complete
+// ActualDate defaults to today when omitted
+type CompleteProjectMilestoneRequest struct {
+	ActualDate string `json:"actual_date,omitempty"` // YYYY-MM-DD
+}
+
+// ProjectMilestoneListResponse represents the response for listing project milestones
+type ProjectMilestoneListResponse struct {
+	Milestones []ProjectMilestone `json:"milestones"`
+	Total      int                `json:"total"`
+}
+
+// Project milestone status constants
+const (
+	MilestoneStatusPending    = "pending"
+	MilestoneStatusInProgress = "in_progress"
+	MilestoneStatusCompleted  = "completed"
+	MilestoneStatusMissed     = "missed"
+)
+
+// IsValidMilestoneStatus reports whether status is a supported milestone status
+func IsValidMilestoneStatus(status string) bool {
+	switch status {
+	case MilestoneStatusPending, MilestoneStatusInProgress, MilestoneStatusCompleted, MilestoneStatusMissed:
+		return true
+	}
+	return false
+}
+
+// CopyToProjectRequest represents the request to copy an issue, RFI or submittal into another project
+type CopyToProjectRequest struct {
+	TargetProjectID int64 `json:"target_project_id" binding:"required"`
+	CopyAttachments bool  `json:"copy_attachments,omitempty"`
+}
+
+// ProjectEntityStats counts one kind of project item. Open and Closed split Total by whether the status
+// is final; Overdue counts open items past their due date.
+type ProjectEntityStats struct {
+	Total    int            `json:"total"`
+	Open     int            `json:"open"`
+	Closed   int            `json:"closed"`
+	Overdue  int            `json:"overdue"`
+	ByStatus map[string]int `json:"by_status"`
+}
+
+// ProjectStats is returned by GET /projects/{projectId}/stats
+type ProjectStats struct {
+	ProjectID  int64              `json:"project_id"`
+	Issues     ProjectEntityStats `json:"issues"`
+	RFIs       ProjectEntityStats `json:"rfis"`
+	Submittals ProjectEntityStats `json:"submittals"`
+}
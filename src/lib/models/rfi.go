@@ -1,6 +1,8 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -24,6 +26,7 @@ type RFI struct {
 	ProjectPhase            *string        `json:"project_phase,omitempty"`
 	Priority                string         `json:"priority"`
 	Status                  string         `json:"status"`
+	ForInformation          bool           `json:"for_information"`
 	ReceivedFrom            *int64         `json:"received_from,omitempty"`
 	AssignedToIDs           []int64        `json:"-"` // Internal field for DB storage
 	BallInCourt             *int64         `json:"ball_in_court,omitempty"`
@@ -59,6 +62,7 @@ type RFIAttachment struct {
 	S3URL          string     `json:"s3_url,omitempty"`
 	AttachmentType string     `json:"attachment_type"`
 	UploadedBy     int64      `json:"uploaded_by"`
+	ScanStatus     string     `json:"scan_status"`
 	UploadDate     time.Time  `json:"upload_date"`
 	CreatedAt      time.Time  `json:"created_at"`
 	CreatedBy      int64      `json:"created_by"`
@@ -77,6 +81,7 @@ type RFICommentAttachment struct {
 	FileType       *string   `json:"file_type,omitempty"`
 	AttachmentType string    `json:"attachment_type"`
 	UploadedBy     int64     `json:"uploaded_by"`
+	ScanStatus     string    `json:"scan_status"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int64     `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -99,6 +104,7 @@ type RFIComment struct {
 	UpdatedAt     time.Time               `json:"updated_at"`
 	UpdatedBy     int64                   `json:"updated_by"`
 	IsDeleted     bool                    `json:"is_deleted"`
+	Mentions      []CommentMention        `json:"mentions,omitempty"`
 }
 
 // CreateRFICommentRequest for adding a comment to an RFI
@@ -131,7 +137,8 @@ type RFIRequest struct {
 	ProjectPhase *string `json:"project_phase,omitempty"`
 
 	// Scheduling
-	DueDate string `json:"due_date,omitempty"` // YYYY-MM-DD format
+	DueDate         string `json:"due_date,omitempty"`          // YYYY-MM-DD format
+	ResponseDueDate string `json:"response_due_date,omitempty"` // YYYY-MM-DD format
 
 	// Assignment
 	ReceivedFrom *int64  `json:"received_from,omitempty"` // User ID who sent/created this RFI
@@ -154,12 +161,46 @@ type RFIRequest struct {
 	ScheduleImpactDays *int     `json:"schedule_impact_days,omitempty"`
 
 	// Status (for updates only)
-	Status string `json:"status,omitempty" binding:"omitempty,oneof=DRAFT OPEN CLOSE"`
+	Status string `json:"status,omitempty" binding:"omitempty,oneof=DRAFT OPEN DISTRIBUTED CLOSE"`
+
+	// ForInformation marks an informational distribution: no answer or due date is expected
+	ForInformation *bool `json:"for_information,omitempty"`
+
+	// Version (for updates only) is the version the client last read; the update is rejected if it is stale
+	Version *int `json:"version,omitempty"`
 
 	// Attachments
 	Attachments []string `json:"attachments,omitempty"` // Array of file URLs
 }
 
+// RFIEventAssigneeChanged is the event_type of RFIAssigneeChangedEvent
+const RFIEventAssigneeChanged = "rfi.assignee_changed"
+
+// RFIAssigneeChangedEvent is recorded in project.rfi_notifications and published to the events topic
+// when an update changes who an RFI is assigned to. RecipientIDs are the newly assigned users, other than
+// the one who made the change, with SNS on for rfi_assigned; notification consumers deliver only to them.
+type RFIAssigneeChangedEvent struct {
+	EventType        string    `json:"event_type"`
+	RFIID            int64     `json:"rfi_id"`
+	RFINumber        string    `json:"rfi_number,omitempty"`
+	ProjectID        int64     `json:"project_id"`
+	OrgID            int64     `json:"org_id"`
+	PreviousAssignee []int64   `json:"previous_assignee"`
+	NewAssignee      []int64   `json:"new_assignee"`
+	RecipientIDs     []int64   `json:"recipient_ids"`
+	ChangedBy        int64     `json:"changed_by"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// RFIVersionConflictError reports an update made against a stale RFI version
+type RFIVersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *RFIVersionConflictError) Error() string {
+	return fmt.Sprintf("RFI version conflict: current version is %d", e.CurrentVersion)
+}
+
 // CreateRFIRequest uses the unified structure
 type CreateRFIRequest RFIRequest
 
@@ -182,12 +223,17 @@ type RFIResponse struct {
 	ProjectPhase          *string          `json:"project_phase,omitempty"`
 	Priority              string           `json:"priority"`
 	Status                string           `json:"status"`
+	ForInformation        bool             `json:"for_information"`
+	IsOverdue             bool             `json:"is_overdue"`
 	ReceivedFrom          *AssignedUser    `json:"received_from,omitempty"`
 	AssignedTo            []AssignedUser   `json:"assigned_to"`
 	BallInCourt           *AssignedUser    `json:"ball_in_court,omitempty"`
 	DistributionList      []string         `json:"distribution_list,omitempty"`
 	DueDate               *time.Time       `json:"due_date,omitempty"`
 	ClosedDate            *time.Time       `json:"closed_date,omitempty"`
+	ResponseDueDate       *time.Time       `json:"response_due_date,omitempty"`
+	RespondedAt           *time.Time       `json:"responded_at,omitempty"`
+	SLABreached           bool             `json:"sla_breached"`
 	CostImpact            bool             `json:"cost_impact"`
 	ScheduleImpact        bool             `json:"schedule_impact"`
 	CostImpactAmount      *float64         `json:"cost_impact_amount,omitempty"`
@@ -202,6 +248,22 @@ type RFIResponse struct {
 	CreatedBy             AssignedUser     `json:"created_by"`
 	UpdatedAt             time.Time        `json:"updated_at"`
 	UpdatedBy             AssignedUser     `json:"updated_by"`
+
+	// Version increases on every update; send it back on PUT to detect concurrent edits
+	Version int `json:"version"`
+
+	// AgeDays is whole days since creation; DaysSinceLastActivity is whole days since the last update or comment
+	AgeDays               int `json:"age_days"`
+	DaysSinceLastActivity int `json:"days_since_last_activity"`
+
+	// DefaultAssigneeApplied is set on create when assigned_to came from the project's default assignee
+	DefaultAssigneeApplied bool `json:"default_assignee_applied,omitempty"`
+
+	// SLADueDateApplied is set on create when due_date was derived from the org's SLA for the priority
+	SLADueDateApplied bool `json:"sla_due_date_applied,omitempty"`
+
+	// AssigneeChange is set by UpdateRFI when assigned_to changed, for the handler to publish
+	AssigneeChange *RFIAssigneeChangedEvent `json:"-"`
 }
 
 // RFIListResponse represents a list of RFIs
@@ -212,13 +274,84 @@ type RFIListResponse struct {
 	PageSize   int           `json:"page_size,omitempty"`
 }
 
+// RFIExportItem represents a single RFI in a project RFI log export
+type RFIExportItem struct {
+	ID               int64        `json:"id"`
+	RFINumber        string       `json:"rfi_number"`
+	Subject          string       `json:"subject"`
+	Description      string       `json:"description"`
+	Category         string       `json:"category"`
+	Priority         string       `json:"priority"`
+	Status           string       `json:"status"`
+	LocationName     string       `json:"location_name,omitempty"`
+	AssignedTo       string       `json:"assigned_to,omitempty"`
+	DueDate          *time.Time   `json:"due_date,omitempty"`
+	ClosedDate       *time.Time   `json:"closed_date,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+	CreatedByName    string       `json:"created_by_name,omitempty"`
+	OfficialResponse string       `json:"official_response,omitempty"` // Latest non-system comment on the RFI
+	CommentCount     int          `json:"comment_count"`
+	Comments         []RFIComment `json:"comments"`
+}
+
+// RFIExportResponse represents the JSON form of a project RFI log export
+type RFIExportResponse struct {
+	ProjectID  int64           `json:"project_id"`
+	ExportedAt time.Time       `json:"exported_at"`
+	TotalCount int             `json:"total_count"`
+	RFIs       []RFIExportItem `json:"rfis"`
+}
+
+// RFI export format constants
+const (
+	RFIExportFormatCSV  = "csv"
+	RFIExportFormatJSON = "json"
+)
+
 // RFI Status constants (matching UI expectations)
+// DISTRIBUTED is the issued state of a for-information RFI, in place of OPEN
 const (
-	RFIStatusDraft = "DRAFT"
-	RFIStatusOpen  = "OPEN"
-	RFIStatusClose = "CLOSE"
+	RFIStatusDraft       = "DRAFT"
+	RFIStatusOpen        = "OPEN"
+	RFIStatusDistributed = "DISTRIBUTED"
+	RFIStatusClose       = "CLOSE"
 )
 
+// RFIStatuses lists every valid RFI status
+var RFIStatuses = []string{RFIStatusDraft, RFIStatusOpen, RFIStatusDistributed, RFIStatusClose}
+
+// ResolveRFIStatus maps a requested status onto the workflow of the RFI's type:
+// issuing a for-information RFI yields DISTRIBUTED and an answerable one yields OPEN.
+func ResolveRFIStatus(status string, forInformation bool) string {
+	switch {
+	case forInformation && status == RFIStatusOpen:
+		return RFIStatusDistributed
+	case !forInformation && status == RFIStatusDistributed:
+		return RFIStatusOpen
+	}
+	return status
+}
+
+// IsRFIOverdue reports whether an RFI is awaiting an answer past its due date.
+// For-information RFIs never expect an answer, so they are never overdue.
+func IsRFIOverdue(status string, forInformation bool, dueDate *time.Time, now time.Time) bool {
+	if forInformation || status != RFIStatusOpen || dueDate == nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return dueDate.Before(today)
+}
+
+// IsRFISLABreached reports whether an RFI is still unanswered past its response due date.
+// The due date itself is within the SLA; the breach starts the following day.
+func IsRFISLABreached(respondedAt, responseDueDate *time.Time, now time.Time) bool {
+	if respondedAt != nil || responseDueDate == nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return responseDueDate.Before(today)
+}
+
 // RFI Priority constants (matching UI expectations)
 const (
 	RFIPriorityLow    = "LOW"
@@ -227,6 +360,15 @@ const (
 	RFIPriorityUrgent = "URGENT"
 )
 
+// RFIPriorities lists every valid RFI priority
+var RFIPriorities = []string{RFIPriorityLow, RFIPriorityMedium, RFIPriorityHigh, RFIPriorityUrgent}
+
+// RFISortFields lists the accepted sort values for RFI lists
+var RFISortFields = []string{SortCreatedAt, SortAgeDays, SortDaysSinceLastActivity}
+
+// MaxRFISearchLength caps the q search parameter on the project RFI list
+const MaxRFISearchLength = 200
+
 // RFI Category constants (matching UI expectations)
 const (
 	RFICategoryDesign        = "DESIGN"
@@ -245,3 +387,50 @@ const (
 	RFICommentTypeAssignment   = "assignment"
 )
 
+
+// UnmarshalJSON accepts project_id and location_id as numbers or numeric strings
+func (r *CreateRFIRequest) UnmarshalJSON(data []byte) error {
+	type plain CreateRFIRequest
+	aux := struct {
+		*plain
+		ProjectID  json.RawMessage `json:"project_id"`
+		LocationID json.RawMessage `json:"location_id"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if r.ProjectID, err = ParseFlexibleInt64("project_id", aux.ProjectID); err != nil {
+		return err
+	}
+	if r.LocationID, err = ParseFlexibleInt64("location_id", aux.LocationID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DefaultRFIAutoCloseDays is the organization default for closing answered RFIs with no further activity
+const DefaultRFIAutoCloseDays = 14
+
+// MaxRFIAutoCloseDays caps the configurable auto-close threshold
+const MaxRFIAutoCloseDays = 365
+
+// StaleRFI is an answered RFI whose last comment is older than its organization's auto-close threshold
+type StaleRFI struct {
+	ID             int64     `json:"id"`
+	ProjectID      int64     `json:"project_id"`
+	OrgID          int64     `json:"org_id"`
+	RFINumber      *string   `json:"rfi_number,omitempty"`
+	CreatedBy      int64     `json:"created_by"`
+	AutoCloseDays  int       `json:"auto_close_days"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
+
+// RFIAutoCloseResult summarizes one scheduled auto-close run
+type RFIAutoCloseResult struct {
+	Scanned int `json:"scanned"`
+	Closed  int `json:"closed"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRFISLABreached(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		date := time.Date(2026, 10, 16+offset, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	respondedAt := func(offset int) *time.Time {
+		at := time.Date(2026, 10, 16+offset, 9, 0, 0, 0, time.UTC)
+		return &at
+	}
+
+	tests := []struct {
+		name        string
+		respondedAt *time.Time
+		dueDate     *time.Time
+		want        bool
+	}{
+		{"responded before due date", respondedAt(-3), day(-1), false},
+		{"responded after due date", respondedAt(-1), day(-5), false},
+		{"responded with no due date", respondedAt(-1), nil, false},
+		{"not responded and no due date", nil, nil, false},
+		{"not responded and due in the future", nil, day(3), false},
+		{"not responded and due today", nil, day(0), false},
+		{"not responded and due yesterday", nil, day(-1), true},
+		{"not responded and well past due", nil, day(-30), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			breached := IsRFISLABreached(tt.respondedAt, tt.dueDate, now)
+
+			//Assert
+			assert.Equal(t, tt.want, breached)
+		})
+	}
+}
+
+func TestIsRFISLABreached_IgnoresTimeOfDay(t *testing.T) {
+	//Arrange
+	dueDate := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	justAfterMidnight := time.Date(2026, 10, 16, 0, 0, 1, 0, time.UTC)
+	endOfDueDate := time.Date(2026, 10, 15, 23, 59, 59, 0, time.UTC)
+
+	//Act
+	breachedNextDay := IsRFISLABreached(nil, &dueDate, justAfterMidnight)
+	breachedOnDueDate := IsRFISLABreached(nil, &dueDate, endOfDueDate)
+
+	//Assert
+	assert.True(t, breachedNextDay)
+	assert.False(t, breachedOnDueDate)
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -70,6 +72,7 @@ type SubmittalAttachment struct {
 	FileType       *string   `json:"file_type,omitempty"`
 	AttachmentType string    `json:"attachment_type"`
 	UploadedBy     int64     `json:"uploaded_by"`
+	ScanStatus     string    `json:"scan_status"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int64     `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -106,6 +109,70 @@ type SubmittalHistory struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// SubmittalRevision is a snapshot of a submittal's review state taken when it is sent back
+// for revision, based on project.submittal_revisions
+type SubmittalRevision struct {
+	ID             int64      `json:"id"`
+	SubmittalID    int64      `json:"submittal_id"`
+	RevisionNumber int        `json:"revision_number"`
+	WorkflowStatus string     `json:"workflow_status"`
+	CurrentPhase   string     `json:"current_phase"`
+	BallInCourt    string     `json:"ball_in_court"`
+	Reviewer       *int64     `json:"reviewer,omitempty"`
+	ReviewedBy     *int64     `json:"reviewed_by,omitempty"`
+	ReviewedDate   *time.Time `json:"reviewed_date,omitempty"`
+	ReviewComments *string    `json:"review_comments,omitempty"`
+	RevisionNotes  *string    `json:"revision_notes,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CreatedBy      int64      `json:"created_by"`
+}
+
+// SubmittalReviewer is one step of a submittal's ordered review routing, based on project.submittal_reviewers
+type SubmittalReviewer struct {
+	ID           int64      `json:"id"`
+	SubmittalID  int64      `json:"submittal_id"`
+	ReviewerID   int64      `json:"reviewer_id"`
+	ReviewerName string     `json:"reviewer_name,omitempty"`
+	Sequence     int        `json:"sequence"`
+	Status       string     `json:"status"` // pending, completed, skipped
+	SkippedBy    *int64     `json:"skipped_by,omitempty"`
+	SkippedAt    *time.Time `json:"skipped_at,omitempty"`
+	SkipReason   *string    `json:"skip_reason,omitempty"`
+}
+
+// SubmittalDistributionRequest sets the order of the reviewers who have not yet reviewed the submittal
+type SubmittalDistributionRequest struct {
+	ReviewerIDs []int64 `json:"reviewer_ids" binding:"required"`
+}
+
+// SkipSubmittalReviewerRequest is the optional body when skipping a reviewer
+type SkipSubmittalReviewerRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// SubmittalDistributionResponse represents a submittal's review routing
+type SubmittalDistributionResponse struct {
+	SubmittalID     int64               `json:"submittal_id"`
+	CurrentReviewer *int64              `json:"current_reviewer,omitempty"`
+	Reviewers       []SubmittalReviewer `json:"reviewers"`
+}
+
+// SubmittalEventReviewRequested is the event_type of SubmittalReviewRequestedEvent
+const SubmittalEventReviewRequested = "submittal.review_requested"
+
+// SubmittalReviewRequestedEvent is published to the events topic when the ball in court passes to a reviewer
+// other than the acting user, and that reviewer has SNS on for submittal_review_requested
+type SubmittalReviewRequestedEvent struct {
+	EventType       string    `json:"event_type"`
+	SubmittalID     int64     `json:"submittal_id"`
+	SubmittalNumber string    `json:"submittal_number"`
+	ProjectID       int64     `json:"project_id"`
+	OrgID           int64     `json:"org_id"`
+	ReviewerID      int64     `json:"reviewer_id"`
+	ActorUserID     int64     `json:"actor_user_id"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
 // SubmittalRequest represents the unified request structure for create/update operations
 type SubmittalRequest struct {
 	// Project Context (from path parameter and JWT)
@@ -230,6 +297,13 @@ const (
 	SubmittalStatusForInformationOnly  = "for_information_only"
 )
 
+// SubmittalStatuses lists every valid submittal workflow status
+var SubmittalStatuses = []string{
+	SubmittalStatusDraft, SubmittalStatusPendingSubmission, SubmittalStatusUnderReview,
+	SubmittalStatusApproved, SubmittalStatusApprovedAsNoted, SubmittalStatusReviseResubmit,
+	SubmittalStatusRejected, SubmittalStatusForInformationOnly,
+}
+
 // Submittal Type constants
 const (
 	SubmittalTypeShopDrawings         = "shop_drawings"
@@ -249,6 +323,11 @@ const (
 	SubmittalPriorityLow      = "low"
 )
 
+// SubmittalPriorities lists every valid submittal priority
+var SubmittalPriorities = []string{
+	SubmittalPriorityCritical, SubmittalPriorityHigh, SubmittalPriorityMedium, SubmittalPriorityLow,
+}
+
 // Submittal Phase constants
 const (
 	SubmittalPhasePreparation  = "preparation"
@@ -270,6 +349,13 @@ const (
 	BallInCourtVendor        = "vendor"
 )
 
+// Submittal reviewer routing status constants
+const (
+	SubmittalReviewerPending   = "pending"
+	SubmittalReviewerCompleted = "completed"
+	SubmittalReviewerSkipped   = "skipped"
+)
+
 // Workflow Actions
 const (
 	WorkflowActionSubmitForReview    = "submit_for_review"
@@ -278,4 +364,88 @@ const (
 	WorkflowActionReviseResubmit     = "revise_resubmit"
 	WorkflowActionReject             = "reject"
 	WorkflowActionMarkForInformation = "mark_for_information"
-)
\ No newline at end of file
+)
+
+// WorkflowActionRequiresComment reports whether a workflow action must carry a reviewer comment.
+// Rejections and revise-and-resubmit decisions always need a stated reason.
+func WorkflowActionRequiresComment(action string) bool {
+	return action == WorkflowActionReject || action == WorkflowActionReviseResubmit
+}
+
+// SubmittalWorkflowTransitions maps a submittal's current workflow status to the actions allowed from it.
+// A submittal goes out for review from draft or pending submission, and goes back out from revise and
+// resubmit as the next revision. Approved, rejected and for-information submittals are closed.
+var SubmittalWorkflowTransitions = map[string][]string{
+	SubmittalStatusDraft:             {WorkflowActionSubmitForReview, WorkflowActionMarkForInformation},
+	SubmittalStatusPendingSubmission: {WorkflowActionSubmitForReview, WorkflowActionMarkForInformation},
+	SubmittalStatusUnderReview: {
+		WorkflowActionApprove, WorkflowActionApproveAsNoted, WorkflowActionReviseResubmit,
+		WorkflowActionReject, WorkflowActionMarkForInformation,
+	},
+	SubmittalStatusReviseResubmit:     {WorkflowActionSubmitForReview},
+	SubmittalStatusApproved:           {},
+	SubmittalStatusApprovedAsNoted:    {},
+	SubmittalStatusRejected:           {},
+	SubmittalStatusForInformationOnly: {},
+}
+
+// InvalidWorkflowTransitionError is returned when a workflow action is not allowed from the
+// submittal's current status. Handlers map it to 409 and surface Error() directly.
+type InvalidWorkflowTransitionError struct {
+	Action  string
+	Status  string
+	Allowed []string
+}
+
+func (e *InvalidWorkflowTransitionError) Error() string {
+	if len(e.Allowed) == 0 {
+		return fmt.Sprintf("cannot %s a submittal that is %s; no workflow actions are allowed", e.Action, e.Status)
+	}
+	return fmt.Sprintf("cannot %s a submittal that is %s; allowed actions: %s", e.Action, e.Status, strings.Join(e.Allowed, ", "))
+}
+
+// ValidateWorkflowTransition returns an *InvalidWorkflowTransitionError when action is not allowed from status
+func ValidateWorkflowTransition(status, action string) error {
+	allowed := SubmittalWorkflowTransitions[status]
+	for _, candidate := range allowed {
+		if candidate == action {
+			return nil
+		}
+	}
+	return &InvalidWorkflowTransitionError{Action: action, Status: status, Allowed: allowed}
+}
+
+// SubmittalWorkflowState is the status, phase and ball in court a workflow action moves a submittal to,
+// with the description recorded in its history
+type SubmittalWorkflowState struct {
+	Status      string
+	Phase       string
+	BallInCourt string
+	Description string
+}
+
+// NextSubmittalWorkflowState returns the state a workflow action moves a submittal to, or false for an
+// unknown action. reviewersPending reports whether routed reviewers remain after the current one: an
+// approval then keeps the submittal under review for the next reviewer, and only the last reviewer's
+// approval closes the review.
+func NextSubmittalWorkflowState(action string, reviewersPending bool) (SubmittalWorkflowState, bool) {
+	switch action {
+	case WorkflowActionSubmitForReview:
+		return SubmittalWorkflowState{SubmittalStatusUnderReview, SubmittalPhaseReview, BallInCourtArchitect, "submitted for review"}, true
+	case WorkflowActionApprove, WorkflowActionApproveAsNoted:
+		if reviewersPending {
+			return SubmittalWorkflowState{SubmittalStatusUnderReview, SubmittalPhaseReview, BallInCourtArchitect, "approved and routed to the next reviewer"}, true
+		}
+		if action == WorkflowActionApproveAsNoted {
+			return SubmittalWorkflowState{SubmittalStatusApprovedAsNoted, SubmittalPhaseFabrication, BallInCourtContractor, "approved as noted"}, true
+		}
+		return SubmittalWorkflowState{SubmittalStatusApproved, SubmittalPhaseFabrication, BallInCourtContractor, "approved"}, true
+	case WorkflowActionReviseResubmit:
+		return SubmittalWorkflowState{SubmittalStatusReviseResubmit, SubmittalPhasePreparation, BallInCourtContractor, "requires revision and resubmission"}, true
+	case WorkflowActionReject:
+		return SubmittalWorkflowState{SubmittalStatusRejected, SubmittalPhasePreparation, BallInCourtContractor, "rejected"}, true
+	case WorkflowActionMarkForInformation:
+		return SubmittalWorkflowState{SubmittalStatusForInformationOnly, SubmittalPhaseCompleted, BallInCourtContractor, "marked for information only"}, true
+	}
+	return SubmittalWorkflowState{}, false
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextSubmittalWorkflowState(t *testing.T) {
+	tests := []struct {
+		name             string
+		action           string
+		reviewersPending bool
+		wantStatus       string
+		wantBallInCourt  string
+	}{
+		{"approve by last reviewer closes review", WorkflowActionApprove, false, SubmittalStatusApproved, BallInCourtContractor},
+		{"approve with reviewers pending stays under review", WorkflowActionApprove, true, SubmittalStatusUnderReview, BallInCourtArchitect},
+		{"approve as noted by last reviewer closes review", WorkflowActionApproveAsNoted, false, SubmittalStatusApprovedAsNoted, BallInCourtContractor},
+		{"approve as noted with reviewers pending stays under review", WorkflowActionApproveAsNoted, true, SubmittalStatusUnderReview, BallInCourtArchitect},
+		{"reject ignores pending reviewers", WorkflowActionReject, true, SubmittalStatusRejected, BallInCourtContractor},
+		{"revise and resubmit ignores pending reviewers", WorkflowActionReviseResubmit, true, SubmittalStatusReviseResubmit, BallInCourtContractor},
+		{"submit for review", WorkflowActionSubmitForReview, true, SubmittalStatusUnderReview, BallInCourtArchitect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			state, ok := NextSubmittalWorkflowState(tt.action, tt.reviewersPending)
+
+			//Assert
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantStatus, state.Status)
+			assert.Equal(t, tt.wantBallInCourt, state.BallInCourt)
+		})
+	}
+}
+
+func TestNextSubmittalWorkflowState_UnknownAction(t *testing.T) {
+	//Act
+	_, ok := NextSubmittalWorkflowState("archive", false)
+
+	//Assert
+	assert.False(t, ok)
+}
+
+var allWorkflowActions = []string{
+	WorkflowActionSubmitForReview, WorkflowActionApprove, WorkflowActionApproveAsNoted,
+	WorkflowActionReviseResubmit, WorkflowActionReject, WorkflowActionMarkForInformation,
+}
+
+func TestValidateWorkflowTransition(t *testing.T) {
+	tests := []struct {
+		status  string
+		allowed []string
+	}{
+		{SubmittalStatusDraft, []string{WorkflowActionSubmitForReview, WorkflowActionMarkForInformation}},
+		{SubmittalStatusPendingSubmission, []string{WorkflowActionSubmitForReview, WorkflowActionMarkForInformation}},
+		{SubmittalStatusUnderReview, []string{WorkflowActionApprove, WorkflowActionApproveAsNoted, WorkflowActionReviseResubmit, WorkflowActionReject, WorkflowActionMarkForInformation}},
+		{SubmittalStatusReviseResubmit, []string{WorkflowActionSubmitForReview}},
+		{SubmittalStatusApproved, nil},
+		{SubmittalStatusApprovedAsNoted, nil},
+		{SubmittalStatusRejected, nil},
+		{SubmittalStatusForInformationOnly, nil},
+	}
+
+	for _, tt := range tests {
+		allowed := make(map[string]bool, len(tt.allowed))
+		for _, action := range tt.allowed {
+			allowed[action] = true
+		}
+
+		for _, action := range allWorkflowActions {
+			t.Run(tt.status+"/"+action, func(t *testing.T) {
+				//Act
+				err := ValidateWorkflowTransition(tt.status, action)
+
+				//Assert
+				if allowed[action] {
+					assert.NoError(t, err)
+					return
+				}
+				var transitionErr *InvalidWorkflowTransitionError
+				if assert.True(t, errors.As(err, &transitionErr)) {
+					assert.Equal(t, tt.status, transitionErr.Status)
+					assert.Equal(t, action, transitionErr.Action)
+					assert.ElementsMatch(t, tt.allowed, transitionErr.Allowed)
+				}
+			})
+		}
+	}
+}
+
+func TestSubmittalWorkflowTransitions_CoverEveryStatus(t *testing.T) {
+	for _, status := range SubmittalStatuses {
+		//Act
+		_, ok := SubmittalWorkflowTransitions[status]
+
+		//Assert
+		assert.True(t, ok, "missing transitions for status %s", status)
+	}
+}
+
+func TestValidateWorkflowTransition_UnknownStatusOrAction(t *testing.T) {
+	//Act
+	unknownStatus := ValidateWorkflowTransition("archived", WorkflowActionApprove)
+	unknownAction := ValidateWorkflowTransition(SubmittalStatusUnderReview, "archive")
+
+	//Assert
+	assert.EqualError(t, unknownStatus, "cannot approve a submittal that is archived; no workflow actions are allowed")
+	assert.EqualError(t, unknownAction, "cannot archive a submittal that is under_review; allowed actions: approve, approve_as_noted, revise_resubmit, reject, mark_for_information")
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,7 @@ type User struct {
 	OrgID                  int64          `json:"org_id"`                              // Organization this user belongs to
 	CreatedAt              time.Time      `json:"created_at"`                          // Creation timestamp
 	UpdatedAt              time.Time      `json:"updated_at"`                          // Last update timestamp
+	LastLoginAt            *time.Time     `json:"last_login_at,omitempty"`             // Last credential sign-in; nil if the user has never signed in
 }
 
 // UserWithLocationsAndRoles represents a user with their assigned locations and roles
@@ -53,6 +55,8 @@ type CreateUserRequest struct {
 	LastSelectedLocationID int64  `json:"last_selected_location_id,omitempty"`
 	// Location and role assignments (optional for initial user creation)
 	LocationRoleAssignments []LocationRoleAssignmentRequest `json:"location_role_assignments,omitempty"`
+	// BypassDomainCheck skips the org email domain allow-list (super admins only)
+	BypassDomainCheck bool `json:"bypass_domain_check,omitempty"`
 	// Note: Status is automatically set to "pending" by backend
 }
 
@@ -89,6 +93,31 @@ type UserListResponse struct {
 	Total int                         `json:"total"`
 }
 
+// UserProjectRole represents a project a user is assigned to along with their role on it
+type UserProjectRole struct {
+	ProjectID     int64   `json:"project_id"`
+	ProjectNumber string  `json:"project_number"`
+	ProjectName   string  `json:"project_name"`
+	ProjectStatus string  `json:"project_status"`
+	LocationID    int64   `json:"location_id"`
+	RoleID        int64   `json:"role_id"`
+	RoleName      string  `json:"role_name"`
+	TradeType     *string `json:"trade_type,omitempty"`
+	IsPrimary     bool    `json:"is_primary"`
+	StartDate     *string `json:"start_date,omitempty"`
+	EndDate       *string `json:"end_date,omitempty"`
+}
+
+// UserProjectListResponse represents the paginated list of projects a user is assigned to
+type UserProjectListResponse struct {
+	UserID     int64             `json:"user_id"`
+	Projects   []UserProjectRole `json:"projects"`
+	TotalCount int               `json:"total_count"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	HasNext    bool              `json:"has_next"`
+}
+
 // CreateUserResponse represents the response after creating a user
 type CreateUserResponse struct {
 	UserWithLocationsAndRoles
@@ -96,6 +125,31 @@ type CreateUserResponse struct {
 	Message           string `json:"message"`
 }
 
+// NormalizeEmail trims and lowercases an email so addresses that differ only in case compare and store equal
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// UserImportColumns are the CSV header names POST /users/import understands; email, first_name and
+// last_name are required
+var UserImportColumns = []string{"email", "first_name", "last_name", "phone", "job_title"}
+
+// UserImportRowError reports why one CSV row was not imported. Row is the 1-based line number in the
+// file, counting the header as row 1.
+type UserImportRowError struct {
+	Row    int    `json:"row"`
+	Email  string `json:"email,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// UserImportResult summarizes POST /users/import. Skipped counts rows whose email already belongs to a
+// user in the organization (or appears earlier in the file); those rows are not reported as errors.
+type UserImportResult struct {
+	Created int                  `json:"created"`
+	Skipped int                  `json:"skipped"`
+	Errors  []UserImportRowError `json:"errors"`
+}
+
 // User status constants
 const (
 	UserStatusPending   = "pending"
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"errors"
+	"time"
+	_ "time/tzdata" // Lambda's provided runtimes ship without a zoneinfo database
+)
+
+// DateLayout is the YYYY-MM-DD layout used for calendar dates in requests and DATE columns
+const DateLayout = "2006-01-02"
+
+// ErrInvalidTimezone is phrased to follow a field name, like the validation errors
+var ErrInvalidTimezone = errors.New("must be an IANA time zone such as America/Los_Angeles")
+
+// LoadTimezone resolves an IANA time zone name. An empty name means UTC; "Local" is rejected
+// because it would silently mean the Lambda's own zone.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// Today returns the calendar date in loc at the instant now. The date is returned as midnight UTC,
+// the same form time.Parse(DateLayout, ...) produces, so the two compare without any zone shift.
+func Today(now time.Time, loc *time.Location) time.Time {
+	year, month, day := now.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		want     string
+		wantErr  error
+	}{
+		{"empty is utc", "", "UTC", nil},
+		{"canonical name", "America/Los_Angeles", "America/Los_Angeles", nil},
+		{"backward link", "US/Pacific", "US/Pacific", nil},
+		{"half hour offset", "Asia/Kolkata", "Asia/Kolkata", nil},
+		{"local rejected", "Local", "", ErrInvalidTimezone},
+		{"unknown zone", "Mars/Olympus_Mons", "", ErrInvalidTimezone},
+		{"offset is not a zone", "+05:30", "", ErrInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			loc, err := LoadTimezone(tt.timezone)
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, loc.String())
+			}
+		})
+	}
+}
+
+func TestToday_AroundUTCMidnight(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      string
+		timezone string
+		want     string
+	}{
+		{"pacific just after utc midnight is still yesterday", "2026-03-02T00:30:00Z", "US/Pacific", "2026-03-01"},
+		{"pacific evening is the same day", "2026-03-01T20:00:00Z", "US/Pacific", "2026-03-01"},
+		{"pacific local midnight", "2026-03-02T08:00:00Z", "US/Pacific", "2026-03-02"},
+		{"pacific daylight time", "2026-07-02T06:59:59Z", "US/Pacific", "2026-07-01"},
+		{"kolkata just before utc midnight is already tomorrow", "2026-03-01T23:30:00Z", "Asia/Kolkata", "2026-03-02"},
+		{"kolkata half hour boundary before", "2026-03-01T18:29:59Z", "Asia/Kolkata", "2026-03-01"},
+		{"kolkata half hour boundary after", "2026-03-01T18:30:00Z", "Asia/Kolkata", "2026-03-02"},
+		{"utc just after midnight", "2026-03-02T00:30:00Z", "", "2026-03-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			now, err := time.Parse(time.RFC3339, tt.now)
+			assert.NoError(t, err)
+			loc, err := LoadTimezone(tt.timezone)
+			assert.NoError(t, err)
+			want, err := time.Parse(DateLayout, tt.want)
+			assert.NoError(t, err)
+
+			//Act
+			today := Today(now, loc)
+
+			//Assert
+			assert.Equal(t, want, today)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mentionPattern matches @[userId] and @email tokens. The @ must start the text or follow a character that
+// cannot be part of an email address, so the @ inside a plain email address is not read as a mention.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+\-])(@(?:\[(\d+)\]|([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})))`)
+
+// MentionToken is one @mention found in comment text. Exactly one of UserID and Email is set;
+// Email is lowercased. Text is the token as written, e.g. "@[42]" or "@Jane@example.com".
+type MentionToken struct {
+	Text   string
+	UserID int64
+	Email  string
+}
+
+// ParseMentions returns the @[userId] and @email tokens in text in the order they first appear.
+// A user mentioned twice the same way is returned once; tokens that are not a valid ID or email are skipped.
+func ParseMentions(text string) []MentionToken {
+	var tokens []MentionToken
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		token := MentionToken{Text: match[1]}
+		key := ""
+		if match[2] != "" {
+			userID, err := strconv.ParseInt(match[2], 10, 64)
+			if err != nil || userID <= 0 {
+				continue
+			}
+			token.UserID = userID
+			key = "id:" + match[2]
+		} else {
+			email := strings.ToLower(match[3])
+			if ValidateEmail(email) != nil {
+				continue
+			}
+			token.Email = email
+			key = "email:" + email
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []MentionToken
+	}{
+		{"no mentions", "Looks good to me", nil},
+		{"user id", "@[42] can you check?", []MentionToken{{Text: "@[42]", UserID: 42}}},
+		{"email", "cc @Jane@Example.com.", []MentionToken{{Text: "@Jane@Example.com", Email: "jane@example.com"}}},
+		{"in order, deduplicated", "@[7], @bob@example.com and @[7] again", []MentionToken{
+			{Text: "@[7]", UserID: 7},
+			{Text: "@bob@example.com", Email: "bob@example.com"},
+		}},
+		{"adjacent punctuation", "(@[3])", []MentionToken{{Text: "@[3]", UserID: 3}}},
+		{"plain email is not a mention", "send it to jane@example.com", nil},
+		{"zero id", "@[0]", nil},
+		{"non numeric id", "@[jane]", nil},
+		{"bare handle", "@jane", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			got := ParseMentions(tt.text)
+
+			//Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
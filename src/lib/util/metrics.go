@@ -0,0 +1,148 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// MetricsNamespace is the CloudWatch namespace every service publishes request metrics under
+const MetricsNamespace = "BuildBoard"
+
+// metricsOutput is where EMF lines are written; Lambda ships stdout to CloudWatch Logs, which
+// extracts the metrics without an agent
+var metricsOutput io.Writer = os.Stdout
+
+type requestMetricsKey struct{}
+
+// RequestMetrics times one API request and the database work done while serving it. Emit writes
+// the result as a CloudWatch Embedded Metric Format (EMF) log line dimensioned by service and resource.
+type RequestMetrics struct {
+	Service  string
+	Resource string
+
+	start      time.Time
+	mu         sync.Mutex
+	dbDuration time.Duration
+	dbQueries  int
+	dbInFlight int
+}
+
+// StartRequestMetrics starts timing a request. Resource should be the API Gateway resource template
+// (e.g. /issues/{issueId}) rather than the raw path so the dimension stays low-cardinality.
+func StartRequestMetrics(service, resource string) *RequestMetrics {
+	return &RequestMetrics{Service: service, Resource: resource, start: time.Now()}
+}
+
+// WithRequestMetrics returns a context carrying m so repositories can report query time through TimeDB
+func WithRequestMetrics(ctx context.Context, m *RequestMetrics) context.Context {
+	return context.WithValue(ctx, requestMetricsKey{}, m)
+}
+
+// TimeDB starts timing a database call and returns the function that stops it. It is a no-op when
+// ctx carries no RequestMetrics, so callers can use `defer util.TimeDB(ctx)()` unconditionally.
+// Calls made while another timed call is in flight (a DAO method reading through another) are
+// folded into the outer call so their time is not counted twice.
+func TimeDB(ctx context.Context) func() {
+	m, ok := ctx.Value(requestMetricsKey{}).(*RequestMetrics)
+	if !ok || m == nil {
+		return func() {}
+	}
+	m.mu.Lock()
+	m.dbInFlight++
+	outer := m.dbInFlight == 1
+	m.mu.Unlock()
+
+	start := time.Now()
+	return func() {
+		m.mu.Lock()
+		m.dbInFlight--
+		m.mu.Unlock()
+		if outer {
+			m.AddDBDuration(time.Since(start))
+		}
+	}
+}
+
+// AddDBDuration records one database call of duration d against the request
+func (m *RequestMetrics) AddDBDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dbDuration += d
+	m.dbQueries++
+}
+
+// Emit writes the request's latency, status-code class counts and database time as one EMF line
+func (m *RequestMetrics) Emit(statusCode int) {
+	line, err := m.emfLine(statusCode, time.Now())
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(metricsOutput, string(line))
+}
+
+// emfLine builds the EMF document for the request completed at now
+func (m *RequestMetrics) emfLine(statusCode int, now time.Time) ([]byte, error) {
+	m.mu.Lock()
+	dbDuration, dbQueries := m.dbDuration, m.dbQueries
+	m.mu.Unlock()
+
+	class := statusCodeClass(statusCode)
+	countFor := func(c string) int {
+		if c == class {
+			return 1
+		}
+		return 0
+	}
+
+	document := map[string]interface{}{
+		"_aws": map[string]interface{}{
+			"Timestamp": now.UnixMilli(),
+			"CloudWatchMetrics": []map[string]interface{}{
+				{
+					"Namespace":  MetricsNamespace,
+					"Dimensions": [][]string{{"Service", "Resource"}, {"Service"}},
+					"Metrics": []map[string]string{
+						{"Name": "Latency", "Unit": "Milliseconds"},
+						{"Name": "DBDuration", "Unit": "Milliseconds"},
+						{"Name": "DBQueries", "Unit": "Count"},
+						{"Name": "2xx", "Unit": "Count"},
+						{"Name": "3xx", "Unit": "Count"},
+						{"Name": "4xx", "Unit": "Count"},
+						{"Name": "5xx", "Unit": "Count"},
+					},
+				},
+			},
+		},
+		"Service":    m.Service,
+		"Resource":   m.Resource,
+		"StatusCode": statusCode,
+		"Latency":    float64(now.Sub(m.start).Microseconds()) / 1000,
+		"DBDuration": float64(dbDuration.Microseconds()) / 1000,
+		"DBQueries":  dbQueries,
+		"2xx":        countFor("2xx"),
+		"3xx":        countFor("3xx"),
+		"4xx":        countFor("4xx"),
+		"5xx":        countFor("5xx"),
+	}
+	return json.Marshal(document)
+}
+
+// statusCodeClass buckets a status code by its hundreds digit; codes outside 2xx-4xx, including
+// invalid ones, are counted as server errors
+func statusCodeClass(statusCode int) string {
+	switch statusCode / 100 {
+	case 2:
+		return "2xx"
+	case 3:
+		return "3xx"
+	case 4:
+		return "4xx"
+	default:
+		return "5xx"
+	}
+}
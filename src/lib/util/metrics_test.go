@@ -0,0 +1,84 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMetrics_EmfLine(t *testing.T) {
+	//Arrange
+	metrics := StartRequestMetrics("issue-management", "/issues/{issueId}")
+	ctx := WithRequestMetrics(context.Background(), metrics)
+	metrics.AddDBDuration(15 * time.Millisecond)
+	TimeDB(ctx)()
+
+	//Act
+	line, err := metrics.emfLine(404, metrics.start.Add(40*time.Millisecond))
+
+	//Assert
+	var document map[string]interface{}
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(line, &document))
+	assert.Equal(t, "issue-management", document["Service"])
+	assert.Equal(t, "/issues/{issueId}", document["Resource"])
+	assert.Equal(t, float64(40), document["Latency"])
+	assert.Equal(t, float64(2), document["DBQueries"])
+	assert.GreaterOrEqual(t, document["DBDuration"].(float64), float64(15))
+	assert.Equal(t, float64(1), document["4xx"])
+	assert.Equal(t, float64(0), document["2xx"])
+	assert.Equal(t, float64(0), document["3xx"])
+	assert.Equal(t, float64(0), document["5xx"])
+	assert.Contains(t, document, "_aws")
+}
+
+func TestTimeDB_WithoutMetricsIsNoop(t *testing.T) {
+	//Arrange
+	ctx := context.Background()
+
+	//Act
+	stop := TimeDB(ctx)
+
+	//Assert
+	assert.NotPanics(t, stop)
+}
+
+func TestTimeDB_NestedCallsCountOnce(t *testing.T) {
+	//Arrange
+	metrics := StartRequestMetrics("rfi-management", "/rfis/{rfiId}")
+	ctx := WithRequestMetrics(context.Background(), metrics)
+
+	//Act
+	stopOuter := TimeDB(ctx)
+	TimeDB(ctx)()
+	stopOuter()
+	TimeDB(ctx)()
+
+	//Assert
+	assert.Equal(t, 2, metrics.dbQueries)
+}
+
+func TestStatusCodeClass(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       string
+	}{
+		{200, "2xx"},
+		{204, "2xx"},
+		{304, "3xx"},
+		{404, "4xx"},
+		{503, "5xx"},
+		{0, "5xx"},
+	}
+
+	for _, tt := range tests {
+		//Act
+		got := statusCodeClass(tt.statusCode)
+
+		//Assert
+		assert.Equal(t, tt.want, got, "status %d", tt.statusCode)
+	}
+}
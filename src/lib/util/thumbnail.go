@@ -0,0 +1,106 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Thumbnails are generated inside the confirm request on a 128MB Lambda, so source images are
+// bounded both in bytes read and in decoded size. A 16MP image decodes to at most 64MB of NRGBA;
+// a small file that declares huge dimensions is rejected before it is decoded.
+const (
+	MaxThumbnailSourceBytes  = 10 * 1024 * 1024
+	maxThumbnailSourcePixels = 16_000_000
+)
+
+// GenerateThumbnail decodes a PNG or JPEG image and scales it so neither side exceeds maxDimension,
+// keeping the aspect ratio. The thumbnail is encoded in the source format and returned with its MIME type.
+// Images already within maxDimension are re-encoded at their original size. Sources larger than
+// MaxThumbnailSourceBytes are rejected.
+func GenerateThumbnail(r io.Reader, maxDimension int) ([]byte, string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxThumbnailSourceBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > MaxThumbnailSourceBytes {
+		return nil, "", fmt.Errorf("image is larger than %d bytes", MaxThumbnailSourceBytes)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("unsupported image: %w", err)
+	}
+	if format != "png" && format != "jpeg" {
+		return nil, "", fmt.Errorf("unsupported image format: %s", format)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxThumbnailSourcePixels {
+		return nil, "", fmt.Errorf("image dimensions %dx%d are not supported", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	width, height := thumbnailSize(cfg.Width, cfg.Height, maxDimension)
+	dst := scaleImage(src, width, height)
+
+	var buf bytes.Buffer
+	if format == "png" {
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return buf.Bytes(), "image/" + format, nil
+}
+
+// thumbnailSize fits width x height inside a maxDimension square without upscaling
+func thumbnailSize(width, height, maxDimension int) (int, int) {
+	if width <= maxDimension && height <= maxDimension {
+		return width, height
+	}
+	if width >= height {
+		return maxDimension, max(1, height*maxDimension/width)
+	}
+	return max(1, width*maxDimension/height), maxDimension
+}
+
+// scaleImage downsamples src by averaging the block of source pixels behind each destination pixel
+func scaleImage(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pixel := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+					r += uint64(pixel.R)
+					g += uint64(pixel.G)
+					b += uint64(pixel.B)
+					a += uint64(pixel.A)
+					n++
+				}
+			}
+			dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,99 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateThumbnail_ScalesLongestSide(t *testing.T) {
+	//Arrange
+	source := encodeTestPNG(t, 1024, 512)
+
+	//Act
+	thumbnail, contentType, err := GenerateThumbnail(bytes.NewReader(source), 256)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+	assert.NoError(t, err)
+	assert.Equal(t, 256, cfg.Width)
+	assert.Equal(t, 128, cfg.Height)
+}
+
+func TestGenerateThumbnail_DoesNotUpscale(t *testing.T) {
+	//Arrange
+	source := encodeTestPNG(t, 100, 40)
+
+	//Act
+	thumbnail, _, err := GenerateThumbnail(bytes.NewReader(source), 256)
+
+	//Assert
+	assert.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, cfg.Width)
+	assert.Equal(t, 40, cfg.Height)
+}
+
+func TestGenerateThumbnail_RejectsNonImage(t *testing.T) {
+	//Arrange
+	source := []byte("%PDF-1.7 not an image")
+
+	//Act
+	_, _, err := GenerateThumbnail(bytes.NewReader(source), 256)
+
+	//Assert
+	assert.Error(t, err)
+}
+
+func TestGenerateThumbnail_RejectsOversizedSource(t *testing.T) {
+	//Arrange
+	source := strings.NewReader(strings.Repeat("x", MaxThumbnailSourceBytes+1))
+
+	//Act
+	_, _, err := GenerateThumbnail(source, 256)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "larger than")
+}
+
+func TestGenerateThumbnail_RejectsTooManyPixels(t *testing.T) {
+	//Arrange
+	// Rewrite a small PNG's header to declare 5000x5000; the pixels are never decoded
+	source := encodeTestPNG(t, 1, 1)
+	ihdr := source[12:29]
+	binary.BigEndian.PutUint32(ihdr[4:8], 5000)
+	binary.BigEndian.PutUint32(ihdr[8:12], 5000)
+	binary.BigEndian.PutUint32(source[29:33], crc32.ChecksumIEEE(ihdr))
+
+	//Act
+	_, _, err := GenerateThumbnail(bytes.NewReader(source), 256)
+
+	//Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "5000x5000")
+}
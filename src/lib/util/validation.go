@@ -0,0 +1,61 @@
+package util
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+// Validation errors are phrased to follow a field name, e.g. "phone " + err.Error()
+var (
+	ErrValueRequired = errors.New("is required")
+	ErrInvalidEmail  = errors.New("must be a valid email address")
+	ErrInvalidPhone  = errors.New("must be a phone number with 7 to 15 digits and an optional leading +")
+)
+
+const (
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+)
+
+// ValidateEmail checks that email is a bare address (no display name) with a dotted domain.
+// Plus-addressed mailboxes such as jane+site@example.com are accepted.
+func ValidateEmail(email string) error {
+	if strings.TrimSpace(email) == "" {
+		return ErrValueRequired
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+	at := strings.LastIndex(email, "@")
+	domain := email[at+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// ValidatePhone checks that phone looks like an E.164 number. Spaces, dashes, dots and
+// parentheses are tolerated as separators; an empty value passes because phone is optional.
+func ValidatePhone(phone string) error {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return nil
+	}
+	digits := 0
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return ErrInvalidPhone
+		}
+	}
+	if digits < minPhoneDigits || digits > maxPhoneDigits {
+		return ErrInvalidPhone
+	}
+	return nil
+}
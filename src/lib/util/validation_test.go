@@ -0,0 +1,67 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  error
+	}{
+		{"simple address", "jane@example.com", nil},
+		{"plus addressed", "jane+site-42@example.com", nil},
+		{"subdomain", "j.doe@mail.builder.co.uk", nil},
+		{"empty", "", ErrValueRequired},
+		{"whitespace only", "   ", ErrValueRequired},
+		{"missing at", "jane.example.com", ErrInvalidEmail},
+		{"missing local part", "@example.com", ErrInvalidEmail},
+		{"undotted domain", "jane@localhost", ErrInvalidEmail},
+		{"trailing dot domain", "jane@example.", ErrInvalidEmail},
+		{"display name", "Jane <jane@example.com>", ErrInvalidEmail},
+		{"embedded space", "jane doe@example.com", ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			err := ValidateEmail(tt.email)
+
+			//Assert
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestValidatePhone(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		want  error
+	}{
+		{"empty is optional", "", nil},
+		{"e164 us", "+14155550123", nil},
+		{"e164 uk", "+44 20 7946 0958", nil},
+		{"e164 india", "+91-98765-43210", nil},
+		{"e164 max length", "+861012345678901", nil},
+		{"national with separators", "(415) 555.0123", nil},
+		{"too short", "+1 555", ErrInvalidPhone},
+		{"too long", "+1234567890123456", ErrInvalidPhone},
+		{"letters", "+1 415 CALL NOW", ErrInvalidPhone},
+		{"plus not leading", "1+4155550123", ErrInvalidPhone},
+		{"extension", "+14155550123 x12", ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			err := ValidatePhone(tt.phone)
+
+			//Assert
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}